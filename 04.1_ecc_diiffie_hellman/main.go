@@ -2,45 +2,44 @@ package main
 
 import (
 	"fmt"
-)
-
-type Point struct {
-	x, y int
-}
+	"math/big"
 
+	"github.com/arya2004/cybersecurity/pkg/ecc"
+)
 
-// Check if point lies on the curve
-func isPoint(a1 Point, a, b, p int) bool {
-	y := (a1.y * a1.y) % p
-	x := (a1.x*a1.x*a1.x + a*a1.x + b) % p
-	return x == y
+func readBigInt(prompt string) *big.Int {
+	fmt.Print(prompt)
+	var s string
+	fmt.Scan(&s)
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		fmt.Println("invalid integer, defaulting to 0")
+		return big.NewInt(0)
+	}
+	return n
 }
 
-// Print all points on the curve
-func allPoints(p, a, b int) {
-	for i := 0; i < p; i++ {
-		for j := 0; j < p; j++ {
-			a2 := Point{i, j}
-			if isPoint(a2, a, b, p) {
+// allPoints prints every point on curve c, by brute-force scanning F_p x
+// F_p - only practical for the small teaching-sized primes this demo is
+// meant for.
+func allPoints(c ecc.Curve) {
+	p := c.P.Int64()
+	for i := int64(0); i < p; i++ {
+		for j := int64(0); j < p; j++ {
+			point := ecc.Point{X: big.NewInt(i), Y: big.NewInt(j)}
+			if c.IsOnCurve(point) {
 				fmt.Println(i, j)
 			}
 		}
 	}
 }
 
-
-
-
 func main() {
-	var a, b, p int
-	fmt.Print("Enter a: ")
-	fmt.Scan(&a)
-	fmt.Print("Enter b: ")
-	fmt.Scan(&b)
-	fmt.Print("Enter p: ")
-	fmt.Scan(&p)
-
+	a := readBigInt("Enter a: ")
+	b := readBigInt("Enter b: ")
+	p := readBigInt("Enter p: ")
+	curve := ecc.Curve{A: a, B: b, P: p}
 
 	fmt.Println("All Points on the curve are:")
-	allPoints(p, a, b)
+	allPoints(curve)
 }
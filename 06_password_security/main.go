@@ -1,15 +1,29 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"crypto/rand"
+	"crypto/sha1"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"math"
 	"math/big"
+	"net/http"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/arya2004/cybersecurity/pkg/strength"
 )
 
+// hibpRangeURL is the Have I Been Pwned Passwords k-anonymity range
+// endpoint: it takes a 5-char SHA-1 prefix and returns every suffix in its
+// bucket, so the full password hash never leaves the machine.
+const hibpRangeURL = "https://api.pwnedpasswords.com/range/"
+
 // Common weak passwords
 var commonPasswords = map[string]bool{
 	"password": true, "123456": true, "123456789": true, "12345678": true,
@@ -28,34 +42,94 @@ var keyboardPatterns = []string{
 type PasswordAnalyzer struct {
 	Password string
 	Length   int
+
+	// HTTPClient is used by CheckBreached to query the HIBP range API. It
+	// defaults to http.DefaultClient but is exported so tests can point it
+	// at an httptest.Server instead of the real API.
+	HTTPClient *http.Client
+
+	// breachCount caches the result of the last CheckBreached call so
+	// CalculateStrengthScore and PrintAnalysis can fold it in without
+	// re-querying the network. -1 means CheckBreached hasn't run.
+	breachCount int
 }
 
 // NewPasswordAnalyzer creates a new password analyzer
 func NewPasswordAnalyzer(password string) *PasswordAnalyzer {
 	return &PasswordAnalyzer{
-		Password: password,
-		Length:   len(password),
+		Password:    password,
+		Length:      len(password),
+		HTTPClient:  http.DefaultClient,
+		breachCount: -1,
 	}
 }
 
-// CalculateEntropy calculates Shannon entropy
-func (pa *PasswordAnalyzer) CalculateEntropy() float64 {
-	if pa.Length == 0 {
-		return 0.0
+// CheckBreached queries the Have I Been Pwned Passwords API via its
+// k-anonymity range endpoint: it never sends the password or its full
+// hash, only a 5-char SHA-1 prefix, and scans the returned prefix bucket
+// locally for a matching suffix. The Add-Padding header asks HIBP to pad
+// the response to a fixed size so an eavesdropper can't infer the bucket's
+// real size from response length. It returns the number of times the
+// password has appeared in a known breach (0 if not found), and caches the
+// count so CalculateStrengthScore and PrintAnalysis can use it afterward.
+func (pa *PasswordAnalyzer) CheckBreached(ctx context.Context) (int, error) {
+	client := pa.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
 	}
 
-	freq := make(map[rune]int)
-	for _, char := range pa.Password {
-		freq[char]++
+	digest := strings.ToUpper(fmt.Sprintf("%x", sha1.Sum([]byte(pa.Password))))
+	prefix, suffix := digest[:5], digest[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hibpRangeURL+prefix, nil)
+	if err != nil {
+		return 0, err
 	}
+	req.Header.Set("Add-Padding", "true")
 
-	entropy := 0.0
-	for _, count := range freq {
-		probability := float64(count) / float64(pa.Length)
-		entropy -= probability * math.Log2(probability)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("hibp: unexpected status %s", resp.Status)
+	}
+
+	count := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 || parts[0] != suffix {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return 0, fmt.Errorf("hibp: malformed count for matching suffix: %w", err)
+		}
+		count = n
+		break
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
 	}
 
-	return entropy * float64(pa.Length)
+	pa.breachCount = count
+	return count, nil
+}
+
+// CalculateEntropy estimates the password's entropy as log2 of the
+// zxcvbn-style minimum-guesses cover pkg/strength computes, rather than
+// plain Shannon entropy over the character distribution: Shannon entropy
+// scores "Tr0ub4dor&3" as strong because its characters look random, while
+// the guesses-cover approach recognizes it as a dictionary word plus a
+// handful of common substitutions and scores it accordingly.
+func (pa *PasswordAnalyzer) CalculateEntropy() float64 {
+	if pa.Length == 0 {
+		return 0.0
+	}
+	return math.Log2(strength.Estimate(pa.Password).Guesses)
 }
 
 // GetCharacterSets detects character sets used
@@ -124,101 +198,62 @@ func (pa *PasswordAnalyzer) CheckCommonPassword() bool {
 	return commonPasswords[strings.ToLower(pa.Password)]
 }
 
-// CalculateStrengthScore calculates password strength (0-100)
+// CalculateStrengthScore scores the password 0-100 from a zxcvbn-style
+// minimum-guesses-cover analysis (pkg/strength) instead of an additive
+// point score built on Shannon entropy and a tiny common-password list,
+// which both badly overestimate passwords like "Tr0ub4dor&3" or
+// "Password1!" that look random character-by-character but decompose into
+// an obvious dictionary word plus a handful of common substitutions.
 func (pa *PasswordAnalyzer) CalculateStrengthScore() (int, string) {
-	score := 0
-
-	// Length scoring (0-30 points)
-	if pa.Length >= 16 {
-		score += 30
-	} else if pa.Length >= 12 {
-		score += 25
-	} else if pa.Length >= 8 {
-		score += 15
-	} else if pa.Length >= 6 {
-		score += 5
-	}
-
-	// Character variety (0-25 points)
-	charset := pa.GetCharacterSets()
-	for _, present := range charset {
-		if present {
-			score += 6
-		}
-	}
+	score := strength.Estimate(pa.Password).Score * 25
 
-	// Entropy bonus (0-25 points)
-	entropy := pa.CalculateEntropy()
-	if entropy >= 80 {
-		score += 25
-	} else if entropy >= 60 {
-		score += 20
-	} else if entropy >= 40 {
-		score += 10
-	} else if entropy >= 20 {
-		score += 5
-	}
-
-	// Deduct for vulnerabilities
-	vulnerabilities := 0
-	if pa.CheckCommonPassword() {
-		vulnerabilities += 10
-	}
-	vulnerabilities += len(pa.DetectSequentialPatterns()) * 2
-	vulnerabilities += len(pa.DetectKeyboardPatterns()) * 3
-
-	score -= vulnerabilities
-	if score < 0 {
-		score = 0
-	}
-	if score > 100 {
-		score = 100
-	}
-
-	// Determine rating
 	rating := ""
-	if score >= 80 {
+	switch {
+	case score >= 100:
 		rating = "VERY STRONG"
-	} else if score >= 60 {
+	case score >= 75:
 		rating = "STRONG"
-	} else if score >= 40 {
+	case score >= 50:
 		rating = "MODERATE"
-	} else if score >= 20 {
+	case score >= 25:
 		rating = "WEAK"
-	} else {
+	default:
 		rating = "VERY WEAK"
 	}
 
+	// A password count is a hard cap, not another signal to blend in: once
+	// CheckBreached has confirmed real attackers already have this exact
+	// password in a cracking dictionary, no amount of pattern-analysis
+	// strength matters.
+	if pa.breachCount > 0 {
+		if score > 20 {
+			score = 20
+		}
+		rating = "COMPROMISED"
+	}
+
 	return score, rating
 }
 
-// EstimateCrackTime estimates brute force crack time
-func (pa *PasswordAnalyzer) EstimateCrackTime() string {
-	charsetSize := pa.CalculateCharsetSize()
-	if charsetSize == 0 {
-		return "Instant"
-	}
-
-	// Assume 10 billion attempts per second
-	attemptsPerSecond := 10_000_000_000.0
-	totalCombinations := math.Pow(float64(charsetSize), float64(pa.Length))
-	seconds := totalCombinations / attemptsPerSecond
+// PatternMatches returns a human-readable description of each pattern
+// (dictionary word, l33t substitution, sequence, repeat, date, or
+// keyboard walk) the winning minimum-guesses cover used to explain the
+// password, so PrintAnalysis can show why a password scored the way it
+// did instead of just the final number.
+func (pa *PasswordAnalyzer) PatternMatches() []string {
+	return strength.Estimate(pa.Password).Matches
+}
 
-	if seconds < 1 {
+// EstimateCrackTime estimates how long the password would take to crack
+// via the same zxcvbn-style guesses-cover analysis CalculateStrengthScore
+// uses, rather than a flat charset^length brute-force estimate that
+// doesn't know a password is a dictionary word dressed up with
+// substitutions.
+func (pa *PasswordAnalyzer) EstimateCrackTime() string {
+	if pa.Length == 0 {
 		return "Instant"
-	} else if seconds < 60 {
-		return fmt.Sprintf("%.1f seconds", seconds)
-	} else if seconds < 3600 {
-		return fmt.Sprintf("%.1f minutes", seconds/60)
-	} else if seconds < 86400 {
-		return fmt.Sprintf("%.1f hours", seconds/3600)
-	} else if seconds < 31536000 {
-		return fmt.Sprintf("%.1f days", seconds/86400)
-	} else if seconds < 31536000*100 {
-		return fmt.Sprintf("%.1f years", seconds/31536000)
-	} else {
-		return fmt.Sprintf("%.0f+ years", seconds/31536000)
 	}
+	return strength.Estimate(pa.Password).CrackTime
 }
 
 // PrintAnalysis prints password analysis
@@ -251,6 +286,10 @@ func (pa *PasswordAnalyzer) PrintAnalysis() {
 		fmt.Println("\n‚ö†Ô∏è  WARNING: This is a commonly used password!")
 	}
 
+	if pa.breachCount > 0 {
+		fmt.Printf("\n\U0001F6D1 WARNING: This password has appeared in %d known data breaches - treat it as compromised!\n", pa.breachCount)
+	}
+
 	vulnerabilities := append(pa.DetectSequentialPatterns(), pa.DetectKeyboardPatterns()...)
 	if len(vulnerabilities) > 0 {
 		fmt.Println("\n‚ö†Ô∏è  Vulnerabilities Found:")
@@ -261,9 +300,264 @@ func (pa *PasswordAnalyzer) PrintAnalysis() {
 		fmt.Println("\n‚úÖ No common vulnerabilities detected!")
 	}
 
+	if matches := pa.PatternMatches(); len(matches) > 0 {
+		fmt.Println("\n\xf0\x9f\xa7\xa9 Why it scored this way:")
+		for _, m := range matches {
+			fmt.Printf("  - %s\n", m)
+		}
+	}
+
 	fmt.Println(strings.Repeat("=", 60))
 }
 
+// PolicyViolation describes one way a password failed a PasswordPolicy.
+// Code is a stable machine-readable identifier for the rule that fired,
+// Message is a ready-to-display explanation, and Severity distinguishes
+// rules a caller should hard-reject (ERROR) from ones worth surfacing but
+// not enforcing (WARNING).
+type PolicyViolation struct {
+	Code     string
+	Message  string
+	Severity string
+}
+
+// PasswordPolicy is a configurable set of acceptance rules, separate from
+// PasswordAnalyzer's descriptive scoring: Validate returns every rule a
+// password breaks instead of collapsing everything into one score, so a
+// caller can reject on ERROR violations while still surfacing WARNINGs.
+// A zero-value field disables that rule.
+type PasswordPolicy struct {
+	MinLength           int
+	MaxLength           int // 0 means no limit
+	RequireClasses      int // how many of upper/lower/digit/special must be present
+	DisallowCommon      bool
+	DisallowUserContext []string // username/email/name fragments the password must not contain
+	MinEntropyBits      float64
+	MaxRepeatRun        int // longest run of one repeated character allowed, 0 means no limit
+	Blocklist           []string
+}
+
+// NIST80063B returns the policy described in NIST SP 800-63B section 5.1.1.2: a
+// minimum length and a check against breached/common passwords, but none
+// of the composition rules, periodic rotation, or character restrictions
+// older guidance required - NIST found those push users toward
+// predictable patterns (Password1!, Password2!, ...) without stopping the
+// attacks that matter, and explicitly recommends allowing any printable
+// ASCII character or space up to at least 64 characters.
+func NIST80063B() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:      8,
+		DisallowCommon: true,
+	}
+}
+
+// Strict layers composition, entropy, and repeat-run limits on top of the
+// NIST baseline, for contexts where compliance doesn't matter and the
+// goal is simply to push out the weakest passwords a user might pick.
+func Strict() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength:      12,
+		MaxLength:      128,
+		RequireClasses: 3,
+		DisallowCommon: true,
+		MinEntropyBits: 40,
+		MaxRepeatRun:   3,
+	}
+}
+
+// policyLeetTable mirrors pkg/strength's l33t-substitution table so
+// DisallowUserContext recognizes "P4ssw0rd" as containing "password" just
+// as easily as the literal spelling.
+var policyLeetTable = map[rune]rune{'@': 'a', '4': 'a', '3': 'e', '1': 'i', '0': 'o', '$': 's', '5': 's', '7': 't'}
+
+// l33tNormalize lowercases s and reverses its l33t-speak substitutions, so
+// callers can compare user-context fragments against a password on equal
+// footing regardless of which form either was typed in.
+func l33tNormalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if plain, ok := policyLeetTable[r]; ok {
+			r = plain
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// longestRepeatRun returns the length of the longest run of one repeated
+// character in s, e.g. 4 for "aaaa1bbb".
+func longestRepeatRun(s string) int {
+	if s == "" {
+		return 0
+	}
+	longest, run := 1, 1
+	for i := 1; i < len(s); i++ {
+		if s[i] == s[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// Validate checks password against every non-zero rule in p and returns a
+// violation per rule broken, in policy-definition order. A nil/empty
+// result means the password satisfies the policy.
+func (p *PasswordPolicy) Validate(password string) []PolicyViolation {
+	var violations []PolicyViolation
+	analyzer := NewPasswordAnalyzer(password)
+
+	if p.MinLength > 0 && len(password) < p.MinLength {
+		violations = append(violations, PolicyViolation{
+			Code:     "min_length",
+			Message:  fmt.Sprintf("password must be at least %d characters", p.MinLength),
+			Severity: "ERROR",
+		})
+	}
+
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		violations = append(violations, PolicyViolation{
+			Code:     "max_length",
+			Message:  fmt.Sprintf("password must be at most %d characters", p.MaxLength),
+			Severity: "ERROR",
+		})
+	}
+
+	if p.RequireClasses > 0 {
+		present := 0
+		for _, ok := range analyzer.GetCharacterSets() {
+			if ok {
+				present++
+			}
+		}
+		if present < p.RequireClasses {
+			violations = append(violations, PolicyViolation{
+				Code:     "character_classes",
+				Message:  fmt.Sprintf("password must use at least %d of uppercase/lowercase/numbers/special characters, found %d", p.RequireClasses, present),
+				Severity: "ERROR",
+			})
+		}
+	}
+
+	if p.DisallowCommon && analyzer.CheckCommonPassword() {
+		violations = append(violations, PolicyViolation{
+			Code:     "common_password",
+			Message:  "password is one of the most commonly used passwords",
+			Severity: "ERROR",
+		})
+	}
+
+	if len(p.DisallowUserContext) > 0 {
+		normalized := l33tNormalize(password)
+		for _, ctx := range p.DisallowUserContext {
+			ctx = strings.TrimSpace(ctx)
+			if ctx == "" {
+				continue
+			}
+			if strings.Contains(normalized, l33tNormalize(ctx)) {
+				violations = append(violations, PolicyViolation{
+					Code:     "user_context",
+					Message:  fmt.Sprintf("password contains user-identifying text %q", ctx),
+					Severity: "ERROR",
+				})
+			}
+		}
+	}
+
+	if p.MinEntropyBits > 0 {
+		if entropy := analyzer.CalculateEntropy(); entropy < p.MinEntropyBits {
+			violations = append(violations, PolicyViolation{
+				Code:     "min_entropy",
+				Message:  fmt.Sprintf("password entropy %.1f bits is below the required %.1f bits", entropy, p.MinEntropyBits),
+				Severity: "WARNING",
+			})
+		}
+	}
+
+	if p.MaxRepeatRun > 0 {
+		if run := longestRepeatRun(password); run > p.MaxRepeatRun {
+			violations = append(violations, PolicyViolation{
+				Code:     "repeat_run",
+				Message:  fmt.Sprintf("password repeats a character %d times in a row, more than the %d allowed", run, p.MaxRepeatRun),
+				Severity: "WARNING",
+			})
+		}
+	}
+
+	for _, token := range p.Blocklist {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(password), strings.ToLower(token)) {
+			violations = append(violations, PolicyViolation{
+				Code:     "blocklist",
+				Message:  fmt.Sprintf("password contains blocked token %q", token),
+				Severity: "ERROR",
+			})
+		}
+	}
+
+	return violations
+}
+
+// maxPolicyAttempts bounds generate-mode's policy retry loop so an
+// unsatisfiable policy (e.g. RequireClasses higher than the character
+// sets -generate was told to draw from) fails loudly instead of hanging.
+const maxPolicyAttempts = 1000
+
+// hasErrorViolation reports whether violations contains a hard ERROR,
+// which generate-mode retries against; WARNINGs are surfaced but don't
+// trigger a retry.
+func hasErrorViolation(violations []PolicyViolation) bool {
+	for _, v := range violations {
+		if v.Severity == "ERROR" {
+			return true
+		}
+	}
+	return false
+}
+
+// loadPolicy resolves the -policy flag value into a PasswordPolicy: "nist"
+// and "strict" select the matching preset, "" disables policy enforcement,
+// and anything else is read as a path to a JSON-encoded PasswordPolicy.
+func loadPolicy(name string) (*PasswordPolicy, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case "nist":
+		return NIST80063B(), nil
+	case "strict":
+		return Strict(), nil
+	default:
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("policy: %w", err)
+		}
+		var policy PasswordPolicy
+		if err := json.Unmarshal(data, &policy); err != nil {
+			return nil, fmt.Errorf("policy: invalid JSON in %s: %w", name, err)
+		}
+		return &policy, nil
+	}
+}
+
+// printPolicyViolations prints each violation under a banner, or nothing
+// if the password satisfied every rule.
+func printPolicyViolations(violations []PolicyViolation) {
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Println("\nüìã Policy Violations:")
+	for _, v := range violations {
+		fmt.Printf("  [%s] %s\n", v.Severity, v.Message)
+	}
+}
+
 // GeneratePassword generates a cryptographically secure password
 func GeneratePassword(length int, useUpper, useLower, useNumbers, useSpecial bool) (string, error) {
 	if length < 4 {
@@ -300,6 +594,200 @@ func GeneratePassword(length int, useUpper, useLower, useNumbers, useSpecial boo
 	return string(password), nil
 }
 
+// startConsonants and vowelLetters partition the pronounceable-generator
+// alphabet into the set a segment may start with and the set treated as
+// vowels for repeat-run and continuation purposes ("Y" counts as a vowel
+// here since it behaves like one in most English syllables).
+const (
+	startConsonants = "BCDFGHJKLMNPQRSTVWXZ"
+	vowelLetters    = "AEIOUY"
+)
+
+// consonantFollowers lists the letters that may plausibly follow each
+// consonant without producing an unpronounceable cluster: every vowel, plus
+// the handful of consonant digraphs that actually occur in English (e.g.
+// "CH", "TH", "ST", "TR"). Letters are deliberately omitted from a
+// consonant's set when no common English word follows that consonant with
+// it, so the walk can't wander into a cluster like "bq" or "vx".
+var consonantFollowers = map[byte]string{
+	'B': vowelLetters + "LR",
+	'C': vowelLetters + "HKLR",
+	'D': vowelLetters + "R",
+	'F': vowelLetters + "LR",
+	'G': vowelLetters + "LR",
+	'H': vowelLetters,
+	'J': vowelLetters,
+	'K': vowelLetters + "L",
+	'L': vowelLetters,
+	'M': vowelLetters,
+	'N': vowelLetters + "D",
+	'P': vowelLetters + "LR",
+	'Q': "U",
+	'R': vowelLetters,
+	'S': vowelLetters + "CKLMNPQTW",
+	'T': vowelLetters + "HR",
+	'V': vowelLetters,
+	'W': vowelLetters,
+	'X': vowelLetters,
+	'Z': vowelLetters,
+}
+
+// vowelFollowers lists the letters that may follow a vowel: any consonant
+// to start the next syllable, or another vowel to allow digraphs like "EA"
+// and "OU" (subject to the max-repeated-vowels invariant in
+// generateSegment).
+const vowelFollowers = startConsonants + vowelLetters
+
+// PronounceOpts configures GeneratePronounceable.
+type PronounceOpts struct {
+	MixedCase     bool   // randomly capitalize individual letters instead of an all-lowercase segment
+	InjectDigits  bool   // append a random digit after each segment
+	WordSeparator string // inserted between segments, e.g. "-" for xkcd-style output
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte(vowelLetters, b) != -1
+}
+
+// randIntn returns a uniformly random int in [0, n) using crypto/rand, the
+// same source GeneratePassword uses.
+func randIntn(n int) (int, error) {
+	num, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(num.Int64()), nil
+}
+
+// generateSegment walks the consonant/vowel state machine for segLen
+// letters, starting from a random consonant and sampling each subsequent
+// letter uniformly from the current letter's continuation table via
+// crypto/rand. It enforces at most two repeated vowels and two repeated
+// consonants in a row by dropping the offending class from the
+// continuation set, and returns the segment alongside the sum of
+// log2(len(allowed set)) across every step - the walk's true entropy in
+// bits, independent of CalculateEntropy's naive estimate.
+func generateSegment(segLen int) (string, float64, error) {
+	letters := make([]byte, 0, segLen)
+	entropy := 0.0
+	consonantRun, vowelRun := 0, 0
+
+	for i := 0; i < segLen; i++ {
+		allowed := startConsonants
+		if i > 0 {
+			prev := letters[i-1]
+			if isVowel(prev) {
+				allowed = vowelFollowers
+			} else {
+				allowed = consonantFollowers[prev]
+			}
+			if vowelRun >= 2 {
+				allowed = strings.Map(func(r rune) rune {
+					if strings.ContainsRune(vowelLetters, r) {
+						return -1
+					}
+					return r
+				}, allowed)
+			}
+			if consonantRun >= 2 {
+				allowed = strings.Map(func(r rune) rune {
+					if strings.ContainsRune(vowelLetters, r) {
+						return r
+					}
+					return -1
+				}, allowed)
+			}
+			if allowed == "" {
+				allowed = vowelLetters
+			}
+		}
+
+		idx, err := randIntn(len(allowed))
+		if err != nil {
+			return "", 0, err
+		}
+		letter := allowed[idx]
+		entropy += math.Log2(float64(len(allowed)))
+		letters = append(letters, letter)
+
+		if isVowel(letter) {
+			vowelRun++
+			consonantRun = 0
+		} else {
+			consonantRun++
+			vowelRun = 0
+		}
+	}
+
+	return string(letters), entropy, nil
+}
+
+// GeneratePronounceable produces a human-pronounceable password using a
+// Markov-style consonant/vowel state machine instead of sampling uniformly
+// from the full printable alphabet: it builds 3-7 letter segments from
+// per-letter continuation tables (see consonantFollowers/vowelFollowers) so
+// the result stays speakable, optionally joining segments with
+// opts.WordSeparator for xkcd-style multi-syllable output and injecting a
+// digit after each one.
+//
+// It returns the password alongside its true entropy in bits - the sum of
+// each step's log2(len(allowed set)), plus 1 bit per letter when
+// opts.MixedCase randomly capitalizes it - so callers can report the real
+// search space instead of CalculateEntropy's naive guess on what looks like
+// a small, low-entropy alphabet.
+func GeneratePronounceable(length int, opts PronounceOpts) (string, float64, error) {
+	if length < 3 {
+		return "", 0, fmt.Errorf("password length must be at least 3")
+	}
+
+	var out strings.Builder
+	entropy := 0.0
+
+	for out.Len() < length {
+		segLen, err := randIntn(5) // 3..7
+		if err != nil {
+			return "", 0, err
+		}
+		segLen += 3
+
+		if out.Len() > 0 && opts.WordSeparator != "" {
+			out.WriteString(opts.WordSeparator)
+		}
+
+		segment, segEntropy, err := generateSegment(segLen)
+		if err != nil {
+			return "", 0, err
+		}
+		entropy += segEntropy
+
+		letters := []byte(strings.ToLower(segment))
+		if opts.MixedCase {
+			for i, c := range letters {
+				bit, err := randIntn(2)
+				if err != nil {
+					return "", 0, err
+				}
+				if bit == 1 {
+					letters[i] = c - ('a' - 'A')
+				}
+				entropy++
+			}
+		}
+		out.Write(letters)
+
+		if opts.InjectDigits {
+			digit, err := randIntn(10)
+			if err != nil {
+				return "", 0, err
+			}
+			fmt.Fprintf(&out, "%d", digit)
+			entropy += math.Log2(10)
+		}
+	}
+
+	return out.String(), entropy, nil
+}
+
 func main() {
 	// Command-line flags
 	analyzePtr := flag.String("analyze", "", "Password to analyze")
@@ -309,6 +797,11 @@ func main() {
 	lowerPtr := flag.Bool("lowercase", true, "Include lowercase letters")
 	numbersPtr := flag.Bool("numbers", true, "Include numbers")
 	specialPtr := flag.Bool("special", true, "Include special characters")
+	pronounceablePtr := flag.Bool("pronounceable", false, "Generate a pronounceable password instead of a random one")
+	separatorPtr := flag.String("separator", "", "Separator inserted between pronounceable segments, e.g. \"-\"")
+	digitsPtr := flag.Bool("inject-digits", false, "Append a digit after each pronounceable segment")
+	checkBreachPtr := flag.Bool("check-breach", false, "Query the HIBP k-anonymity API to check -analyze's password against known breaches (off by default so analysis works offline)")
+	policyPtr := flag.String("policy", "", "Password policy to enforce: \"nist\", \"strict\", or a path to a custom JSON-encoded PasswordPolicy")
 
 	flag.Parse()
 
@@ -316,24 +809,93 @@ func main() {
 	fmt.Println("üîê Password Security Tool (Go)")
 	fmt.Println(strings.Repeat("=", 60))
 
+	policy, err := loadPolicy(*policyPtr)
+	if err != nil {
+		fmt.Printf("‚ùå %v\n", err)
+		return
+	}
+
 	if *analyzePtr != "" {
 		// Analyze mode
 		analyzer := NewPasswordAnalyzer(*analyzePtr)
+		if *checkBreachPtr {
+			if _, err := analyzer.CheckBreached(context.Background()); err != nil {
+				fmt.Printf("‚ö†Ô∏è  Could not check HIBP breach status: %v\n", err)
+			}
+		}
 		analyzer.PrintAnalysis()
+		if policy != nil {
+			printPolicyViolations(policy.Validate(*analyzePtr))
+		}
+	} else if *generatePtr && *pronounceablePtr {
+		// Pronounceable generate mode: when a policy is active, retry until
+		// the generated password clears every ERROR-severity rule instead
+		// of handing back the first (possibly non-compliant) attempt.
+		var password string
+		var trueEntropy float64
+		var violations []PolicyViolation
+		for attempt := 1; ; attempt++ {
+			password, trueEntropy, err = GeneratePronounceable(*lengthPtr, PronounceOpts{
+				MixedCase:     *upperPtr,
+				InjectDigits:  *digitsPtr,
+				WordSeparator: *separatorPtr,
+			})
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+			if policy == nil {
+				break
+			}
+			violations = policy.Validate(password)
+			if !hasErrorViolation(violations) {
+				break
+			}
+			if attempt >= maxPolicyAttempts {
+				fmt.Printf("⚠️  Could not generate a password satisfying the active policy after %d attempts; showing the last attempt.\n", attempt)
+				break
+			}
+		}
+
+		fmt.Printf("\n🎲 Generated Password: %s\n", password)
+		fmt.Printf("True Entropy: %.2f bits\n", trueEntropy)
+
+		// Analyze generated password
+		analyzer := NewPasswordAnalyzer(password)
+		score, rating := analyzer.CalculateStrengthScore()
+		fmt.Printf("Strength: %d/100 - %s\n", score, rating)
+		printPolicyViolations(violations)
+		fmt.Println(strings.Repeat("=", 60))
 	} else if *generatePtr {
-		// Generate mode
-		password, err := GeneratePassword(*lengthPtr, *upperPtr, *lowerPtr, *numbersPtr, *specialPtr)
-		if err != nil {
-			fmt.Printf("‚ùå Error: %v\n", err)
-			return
+		// Generate mode: same policy retry loop as pronounceable mode.
+		var password string
+		var violations []PolicyViolation
+		for attempt := 1; ; attempt++ {
+			password, err = GeneratePassword(*lengthPtr, *upperPtr, *lowerPtr, *numbersPtr, *specialPtr)
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+			if policy == nil {
+				break
+			}
+			violations = policy.Validate(password)
+			if !hasErrorViolation(violations) {
+				break
+			}
+			if attempt >= maxPolicyAttempts {
+				fmt.Printf("⚠️  Could not generate a password satisfying the active policy after %d attempts; showing the last attempt.\n", attempt)
+				break
+			}
 		}
 
-		fmt.Printf("\nüé≤ Generated Password: %s\n", password)
+		fmt.Printf("\n🎲 Generated Password: %s\n", password)
 
 		// Analyze generated password
 		analyzer := NewPasswordAnalyzer(password)
 		score, rating := analyzer.CalculateStrengthScore()
 		fmt.Printf("Strength: %d/100 - %s\n", score, rating)
+		printPolicyViolations(violations)
 		fmt.Println(strings.Repeat("=", 60))
 	} else {
 		// Interactive mode
@@ -11,6 +11,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"net"
 	"os"
@@ -19,16 +20,23 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/arya2004/cybersecurity/pkg/bruteforce"
+	"github.com/arya2004/cybersecurity/pkg/report"
+	"github.com/arya2004/cybersecurity/pkg/scripts"
 )
 
 // ScanResult represents the result of a port scan
 type ScanResult struct {
-	Host    string
-	Port    int
-	Open    bool
-	Service string
-	Banner  string
-	Error   error
+	Host     string
+	Port     int
+	Protocol string // "tcp" or "udp"; defaults to "tcp" for TCP-only call sites
+	Open     bool
+	Service  string
+	Banner   string
+	Error    error
+	CVE      string
+	Severity string
 }
 
 // NetworkScanner performs various network security scans
@@ -36,6 +44,8 @@ type NetworkScanner struct {
 	Timeout         time.Duration
 	MaxConcurrency  int
 	ServiceDatabase map[int]string
+	ScanMode        ScanMode     // connect (default) or syn
+	RateLimiter     *RateLimiter // nil disables rate limiting
 }
 
 // NewNetworkScanner creates a new network scanner instance
@@ -44,41 +54,55 @@ func NewNetworkScanner() *NetworkScanner {
 		Timeout:         5 * time.Second,
 		MaxConcurrency:  100,
 		ServiceDatabase: getCommonServices(),
+		ScanMode:        ModeConnect,
 	}
 }
 
 // getCommonServices returns a map of common ports to their services
 func getCommonServices() map[int]string {
 	return map[int]string{
-		21:   "FTP",
-		22:   "SSH",
-		23:   "Telnet",
-		25:   "SMTP",
-		53:   "DNS",
-		80:   "HTTP",
-		110:  "POP3",
-		143:  "IMAP",
-		443:  "HTTPS",
-		993:  "IMAPS",
-		995:  "POP3S",
-		1433: "MSSQL",
-		3306: "MySQL",
-		3389: "RDP",
-		5432: "PostgreSQL",
-		5900: "VNC",
-		6379: "Redis",
-		8080: "HTTP-Alt",
-		9200: "Elasticsearch",
+		21:    "FTP",
+		22:    "SSH",
+		23:    "Telnet",
+		25:    "SMTP",
+		53:    "DNS",
+		80:    "HTTP",
+		110:   "POP3",
+		143:   "IMAP",
+		443:   "HTTPS",
+		445:   "SMB",
+		993:   "IMAPS",
+		995:   "POP3S",
+		1433:  "MSSQL",
+		3306:  "MySQL",
+		3389:  "RDP",
+		5432:  "PostgreSQL",
+		5900:  "VNC",
+		6379:  "Redis",
+		8080:  "HTTP-Alt",
+		9200:  "Elasticsearch",
 		27017: "MongoDB",
 	}
 }
 
 // ScanTCPPort performs a TCP port scan on a single port
 func (ns *NetworkScanner) ScanTCPPort(host string, port int) ScanResult {
+	ns.RateLimiter.Wait()
+
+	if ns.ScanMode == ModeSYN {
+		result := rawSYNScan(host, port, ns.Timeout)
+		result.Protocol = "tcp"
+		if service, exists := ns.ServiceDatabase[port]; exists {
+			result.Service = service
+		}
+		return result
+	}
+
 	result := ScanResult{
-		Host: host,
-		Port: port,
-		Open: false,
+		Host:     host,
+		Port:     port,
+		Protocol: "tcp",
+		Open:     false,
 	}
 
 	// Set service name if known
@@ -119,7 +143,7 @@ func (ns *NetworkScanner) ScanPortRange(host string, startPort, endPort int) []S
 		wg.Add(1)
 		go func(p int) {
 			defer wg.Done()
-			semaphore <- struct{}{} // Acquire semaphore
+			semaphore <- struct{}{}        // Acquire semaphore
 			defer func() { <-semaphore }() // Release semaphore
 
 			result := ns.ScanTCPPort(host, p)
@@ -146,13 +170,18 @@ func (ns *NetworkScanner) ScanPortRange(host string, startPort, endPort int) []S
 	return results
 }
 
-// ScanCommonPorts scans the most common ports
+// commonUDPPorts are the UDP services ScanCommonPorts also probes:
+// everything udpProbes has a protocol-specific payload for.
+var commonUDPPorts = []int{53, 123, 137, 161, 500, 1900, 5353}
+
+// ScanCommonPorts scans the most common TCP ports, plus the common UDP
+// services in commonUDPPorts, so one call reports both transports.
 func (ns *NetworkScanner) ScanCommonPorts(host string) []ScanResult {
-	commonPorts := []int{21, 22, 23, 25, 53, 80, 110, 143, 443, 993, 995, 1433, 3306, 3389, 5432, 5900, 6379, 8080}
-	
+	commonPorts := []int{21, 22, 23, 25, 53, 80, 110, 143, 443, 445, 993, 995, 1433, 3306, 3389, 5432, 5900, 6379, 8080}
+
 	var results []ScanResult
 	var wg sync.WaitGroup
-	resultChan := make(chan ScanResult, len(commonPorts))
+	resultChan := make(chan ScanResult, len(commonPorts)+len(commonUDPPorts))
 	semaphore := make(chan struct{}, ns.MaxConcurrency)
 
 	for _, port := range commonPorts {
@@ -167,6 +196,18 @@ func (ns *NetworkScanner) ScanCommonPorts(host string) []ScanResult {
 		}(port)
 	}
 
+	for _, port := range commonUDPPorts {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result := ns.ScanUDPPort(host, p)
+			resultChan <- result
+		}(port)
+	}
+
 	go func() {
 		wg.Wait()
 		close(resultChan)
@@ -177,7 +218,10 @@ func (ns *NetworkScanner) ScanCommonPorts(host string) []ScanResult {
 	}
 
 	sort.Slice(results, func(i, j int) bool {
-		return results[i].Port < results[j].Port
+		if results[i].Port != results[j].Port {
+			return results[i].Port < results[j].Port
+		}
+		return results[i].Protocol < results[j].Protocol
 	})
 
 	return results
@@ -227,8 +271,9 @@ func (ns *NetworkScanner) DiscoverHosts(network string) []string {
 func (ns *NetworkScanner) isHostActive(host string) bool {
 	// Try TCP connect to common ports
 	commonPorts := []int{22, 80, 443}
-	
+
 	for _, port := range commonPorts {
+		ns.RateLimiter.Wait()
 		conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 1*time.Second)
 		if err == nil {
 			conn.Close()
@@ -253,38 +298,41 @@ func (ns *NetworkScanner) DetectOS(host string) string {
 	// Check for common OS-specific services and behaviors
 	sshResult := ns.ScanTCPPort(host, 22)
 	rdpResult := ns.ScanTCPPort(host, 3389)
-	
+
 	if rdpResult.Open {
 		return "Windows (RDP detected)"
 	}
-	
+
 	if sshResult.Open && strings.Contains(strings.ToLower(sshResult.Banner), "ubuntu") {
 		return "Ubuntu Linux"
 	}
-	
+
 	if sshResult.Open && strings.Contains(strings.ToLower(sshResult.Banner), "centos") {
 		return "CentOS Linux"
 	}
-	
+
 	if sshResult.Open {
 		return "Linux/Unix (SSH detected)"
 	}
-	
+
 	return "Unknown"
 }
 
 // VulnerabilityCheck performs basic vulnerability checks
 func (ns *NetworkScanner) VulnerabilityCheck(host string, results []ScanResult) []string {
 	var vulnerabilities []string
-	
-	for _, result := range results {
+
+	for i := range results {
+		result := &results[i]
 		if !result.Open {
 			continue
 		}
-		
+
 		switch result.Port {
 		case 21: // FTP
 			if strings.Contains(strings.ToLower(result.Banner), "vsftpd 2.3.4") {
+				result.CVE = "CVE-2011-2523"
+				result.Severity = "Critical"
 				vulnerabilities = append(vulnerabilities, "CVE-2011-2523: vsftpd 2.3.4 backdoor")
 			}
 		case 22: // SSH
@@ -297,24 +345,39 @@ func (ns *NetworkScanner) VulnerabilityCheck(host string, results []ScanResult)
 			vulnerabilities = append(vulnerabilities, "HTTP service - check for web vulnerabilities")
 		case 443: // HTTPS
 			vulnerabilities = append(vulnerabilities, "HTTPS service - verify SSL/TLS configuration")
+		case 445: // SMB
+			if vulnerable, err := ns.CheckMS17010(host); err == nil && vulnerable {
+				result.CVE = "CVE-2017-0144"
+				result.Severity = "Critical"
+				vulnerabilities = append(vulnerabilities, "CVE-2017-0144: MS17-010/EternalBlue - unpatched SMB1")
+			}
+			if vulnerable, err := ns.CheckSMBGhost(host); err == nil && vulnerable {
+				result.CVE = "CVE-2020-0796"
+				result.Severity = "Critical"
+				vulnerabilities = append(vulnerabilities, "CVE-2020-0796: SMBGhost - SMBv3 compression RCE")
+			}
 		case 3389: // RDP
 			vulnerabilities = append(vulnerabilities, "RDP exposed - potential brute force target")
 		}
 	}
-	
+
 	return vulnerabilities
 }
 
 // PrintResults prints scan results in a formatted manner
 func (ns *NetworkScanner) PrintResults(host string, results []ScanResult) {
 	fmt.Printf("\n=== Scan Results for %s ===\n", host)
-	fmt.Printf("%-8s %-12s %-15s %s\n", "Port", "State", "Service", "Banner")
+	fmt.Printf("%-8s %-6s %-12s %-15s %s\n", "Port", "Proto", "State", "Service", "Banner")
 	fmt.Println(strings.Repeat("-", 60))
-	
+
 	openPorts := 0
 	for _, result := range results {
 		if result.Open {
 			state := "open"
+			proto := result.Protocol
+			if proto == "" {
+				proto = "tcp"
+			}
 			service := result.Service
 			if service == "" {
 				service = "unknown"
@@ -323,17 +386,17 @@ func (ns *NetworkScanner) PrintResults(host string, results []ScanResult) {
 			if len(banner) > 30 {
 				banner = banner[:30] + "..."
 			}
-			fmt.Printf("%-8d %-12s %-15s %s\n", result.Port, state, service, banner)
+			fmt.Printf("%-8d %-6s %-12s %-15s %s\n", result.Port, proto, state, service, banner)
 			openPorts++
 		}
 	}
-	
+
 	fmt.Printf("\nSummary: %d open ports found\n", openPorts)
-	
+
 	// OS Detection
 	os := ns.DetectOS(host)
 	fmt.Printf("OS Detection: %s\n", os)
-	
+
 	// Vulnerability Check
 	vulns := ns.VulnerabilityCheck(host, results)
 	if len(vulns) > 0 {
@@ -342,6 +405,140 @@ func (ns *NetworkScanner) PrintResults(host string, results []ScanResult) {
 			fmt.Printf("- %s\n", vuln)
 		}
 	}
+	for _, result := range results {
+		if result.CVE != "" {
+			fmt.Printf("- [%s] %s confirmed on port %d via protocol-level probe\n", result.Severity, result.CVE, result.Port)
+		}
+	}
+}
+
+// bruteforceTargets converts the open, service-identified ports in results
+// into the Targets CrackWeakCredentials hands to the bruteforce package.
+func bruteforceTargets(host string, results []ScanResult) []bruteforce.Target {
+	var targets []bruteforce.Target
+	for _, result := range results {
+		if result.Open && result.Service != "" {
+			targets = append(targets, bruteforce.Target{Host: host, Port: result.Port, Service: result.Service})
+		}
+	}
+	return targets
+}
+
+// CrackWeakCredentials runs a weak-credential sweep against every open
+// service in results that bruteforce has a CredentialChecker for, reusing
+// ns.MaxConcurrency as the worker pool size.
+func (ns *NetworkScanner) CrackWeakCredentials(host string, results []ScanResult, opts bruteforce.Options) []bruteforce.CredentialFinding {
+	targets := bruteforceTargets(host, results)
+	if len(targets) == 0 {
+		return nil
+	}
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = ns.MaxConcurrency
+	}
+	return bruteforce.Run(targets, opts)
+}
+
+// PrintCredentialFindings reports the weak credentials CrackWeakCredentials
+// confirmed, in the same dash-bullet style as VulnerabilityCheck's output.
+func PrintCredentialFindings(findings []bruteforce.CredentialFinding) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Println("\nWeak Credentials Found:")
+	for _, f := range findings {
+		fmt.Printf("- [%s] %s:%d %s:%s\n", f.Service, f.Host, f.Port, f.Username, f.Password)
+	}
+}
+
+// scriptTargets converts the open, service-identified ports in results
+// into the Targets the scripts engine dispatches against.
+func scriptTargets(host string, results []ScanResult) []scripts.Target {
+	var targets []scripts.Target
+	for _, result := range results {
+		if result.Open && result.Service != "" {
+			targets = append(targets, scripts.Target{Host: host, Port: result.Port, Service: result.Service, Banner: result.Banner})
+		}
+	}
+	return targets
+}
+
+// RunScripts dispatches every registered NSE-style script in categories
+// against the open ports in results, reusing ns.MaxConcurrency as the
+// worker pool size. An empty categories falls back to scripts.DefaultCategories.
+func (ns *NetworkScanner) RunScripts(ctx context.Context, host string, results []ScanResult, categories []string) []scripts.Finding {
+	targets := scriptTargets(host, results)
+	if len(targets) == 0 {
+		return nil
+	}
+	return scripts.Run(ctx, targets, categories, ns.MaxConcurrency)
+}
+
+// PrintScriptFindings reports what RunScripts found, one line per script
+// per port, the same dash-bullet style as the other result sections.
+func PrintScriptFindings(findings []scripts.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Println("\nScript Results:")
+	for _, f := range findings {
+		fmt.Printf("- [%s] %s:%d %s\n", f.Script, f.Target.Host, f.Target.Port, f.Summary)
+	}
+}
+
+// buildReport converts one host's scan results and script findings into
+// the tool-agnostic report.Report model, for writeReportFile to render.
+func buildReport(host string, results []ScanResult, scriptFindings []scripts.Finding) report.Report {
+	findingsByPort := make(map[int][]report.Finding)
+	for _, f := range scriptFindings {
+		findingsByPort[f.Target.Port] = append(findingsByPort[f.Target.Port], report.Finding{
+			Source:  f.Script,
+			Summary: f.Summary,
+		})
+	}
+
+	rep := report.Report{Tool: "network-scanner", StartedAt: time.Now()}
+	h := report.Host{Address: host}
+	for _, r := range results {
+		protocol := r.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		state := "closed"
+		if r.Open {
+			state = "open"
+		} else if r.Error != nil {
+			state = "filtered"
+		}
+
+		port := report.Port{
+			Number:   r.Port,
+			Protocol: protocol,
+			State:    state,
+			Service:  r.Service,
+			Banner:   r.Banner,
+			Findings: findingsByPort[r.Port],
+		}
+		if r.CVE != "" {
+			port.CVEs = []string{r.CVE}
+		}
+		h.Ports = append(h.Ports, port)
+	}
+	rep.Hosts = append(rep.Hosts, h)
+	return rep
+}
+
+// writeReportFile renders rep in format and writes it to path, doing
+// nothing if either is empty so -o/-oFile stay optional.
+func writeReportFile(rep report.Report, format, path string) error {
+	if format == "" || path == "" {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	defer f.Close()
+	return report.Write(f, format, rep)
 }
 
 // Interactive menu system
@@ -356,34 +553,91 @@ func showMenu() {
 }
 
 func main() {
+	noCrack := flag.Bool("nocrack", false, "skip the weak-credential brute-force sweep after each scan")
+	userlistPath := flag.String("userlist", "", "path to a custom username wordlist (default: built-in list)")
+	passlistPath := flag.String("passlist", "", "path to a custom password wordlist (default: built-in list)")
+	scriptCategories := flag.String("scripts", "", "comma-separated script categories to run (default,safe,vuln,brute,discovery); empty runs the default set")
+	scanTypeFlag := flag.String("scan-type", string(ModeConnect), "scan type: connect|syn (syn needs CAP_NET_RAW/root; falls back to connect otherwise)")
+	maxRate := flag.Int("max-rate", 0, "maximum probes per second (0 = unlimited); recommended for syn scans of large CIDRs")
+	outFormat := flag.String("o", "", "structured report format to also write: json|xml|sarif (xml is nmap-compatible)")
+	outFile := flag.String("oFile", "", "path to write the -o report to")
+	flag.Parse()
+
+	var categories []string
+	if *scriptCategories != "" {
+		categories = strings.Split(*scriptCategories, ",")
+	}
+
+	scanMode, err := ParseScanMode(*scanTypeFlag)
+	if err != nil {
+		fmt.Printf("%v; falling back to connect scan\n", err)
+		scanMode = ModeConnect
+	}
+	scanMode = ResolveScanMode(scanMode, hasRawSocketCap)
+	if scanMode != ScanMode(*scanTypeFlag) {
+		fmt.Printf("Warning: -scan-type=%s requires CAP_NET_RAW/root; falling back to connect scan\n", *scanTypeFlag)
+	}
+
 	scanner := NewNetworkScanner()
+	scanner.ScanMode = scanMode
+	scanner.RateLimiter = NewRateLimiter(*maxRate)
+	defer scanner.RateLimiter.Stop()
+
 	reader := bufio.NewReader(os.Stdin)
-	
+	ctx := context.Background()
+
+	crackOpts := bruteforce.DefaultOptions()
+	crackOpts.MaxConcurrency = scanner.MaxConcurrency
+	if *userlistPath != "" {
+		users, err := bruteforce.LoadWordlist(*userlistPath)
+		if err != nil {
+			fmt.Printf("Warning: %v; using built-in username list\n", err)
+		} else {
+			crackOpts.Usernames = users
+		}
+	}
+	if *passlistPath != "" {
+		passwords, err := bruteforce.LoadWordlist(*passlistPath)
+		if err != nil {
+			fmt.Printf("Warning: %v; using built-in password list\n", err)
+		} else {
+			crackOpts.Passwords = passwords
+		}
+	}
+
 	fmt.Println("Network Security Scanner v1.0")
 	fmt.Println("Educational tool for cybersecurity learning")
 	fmt.Println("Use responsibly and only on networks you own or have permission to test")
-	
+
 	for {
 		showMenu()
-		
+
 		input, _ := reader.ReadString('\n')
 		choice := strings.TrimSpace(input)
-		
+
 		switch choice {
 		case "1":
 			fmt.Print("Enter target host/IP: ")
 			host, _ := reader.ReadString('\n')
 			host = strings.TrimSpace(host)
-			
+
 			fmt.Printf("Scanning common ports on %s...\n", host)
 			results := scanner.ScanCommonPorts(host)
 			scanner.PrintResults(host, results)
-			
+			if !*noCrack {
+				PrintCredentialFindings(scanner.CrackWeakCredentials(host, results, crackOpts))
+			}
+			scriptFindings := scanner.RunScripts(ctx, host, results, categories)
+			PrintScriptFindings(scriptFindings)
+			if err := writeReportFile(buildReport(host, results, scriptFindings), *outFormat, *outFile); err != nil {
+				fmt.Println(err)
+			}
+
 		case "2":
 			fmt.Print("Enter target host/IP: ")
 			host, _ := reader.ReadString('\n')
 			host = strings.TrimSpace(host)
-			
+
 			fmt.Print("Enter start port: ")
 			startPortStr, _ := reader.ReadString('\n')
 			startPort, err := strconv.Atoi(strings.TrimSpace(startPortStr))
@@ -391,7 +645,7 @@ func main() {
 				fmt.Println("Invalid start port")
 				continue
 			}
-			
+
 			fmt.Print("Enter end port: ")
 			endPortStr, _ := reader.ReadString('\n')
 			endPort, err := strconv.Atoi(strings.TrimSpace(endPortStr))
@@ -399,45 +653,62 @@ func main() {
 				fmt.Println("Invalid end port")
 				continue
 			}
-			
+
 			fmt.Printf("Scanning ports %d-%d on %s...\n", startPort, endPort, host)
 			results := scanner.ScanPortRange(host, startPort, endPort)
 			scanner.PrintResults(host, results)
-			
+			if !*noCrack {
+				PrintCredentialFindings(scanner.CrackWeakCredentials(host, results, crackOpts))
+			}
+			scriptFindings := scanner.RunScripts(ctx, host, results, categories)
+			PrintScriptFindings(scriptFindings)
+			if err := writeReportFile(buildReport(host, results, scriptFindings), *outFormat, *outFile); err != nil {
+				fmt.Println(err)
+			}
+
 		case "3":
 			fmt.Print("Enter network CIDR (e.g., 192.168.1.0/24): ")
 			network, _ := reader.ReadString('\n')
 			network = strings.TrimSpace(network)
-			
+
 			fmt.Printf("Discovering hosts in %s...\n", network)
 			hosts := scanner.DiscoverHosts(network)
-			
+
 			fmt.Printf("\nDiscovered %d active hosts:\n", len(hosts))
 			for _, host := range hosts {
 				fmt.Printf("- %s\n", host)
 			}
-			
+
 		case "4":
 			fmt.Print("Enter target host/IP: ")
 			host, _ := reader.ReadString('\n')
 			host = strings.TrimSpace(host)
-			
+
 			fmt.Printf("Performing comprehensive scan on %s...\n", host)
-			
+
 			// Scan common ports
 			results := scanner.ScanCommonPorts(host)
 			scanner.PrintResults(host, results)
-			
+
 			// Additional detailed scan for open ports
 			if len(results) > 0 {
 				fmt.Println("\nPerforming detailed service enumeration...")
 				// Here you could add more detailed service enumeration
 			}
-			
+
+			if !*noCrack {
+				PrintCredentialFindings(scanner.CrackWeakCredentials(host, results, crackOpts))
+			}
+			scriptFindings := scanner.RunScripts(ctx, host, results, categories)
+			PrintScriptFindings(scriptFindings)
+			if err := writeReportFile(buildReport(host, results, scriptFindings), *outFormat, *outFile); err != nil {
+				fmt.Println(err)
+			}
+
 		case "5":
 			fmt.Println("Exiting...")
 			return
-			
+
 		default:
 			fmt.Println("Invalid option. Please try again.")
 		}
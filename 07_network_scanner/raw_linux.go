@@ -0,0 +1,174 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+// hasRawSocketCap reports whether the process can open raw sockets, by
+// attempting to open one and immediately closing it. This covers both
+// "running as root" and "has CAP_NET_RAW".
+func hasRawSocketCap() bool {
+	if os.Geteuid() == 0 {
+		return true
+	}
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_RAW, syscall.IPPROTO_TCP)
+	if err != nil {
+		return false
+	}
+	syscall.Close(fd)
+	return true
+}
+
+// buildSYNHeader constructs a minimal 20-byte TCP SYN header (no options)
+// plus the checksum computed over the pseudo-header + header, per RFC 793.
+func buildSYNHeader(srcIP, dstIP net.IP, srcPort, dstPort uint16, seq uint32) []byte {
+	header := make([]byte, 20)
+	binary.BigEndian.PutUint16(header[0:2], srcPort)
+	binary.BigEndian.PutUint16(header[2:4], dstPort)
+	binary.BigEndian.PutUint32(header[4:8], seq)
+	binary.BigEndian.PutUint32(header[8:12], 0) // ack number
+	header[12] = 5 << 4                         // data offset: 5 words, no options
+	header[13] = 0x02                           // flags: SYN
+	binary.BigEndian.PutUint16(header[14:16], 65535)
+	binary.BigEndian.PutUint16(header[16:18], 0) // checksum, filled below
+	binary.BigEndian.PutUint16(header[18:20], 0)
+
+	checksum := tcpChecksum(srcIP, dstIP, header)
+	binary.BigEndian.PutUint16(header[16:18], checksum)
+	return header
+}
+
+func tcpChecksum(srcIP, dstIP net.IP, tcpSegment []byte) uint16 {
+	pseudo := make([]byte, 0, 12+len(tcpSegment))
+	pseudo = append(pseudo, srcIP.To4()...)
+	pseudo = append(pseudo, dstIP.To4()...)
+	pseudo = append(pseudo, 0, syscall.IPPROTO_TCP)
+	pseudo = append(pseudo, byte(len(tcpSegment)>>8), byte(len(tcpSegment)))
+	pseudo = append(pseudo, tcpSegment...)
+	return checksum16(pseudo)
+}
+
+func checksum16(data []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(data); i += 2 {
+		sum += uint32(data[i])<<8 | uint32(data[i+1])
+	}
+	if len(data)%2 == 1 {
+		sum += uint32(data[len(data)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
+
+// rawSYNScan sends a single crafted SYN segment and classifies the reply
+// per the classic half-open scan semantics: SYN-ACK means open, RST means
+// closed, no reply within timeout means filtered. It never completes the
+// handshake, so the target never sees an established connection - and
+// since the kernel doesn't know about this raw-socket SYN, it will itself
+// emit a stray RST on a SYN-ACK reply; that race doesn't affect the
+// classification since we've already read the SYN-ACK by the time it
+// lands.
+func rawSYNScan(host string, port int, timeout time.Duration) ScanResult {
+	result := ScanResult{Host: host, Port: port}
+
+	dstAddr, err := net.ResolveIPAddr("ip4", host)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	sendFD, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_RAW, syscall.IPPROTO_TCP)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer syscall.Close(sendFD)
+
+	recvFD, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_RAW, syscall.IPPROTO_TCP)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer syscall.Close(recvFD)
+	tv := syscall.NsecToTimeval(timeout.Nanoseconds())
+	syscall.SetsockoptTimeval(recvFD, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv)
+
+	srcPort := uint16(40000 + port%10000)
+	localIP := outboundIP(dstAddr.IP)
+	segment := buildSYNHeader(localIP, dstAddr.IP, srcPort, uint16(port), 0)
+
+	var sockAddr syscall.SockaddrInet4
+	copy(sockAddr.Addr[:], dstAddr.IP.To4())
+	if err := syscall.Sendto(sendFD, segment, 0, &sockAddr); err != nil {
+		result.Error = err
+		return result
+	}
+
+	buf := make([]byte, 4096)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		n, _, err := syscall.Recvfrom(recvFD, buf, 0)
+		if err != nil || n < 20 {
+			break
+		}
+		reply := parseTCPReply(buf[:n])
+		if reply == nil || reply.srcPort != uint16(port) || reply.dstPort != srcPort {
+			continue
+		}
+		if reply.syn && reply.ack {
+			result.Open = true
+		}
+		return result
+	}
+
+	// No reply within the timeout: treat as closed/filtered, the same
+	// undetermined state ScanTCPPort's connect-refused path reports.
+	result.Error = fmt.Errorf("no response (filtered)")
+	return result
+}
+
+type tcpReplyFlags struct {
+	srcPort, dstPort uint16
+	syn, ack, rst    bool
+}
+
+// parseTCPReply interprets a raw IPv4 packet read from a SOCK_RAW socket,
+// whose payload starts with the IP header followed by the TCP header.
+func parseTCPReply(packet []byte) *tcpReplyFlags {
+	if len(packet) < 20 {
+		return nil
+	}
+	ihl := int(packet[0]&0x0f) * 4
+	if len(packet) < ihl+20 {
+		return nil
+	}
+	tcp := packet[ihl:]
+	flags := tcp[13]
+	return &tcpReplyFlags{
+		srcPort: binary.BigEndian.Uint16(tcp[0:2]),
+		dstPort: binary.BigEndian.Uint16(tcp[2:4]),
+		syn:     flags&0x02 != 0,
+		ack:     flags&0x10 != 0,
+		rst:     flags&0x04 != 0,
+	}
+}
+
+// outboundIP returns the local address the kernel would use to reach dst,
+// by opening a UDP "connection" (which performs no I/O) and inspecting it.
+func outboundIP(dst net.IP) net.IP {
+	conn, err := net.Dial("udp", net.JoinHostPort(dst.String(), "80"))
+	if err != nil {
+		return net.IPv4zero
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP
+}
@@ -0,0 +1,19 @@
+//go:build !linux
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// hasRawSocketCap is always false on non-Linux platforms: raw TCP/IP
+// crafting here is Linux-specific (syscall.SOCK_RAW with a hand-built TCP
+// header).
+func hasRawSocketCap() bool { return false }
+
+// rawSYNScan is unreachable on non-Linux platforms because ResolveScanMode
+// always falls back to ModeConnect when hasRawSocketCap is false.
+func rawSYNScan(host string, port int, timeout time.Duration) ScanResult {
+	return ScanResult{Host: host, Port: port, Error: fmt.Errorf("SYN scan unsupported on this platform")}
+}
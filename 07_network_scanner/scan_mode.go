@@ -0,0 +1,38 @@
+package main
+
+// ScanMode selects how NetworkScanner probes each port: a normal TCP
+// three-way handshake, or a half-open SYN probe that never completes the
+// handshake (faster, and leaves no connection in the target's accept
+// queue).
+type ScanMode string
+
+const (
+	ModeConnect ScanMode = "connect"
+	ModeSYN     ScanMode = "syn"
+)
+
+// ParseScanMode validates a -scan-type flag value.
+func ParseScanMode(s string) (ScanMode, error) {
+	switch ScanMode(s) {
+	case ModeConnect, ModeSYN:
+		return ScanMode(s), nil
+	default:
+		return "", errInvalidScanMode(s)
+	}
+}
+
+type errInvalidScanMode string
+
+func (e errInvalidScanMode) Error() string {
+	return "invalid scan type: " + string(e)
+}
+
+// ResolveScanMode falls back to ModeConnect (with a warning printed by the
+// caller) when ModeSYN was requested but the process lacks the raw-socket
+// privilege it needs.
+func ResolveScanMode(requested ScanMode, hasRawSocketCap func() bool) ScanMode {
+	if requested == ModeSYN && !hasRawSocketCap() {
+		return ModeConnect
+	}
+	return requested
+}
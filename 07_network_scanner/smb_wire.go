@@ -0,0 +1,308 @@
+// Minimal SMB1/SMB2 wire encoding for smb.go's probes: just enough of
+// MS-CIFS (SMB1) and MS-SMB2 to negotiate, establish a null session, send
+// a Trans2 SESSION_SETUP, and parse an SMB2 NEGOTIATE response's
+// negotiate context list. Not a general SMB client - scoped to exactly
+// the requests CheckMS17010/CheckSMBGhost need.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// --- NetBIOS session service framing ---------------------------------
+
+// wrapNetBIOS prepends the 4-byte NetBIOS Session Service header (type 0,
+// 24-bit big-endian length) every SMB1/SMB2 message over TCP/445 is
+// framed in.
+func wrapNetBIOS(msg []byte) []byte {
+	out := make([]byte, 4+len(msg))
+	out[0] = 0x00
+	out[1] = byte(len(msg) >> 16)
+	out[2] = byte(len(msg) >> 8)
+	out[3] = byte(len(msg))
+	copy(out[4:], msg)
+	return out
+}
+
+// readSMBResponse reads one NetBIOS-framed SMB1/SMB2 message from conn
+// and returns the message body (header + payload, NetBIOS framing
+// stripped).
+func readSMBResponse(conn net.Conn) ([]byte, error) {
+	var nb [4]byte
+	if _, err := io.ReadFull(conn, nb[:]); err != nil {
+		return nil, err
+	}
+	length := int(nb[1])<<16 | int(nb[2])<<8 | int(nb[3])
+	msg := make([]byte, length)
+	if _, err := io.ReadFull(conn, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// --- SMB1 (MS-CIFS) ----------------------------------------------------
+
+const (
+	smb1CommandNegotiate    = 0x72
+	smb1CommandSessionSetup = 0x73
+	smb1CommandTransaction2 = 0x32
+	smb1Trans2SessionSetup  = 0x000e
+	smb1Flags2NTStatus      = 0x4000
+	smb1Flags2ExtendedAttrs = 0x0001
+)
+
+// smb1Header builds the fixed 32-byte SMB1 header. Flags2 always requests
+// NT status codes so responses carry the 32-bit NTSTATUS values
+// CheckMS17010 switches on, rather than the legacy DOS error class/code.
+func smb1Header(command byte, tid, uid, mid uint16) []byte {
+	h := make([]byte, 32)
+	copy(h[0:4], []byte{0xFF, 'S', 'M', 'B'})
+	h[4] = command
+	binary.LittleEndian.PutUint16(h[10:12], smb1Flags2NTStatus|smb1Flags2ExtendedAttrs)
+	binary.LittleEndian.PutUint16(h[24:26], tid)
+	binary.LittleEndian.PutUint16(h[28:30], uid)
+	binary.LittleEndian.PutUint16(h[30:32], mid)
+	return h
+}
+
+// smb1NegotiateRequest builds SMB_COM_NEGOTIATE advertising a single
+// dialect, "NT LM 0.12" - the last pre-SMB2 CIFS dialect and the one
+// MS17-010's probe depends on.
+func smb1NegotiateRequest() []byte {
+	body := smb1Header(smb1CommandNegotiate, 0, 0, 0)
+	body = append(body, 0x00) // WordCount
+	dialect := append([]byte{0x02}, []byte("NT LM 0.12\x00")...)
+	body = append(body, byte(len(dialect)), byte(len(dialect)>>8))
+	body = append(body, dialect...)
+	return wrapNetBIOS(body)
+}
+
+// smb1SessionSetupRequest builds a classic (non-extended-security)
+// SMB_COM_SESSION_SETUP_ANDX request with an empty account/password,
+// i.e. a null/anonymous session - sufficient to reach the Trans2 handler
+// MS17-010 targets.
+func smb1SessionSetupRequest() []byte {
+	body := smb1Header(smb1CommandSessionSetup, 0, 0, 0)
+
+	words := make([]byte, 26)                       // 13 words
+	words[0] = 0xFF                                 // AndXCommand: none
+	binary.LittleEndian.PutUint16(words[2:4], 0)    // AndXOffset
+	binary.LittleEndian.PutUint16(words[4:6], 4356) // MaxBufferSize
+	binary.LittleEndian.PutUint16(words[6:8], 2)    // MaxMpxCount
+	binary.LittleEndian.PutUint16(words[8:10], 1)   // VcNumber
+	binary.LittleEndian.PutUint32(words[10:14], 0)  // SessionKey
+	binary.LittleEndian.PutUint16(words[14:16], 0)  // OEMPasswordLen
+	binary.LittleEndian.PutUint16(words[16:18], 0)  // UnicodePasswordLen
+	// words[18:22] Reserved, left zero.
+	binary.LittleEndian.PutUint32(words[22:26], 0) // Capabilities
+
+	body = append(body, 13)
+	body = append(body, words...)
+
+	bytesField := []byte{0x00, 0x00}                     // empty AccountName, PrimaryDomain (OEM strings)
+	bytesField = append(bytesField, []byte("Go\x00")...) // NativeOS
+	bytesField = append(bytesField, []byte("Go\x00")...) // NativeLanMan
+	body = append(body, byte(len(bytesField)), byte(len(bytesField)>>8))
+	body = append(body, bytesField...)
+
+	return wrapNetBIOS(body)
+}
+
+// smb1ResponseUID extracts the UID SMB_COM_SESSION_SETUP_ANDX's response
+// assigned, so the Trans2 probe can run inside that session.
+func smb1ResponseUID(resp []byte) uint16 {
+	if len(resp) < 30 {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(resp[28:30])
+}
+
+// smb1ResponseStatus extracts the NTSTATUS from an SMB1 response header
+// (valid because every request here sets smb1Flags2NTStatus).
+func smb1ResponseStatus(resp []byte) uint32 {
+	if len(resp) < 9 {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(resp[5:9])
+}
+
+// smb1Trans2SessionSetupRequest builds SMB_COM_TRANSACTION2 selecting
+// subcommand TRANS2_SESSION_SETUP (0x000e) against fid 0xffff, a
+// guaranteed-invalid file handle. An unpatched MS17-010 target mishandles
+// this combination and replies STATUS_INSUFF_SERVER_RESOURCES instead of
+// rejecting it outright.
+func smb1Trans2SessionSetupRequest(uid uint16) []byte {
+	const invalidFID = 0xffff
+
+	params := make([]byte, 4)
+	binary.LittleEndian.PutUint16(params[0:2], invalidFID)
+	binary.LittleEndian.PutUint16(params[2:4], 0) // Level of interest
+
+	data := []byte{0x00, 0x00} // empty Trans2_FSCTL data payload
+
+	body := smb1Header(smb1CommandTransaction2, 0, uid, 0)
+
+	// Parameters and data immediately follow WordCount(1) + words(28) +
+	// setup(2) + ByteCount(2), all of which precede them in the message.
+	const fixedWordsSize = 28 // 14 fixed words
+	const setupSize = 2       // 1 setup word (the subcommand)
+	paramOffset := uint16(len(body) + 1 + fixedWordsSize + setupSize + 2)
+	dataOffset := paramOffset + uint16(len(params))
+
+	words := make([]byte, fixedWordsSize)
+	binary.LittleEndian.PutUint16(words[0:2], uint16(len(params)))   // TotalParameterCount
+	binary.LittleEndian.PutUint16(words[2:4], uint16(len(data)))     // TotalDataCount
+	binary.LittleEndian.PutUint16(words[4:6], 1024)                  // MaxParameterCount
+	binary.LittleEndian.PutUint16(words[6:8], 4096)                  // MaxDataCount
+	words[8] = 0                                                     // MaxSetupCount
+	words[9] = 0                                                     // Reserved1
+	binary.LittleEndian.PutUint16(words[10:12], 0)                   // Flags
+	binary.LittleEndian.PutUint32(words[12:16], 0)                   // Timeout
+	binary.LittleEndian.PutUint16(words[16:18], 0)                   // Reserved2
+	binary.LittleEndian.PutUint16(words[18:20], uint16(len(params))) // ParameterCount
+	binary.LittleEndian.PutUint16(words[20:22], paramOffset)         // ParameterOffset
+	binary.LittleEndian.PutUint16(words[22:24], uint16(len(data)))   // DataCount
+	binary.LittleEndian.PutUint16(words[24:26], dataOffset)          // DataOffset
+	words[26] = 1                                                    // SetupCount
+	words[27] = 0                                                    // Reserved3
+
+	setup := make([]byte, setupSize)
+	binary.LittleEndian.PutUint16(setup, smb1Trans2SessionSetup)
+
+	body = append(body, 15) // WordCount = 14 fixed words + 1 setup word
+	body = append(body, words...)
+	body = append(body, setup...)
+
+	payload := append(append([]byte{}, params...), data...)
+	body = append(body, byte(len(payload)), byte(len(payload)>>8))
+	body = append(body, payload...)
+
+	return wrapNetBIOS(body)
+}
+
+// --- SMB2 (MS-SMB2) -----------------------------------------------------
+
+const (
+	smb2CommandNegotiate = 0x0000
+	smb2DialectSMB311    = 0x0311
+)
+
+// smb2Header builds the fixed 64-byte SMB2 header for a synchronous
+// request.
+func smb2Header(command uint16, messageID uint64) []byte {
+	h := make([]byte, 64)
+	copy(h[0:4], []byte{0xFE, 'S', 'M', 'B'})
+	binary.LittleEndian.PutUint16(h[4:6], 64)
+	binary.LittleEndian.PutUint16(h[12:14], command)
+	binary.LittleEndian.PutUint16(h[14:16], 1) // CreditRequest
+	binary.LittleEndian.PutUint64(h[24:32], messageID)
+	return h
+}
+
+// smb2NegotiateRequest builds an SMB2 NEGOTIATE advertising SMB 3.1.1
+// (among the usual fallback dialects) with the
+// SMB2_PREAUTH_INTEGRITY_CAPABILITIES negotiate context that's mandatory
+// whenever 3.1.1 is offered - required for SMBGhost's signature to even
+// be reachable.
+func smb2NegotiateRequest() []byte {
+	dialects := []uint16{0x0202, 0x0210, 0x0300, 0x0302, smb2DialectSMB311}
+
+	body := make([]byte, 36)
+	binary.LittleEndian.PutUint16(body[0:2], 36) // StructureSize
+	binary.LittleEndian.PutUint16(body[2:4], uint16(len(dialects)))
+	binary.LittleEndian.PutUint16(body[4:6], 1) // SecurityMode: signing enabled
+	// body[6:8] Reserved, body[8:12] Capabilities: left zero.
+	clientGUID := make([]byte, 16)
+	rand.Read(clientGUID)
+	copy(body[12:28], clientGUID)
+	// body[28:36] (NegotiateContextOffset/Count/Reserved2) filled in below,
+	// once the dialect list and context list's own offset are known.
+
+	for _, d := range dialects {
+		dialectBytes := make([]byte, 2)
+		binary.LittleEndian.PutUint16(dialectBytes, d)
+		body = append(body, dialectBytes...)
+	}
+
+	// Negotiate contexts start 8-byte aligned relative to the SMB2 header.
+	headerLen := 64
+	for (headerLen+len(body))%8 != 0 {
+		body = append(body, 0x00)
+	}
+	contextOffset := headerLen + len(body)
+
+	salt := make([]byte, 32)
+	rand.Read(salt)
+	preauthData := make([]byte, 4+2+len(salt))
+	binary.LittleEndian.PutUint16(preauthData[0:2], 1)                 // HashAlgorithmCount
+	binary.LittleEndian.PutUint16(preauthData[2:4], uint16(len(salt))) // SaltLength
+	binary.LittleEndian.PutUint16(preauthData[4:6], 0x0001)            // SHA-512
+	copy(preauthData[6:], salt)
+
+	ctx := make([]byte, 8+len(preauthData))
+	binary.LittleEndian.PutUint16(ctx[0:2], smb2PreauthIntegrityCapabilities)
+	binary.LittleEndian.PutUint16(ctx[2:4], uint16(len(preauthData)))
+	copy(ctx[8:], preauthData)
+	body = append(body, ctx...)
+
+	binary.LittleEndian.PutUint32(body[28:32], uint32(contextOffset))
+	binary.LittleEndian.PutUint16(body[32:34], 1) // NegotiateContextCount
+
+	msg := append(smb2Header(smb2CommandNegotiate, 0), body...)
+	return wrapNetBIOS(msg)
+}
+
+// smb2NegotiateResponseCompressionAlgorithms parses an SMB2 NEGOTIATE
+// response for a SMB2_COMPRESSION_CAPABILITIES negotiate context and
+// returns the algorithms it advertises. Returns an error if the response
+// isn't a 3.1.1 negotiate with a context list at all.
+func smb2NegotiateResponseCompressionAlgorithms(resp []byte) ([]uint16, error) {
+	const headerLen = 64
+	if len(resp) < headerLen+66 {
+		return nil, fmt.Errorf("smb2 negotiate response too short")
+	}
+	body := resp[headerLen:]
+
+	dialectRevision := binary.LittleEndian.Uint16(body[4:6])
+	if dialectRevision != smb2DialectSMB311 {
+		return nil, fmt.Errorf("server did not negotiate SMB 3.1.1")
+	}
+
+	contextCount := binary.LittleEndian.Uint16(body[6:8])
+	contextOffset := binary.LittleEndian.Uint32(body[60:64])
+	if contextCount == 0 || int(contextOffset) >= len(resp) {
+		return nil, fmt.Errorf("no negotiate context list present")
+	}
+
+	var algos []uint16
+	offset := int(contextOffset)
+	for i := uint16(0); i < contextCount && offset+8 <= len(resp); i++ {
+		ctxType := binary.LittleEndian.Uint16(resp[offset : offset+2])
+		dataLen := binary.LittleEndian.Uint16(resp[offset+2 : offset+4])
+		dataStart := offset + 8
+		dataEnd := dataStart + int(dataLen)
+		if dataEnd > len(resp) {
+			break
+		}
+
+		if ctxType == smb2CompressionCapabilities && dataLen >= 4 {
+			data := resp[dataStart:dataEnd]
+			algoCount := binary.LittleEndian.Uint16(data[0:2])
+			for a := 0; a < int(algoCount) && 8+a*2+2 <= len(data); a++ {
+				algos = append(algos, binary.LittleEndian.Uint16(data[8+a*2:10+a*2]))
+			}
+		}
+
+		// Contexts are individually padded to an 8-byte boundary.
+		advance := 8 + int(dataLen)
+		if pad := advance % 8; pad != 0 {
+			advance += 8 - pad
+		}
+		offset += advance
+	}
+	return algos, nil
+}
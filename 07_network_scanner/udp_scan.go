@@ -0,0 +1,408 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// udpProbes carries protocol-specific payloads for the handful of UDP
+// services that never reply to an empty datagram, keyed by the port they
+// target. A service with no entry here still gets a zero-length probe,
+// which is enough for anything that replies unconditionally.
+var udpProbes = map[int][]byte{
+	53:   dnsVersionBindProbe(),
+	123:  ntpClientProbe(),
+	137:  netbiosNodeStatusProbe(),
+	161:  snmpGetRequestProbe(),
+	500:  ikeMainModeProbe(),
+	1900: ssdpSearchProbe(),
+	5353: mdnsServicesProbe(),
+}
+
+// dnsVersionBindProbe builds a CHAOS/TXT query for "version.bind", the
+// same probe the scripts package's dns-version-bind NSE script sends, just
+// duplicated here since the scanner core doesn't depend on that package.
+func dnsVersionBindProbe() []byte {
+	msg := []byte{
+		0x13, 0x37, // transaction ID
+		0x00, 0x00, // flags: standard query
+		0x00, 0x01, // QDCOUNT
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // ANCOUNT, NSCOUNT, ARCOUNT
+	}
+	for _, label := range strings.Split("version.bind", ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0x00)
+	msg = append(msg, 0, 16) // QTYPE  = TXT
+	msg = append(msg, 0, 3)  // QCLASS = CH
+	return msg
+}
+
+// snmpGetRequestProbe builds a minimal SNMPv1 GetRequest for
+// sysDescr.0 (1.3.6.1.2.1.1.1.0) under the "public" community, BER-encoded
+// by hand since this tool has no ASN.1 dependency.
+func snmpGetRequestProbe() []byte {
+	oid := []byte{0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x01, 0x00} // 1.3.6.1.2.1.1.1.0, first two arcs combined per X.690
+	varBind := berSequence(append(berOID(oid), berNull()...))
+	varBindList := berSequence(varBind)
+
+	pdu := berSequence(concatBytes(
+		berInt(1),          // request ID
+		berInt(0),          // error status
+		berInt(0),          // error index
+		varBindList,
+	))
+	pdu[0] = 0xA0 // PDU type: GetRequest (context-specific, constructed, tag 0)
+
+	community := berOctetString([]byte("public"))
+	message := berSequence(concatBytes(
+		berInt(0), // SNMP version 1
+		community,
+		pdu,
+	))
+	return message
+}
+
+func concatBytes(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func berLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var lenBytes []byte
+	for n > 0 {
+		lenBytes = append([]byte{byte(n)}, lenBytes...)
+		n >>= 8
+	}
+	return append([]byte{0x80 | byte(len(lenBytes))}, lenBytes...)
+}
+
+func berSequence(content []byte) []byte {
+	return append(append([]byte{0x30}, berLength(len(content))...), content...)
+}
+
+func berInt(v int) []byte {
+	return append([]byte{0x02, 0x01}, byte(v))
+}
+
+func berOctetString(s []byte) []byte {
+	return append(append([]byte{0x04}, berLength(len(s))...), s...)
+}
+
+func berOID(encoded []byte) []byte {
+	return append(append([]byte{0x06}, berLength(len(encoded))...), encoded...)
+}
+
+func berNull() []byte {
+	return []byte{0x05, 0x00}
+}
+
+// netbiosNodeStatusProbe builds a NetBIOS Name Service NBSTAT query for
+// "*" (the wildcard name every NetBIOS host answers, carrying its real
+// computer name in the reply) against UDP/137.
+func netbiosNodeStatusProbe() []byte {
+	msg := []byte{
+		0x13, 0x38, // transaction ID
+		0x00, 0x00, // flags: standard query
+		0x00, 0x01, // QDCOUNT
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	// The wildcard name is 16 bytes: '*' followed by 15 NUL bytes,
+	// first-level-encoded into 32 bytes of 'A'-'P' nibble pairs.
+	name := append([]byte{'*'}, make([]byte, 15)...)
+	encoded := make([]byte, 32)
+	for i, b := range name {
+		encoded[i*2] = 'A' + (b >> 4)
+		encoded[i*2+1] = 'A' + (b & 0x0f)
+	}
+	msg = append(msg, 32)
+	msg = append(msg, encoded...)
+	msg = append(msg, 0x00)
+	msg = append(msg, 0x00, 0x21) // QTYPE  = NBSTAT
+	msg = append(msg, 0x00, 0x01) // QCLASS = IN
+	return msg
+}
+
+// ntpClientProbe builds an NTP mode-3 (client) request: a 48-byte packet
+// with LI=0, VN=4, Mode=3 and everything else zeroed, the minimum a
+// server needs to reply with a mode-4 response.
+func ntpClientProbe() []byte {
+	packet := make([]byte, 48)
+	packet[0] = (4 << 3) | 3 // VN=4, Mode=3
+	return packet
+}
+
+// ikeMainModeProbe builds a minimal ISAKMP header plus a single-proposal
+// SA payload offering one transform, enough to elicit a reply from an
+// IKEv1 responder on UDP/500. Not a negotiable SA - scoped to detection,
+// not key exchange.
+func ikeMainModeProbe() []byte {
+	const (
+		isakmpHeaderLen = 28
+		payloadTypeSA   = 1
+		payloadTypeNone = 0
+		exchangeMain    = 2
+	)
+
+	transform := []byte{
+		0, 0, 0, 8, // next payload (0 = none), reserved, payload length
+		1, 1, 0, 0, // transform #, transform ID, reserved
+	}
+	transform[2] = byte(len(transform) >> 8)
+	transform[3] = byte(len(transform))
+
+	proposal := append([]byte{
+		0, 0, 0, 0, // next payload, reserved, payload length (filled below)
+		1, 1, 0, 1, // proposal #, protocol ID (ISAKMP), SPI size, # transforms
+	}, transform...)
+	binary.BigEndian.PutUint16(proposal[2:4], uint16(len(proposal)))
+
+	saPayload := append([]byte{
+		0, 0, 0, 0, // next payload, reserved, payload length (filled below)
+		0, 0, 0, 1, // DOI = IPSEC
+		0, 0, 0, 1, // situation = identity only
+	}, proposal...)
+	binary.BigEndian.PutUint16(saPayload[2:4], uint16(len(saPayload)))
+
+	header := make([]byte, isakmpHeaderLen)
+	// Initiator cookie: random-looking but fixed so replies can be
+	// recognized; responder cookie stays zero until one is assigned.
+	copy(header[0:8], []byte{0xDE, 0xAD, 0xBE, 0xEF, 0xCA, 0xFE, 0xBA, 0xBE})
+	header[16] = payloadTypeSA
+	header[17] = 0x10 // version 1.0
+	header[18] = exchangeMain
+	binary.BigEndian.PutUint32(header[24:28], uint32(len(header)+len(saPayload)))
+
+	return append(header, saPayload...)
+}
+
+// mdnsServicesProbe builds an mDNS query for "_services._dns-sd._udp.local"
+// PTR records - the meta-query every mDNS responder answers with the list
+// of service types it advertises.
+func mdnsServicesProbe() []byte {
+	msg := []byte{
+		0x00, 0x00, // transaction ID: 0 per RFC 6762
+		0x00, 0x00, // flags: standard query
+		0x00, 0x01, // QDCOUNT
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}
+	for _, label := range strings.Split("_services._dns-sd._udp.local", ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0x00)
+	msg = append(msg, 0, 12) // QTYPE  = PTR
+	msg = append(msg, 0, 1)  // QCLASS = IN
+	return msg
+}
+
+// ssdpSearchProbe builds an SSDP M-SEARCH request for all devices
+// (ssdp:all), the UPnP discovery broadcast sent to 239.255.255.250:1900
+// or, for a unicast scan, directly at the target host.
+func ssdpSearchProbe() []byte {
+	req := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: 239.255.255.250:1900\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 1\r\n" +
+		"ST: ssdp:all\r\n\r\n"
+	return []byte(req)
+}
+
+// ScanUDPPort sends the port's protocol-specific probe (or an empty
+// datagram if none is registered) and classifies the result: a reply
+// means open, an ICMP port-unreachable means closed, and no signal within
+// the timeout means open|filtered - UDP gives no handshake to confirm a
+// closed port outright, so that ambiguity is the best any UDP scanner can
+// report without root to read ICMP errors.
+func (ns *NetworkScanner) ScanUDPPort(host string, port int) ScanResult {
+	ns.RateLimiter.Wait()
+
+	result := ScanResult{Host: host, Port: port, Protocol: "udp"}
+	if service, exists := ns.ServiceDatabase[port]; exists {
+		result.Service = service
+	}
+
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := net.DialTimeout("udp", addr, ns.Timeout)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(udpProbes[port]); err != nil {
+		result.Error = err
+		return result
+	}
+
+	conn.SetReadDeadline(time.Now().Add(ns.Timeout))
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err == nil && n > 0 {
+		result.Open = true
+		result.Banner = identifyUDPReply(port, buf[:n])
+		return result
+	}
+
+	if unreachable, ok := readICMPPortUnreachable(host, ns.Timeout); ok {
+		if !unreachable {
+			result.Error = fmt.Errorf("filtered (ICMP error other than port-unreachable)")
+		} else {
+			result.Error = fmt.Errorf("closed (ICMP port-unreachable)")
+		}
+		return result
+	}
+
+	result.Error = fmt.Errorf("open|filtered (no response)")
+	return result
+}
+
+// identifyUDPReply extracts what it can from a UDP reply for ScanUDPPort's
+// Banner field, falling back to a byte count for protocols this package
+// doesn't decode.
+func identifyUDPReply(port int, reply []byte) string {
+	switch port {
+	case 53:
+		if version, err := parseDNSTXTReply(reply); err == nil && version != "" {
+			return "version.bind: " + version
+		}
+	case 137:
+		if name, err := parseNetBIOSNodeName(reply); err == nil && name != "" {
+			return "NetBIOS name: " + name
+		}
+	case 1900:
+		return strings.SplitN(string(reply), "\r\n", 2)[0]
+	}
+	return fmt.Sprintf("%d byte reply", len(reply))
+}
+
+// parseDNSTXTReply extracts the first TXT record's character-string from
+// a DNS response, skipping the echoed question section - the same parse
+// scripts.dnsVersionScript does, duplicated since this package doesn't
+// depend on pkg/scripts.
+func parseDNSTXTReply(msg []byte) (string, error) {
+	if len(msg) < 12 {
+		return "", fmt.Errorf("truncated DNS response")
+	}
+	ancount := binary.BigEndian.Uint16(msg[6:8])
+	if ancount == 0 {
+		return "", nil
+	}
+
+	i := 12
+	skipName := func() error {
+		for i < len(msg) {
+			l := int(msg[i])
+			if l == 0 {
+				i++
+				return nil
+			}
+			if l&0xc0 == 0xc0 {
+				i += 2
+				return nil
+			}
+			i += 1 + l
+		}
+		return fmt.Errorf("truncated name")
+	}
+
+	if err := skipName(); err != nil {
+		return "", err
+	}
+	i += 4 // QTYPE + QCLASS
+
+	for a := 0; a < int(ancount); a++ {
+		if err := skipName(); err != nil {
+			return "", err
+		}
+		if i+10 > len(msg) {
+			return "", fmt.Errorf("truncated answer")
+		}
+		rtype := binary.BigEndian.Uint16(msg[i:])
+		rdlen := int(binary.BigEndian.Uint16(msg[i+8:]))
+		i += 10
+		if i+rdlen > len(msg) {
+			return "", fmt.Errorf("truncated rdata")
+		}
+		rdata := msg[i : i+rdlen]
+		i += rdlen
+
+		if rtype == 16 && len(rdata) > 0 {
+			n := int(rdata[0])
+			if 1+n <= len(rdata) {
+				return string(rdata[1 : 1+n]), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// parseNetBIOSNodeName reads the first NetBIOS name entry out of an
+// NBSTAT response, whose single answer record carries the queried name
+// followed by a NODE_NAME_ARRAY of 16-byte (name + flags) records.
+func parseNetBIOSNodeName(resp []byte) (string, error) {
+	if len(resp) < 57 {
+		return "", fmt.Errorf("truncated NBSTAT response")
+	}
+	// Header(12) + encoded RR name(1+32+1) + TYPE/CLASS/TTL/RDLENGTH(10),
+	// then NUM_NAMES(1); the response carries no question section.
+	i := 12 + 34 + 10
+	numNames := int(resp[i])
+	i++
+	if numNames == 0 || i+16 > len(resp) {
+		return "", nil
+	}
+	name := strings.TrimRight(string(resp[i:i+15]), " ")
+	return name, nil
+}
+
+// readICMPPortUnreachable listens briefly on a raw ICMP socket for a
+// destination-unreachable message from host. ok is false when raw ICMP
+// sockets aren't available (the normal unprivileged case) or nothing
+// arrives in time, leaving ScanUDPPort's result as open|filtered; when ok
+// is true, the bool reports whether it was specifically port-unreachable
+// (code 3) rather than some other filtering error.
+func readICMPPortUnreachable(host string, timeout time.Duration) (isPortUnreachable bool, ok bool) {
+	conn, err := net.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return false, false
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(timeout))
+
+	buf := make([]byte, 512)
+	n, peer, err := conn.ReadFrom(buf)
+	if err != nil || n < 8 {
+		return false, false
+	}
+	if addrs, lookupErr := net.LookupHost(host); lookupErr == nil {
+		matched := false
+		for _, a := range addrs {
+			if a == peer.String() {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, false
+		}
+	}
+
+	icmpType := buf[0]
+	icmpCode := buf[1]
+	if icmpType != 3 { // not destination-unreachable
+		return false, false
+	}
+	return icmpCode == 3, true
+}
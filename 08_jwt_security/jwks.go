@@ -0,0 +1,288 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JWK is a single entry of a JSON Web Key Set (RFC 7517), restricted to the
+// fields needed to recover an RSA, EC, or OKP (Ed25519) public key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is a JWK Set document as served from a jwks_uri.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKey converts the JWK into the Go key type NewVerifier expects:
+// *rsa.PublicKey, *ecdsa.PublicKey, or ed25519.PublicKey.
+func (k JWK) PublicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := jwkBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: decoding RSA modulus: %w", err)
+		}
+		e, err := jwkBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: decoding RSA exponent: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		curve, err := jwkCurve(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := jwkBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: decoding EC x: %w", err)
+		}
+		y, err := jwkBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: decoding EC y: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("jwks: unsupported OKP curve %q", k.Crv)
+		}
+		raw, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("jwks: decoding Ed25519 x: %w", err)
+		}
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("jwks: Ed25519 key has wrong length %d", len(raw))
+		}
+		return ed25519.PublicKey(raw), nil
+
+	default:
+		return nil, fmt.Errorf("jwks: unsupported key type %q", k.Kty)
+	}
+}
+
+func jwkBigInt(s string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
+
+func jwkCurve(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("jwks: unsupported EC curve %q", crv)
+	}
+}
+
+// jwksEntry is the per-URL cache line: the last successfully fetched set,
+// when it expires, and backoff state for a failing URL.
+type jwksEntry struct {
+	mu        sync.Mutex
+	set       JWKSet
+	expiresAt time.Time
+	failures  int
+	retryAt   time.Time
+}
+
+// JWKSCache resolves `kid` values against remote JWKS documents, refetching
+// on expiry (driven by Cache-Control/Expires, floored at minTTL) and backing
+// off exponentially on fetch failures so a down IdP isn't hammered.
+type JWKSCache struct {
+	mu         sync.Mutex
+	entries    map[string]*jwksEntry
+	minTTL     time.Duration
+	maxBackoff time.Duration
+}
+
+// NewJWKSCache returns a cache that refreshes at most every minTTL and caps
+// failure backoff at maxBackoff.
+func NewJWKSCache(minTTL, maxBackoff time.Duration) *JWKSCache {
+	return &JWKSCache{
+		entries:    make(map[string]*jwksEntry),
+		minTTL:     minTTL,
+		maxBackoff: maxBackoff,
+	}
+}
+
+func (c *JWKSCache) entry(url string) *jwksEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[url]
+	if !ok {
+		e = &jwksEntry{}
+		c.entries[url] = e
+	}
+	return e
+}
+
+// Get returns the cached JWK Set for url, fetching or refreshing it as
+// needed. Locking is per-URL, so a cold cache for one IdP never blocks
+// Verify calls against another.
+func (c *JWKSCache) Get(url string) (JWKSet, error) {
+	e := c.entry(url)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(e.expiresAt) {
+		return e.set, nil
+	}
+	if e.failures > 0 && now.Before(e.retryAt) {
+		return JWKSet{}, fmt.Errorf("jwks: %s unreachable, retrying after %s", url, e.retryAt.Format(time.RFC3339))
+	}
+
+	set, ttl, err := fetchJWKS(url)
+	if err != nil {
+		e.failures++
+		backoff := time.Duration(1<<uint(e.failures)) * time.Second
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+		e.retryAt = now.Add(backoff)
+		return JWKSet{}, err
+	}
+
+	if ttl < c.minTTL {
+		ttl = c.minTTL
+	}
+	e.set = set
+	e.expiresAt = now.Add(ttl)
+	e.failures = 0
+	return set, nil
+}
+
+// KeyByKID resolves kid against url's JWK Set, forcing one refresh if kid
+// is not found in the cached set so that newly rotated-in keys are picked
+// up without waiting out the full TTL.
+func (c *JWKSCache) KeyByKID(url, kid string) (JWK, error) {
+	set, err := c.Get(url)
+	if err != nil {
+		return JWK{}, err
+	}
+	if key, ok := findKID(set, kid); ok {
+		return key, nil
+	}
+
+	e := c.entry(url)
+	e.mu.Lock()
+	e.expiresAt = time.Time{}
+	e.mu.Unlock()
+
+	set, err = c.Get(url)
+	if err != nil {
+		return JWK{}, err
+	}
+	if key, ok := findKID(set, kid); ok {
+		return key, nil
+	}
+	return JWK{}, fmt.Errorf("jwks: kid %q not found at %s", kid, url)
+}
+
+func findKID(set JWKSet, kid string) (JWK, bool) {
+	for _, key := range set.Keys {
+		if key.Kid == kid {
+			return key, true
+		}
+	}
+	return JWK{}, false
+}
+
+// fetchJWKS downloads and parses the JWK Set at url, returning the TTL
+// derived from its Cache-Control max-age or Expires header (0 if neither
+// is present; the caller applies its own floor).
+func fetchJWKS(url string) (JWKSet, time.Duration, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return JWKSet{}, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return JWKSet{}, 0, fmt.Errorf("jwks: unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return JWKSet{}, 0, fmt.Errorf("jwks: reading response from %s: %w", url, err)
+	}
+
+	var set JWKSet
+	if err := json.Unmarshal(body, &set); err != nil {
+		return JWKSet{}, 0, fmt.Errorf("jwks: parsing JWK Set from %s: %w", url, err)
+	}
+
+	return set, cacheTTL(resp.Header), nil
+}
+
+// cacheTTL reads Cache-Control: max-age=N, falling back to Expires, from an
+// HTTP response's headers.
+func cacheTTL(h http.Header) time.Duration {
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(directive)
+		if rest, ok := strings.CutPrefix(directive, "max-age="); ok {
+			if seconds, err := strconv.Atoi(rest); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	if expires := h.Get("Expires"); expires != "" {
+		if t, err := http.ParseTime(expires); err == nil {
+			if ttl := time.Until(t); ttl > 0 {
+				return ttl
+			}
+		}
+	}
+	return 0
+}
+
+// VerifyWithJWKS verifies jwt against the key its header's "kid" names,
+// fetched (and cached) from jwksURL.
+func (jst *JWTSecurityTool) VerifyWithJWKS(jwt *JWT, jwksURL string) error {
+	kid, ok := jwt.Header["kid"].(string)
+	if !ok || kid == "" {
+		return fmt.Errorf("jwt: header has no \"kid\" to resolve against the JWKS")
+	}
+
+	jwk, err := jst.JWKSCache.KeyByKID(jwksURL, kid)
+	if err != nil {
+		return err
+	}
+
+	key, err := jwk.PublicKey()
+	if err != nil {
+		return err
+	}
+
+	return jst.VerifyJWT(jwt, key)
+}
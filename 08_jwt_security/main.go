@@ -9,7 +9,10 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"crypto/hmac"
+	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
@@ -18,7 +21,8 @@ import (
 	"os"
 	"strings"
 	"time"
-	"bufio"
+
+	"github.com/arya2004/cybersecurity/pkg/cracker"
 )
 
 // JWT represents the structure of a JSON Web Token
@@ -67,11 +71,13 @@ type Vulnerability struct {
 // JWTSecurityTool provides JWT security analysis capabilities
 type JWTSecurityTool struct {
 	CommonSecrets []string
+	JWKSCache     *JWKSCache
 }
 
 // NewJWTSecurityTool creates a new JWT security tool instance
 func NewJWTSecurityTool() *JWTSecurityTool {
 	return &JWTSecurityTool{
+		JWKSCache: NewJWKSCache(15*time.Minute, 30*time.Minute),
 		CommonSecrets: []string{
 			"secret",
 			"password",
@@ -305,7 +311,9 @@ func (jst *JWTSecurityTool) checkSignatureBypass(jwt *JWT) ([]Vulnerability, []s
 	return vulnerabilities, recommendations
 }
 
-// isWeakSecret checks if JWT is signed with a weak/common secret
+// isWeakSecret does a quick check against the hardcoded CommonSecrets
+// list; CrackSecretDictionary/CrackSecretMask cover the rest of the
+// keyspace with a real worker-pool cracker.
 func (jst *JWTSecurityTool) isWeakSecret(jwt *JWT) bool {
 	parts := strings.Split(jwt.Raw, ".")
 	if len(parts) != 3 {
@@ -328,8 +336,11 @@ func (jst *JWTSecurityTool) isWeakSecret(jwt *JWT) bool {
 	return false
 }
 
-// CreateJWT creates a new JWT with specified claims
-func (jst *JWTSecurityTool) CreateJWT(header map[string]interface{}, payload map[string]interface{}, secret string) (string, error) {
+// CreateJWT creates a new JWT with specified claims, signing it with key
+// under the algorithm named in header["alg"]. key is a []byte secret for
+// HS256/384/512 or a private key (*rsa.PrivateKey, *ecdsa.PrivateKey,
+// ed25519.PrivateKey) for RS/PS/ES/EdDSA; it is ignored for "none".
+func (jst *JWTSecurityTool) CreateJWT(header map[string]interface{}, payload map[string]interface{}, key interface{}) (string, error) {
 	// Encode header
 	headerBytes, err := json.Marshal(header)
 	if err != nil {
@@ -346,24 +357,59 @@ func (jst *JWTSecurityTool) CreateJWT(header map[string]interface{}, payload map
 
 	// Create signature
 	headerPayload := encodedHeader + "." + encodedPayload
-	
+
+	alg, _ := header["alg"].(string)
 	var signature string
-	if alg, ok := header["alg"].(string); ok {
-		switch alg {
-		case "none":
-			signature = ""
-		case "HS256":
-			mac := hmac.New(sha256.New, []byte(secret))
-			mac.Write([]byte(headerPayload))
-			signature = base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
-		default:
-			return "", fmt.Errorf("unsupported algorithm: %s", alg)
+	if alg == "none" {
+		signature = ""
+	} else {
+		signer, err := NewSigner(alg, key)
+		if err != nil {
+			return "", err
+		}
+		sig, err := signer.Sign([]byte(headerPayload))
+		if err != nil {
+			return "", fmt.Errorf("signing JWT: %w", err)
 		}
+		signature = base64.RawURLEncoding.EncodeToString(sig)
 	}
 
 	return headerPayload + "." + signature, nil
 }
 
+// VerifyJWT verifies jwt's signature against key under the algorithm named
+// in its header. It returns an alg/key mismatch error distinctly from
+// ErrSignatureInvalid, so callers can tell "wrong key type for this alg"
+// apart from "the signature just doesn't match".
+func (jst *JWTSecurityTool) VerifyJWT(jwt *JWT, key interface{}) error {
+	alg, ok := jwt.Header["alg"].(string)
+	if !ok {
+		return fmt.Errorf("jwt: header has no \"alg\"")
+	}
+	if alg == "none" {
+		if jwt.Signature != "" {
+			return ErrSignatureInvalid
+		}
+		return nil
+	}
+
+	verifier, err := NewVerifier(alg, key)
+	if err != nil {
+		return fmt.Errorf("jwt: alg/key mismatch: %w", err)
+	}
+
+	parts := strings.Split(jwt.Raw, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid JWT format: expected 3 parts, got %d", len(parts))
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	return verifier.Verify([]byte(parts[0]+"."+parts[1]), signature)
+}
+
 // PrintJWTInfo prints detailed information about a JWT
 func (jst *JWTSecurityTool) PrintJWTInfo(jwt *JWT) {
 	fmt.Println("\n=== JWT Analysis ===")
@@ -404,6 +450,21 @@ func (jst *JWTSecurityTool) PrintJWTInfo(jwt *JWT) {
 	}
 }
 
+// PrintJWEInfo prints detailed information about a JWE
+func (jst *JWTSecurityTool) PrintJWEInfo(jwe *JWE) {
+	fmt.Println("\n=== JWE Analysis ===")
+
+	fmt.Println("\n--- Protected Header ---")
+	headerBytes, _ := json.MarshalIndent(jwe.Header, "", "  ")
+	fmt.Println(string(headerBytes))
+
+	fmt.Println("\n--- Segments ---")
+	fmt.Printf("Encrypted key: %d bytes\n", len(jwe.EncryptedKey))
+	fmt.Printf("IV: %d bytes\n", len(jwe.IV))
+	fmt.Printf("Ciphertext: %d bytes\n", len(jwe.Ciphertext))
+	fmt.Printf("Authentication tag: %d bytes\n", len(jwe.Tag))
+}
+
 // PrintVulnerabilityReport prints the security analysis report
 func (jst *JWTSecurityTool) PrintVulnerabilityReport(report VulnerabilityReport) {
 	fmt.Println("\n=== Security Analysis Report ===")
@@ -431,17 +492,103 @@ func (jst *JWTSecurityTool) PrintVulnerabilityReport(report VulnerabilityReport)
 	}
 }
 
+// printCrackProgress reports live throughput/ETA from a CrackSecret* run,
+// mirroring hash-generator-cracker's mask-attack progress line.
+func printCrackProgress(s cracker.Stats) {
+	if s.KnownETA {
+		fmt.Printf("\r%d/%d attempts (%.0f/s), ETA %s   ", s.Attempts, s.Keyspace, s.Rate, s.ETA.Round(time.Second))
+	} else {
+		fmt.Printf("\r%d attempts (%.0f/s)   ", s.Attempts, s.Rate)
+	}
+}
+
 // Interactive menu
 func showMenu() {
 	fmt.Println("\n=== JWT Security Tool ===")
 	fmt.Println("1. Analyze JWT token")
 	fmt.Println("2. Create JWT token")
 	fmt.Println("3. Test signature bypass")
-	fmt.Println("4. Brute force weak secrets")
-	fmt.Println("5. Exit")
+	fmt.Println("4. Crack HMAC secret (common list, dictionary, or mask)")
+	fmt.Println("5. Verify JWT signature")
+	fmt.Println("6. Exit")
 	fmt.Print("Select option: ")
 }
 
+// loadSigningKey prompts for the key material CreateJWT/VerifyJWT need for
+// alg: a raw secret for HMAC algorithms, or a PEM key file path otherwise.
+func loadSigningKey(scanner *bufio.Scanner, alg string, private bool) (interface{}, error) {
+	if strings.HasPrefix(alg, "HS") {
+		fmt.Print("Enter secret key: ")
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("no input")
+		}
+		return []byte(strings.TrimSpace(scanner.Text())), nil
+	}
+
+	if private {
+		fmt.Print("Enter path to PEM private key file: ")
+	} else {
+		fmt.Print("Enter path to PEM public key file: ")
+	}
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("no input")
+	}
+	path := strings.TrimSpace(scanner.Text())
+	if private {
+		return LoadPrivateKey(path)
+	}
+	return LoadPublicKey(path)
+}
+
+// loadRSAPublicKeyForForge prompts for the RSA public key to use as the
+// forged HMAC secret in an algorithm-confusion attack: either a local PEM
+// file (in which case the raw PEM bytes are also returned, for the
+// exact-encoding candidates) or a jwks_uri resolved against kid.
+func loadRSAPublicKeyForForge(scanner *bufio.Scanner, tool *JWTSecurityTool, kid string) (*rsa.PublicKey, []byte, error) {
+	fmt.Print("Enter path to RSA public key PEM file, or a jwks_uri: ")
+	if !scanner.Scan() {
+		return nil, nil, fmt.Errorf("no input")
+	}
+	source := strings.TrimSpace(scanner.Text())
+
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		if kid == "" {
+			fmt.Print("Enter kid: ")
+			if !scanner.Scan() {
+				return nil, nil, fmt.Errorf("no input")
+			}
+			kid = strings.TrimSpace(scanner.Text())
+		}
+		jwk, err := tool.JWKSCache.KeyByKID(source, kid)
+		if err != nil {
+			return nil, nil, err
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		pubKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, nil, fmt.Errorf("algconfusion: kid %q is not an RSA key", kid)
+		}
+		return pubKey, nil, nil
+	}
+
+	pemSource, err := os.ReadFile(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := LoadPublicKey(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("algconfusion: %s is not an RSA public key", source)
+	}
+	return pubKey, pemSource, nil
+}
+
 func main() {
 	tool := NewJWTSecurityTool()
 	scanner := bufio.NewScanner(os.Stdin)
@@ -460,12 +607,46 @@ func main() {
 
 		switch choice {
 		case "1":
-			fmt.Print("Enter JWT token: ")
+			fmt.Print("Enter JWT/JWE token: ")
 			if !scanner.Scan() {
 				continue
 			}
 			token := strings.TrimSpace(scanner.Text())
 
+			if LooksLikeJWE(token) {
+				jwe, err := ParseJWE(token)
+				if err != nil {
+					fmt.Printf("Error parsing JWE: %v\n", err)
+					continue
+				}
+				tool.PrintJWEInfo(jwe)
+				report := tool.AnalyzeJWE(jwe)
+				tool.PrintVulnerabilityReport(report)
+
+				fmt.Print("\nAttempt decryption with a known key? (y/n): ")
+				if scanner.Scan() && strings.EqualFold(strings.TrimSpace(scanner.Text()), "y") {
+					alg, _ := jwe.Header["alg"].(string)
+					var key interface{}
+					var err error
+					if alg == "dir" {
+						key, err = loadSigningKey(scanner, "HS256", false) // any HS* path prompts for a raw secret
+					} else {
+						key, err = loadSigningKey(scanner, alg, true)
+					}
+					if err != nil {
+						fmt.Printf("Error loading key: %v\n", err)
+						continue
+					}
+					plaintext, err := DecryptJWE(jwe, key)
+					if err != nil {
+						fmt.Printf("Decryption failed: %v\n", err)
+						continue
+					}
+					fmt.Printf("Plaintext: %s\n", plaintext)
+				}
+				continue
+			}
+
 			jwt, err := tool.ParseJWT(token)
 			if err != nil {
 				fmt.Printf("Error parsing JWT: %v\n", err)
@@ -477,8 +658,14 @@ func main() {
 			tool.PrintVulnerabilityReport(report)
 
 		case "2":
+			fmt.Print("Enter algorithm (HS256/HS384/HS512/RS256/RS384/RS512/PS256/PS384/PS512/ES256/ES384/ES512/EdDSA/none): ")
+			if !scanner.Scan() {
+				continue
+			}
+			alg := strings.TrimSpace(scanner.Text())
+
 			header := map[string]interface{}{
-				"alg": "HS256",
+				"alg": alg,
 				"typ": "JWT",
 			}
 
@@ -489,13 +676,17 @@ func main() {
 				"exp":  time.Now().Add(time.Hour).Unix(),
 			}
 
-			fmt.Print("Enter secret key: ")
-			if !scanner.Scan() {
-				continue
+			var key interface{}
+			if alg != "none" {
+				var err error
+				key, err = loadSigningKey(scanner, alg, true)
+				if err != nil {
+					fmt.Printf("Error loading key: %v\n", err)
+					continue
+				}
 			}
-			secret := strings.TrimSpace(scanner.Text())
 
-			token, err := tool.CreateJWT(header, payload, secret)
+			token, err := tool.CreateJWT(header, payload, key)
 			if err != nil {
 				fmt.Printf("Error creating JWT: %v\n", err)
 				continue
@@ -526,6 +717,29 @@ func main() {
 				fmt.Printf("None algorithm bypass: %s\n", noneToken)
 			}
 
+			if jwt, err := tool.ParseJWT(token); err == nil {
+				if alg, _ := jwt.Header["alg"].(string); strings.HasPrefix(alg, "RS") {
+					fmt.Print("\nTarget uses RS256/384/512 - attempt active RS256->HS256 key-confusion forgery? (y/n): ")
+					if scanner.Scan() && strings.EqualFold(strings.TrimSpace(scanner.Text()), "y") {
+						kid, _ := jwt.Header["kid"].(string)
+						pubKey, pemSource, err := loadRSAPublicKeyForForge(scanner, tool, kid)
+						if err != nil {
+							fmt.Printf("Error loading public key: %v\n", err)
+							continue
+						}
+						candidates, err := ForgeAlgConfusion(token, pubKey, pemSource)
+						if err != nil {
+							fmt.Printf("Error forging token: %v\n", err)
+							continue
+						}
+						fmt.Println("\nCandidate HS256-forged tokens (replay each against the target; it is vulnerable if any verifies):")
+						for _, c := range candidates {
+							fmt.Printf("  [%s] %s\n", c.Encoding, c.Token)
+						}
+					}
+				}
+			}
+
 		case "4":
 			fmt.Print("Enter JWT token: ")
 			if !scanner.Scan() {
@@ -542,11 +756,111 @@ func main() {
 			fmt.Println("Testing common weak secrets...")
 			if tool.isWeakSecret(jwt) {
 				fmt.Println("⚠️  JWT signed with weak/common secret!")
+				continue
+			}
+			fmt.Println("✓ No common weak secrets found")
+
+			alg, _ := jwt.Header["alg"].(string)
+			if !strings.HasPrefix(alg, "HS") {
+				fmt.Printf("Secret cracking only applies to HMAC algorithms (got %q)\n", alg)
+				continue
+			}
+
+			fmt.Print("Run a full secret cracker? (d)ictionary / (m)ask / (n)o: ")
+			if !scanner.Scan() {
+				continue
+			}
+
+			var result cracker.Result
+			switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+			case "d":
+				fmt.Print("Enter path to wordlist file: ")
+				if !scanner.Scan() {
+					continue
+				}
+				words, err := loadWordlist(strings.TrimSpace(scanner.Text()))
+				if err != nil {
+					fmt.Printf("Error loading wordlist: %v\n", err)
+					continue
+				}
+				rules := cracker.DefaultRules()
+				fmt.Printf("Secret list: %d words x %d rules\n", len(words), len(rules))
+				result, err = tool.CrackSecretDictionary(context.Background(), jwt, words, rules, printCrackProgress)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+			case "m":
+				fmt.Print("Mask (?l=lower ?u=upper ?d=digit ?s=symbol ?a=all, e.g. ?u?l?l?l?d?d?d?d): ")
+				if !scanner.Scan() {
+					continue
+				}
+				mask := strings.TrimSpace(scanner.Text())
+				var err error
+				result, err = tool.CrackSecretMask(context.Background(), jwt, mask, printCrackProgress)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+			default:
+				continue
+			}
+			fmt.Println()
+			if result.Success {
+				fmt.Printf("✓ Secret found: %s (%d attempts, %s)\n", result.Password, result.Attempts, result.Elapsed.Round(time.Millisecond))
 			} else {
-				fmt.Println("✓ No common weak secrets found")
+				fmt.Printf("✗ Secret not found (%d attempts, %s)\n", result.Attempts, result.Elapsed.Round(time.Millisecond))
 			}
 
 		case "5":
+			fmt.Print("Enter JWT token: ")
+			if !scanner.Scan() {
+				continue
+			}
+			token := strings.TrimSpace(scanner.Text())
+
+			jwt, err := tool.ParseJWT(token)
+			if err != nil {
+				fmt.Printf("Error parsing JWT: %v\n", err)
+				continue
+			}
+
+			alg, _ := jwt.Header["alg"].(string)
+			_, hasKID := jwt.Header["kid"].(string)
+
+			useJWKS := false
+			if hasKID {
+				fmt.Print("Verify against a JWKS URL? (y/n): ")
+				useJWKS = scanner.Scan() && strings.EqualFold(strings.TrimSpace(scanner.Text()), "y")
+			}
+
+			var verifyErr error
+			if useJWKS {
+				fmt.Print("Enter jwks_uri: ")
+				if !scanner.Scan() {
+					continue
+				}
+				jwksURL := strings.TrimSpace(scanner.Text())
+				verifyErr = tool.VerifyWithJWKS(jwt, jwksURL)
+			} else {
+				var key interface{}
+				if alg != "none" {
+					key, err = loadSigningKey(scanner, alg, false)
+					if err != nil {
+						fmt.Printf("Error loading key: %v\n", err)
+						continue
+					}
+				}
+				verifyErr = tool.VerifyJWT(jwt, key)
+			}
+
+			if verifyErr != nil {
+				fmt.Printf("✗ Verification failed: %v\n", verifyErr)
+			} else {
+				fmt.Println("✓ Signature verified")
+			}
+
+		case "6":
 			fmt.Println("Exiting...")
 			return
 
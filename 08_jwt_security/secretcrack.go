@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"os"
+	"strings"
+
+	"github.com/arya2004/cybersecurity/pkg/cracker"
+)
+
+// hmacFactory maps an HS-family JWT algorithm to the cracker.NewMAC
+// constructor pkg/cracker's worker pool drives directly.
+func hmacFactory(alg string) (cracker.NewMAC, error) {
+	switch alg {
+	case "HS256":
+		return func(key []byte) hash.Hash { return hmac.New(sha256.New, key) }, nil
+	case "HS384":
+		return func(key []byte) hash.Hash { return hmac.New(sha512.New384, key) }, nil
+	case "HS512":
+		return func(key []byte) hash.Hash { return hmac.New(sha512.New, key) }, nil
+	default:
+		return nil, fmt.Errorf("jwt: %q is not an HMAC algorithm", alg)
+	}
+}
+
+// signingInputAndTag splits jwt's raw token into the signed input
+// (header.payload) and the decoded signature bytes a cracker compares
+// candidates against.
+func signingInputAndTag(jwt *JWT) (message, tag []byte, err error) {
+	parts := strings.Split(jwt.Raw, ".")
+	if len(parts) != 3 {
+		return nil, nil, fmt.Errorf("invalid JWT format: expected 3 parts, got %d", len(parts))
+	}
+	tag, err = base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+	return []byte(parts[0] + "." + parts[1]), tag, nil
+}
+
+// CrackSecretMask runs a hashcat-style mask attack (e.g. "?u?l?l?l?d?d?d?d")
+// against jwt's HMAC secret, splitting the keyspace across a pkg/cracker
+// worker pool. jwt's "alg" must be HS256, HS384, or HS512.
+func (jst *JWTSecurityTool) CrackSecretMask(ctx context.Context, jwt *JWT, mask string, progress func(cracker.Stats)) (cracker.Result, error) {
+	alg, _ := jwt.Header["alg"].(string)
+	newMAC, err := hmacFactory(alg)
+	if err != nil {
+		return cracker.Result{}, err
+	}
+	message, tag, err := signingInputAndTag(jwt)
+	if err != nil {
+		return cracker.Result{}, err
+	}
+	charsets, err := cracker.ParseMask(mask)
+	if err != nil {
+		return cracker.Result{}, err
+	}
+	return cracker.CrackMaskHMAC(ctx, message, tag, newMAC, charsets, cracker.Options{Progress: progress})
+}
+
+// CrackSecretDictionary runs a rule-mangled dictionary attack against
+// jwt's HMAC secret, splitting the dictionary across a pkg/cracker worker
+// pool. jwt's "alg" must be HS256, HS384, or HS512.
+func (jst *JWTSecurityTool) CrackSecretDictionary(ctx context.Context, jwt *JWT, dictionary []string, rules []cracker.Rule, progress func(cracker.Stats)) (cracker.Result, error) {
+	alg, _ := jwt.Header["alg"].(string)
+	newMAC, err := hmacFactory(alg)
+	if err != nil {
+		return cracker.Result{}, err
+	}
+	message, tag, err := signingInputAndTag(jwt)
+	if err != nil {
+		return cracker.Result{}, err
+	}
+	return cracker.CrackDictionaryWithRulesHMAC(ctx, message, tag, newMAC, dictionary, rules, cracker.Options{Progress: progress})
+}
+
+// loadWordlist reads one candidate secret per line from path, skipping
+// blank lines.
+func loadWordlist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words, scanner.Err()
+}
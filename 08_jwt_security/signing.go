@@ -0,0 +1,265 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	_ "crypto/sha256" // register SHA-224/256 with crypto.Hash
+	_ "crypto/sha512" // register SHA-512/384 with crypto.Hash
+)
+
+// ErrSignatureInvalid is returned by a Verifier when a signature does not
+// match its signing input, as opposed to a setup error (bad alg, wrong key
+// type) which is reported separately.
+var ErrSignatureInvalid = errors.New("jwt: signature verification failed")
+
+// Signer produces a raw JWS signature over signingInput, the base64url
+// header and payload joined by '.'.
+type Signer interface {
+	Sign(signingInput []byte) ([]byte, error)
+}
+
+// Verifier checks a raw JWS signature over signingInput.
+type Verifier interface {
+	Verify(signingInput, signature []byte) error
+}
+
+// algParams describes what NewSigner/NewVerifier need to build the right
+// implementation for a JWS "alg" value.
+type algParams struct {
+	hash    crypto.Hash
+	pss     bool
+	curve   string // ECDSA curve name (elliptic.Curve.Params().Name); empty for non-ECDSA algs
+	byteLen int    // ECDSA r/s field width in bytes, per RFC 7518 section 3.4
+}
+
+var jwsAlgorithms = map[string]algParams{
+	"HS256": {hash: crypto.SHA256},
+	"HS384": {hash: crypto.SHA384},
+	"HS512": {hash: crypto.SHA512},
+	"RS256": {hash: crypto.SHA256},
+	"RS384": {hash: crypto.SHA384},
+	"RS512": {hash: crypto.SHA512},
+	"PS256": {hash: crypto.SHA256, pss: true},
+	"PS384": {hash: crypto.SHA384, pss: true},
+	"PS512": {hash: crypto.SHA512, pss: true},
+	"ES256": {hash: crypto.SHA256, curve: "P-256", byteLen: 32},
+	"ES384": {hash: crypto.SHA384, curve: "P-384", byteLen: 48},
+	"ES512": {hash: crypto.SHA512, curve: "P-521", byteLen: 66},
+	"EdDSA": {},
+}
+
+// NewSigner builds the Signer for alg, validating that key is of the type
+// alg requires and reporting a descriptive error (rather than a panic or a
+// silent wrong-answer) on any alg/key-type mismatch.
+func NewSigner(alg string, key interface{}) (Signer, error) {
+	params, ok := jwsAlgorithms[alg]
+	if !ok {
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", alg)
+	}
+
+	switch {
+	case strings.HasPrefix(alg, "HS"):
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("jwt: %s requires a []byte secret, got %T", alg, key)
+		}
+		return hmacSigner{secret: secret, hash: params.hash}, nil
+
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		priv, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt: %s requires an RSA private key, got %T", alg, key)
+		}
+		return rsaSigner{private: priv, public: &priv.PublicKey, hash: params.hash, pss: params.pss}, nil
+
+	case strings.HasPrefix(alg, "ES"):
+		priv, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt: %s requires an ECDSA private key, got %T", alg, key)
+		}
+		if priv.Curve.Params().Name != params.curve {
+			return nil, fmt.Errorf("jwt: %s requires curve %s, key uses %s", alg, params.curve, priv.Curve.Params().Name)
+		}
+		return ecdsaSigner{private: priv, public: &priv.PublicKey, hash: params.hash, byteLen: params.byteLen}, nil
+
+	case alg == "EdDSA":
+		priv, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt: EdDSA requires an Ed25519 private key, got %T", key)
+		}
+		return ed25519Signer{private: priv, public: priv.Public().(ed25519.PublicKey)}, nil
+
+	default:
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", alg)
+	}
+}
+
+// NewVerifier builds the Verifier for alg, the verification-side mirror of
+// NewSigner. For HS* algs key is the same shared secret used to sign.
+func NewVerifier(alg string, key interface{}) (Verifier, error) {
+	params, ok := jwsAlgorithms[alg]
+	if !ok {
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", alg)
+	}
+
+	switch {
+	case strings.HasPrefix(alg, "HS"):
+		secret, ok := key.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("jwt: %s requires a []byte secret, got %T", alg, key)
+		}
+		return hmacSigner{secret: secret, hash: params.hash}, nil
+
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt: %s requires an RSA public key, got %T", alg, key)
+		}
+		return rsaSigner{public: pub, hash: params.hash, pss: params.pss}, nil
+
+	case strings.HasPrefix(alg, "ES"):
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt: %s requires an ECDSA public key, got %T", alg, key)
+		}
+		if pub.Curve.Params().Name != params.curve {
+			return nil, fmt.Errorf("jwt: %s requires curve %s, key uses %s", alg, params.curve, pub.Curve.Params().Name)
+		}
+		return ecdsaSigner{public: pub, hash: params.hash, byteLen: params.byteLen}, nil
+
+	case alg == "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt: EdDSA requires an Ed25519 public key, got %T", key)
+		}
+		return ed25519Signer{public: pub}, nil
+
+	default:
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q", alg)
+	}
+}
+
+// hmacSigner implements the HS256/384/512 family. It also serves as its own
+// Verifier, since HMAC verification is just re-signing and comparing.
+type hmacSigner struct {
+	secret []byte
+	hash   crypto.Hash
+}
+
+func (s hmacSigner) Sign(signingInput []byte) ([]byte, error) {
+	mac := hmac.New(s.hash.New, s.secret)
+	mac.Write(signingInput)
+	return mac.Sum(nil), nil
+}
+
+func (s hmacSigner) Verify(signingInput, signature []byte) error {
+	expected, _ := s.Sign(signingInput)
+	if !hmac.Equal(expected, signature) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// rsaSigner implements RS256/384/512 (PKCS#1 v1.5) and PS256/384/512 (PSS),
+// distinguished by pss. Only one of private/public needs to be set,
+// depending on whether this instance signs or verifies.
+type rsaSigner struct {
+	private *rsa.PrivateKey
+	public  *rsa.PublicKey
+	hash    crypto.Hash
+	pss     bool
+}
+
+func (s rsaSigner) digest(signingInput []byte) []byte {
+	h := s.hash.New()
+	h.Write(signingInput)
+	return h.Sum(nil)
+}
+
+func (s rsaSigner) Sign(signingInput []byte) ([]byte, error) {
+	digest := s.digest(signingInput)
+	if s.pss {
+		return rsa.SignPSS(rand.Reader, s.private, s.hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: s.hash})
+	}
+	return rsa.SignPKCS1v15(rand.Reader, s.private, s.hash, digest)
+}
+
+func (s rsaSigner) Verify(signingInput, signature []byte) error {
+	digest := s.digest(signingInput)
+	var err error
+	if s.pss {
+		err = rsa.VerifyPSS(s.public, s.hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: s.hash})
+	} else {
+		err = rsa.VerifyPKCS1v15(s.public, s.hash, digest, signature)
+	}
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// ecdsaSigner implements ES256/384/512, encoding (r, s) as fixed-width
+// concatenated big-endian integers per RFC 7518 section 3.4, rather than
+// the ASN.1 DER encoding crypto/ecdsa's own Sign/Verify helpers assume.
+type ecdsaSigner struct {
+	private *ecdsa.PrivateKey
+	public  *ecdsa.PublicKey
+	hash    crypto.Hash
+	byteLen int
+}
+
+func (s ecdsaSigner) digest(signingInput []byte) []byte {
+	h := s.hash.New()
+	h.Write(signingInput)
+	return h.Sum(nil)
+}
+
+func (s ecdsaSigner) Sign(signingInput []byte) ([]byte, error) {
+	r, sVal, err := ecdsa.Sign(rand.Reader, s.private, s.digest(signingInput))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 2*s.byteLen)
+	r.FillBytes(out[:s.byteLen])
+	sVal.FillBytes(out[s.byteLen:])
+	return out, nil
+}
+
+func (s ecdsaSigner) Verify(signingInput, signature []byte) error {
+	if len(signature) != 2*s.byteLen {
+		return ErrSignatureInvalid
+	}
+	r := new(big.Int).SetBytes(signature[:s.byteLen])
+	sVal := new(big.Int).SetBytes(signature[s.byteLen:])
+	if !ecdsa.Verify(s.public, s.digest(signingInput), r, sVal) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// ed25519Signer implements EdDSA restricted to the Ed25519 curve, per
+// RFC 8037 (the only curve the JWA EdDSA registration currently covers).
+type ed25519Signer struct {
+	private ed25519.PrivateKey
+	public  ed25519.PublicKey
+}
+
+func (s ed25519Signer) Sign(signingInput []byte) ([]byte, error) {
+	return ed25519.Sign(s.private, signingInput), nil
+}
+
+func (s ed25519Signer) Verify(signingInput, signature []byte) error {
+	if !ed25519.Verify(s.public, signingInput, signature) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
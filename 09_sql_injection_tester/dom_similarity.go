@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// domStructureSignature walks the parsed HTML tree and returns the
+// sequence of element tag names in document order (attributes and text
+// are ignored), e.g. "html body div p a". Two responses with the same
+// structure but different text or attributes (a changed nonce) produce the
+// same signature, while a different row count in a table changes it - and boolean-blind
+// payloads that flip a WHERE clause typically do change the number of
+// rows rendered, which plain text-similarity on its own can under- or
+// over-weight depending on how much surrounding markup is identical.
+func domStructureSignature(body string) string {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var tags []string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			tags = append(tags, n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return strings.Join(tags, " ")
+}
+
+// domStructureSimilarity scores two response bodies' full DOM tag
+// structure on the same 0..1 scale as responseSimilarity, using the
+// Dice token-overlap coefficient over their tag sequences. Non-HTML
+// bodies (JSON APIs, plain text) parse to the same bare "html head body"
+// signature on both sides, which tokenOverlapRatio scores as a perfect match - so this
+// signal only discriminates when there's actually markup to compare.
+func domStructureSimilarity(a, b string) float64 {
+	return tokenOverlapRatio(domStructureSignature(a), domStructureSignature(b))
+}
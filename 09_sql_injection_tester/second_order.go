@@ -0,0 +1,165 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// AuthSession carries the cookies/headers that authenticate both legs of a
+// SecondOrderTest, since reaching a stored-value inject endpoint and then
+// its trigger endpoint typically requires a logged-in session.
+type AuthSession struct {
+	Headers map[string]string
+	Cookies map[string]string
+}
+
+// applyTo overlays session's headers and cookies onto tmpl, leaving
+// tmpl's own entries in place for anything session doesn't set. A nil
+// session leaves tmpl unchanged.
+func (session *AuthSession) applyTo(tmpl RequestTemplate) RequestTemplate {
+	if session == nil {
+		return tmpl
+	}
+	merged := tmpl
+	if len(session.Headers) > 0 {
+		merged.Headers = mergeStringMaps(tmpl.Headers, session.Headers)
+	}
+	if len(session.Cookies) > 0 {
+		merged.Cookies = mergeStringMaps(tmpl.Cookies, session.Cookies)
+	}
+	return merged
+}
+
+func mergeStringMaps(base, overlay map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overlay))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
+}
+
+// SecondOrderTest describes a stored/second-order SQL injection check: a
+// payload is submitted via InjectRequest (e.g. a profile update), and the
+// resulting SQL error, time delay, or response change only shows up later
+// when TriggerRequest fetches the stored value back (e.g. viewing that
+// profile) - a class of SQLi the single-request TestRequest loop can't
+// find, since it only ever inspects the response to the request that
+// carried the payload.
+type SecondOrderTest struct {
+	InjectRequest  RequestTemplate
+	TriggerRequest RequestTemplate
+	AuthSession    *AuthSession
+}
+
+// newCanary returns a random marker to embed in each stored payload, so a
+// later trigger response can be correlated back to the specific injection
+// attempt that produced it rather than a leftover value from a previous
+// test run or another tester's payload.
+func newCanary() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("second-order: generating canary: %w", err)
+	}
+	return "zzcanary" + hex.EncodeToString(buf), nil
+}
+
+// TestSecondOrder submits each payload (with a unique canary appended) to
+// test.InjectRequest, then fetches test.TriggerRequest and reuses the same
+// error-pattern and time-based signals TestRequest uses, but measured
+// against the trigger response instead of the inject response.
+func (sqli *SQLITester) TestSecondOrder(test SecondOrderTest) []VulnerabilityResult {
+	var results []VulnerabilityResult
+
+	injectTmpl := test.AuthSession.applyTo(test.InjectRequest)
+	triggerTmpl := test.AuthSession.applyTo(test.TriggerRequest)
+
+	triggerBaseline, err := sqli.makeRequest(triggerTmpl, "")
+	if err != nil {
+		fmt.Printf("Error getting trigger baseline response: %v\n", err)
+		return results
+	}
+
+	fmt.Printf("Testing second-order injection '%s' -> '%s' with %d payloads...\n",
+		injectTmpl.Parameter, triggerTmpl.Parameter, len(sqli.Payloads))
+
+	for i, payload := range sqli.Payloads {
+		fmt.Printf("Progress: %d/%d - Storing: %s\n", i+1, len(sqli.Payloads), payload.Type)
+
+		result := sqli.testSecondOrderPayload(injectTmpl, triggerTmpl, payload, triggerBaseline)
+		if result.Vulnerable {
+			results = append(results, result)
+		}
+	}
+
+	return results
+}
+
+// testSecondOrderPayload stores payload (plus a fresh canary) via
+// injectTmpl, then fetches triggerTmpl and checks its response for the
+// canary's effects: a time delay for TIME_BASED payloads, a database error
+// pattern, or a significant divergence from triggerBaseline.
+func (sqli *SQLITester) testSecondOrderPayload(injectTmpl, triggerTmpl RequestTemplate, payload SQLIPayload, triggerBaseline string) VulnerabilityResult {
+	result := VulnerabilityResult{
+		URL:       triggerTmpl.URL,
+		Parameter: fmt.Sprintf("%s -> %s", injectTmpl.Parameter, triggerTmpl.Parameter),
+		Payload:   payload,
+	}
+
+	canary, err := newCanary()
+	if err != nil {
+		result.Response = fmt.Sprintf("Error: %v", err)
+		return result
+	}
+
+	if _, err := sqli.makeRequest(injectTmpl, payload.Payload+canary); err != nil {
+		result.Response = fmt.Sprintf("Error submitting inject request: %v", err)
+		return result
+	}
+
+	start := time.Now()
+	triggerResponse, err := sqli.makeRequest(triggerTmpl, "")
+	duration := time.Since(start)
+	if err != nil {
+		result.Response = fmt.Sprintf("Error fetching trigger request: %v", err)
+		return result
+	}
+	result.Response = triggerResponse
+
+	if payload.Type == "TIME_BASED" && duration > sqli.TimeoutThreshold {
+		result.Vulnerable = true
+		result.Confidence = "HIGH"
+		result.ErrorType = "SECOND_ORDER_TIME_DELAY"
+		result.Evidence = []string{
+			fmt.Sprintf("Trigger response took %v (expected: <%v) after storing canary %s", duration, sqli.TimeoutThreshold, canary),
+		}
+		return result
+	}
+
+	for dbType, patterns := range sqli.ErrorPatterns {
+		for _, pattern := range patterns {
+			if matched, _ := regexp.MatchString("(?i)"+pattern, triggerResponse); matched {
+				result.Vulnerable = true
+				result.Confidence = "HIGH"
+				result.ErrorType = "SECOND_ORDER_" + dbType + "_ERROR"
+				result.Evidence = append(result.Evidence,
+					fmt.Sprintf("Trigger response contains %s error pattern %q after storing canary %s", dbType, pattern, canary))
+			}
+		}
+	}
+
+	if !result.Vulnerable && responseSimilarity(triggerBaseline, triggerResponse) < differentFalseThreshold {
+		result.Vulnerable = true
+		result.Confidence = "MEDIUM"
+		result.ErrorType = "SECOND_ORDER_RESPONSE_DIFFERENCE"
+		result.Evidence = append(result.Evidence,
+			fmt.Sprintf("Trigger response diverged from baseline after storing canary %s", canary))
+	}
+
+	return result
+}
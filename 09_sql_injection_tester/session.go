@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+)
+
+// Session wraps SQLITester with the pieces a CI pipeline needs instead of
+// an interactive REPL: a context cancelable via SIGINT so a scan stops
+// cleanly mid-run, a requests/sec rate limiter so a scan doesn't hammer
+// the target, and a --fail-on exit-code policy.
+type Session struct {
+	Tester *SQLITester
+	FailOn string // "", "low", "medium", or "high"
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewSession wraps tester in a Session, installing a SIGINT-cancelable
+// context and, if requestsPerSecond > 0, a rate limiter on tester.Client.
+func NewSession(tester *SQLITester, requestsPerSecond float64, failOn string) *Session {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	if requestsPerSecond > 0 {
+		tester.Client = &http.Client{
+			Timeout:   tester.Client.Timeout,
+			Transport: newRateLimitedTransport(ctx, tester.Client.Transport, requestsPerSecond),
+		}
+	}
+
+	return &Session{Tester: tester, FailOn: failOn, ctx: ctx, cancel: cancel}
+}
+
+// Stop cancels the session's context, so any in-flight rate-limit wait
+// returns immediately and further requests fail fast.
+func (s *Session) Stop() {
+	s.cancel()
+}
+
+// Done reports the session's cancellation channel, closed once Stop is
+// called or SIGINT is received; callers can check it between requests to
+// stop a multi-parameter scan early.
+func (s *Session) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// ExitCode derives a process exit code from results and s.FailOn: 0
+// unless a finding at or above FailOn's risk level was found.
+func (s *Session) ExitCode(results []VulnerabilityResult) int {
+	if s.FailOn == "" {
+		return 0
+	}
+
+	threshold := severityRank(s.FailOn)
+	for _, result := range results {
+		if severityRank(result.Payload.Risk) >= threshold {
+			return 1
+		}
+	}
+	return 0
+}
+
+// severityRank maps a risk/fail-on level to a comparable rank (low=1,
+// medium=2, high=3); anything unrecognized ranks 0 and never trips
+// ExitCode's threshold.
+func severityRank(level string) int {
+	switch strings.ToLower(level) {
+	case "low":
+		return 1
+	case "medium":
+		return 2
+	case "high":
+		return 3
+	default:
+		return 0
+	}
+}
+
+// rateLimitedTransport wraps an http.RoundTripper with a simple
+// time.Ticker-based rate limiter and ctx-cancellation, so Session can cap
+// a scan at a fixed requests/sec and stop promptly on SIGINT.
+type rateLimitedTransport struct {
+	ctx    context.Context
+	base   http.RoundTripper
+	ticker *time.Ticker
+}
+
+func newRateLimitedTransport(ctx context.Context, base http.RoundTripper, requestsPerSecond float64) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	interval := time.Duration(float64(time.Second) / requestsPerSecond)
+	return &rateLimitedTransport{ctx: ctx, base: base, ticker: time.NewTicker(interval)}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case <-t.ctx.Done():
+		return nil, t.ctx.Err()
+	case <-t.ticker.C:
+	}
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
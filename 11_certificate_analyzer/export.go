@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonReport is the machine-readable shape written by Export(format="json"),
+// independent of PrintReport's human-readable console formatting.
+type jsonReport struct {
+	Domain string          `json:"domain"`
+	Score  int             `json:"score"`
+	Issues []SecurityIssue `json:"issues"`
+}
+
+// sarifLevel maps a SecurityIssue's Severity to the SARIF 2.1.0 result
+// level enum ("error", "warning", "note"), collapsing this tool's five
+// severities onto SARIF's three.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "Critical", "High":
+		return "error"
+	case "Medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifLog mirrors the subset of the SARIF 2.1.0 schema this tool emits:
+// one run, one tool driver, one result per SecurityIssue.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// Export writes the analyzer's findings to w in the given format ("json"
+// or "sarif"), for consumption by CI pipelines and code-scanning tools
+// rather than a human reading the console report.
+func (ca *CertificateAnalyzer) Export(format string, w io.Writer) error {
+	switch format {
+	case "json":
+		return ca.exportJSON(w)
+	case "sarif":
+		return ca.exportSARIF(w)
+	default:
+		return fmt.Errorf("export: unsupported format %q (want \"json\" or \"sarif\")", format)
+	}
+}
+
+func (ca *CertificateAnalyzer) exportJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonReport{
+		Domain: ca.Domain,
+		Score:  ca.Score,
+		Issues: ca.Issues,
+	})
+}
+
+// exportSARIF converts each SecurityIssue into a SARIF result, using the
+// leaf certificate's serial number plus its first SAN, if any (or the
+// domain when no certificate was fetched), as the logical location, since
+// a certificate has no file path for SARIF to anchor to.
+func (ca *CertificateAnalyzer) exportSARIF(w io.Writer) error {
+	location := ca.Domain
+	if len(ca.Certificates) > 0 {
+		cert := ca.Certificates[0]
+		location = fmt.Sprintf("serial:%s", cert.SerialNumber.String())
+		if len(cert.DNSNames) > 0 {
+			location = fmt.Sprintf("%s san:%s", location, cert.DNSNames[0])
+		}
+	}
+
+	rules := make([]sarifRule, 0, len(ca.Policy.Rules))
+	for id, rule := range ca.Policy.Rules {
+		rules = append(rules, sarifRule{ID: id, Name: rule.Title})
+	}
+
+	results := make([]sarifResult, 0, len(ca.Issues))
+	for _, issue := range ca.Issues {
+		results = append(results, sarifResult{
+			RuleID: issue.ID,
+			Level:  sarifLevel(issue.Severity),
+			Message: sarifMessage{
+				Text: issue.Description,
+			},
+			Locations: []sarifLocation{{
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               ca.Domain,
+					FullyQualifiedName: location,
+				}},
+			}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:  "cert-analyzer",
+					Rules: rules,
+				},
+			},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
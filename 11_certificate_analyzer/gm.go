@@ -0,0 +1,86 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+)
+
+// sm2PublicKeyOID identifies an SM2 public key over the sm2p256v1 curve
+// (GM/T 0003.1), used by certificates issued under the Chinese National
+// Cryptography Administration's GM/T standards. crypto/x509 doesn't
+// recognize it, so cert.PublicKeyAlgorithm comes back as
+// x509.UnknownPublicKeyAlgorithm for these certificates.
+var sm2PublicKeyOID = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+
+// sm3WithSM2OID identifies the SM3WithSM2 signature algorithm (GM/T
+// 0003.2). Like sm2PublicKeyOID, crypto/x509 reports this as
+// x509.UnknownSignatureAlgorithm.
+var sm3WithSM2OID = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 501}
+
+// algorithmIdentifier mirrors the ASN.1 AlgorithmIdentifier used by both a
+// SubjectPublicKeyInfo and a certificate's signatureAlgorithm field
+// (RFC 5280 section 4.1.1.2), just enough to read out the algorithm OID.
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+// rawCertificateSignature mirrors the outer Certificate SEQUENCE (RFC 5280
+// section 4.1), used to read the actual signatureAlgorithm OID without
+// going through crypto/x509's algorithm table, which doesn't include GM/T
+// algorithms.
+type rawCertificateSignature struct {
+	TBSCertificate     asn1.RawValue
+	SignatureAlgorithm algorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+// rawSubjectPublicKeyInfo mirrors SubjectPublicKeyInfo (RFC 5280 section
+// 4.1.2.7), used to read the public key algorithm OID without going
+// through crypto/x509's algorithm table.
+type rawSubjectPublicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// isSM2PublicKey reports whether cert's SubjectPublicKeyInfo carries the
+// SM2 public key OID, independent of whether crypto/x509 recognized it.
+func isSM2PublicKey(cert *x509.Certificate) bool {
+	var spki rawSubjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(cert.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return false
+	}
+	return spki.Algorithm.Algorithm.Equal(sm2PublicKeyOID)
+}
+
+// isSM3WithSM2Signature reports whether cert was signed with SM3WithSM2,
+// independent of whether crypto/x509 recognized the algorithm.
+func isSM3WithSM2Signature(cert *x509.Certificate) bool {
+	var raw rawCertificateSignature
+	if _, err := asn1.Unmarshal(cert.Raw, &raw); err != nil {
+		return false
+	}
+	return raw.SignatureAlgorithm.Algorithm.Equal(sm3WithSM2OID)
+}
+
+// CheckGMCompliance flags the leaf certificate when ca.Policy.RequireGM is
+// set and the leaf doesn't use SM2/SM3WithSM2, for organizations whose
+// jurisdiction mandates GM/T cryptography. It is a no-op under policies
+// that don't require GM compliance.
+func (ca *CertificateAnalyzer) CheckGMCompliance(cert *x509.Certificate) {
+	if !ca.Policy.RequireGM {
+		return
+	}
+
+	fmt.Println("\n[12] GM/T Compliance Check:")
+
+	if isSM2PublicKey(cert) && isSM3WithSM2Signature(cert) {
+		fmt.Println("    [✓] SM2 key with SM3WithSM2 signature")
+		return
+	}
+
+	ca.addIssue("non-gm-algorithm",
+		fmt.Sprintf("Leaf uses %s/%s instead of SM2/SM3WithSM2", cert.PublicKeyAlgorithm, cert.SignatureAlgorithm))
+	fmt.Println("    [✗] Not GM/T compliant")
+}
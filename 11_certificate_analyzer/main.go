@@ -32,22 +32,27 @@ package main
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"flag"
 	"fmt"
 	"net"
+	"os"
 	"strings"
 	"time"
 )
 
 // CertificateAnalyzer holds certificate analysis data
 type CertificateAnalyzer struct {
-	Domain      string
+	Domain       string
 	Certificates []*x509.Certificate
-	Issues      []SecurityIssue
-	Score       int
+	ConnState    *tls.ConnectionState
+	Issues       []SecurityIssue
+	Score        int
+	Policy       *Policy
 }
 
 // SecurityIssue represents a security finding
 type SecurityIssue struct {
+	ID          string // policy rule ID, e.g. "cert-expired"
 	Severity    string // "Critical", "High", "Medium", "Low", "Info"
 	Title       string
 	Description string
@@ -57,9 +62,10 @@ type SecurityIssue struct {
 // NewCertificateAnalyzer creates a new analyzer instance
 func NewCertificateAnalyzer(domain string) *CertificateAnalyzer {
 	return &CertificateAnalyzer{
-		Domain:  domain,
-		Issues:  make([]SecurityIssue, 0),
-		Score:   100, // Start with perfect score
+		Domain: domain,
+		Issues: make([]SecurityIssue, 0),
+		Score:  100, // Start with perfect score
+		Policy: DefaultPolicy(),
 	}
 }
 
@@ -77,6 +83,7 @@ func (ca *CertificateAnalyzer) FetchCertificate() error {
 
 	state := conn.ConnectionState()
 	ca.Certificates = state.PeerCertificates
+	ca.ConnState = &state
 
 	fmt.Printf("[✓] Successfully retrieved %d certificate(s)\n", len(ca.Certificates))
 	return nil
@@ -115,16 +122,10 @@ func (ca *CertificateAnalyzer) checkExpiration(cert *x509.Certificate) {
 	daysUntilExpiry := int(cert.NotAfter.Sub(now).Hours() / 24)
 
 	if cert.NotAfter.Before(now) {
-		ca.addIssue("Critical", "Certificate Expired",
-			fmt.Sprintf("Certificate expired %d days ago", -daysUntilExpiry),
-			"Renew the certificate immediately")
-		ca.Score -= 50
+		ca.addIssue("cert-expired", fmt.Sprintf("Certificate expired %d days ago", -daysUntilExpiry))
 		fmt.Printf("    [✗] EXPIRED (%d days ago)\n", -daysUntilExpiry)
 	} else if daysUntilExpiry <= 30 {
-		ca.addIssue("High", "Certificate Expiring Soon",
-			fmt.Sprintf("Certificate expires in %d days", daysUntilExpiry),
-			"Plan certificate renewal")
-		ca.Score -= 20
+		ca.addIssue("cert-expiring-soon", fmt.Sprintf("Certificate expires in %d days", daysUntilExpiry))
 		fmt.Printf("    [!] Expires soon (%d days)\n", daysUntilExpiry)
 	} else {
 		fmt.Printf("    [✓] Valid (%d days remaining)\n", daysUntilExpiry)
@@ -134,6 +135,14 @@ func (ca *CertificateAnalyzer) checkExpiration(cert *x509.Certificate) {
 // checkKeyStrength analyzes key strength
 func (ca *CertificateAnalyzer) checkKeyStrength(cert *x509.Certificate) {
 	fmt.Println("\n[2] Key Strength Analysis:")
+
+	if isSM2PublicKey(cert) {
+		fmt.Println("    Algorithm: SM2 (GM/T 0003.1, sm2p256v1 curve)")
+		fmt.Println("    Key Size: 256 bits (ECC-equivalent)")
+		fmt.Println("    [✓] Strong key size")
+		return
+	}
+
 	fmt.Printf("    Algorithm: %s\n", cert.PublicKeyAlgorithm)
 
 	keySize := 0
@@ -145,16 +154,10 @@ func (ca *CertificateAnalyzer) checkKeyStrength(cert *x509.Certificate) {
 	fmt.Printf("    Key Size: %d bits\n", keySize)
 
 	if keySize < 2048 {
-		ca.addIssue("Critical", "Weak Key Size",
-			fmt.Sprintf("Key size of %d bits is insufficient", keySize),
-			"Use at least 2048-bit RSA or 256-bit ECC keys")
-		ca.Score -= 30
+		ca.addIssue("weak-key-size", fmt.Sprintf("Key size of %d bits is insufficient", keySize))
 		fmt.Printf("    [✗] WEAK (< 2048 bits)\n")
 	} else if keySize < 3072 {
-		ca.addIssue("Low", "Key Size Below Recommended",
-			fmt.Sprintf("Key size of %d bits meets minimum but not recommended", keySize),
-			"Consider upgrading to 3072-bit or 4096-bit keys")
-		ca.Score -= 5
+		ca.addIssue("key-size-below-recommended", fmt.Sprintf("Key size of %d bits meets minimum but not recommended", keySize))
 		fmt.Printf("    [!] Acceptable but not recommended\n")
 	} else {
 		fmt.Printf("    [✓] Strong key size\n")
@@ -164,6 +167,13 @@ func (ca *CertificateAnalyzer) checkKeyStrength(cert *x509.Certificate) {
 // checkSignatureAlgorithm checks for weak signature algorithms
 func (ca *CertificateAnalyzer) checkSignatureAlgorithm(cert *x509.Certificate) {
 	fmt.Println("\n[3] Signature Algorithm Check:")
+
+	if isSM3WithSM2Signature(cert) {
+		fmt.Println("    Algorithm: SM3WithSM2 (GM/T 0003.2)")
+		fmt.Println("    [✓] Strong signature algorithm")
+		return
+	}
+
 	fmt.Printf("    Algorithm: %s\n", cert.SignatureAlgorithm)
 
 	weakAlgorithms := map[string]bool{
@@ -177,10 +187,7 @@ func (ca *CertificateAnalyzer) checkSignatureAlgorithm(cert *x509.Certificate) {
 	algName := cert.SignatureAlgorithm.String()
 
 	if weakAlgorithms[algName] {
-		ca.addIssue("High", "Weak Signature Algorithm",
-			fmt.Sprintf("%s is cryptographically weak", algName),
-			"Use SHA-256 or stronger signature algorithms")
-		ca.Score -= 25
+		ca.addIssue("weak-signature-algorithm", fmt.Sprintf("%s is cryptographically weak", algName))
 		fmt.Printf("    [✗] WEAK ALGORITHM\n")
 	} else if strings.Contains(algName, "SHA256") || strings.Contains(algName, "SHA384") || strings.Contains(algName, "SHA512") {
 		fmt.Printf("    [✓] Strong signature algorithm\n")
@@ -200,16 +207,10 @@ func (ca *CertificateAnalyzer) checkCommonName(cert *x509.Certificate) {
 	}
 
 	if cert.Subject.CommonName == "" {
-		ca.addIssue("Medium", "Empty Common Name",
-			"Certificate has no Common Name set",
-			"Set appropriate Common Name in certificate")
-		ca.Score -= 10
+		ca.addIssue("empty-common-name", "Certificate has no Common Name set")
 		fmt.Printf("    [✗] Empty Common Name\n")
 	} else if !strings.Contains(cert.Subject.CommonName, expectedDomain) {
-		ca.addIssue("Medium", "CN Mismatch",
-			fmt.Sprintf("CN '%s' doesn't match domain '%s'", cert.Subject.CommonName, ca.Domain),
-			"Ensure CN matches the domain")
-		ca.Score -= 10
+		ca.addIssue("cn-mismatch", fmt.Sprintf("CN '%s' doesn't match domain '%s'", cert.Subject.CommonName, ca.Domain))
 		fmt.Printf("    [!] Potential mismatch\n")
 	} else {
 		fmt.Printf("    [✓] Valid Common Name\n")
@@ -223,10 +224,7 @@ func (ca *CertificateAnalyzer) checkKeyUsage(cert *x509.Certificate) {
 	fmt.Printf("    Extended Key Usage: %v\n", cert.ExtKeyUsage)
 
 	if cert.KeyUsage == 0 {
-		ca.addIssue("Low", "No Key Usage Set",
-			"Certificate has no key usage constraints",
-			"Define appropriate key usage")
-		ca.Score -= 5
+		ca.addIssue("no-key-usage", "Certificate has no key usage constraints")
 		fmt.Printf("    [!] No key usage defined\n")
 	} else {
 		fmt.Printf("    [✓] Key usage properly defined\n")
@@ -238,10 +236,7 @@ func (ca *CertificateAnalyzer) checkSANs(cert *x509.Certificate) {
 	fmt.Println("\n[6] Subject Alternative Names (SAN) Check:")
 
 	if len(cert.DNSNames) == 0 {
-		ca.addIssue("High", "No SANs Defined",
-			"Certificate has no Subject Alternative Names",
-			"Add SANs for all domains and subdomains")
-		ca.Score -= 15
+		ca.addIssue("no-sans", "Certificate has no Subject Alternative Names")
 		fmt.Printf("    [✗] No SANs found\n")
 	} else {
 		fmt.Printf("    SANs: %v\n", cert.DNSNames)
@@ -257,10 +252,7 @@ func (ca *CertificateAnalyzer) checkSANs(cert *x509.Certificate) {
 		}
 
 		if !domainFound {
-			ca.addIssue("High", "Domain Not in SANs",
-				fmt.Sprintf("Requested domain '%s' not found in SANs", ca.Domain),
-				"Add the domain to certificate SANs")
-			ca.Score -= 15
+			ca.addIssue("domain-not-in-sans", fmt.Sprintf("Requested domain '%s' not found in SANs", ca.Domain))
 			fmt.Printf("    [!] Requested domain not in SANs\n")
 		}
 	}
@@ -276,16 +268,10 @@ func (ca *CertificateAnalyzer) checkValidityPeriod(cert *x509.Certificate) {
 	fmt.Printf("    Validity Period: %d days\n", validityDays)
 
 	if validityDays > 825 { // Apple/Google limit
-		ca.addIssue("Medium", "Excessive Validity Period",
-			fmt.Sprintf("Certificate valid for %d days (> 825 days)", validityDays),
-			"Use certificates with validity periods under 398 days")
-		ca.Score -= 10
+		ca.addIssue("excessive-validity-period", fmt.Sprintf("Certificate valid for %d days (> 825 days)", validityDays))
 		fmt.Printf("    [!] Exceeds browser limits (> 825 days)\n")
 	} else if validityDays > 398 {
-		ca.addIssue("Low", "Long Validity Period",
-			fmt.Sprintf("Certificate valid for %d days", validityDays),
-			"Consider using shorter validity periods (< 90 days)")
-		ca.Score -= 5
+		ca.addIssue("long-validity-period", fmt.Sprintf("Certificate valid for %d days", validityDays))
 		fmt.Printf("    [!] Longer than recommended (> 398 days)\n")
 	} else {
 		fmt.Printf("    [✓] Appropriate validity period\n")
@@ -299,24 +285,25 @@ func (ca *CertificateAnalyzer) checkSelfSigned(cert *x509.Certificate) {
 	fmt.Printf("    Subject: %s\n", cert.Subject.CommonName)
 
 	if cert.Issuer.CommonName == cert.Subject.CommonName {
-		ca.addIssue("Critical", "Self-Signed Certificate",
-			"Certificate is self-signed and not trusted by browsers",
-			"Obtain certificate from a trusted Certificate Authority")
-		ca.Score -= 40
+		ca.addIssue("self-signed", "Certificate is self-signed and not trusted by browsers")
 		fmt.Printf("    [✗] SELF-SIGNED (not trusted)\n")
 	} else {
 		fmt.Printf("    [✓] Issued by CA\n")
 	}
 }
 
-// addIssue adds a security issue to the list
-func (ca *CertificateAnalyzer) addIssue(severity, title, description, remediation string) {
+// addIssue records a security finding for issue ID id, looking up its
+// severity, title, remediation, and score deduction from ca.Policy.
+func (ca *CertificateAnalyzer) addIssue(id, description string) {
+	rule := ca.Policy.rule(id)
 	ca.Issues = append(ca.Issues, SecurityIssue{
-		Severity:    severity,
-		Title:       title,
+		ID:          id,
+		Severity:    rule.Severity,
+		Title:       rule.Title,
 		Description: description,
-		Remediation: remediation,
+		Remediation: rule.Remediation,
 	})
+	ca.Score -= rule.ScoreWeight
 }
 
 // PrintReport prints the final security report
@@ -441,6 +428,16 @@ func (ca *CertificateAnalyzer) PrintCertificateDetails() {
 }
 
 func main() {
+	inputFile := flag.String("input", "", "path to a file of hosts (one \"host\" or \"host:port\" per line) for batch scanning")
+	workers := flag.Int("workers", 20, "number of concurrent workers in batch mode")
+	outDir := flag.String("out", "results", "output directory for batch mode reports")
+	flag.Parse()
+
+	if *inputFile != "" {
+		runBatch(*inputFile, *workers, *outDir)
+		return
+	}
+
 	fmt.Println(strings.Repeat("=", 60))
 	fmt.Println("  SSL/TLS CERTIFICATE SECURITY ANALYZER")
 	fmt.Println("  Educational Tool for Certificate Assessment")
@@ -467,15 +464,75 @@ func main() {
 	// Analyze security
 	analyzer.AnalyzeCertificate()
 
+	// Validate the full chain against the system root store
+	analyzer.ValidateChain(nil)
+
+	// Check revocation status via OCSP, falling back to CRL
+	analyzer.CheckRevocation()
+
+	// Check Certificate Transparency coverage
+	analyzer.checkCT(analyzer.Certificates[0], analyzer.ConnState)
+
+	// Flag non-SM2/SM3 leafs under policies that require GM/T compliance
+	analyzer.CheckGMCompliance(analyzer.Certificates[0])
+
+	// Grade the live handshake itself: protocol/cipher support, forward
+	// secrecy, downgrade protection, and known implementation CVEs
+	analyzer.checkTLSParameters(analyzer.Certificates[0])
+
 	// Print detailed certificate info
 	analyzer.PrintCertificateDetails()
 
 	// Print final report
 	analyzer.PrintReport()
 
+	// Offer a machine-readable export for CI/code-scanning pipelines
+	fmt.Print("\nExport report (json/sarif/none): ")
+	var exportFormat string
+	fmt.Scanln(&exportFormat)
+	if exportFormat == "json" || exportFormat == "sarif" {
+		filename := fmt.Sprintf("cert-report-%s.%s", analyzer.Domain, exportFormat)
+		file, err := os.Create(filename)
+		if err != nil {
+			fmt.Printf("[✗] Could not create %s: %v\n", filename, err)
+		} else {
+			defer file.Close()
+			if err := analyzer.Export(exportFormat, file); err != nil {
+				fmt.Printf("[✗] Export failed: %v\n", err)
+			} else {
+				fmt.Printf("[✓] Wrote %s\n", filename)
+			}
+		}
+	}
+
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	fmt.Println("Analysis complete!")
 	fmt.Println("\n⚠️  DISCLAIMER: This tool is for educational purposes only.")
 	fmt.Println("Only analyze certificates of systems you own or have authorization to test.")
 	fmt.Println(strings.Repeat("=", 60))
 }
+
+// runBatch scans every host listed in inputFile with a bounded pool of
+// workers, writing per-host JSON reports and a "changes since last scan"
+// diff (against a persisted snapshot store) into outDir.
+func runBatch(inputFile string, workers int, outDir string) {
+	hosts, err := readHostsFile(inputFile)
+	if err != nil {
+		fmt.Printf("[✗] %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("[*] Loaded %d host(s) from %s (%d workers)\n", len(hosts), inputFile, workers)
+
+	bs, err := NewBatchScanner(workers, outDir)
+	if err != nil {
+		fmt.Printf("[✗] %v\n", err)
+		os.Exit(1)
+	}
+	defer bs.Close()
+
+	if err := bs.Run(hosts); err != nil {
+		fmt.Printf("[✗] batch scan: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("[✓] Batch scan complete. Reports and changes.txt written to %s\n", outDir)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyRule defines how one issue ID is scored and described.
+type PolicyRule struct {
+	Severity    string `json:"severity" yaml:"severity"`
+	Title       string `json:"title" yaml:"title"`
+	ScoreWeight int    `json:"score_weight" yaml:"score_weight"`
+	Remediation string `json:"remediation" yaml:"remediation"`
+}
+
+// Policy maps issue IDs to severity, score weight, and remediation text,
+// replacing the single hard-coded scoring this tool originally baked
+// into each check* function. Users can load alternative profiles such as
+// "Mozilla Modern", "PCI-DSS", or "internal-CA-only".
+type Policy struct {
+	Name  string                `json:"name" yaml:"name"`
+	Rules map[string]PolicyRule `json:"rules" yaml:"rules"`
+	// RequireGM enables CheckGMCompliance, which flags any leaf that
+	// doesn't use SM2/SM3WithSM2, for jurisdictions mandating GM/T
+	// cryptography (e.g. a "PCI-DSS" or "Mozilla Modern" profile leaves
+	// this false; an "internal-CA-only" GM/T profile sets it true).
+	RequireGM bool `json:"require_gm" yaml:"require_gm"`
+}
+
+// LoadPolicyYAML parses a Policy from YAML.
+func LoadPolicyYAML(data []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("policy: parsing YAML: %w", err)
+	}
+	return &p, nil
+}
+
+// LoadPolicyJSON parses a Policy from JSON.
+func LoadPolicyJSON(data []byte) (*Policy, error) {
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("policy: parsing JSON: %w", err)
+	}
+	return &p, nil
+}
+
+// rule looks up id, falling back to a generic Medium-severity rule for
+// any ID a loaded policy doesn't mention, so a partial custom policy
+// degrades gracefully instead of losing findings.
+func (p *Policy) rule(id string) PolicyRule {
+	if p != nil {
+		if r, ok := p.Rules[id]; ok {
+			return r
+		}
+	}
+	return PolicyRule{Severity: "Medium", Title: id, ScoreWeight: 10, Remediation: "Review this finding"}
+}
+
+// DefaultPolicy reproduces the severities and score deductions this tool
+// originally hard-coded into its check* functions.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Name: "default",
+		Rules: map[string]PolicyRule{
+			"cert-expired":                 {Severity: "Critical", Title: "Certificate Expired", ScoreWeight: 50, Remediation: "Renew the certificate immediately"},
+			"cert-expiring-soon":           {Severity: "High", Title: "Certificate Expiring Soon", ScoreWeight: 20, Remediation: "Plan certificate renewal"},
+			"weak-key-size":                {Severity: "Critical", Title: "Weak Key Size", ScoreWeight: 30, Remediation: "Use at least 2048-bit RSA or 256-bit ECC keys"},
+			"key-size-below-recommended":   {Severity: "Low", Title: "Key Size Below Recommended", ScoreWeight: 5, Remediation: "Consider upgrading to 3072-bit or 4096-bit keys"},
+			"weak-signature-algorithm":     {Severity: "High", Title: "Weak Signature Algorithm", ScoreWeight: 25, Remediation: "Use SHA-256 or stronger signature algorithms"},
+			"empty-common-name":            {Severity: "Medium", Title: "Empty Common Name", ScoreWeight: 10, Remediation: "Set appropriate Common Name in certificate"},
+			"cn-mismatch":                  {Severity: "Medium", Title: "CN Mismatch", ScoreWeight: 10, Remediation: "Ensure CN matches the domain"},
+			"no-key-usage":                 {Severity: "Low", Title: "No Key Usage Set", ScoreWeight: 5, Remediation: "Define appropriate key usage"},
+			"no-sans":                      {Severity: "High", Title: "No SANs Defined", ScoreWeight: 15, Remediation: "Add SANs for all domains and subdomains"},
+			"domain-not-in-sans":           {Severity: "High", Title: "Domain Not in SANs", ScoreWeight: 15, Remediation: "Add the domain to certificate SANs"},
+			"excessive-validity-period":    {Severity: "Medium", Title: "Excessive Validity Period", ScoreWeight: 10, Remediation: "Use certificates with validity periods under 398 days"},
+			"long-validity-period":         {Severity: "Low", Title: "Long Validity Period", ScoreWeight: 5, Remediation: "Consider using shorter validity periods (< 90 days)"},
+			"self-signed":                  {Severity: "Critical", Title: "Self-Signed Certificate", ScoreWeight: 40, Remediation: "Obtain certificate from a trusted Certificate Authority"},
+			"chain-linkage-mismatch":       {Severity: "High", Title: "Chain Linkage Mismatch", ScoreWeight: 15, Remediation: "Ensure intermediates are served in the correct order and match the issuer chain"},
+			"unknown-authority":            {Severity: "Critical", Title: "Unknown Certificate Authority", ScoreWeight: 40, Remediation: "Use a certificate from a publicly trusted CA"},
+			"hostname-mismatch":            {Severity: "High", Title: "Hostname Mismatch", ScoreWeight: 20, Remediation: "Issue a certificate covering the requested hostname"},
+			"chain-verification-failed":    {Severity: "High", Title: "Chain Verification Failed", ScoreWeight: 20, Remediation: "Investigate and rebuild the certificate chain"},
+			"not-authorized-to-sign":       {Severity: "Critical", Title: "Intermediate Not Authorized to Sign", ScoreWeight: 35, Remediation: "Replace with a properly constrained intermediate"},
+			"chain-expired":                {Severity: "Critical", Title: "Chain Contains Expired Certificate", ScoreWeight: 40, Remediation: "Renew the expired certificate"},
+			"name-constraint-violation":    {Severity: "High", Title: "Name Constraint Violation", ScoreWeight: 30, Remediation: "Reissue under a CA whose constraints permit this name"},
+			"too-many-intermediates":       {Severity: "High", Title: "Path Length Constraint Exceeded", ScoreWeight: 25, Remediation: "Remove unnecessary intermediates"},
+			"incompatible-usage":           {Severity: "Medium", Title: "Incompatible Key Usage", ScoreWeight: 15, Remediation: "Reissue with the correct EKU"},
+			"name-mismatch":                {Severity: "High", Title: "Issuer/Subject Name Mismatch", ScoreWeight: 25, Remediation: "Ensure the chain is built in the correct order"},
+			"chain-invalid":                {Severity: "High", Title: "Chain Invalid", ScoreWeight: 20, Remediation: "Investigate the certificate chain"},
+			"cert-revoked":                 {Severity: "Critical", Title: "Certificate Revoked", ScoreWeight: 60, Remediation: "Stop trusting this certificate and obtain a new one"},
+			"no-must-staple":               {Severity: "Info", Title: "No OCSP Must-Staple", ScoreWeight: 0, Remediation: "Consider requesting the Must-Staple extension at issuance"},
+			"insufficient-ct":              {Severity: "Medium", Title: "Insufficient Certificate Transparency Coverage", ScoreWeight: 10, Remediation: "Request the CA reissue with SCTs embedded from at least two independent CT logs"},
+			"non-gm-algorithm":             {Severity: "High", Title: "Not GM/T Compliant", ScoreWeight: 20, Remediation: "Reissue the certificate with an SM2 key and SM3WithSM2 signature"},
+			"legacy-tls-supported":         {Severity: "High", Title: "Legacy TLS Version Supported", ScoreWeight: 20, Remediation: "Disable TLS 1.0 and TLS 1.1"},
+			"no-tls13":                     {Severity: "Low", Title: "TLS 1.3 Not Supported", ScoreWeight: 5, Remediation: "Enable TLS 1.3"},
+			"weak-cipher-suite":            {Severity: "Critical", Title: "Weak Cipher Suite Accepted", ScoreWeight: 25, Remediation: "Disable RC4, 3DES, and other insecure cipher suites"},
+			"no-forward-secrecy":           {Severity: "High", Title: "No Forward Secrecy", ScoreWeight: 20, Remediation: "Prefer ECDHE/DHE cipher suites over static RSA key exchange"},
+			"no-downgrade-protection":      {Severity: "Medium", Title: "No Downgrade Protection", ScoreWeight: 10, Remediation: "Ensure the server honors TLS_FALLBACK_SCSV"},
+			"heartbleed-vulnerable":        {Severity: "Critical", Title: "Heartbleed (CVE-2014-0160)", ScoreWeight: 60, Remediation: "Patch OpenSSL and reissue all certificates/keys used on this host"},
+			"ccs-injection-suspected":      {Severity: "Medium", Title: "Possible CCS Injection (CVE-2014-0224)", ScoreWeight: 15, Remediation: "Patch OpenSSL; confirm with a full CCS injection exploitation test"},
+			"robot-potentially-vulnerable": {Severity: "Medium", Title: "Possible ROBOT (Bleichenbacher Oracle)", ScoreWeight: 15, Remediation: "Disable plain RSA key exchange suites; confirm with a timing-oracle probe"},
+		},
+	}
+}
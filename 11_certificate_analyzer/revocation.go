@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// mustStapleOID is the OCSP Must-Staple extension (RFC 7633).
+var mustStapleOID = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+
+// CheckRevocation consults OCSP responders in cert.OCSPServer, falling
+// back to the CRL distribution points in cert.CRLDistributionPoints if no
+// responder is reachable or returns an inconclusive answer.
+func (ca *CertificateAnalyzer) CheckRevocation() {
+	fmt.Println("\n[10] Revocation Check:")
+
+	if len(ca.Certificates) < 1 {
+		fmt.Println("    [!] No certificate to check")
+		return
+	}
+	cert := ca.Certificates[0]
+
+	var status revocationStatus
+	ok := false
+	source := "OCSP"
+	if issuer, err := ca.issuerCertificate(cert); err != nil {
+		fmt.Printf("    [!] Could not obtain issuer certificate: %v\n", err)
+	} else {
+		status, ok = ca.checkOCSP(cert, issuer)
+	}
+	if !ok {
+		source = "CRL"
+		status, ok = ca.checkCRL(cert)
+	}
+	if ok {
+		ca.reportRevocationStatus(source, status)
+	} else {
+		fmt.Println("    [!] Revocation status could not be determined (no OCSP or CRL reachable)")
+	}
+
+	ca.checkMustStaple(cert)
+}
+
+// issuerCertificate returns the signer of cert: the next certificate in
+// the fetched chain if present, else a certificate fetched from
+// cert.IssuingCertificateURL.
+func (ca *CertificateAnalyzer) issuerCertificate(cert *x509.Certificate) (*x509.Certificate, error) {
+	for _, candidate := range ca.Certificates[1:] {
+		if candidate.Subject.String() == cert.Issuer.String() {
+			return candidate, nil
+		}
+	}
+	for _, url := range cert.IssuingCertificateURL {
+		resp, err := http.Get(url)
+		if err != nil {
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			continue
+		}
+		issuer, err := x509.ParseCertificate(body)
+		if err == nil {
+			return issuer, nil
+		}
+	}
+	return nil, fmt.Errorf("no issuer certificate available")
+}
+
+type revocationStatus struct {
+	revoked   bool
+	reason    int
+	revokedAt time.Time
+}
+
+// checkOCSP POSTs an OCSP request for cert to each of cert.OCSPServer in
+// turn, returning the first parseable Good or Revoked response; an Unknown
+// status counts as inconclusive and moves on to the next responder.
+func (ca *CertificateAnalyzer) checkOCSP(cert, issuer *x509.Certificate) (revocationStatus, bool) {
+	for _, responderURL := range cert.OCSPServer {
+		request, err := ocsp.CreateRequest(cert, issuer, nil)
+		if err != nil {
+			continue
+		}
+		resp, err := http.Post(responderURL, "application/ocsp-request", bytes.NewReader(request))
+		if err != nil {
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			continue
+		}
+		parsed, err := ocsp.ParseResponseForCert(body, cert, issuer)
+		if err != nil || parsed.Status == ocsp.Unknown {
+			continue
+		}
+		return revocationStatus{
+			revoked:   parsed.Status == ocsp.Revoked,
+			reason:    parsed.RevocationReason,
+			revokedAt: parsed.RevokedAt,
+		}, true
+	}
+	return revocationStatus{}, false
+}
+
+// checkCRL downloads each CRL distribution point and scans its revoked
+// entries for cert's serial number.
+func (ca *CertificateAnalyzer) checkCRL(cert *x509.Certificate) (revocationStatus, bool) {
+	for _, url := range cert.CRLDistributionPoints {
+		resp, err := http.Get(url)
+		if err != nil {
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			continue
+		}
+		crl, err := x509.ParseRevocationList(body)
+		if err != nil {
+			continue
+		}
+		for _, entry := range crl.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return revocationStatus{revoked: true, reason: entry.ReasonCode, revokedAt: entry.RevocationTime}, true
+			}
+		}
+		return revocationStatus{revoked: false}, true
+	}
+	return revocationStatus{}, false
+}
+
+func (ca *CertificateAnalyzer) reportRevocationStatus(source string, status revocationStatus) {
+	if !status.revoked {
+		fmt.Printf("    [✓] Not revoked (checked via %s)\n", source)
+		return
+	}
+	ca.addIssue("cert-revoked",
+		fmt.Sprintf("%s reports this certificate was revoked at %s (reason code %d)",
+			source, status.revokedAt.Format(time.RFC3339), status.reason))
+	fmt.Printf("    [✗] REVOKED via %s (reason %d)\n", source, status.reason)
+}
+
+// checkMustStaple flags the absence of the OCSP Must-Staple extension as
+// an informational finding.
+func (ca *CertificateAnalyzer) checkMustStaple(cert *x509.Certificate) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(mustStapleOID) {
+			fmt.Println("    [✓] OCSP Must-Staple extension present")
+			return
+		}
+	}
+	ca.addIssue("no-must-staple", "Certificate does not request OCSP stapling be mandatory")
+	fmt.Println("    [i] No Must-Staple extension")
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BruteForceResult summarizes a completed (or cancelled) brute-force run.
+type BruteForceResult struct {
+	Password string
+	Found    bool
+	Attempts uint64
+	Elapsed  time.Duration
+}
+
+// CrackHashBruteForceParallel exhaustively enumerates every candidate over
+// charset, from length 1 up to maxLength, using a worker pool sized to
+// runtime.NumCPU(). Work is partitioned by fixing each candidate's first
+// character: worker i owns charset[i], charset[i+workers], ... and
+// enumerates every suffix under it. The first worker to find a match
+// cancels the shared context so every other worker stops immediately.
+func CrackHashBruteForceParallel(targetHash string, algorithm HashAlgorithm, charset string, maxLength int) BruteForceResult {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	workers := runtime.NumCPU()
+	var attempts uint64
+	var found atomic.Value // string
+	var wg sync.WaitGroup
+
+	startTime := time.Now()
+	progressDone := make(chan struct{})
+	go reportProgress(ctx, &attempts, progressDone)
+
+	for length := 1; length <= maxLength && ctx.Err() == nil; length++ {
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(startIdx int) {
+				defer wg.Done()
+				prefix := make([]byte, 1)
+				for i := startIdx; i < len(charset); i += workers {
+					prefix[0] = charset[i]
+					bruteForceSuffix(ctx, cancel, targetHash, algorithm, charset, prefix, length-1, &attempts, &found)
+					if ctx.Err() != nil {
+						return
+					}
+				}
+			}(w)
+		}
+		wg.Wait()
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	close(progressDone)
+	elapsed := time.Since(startTime)
+
+	if v := found.Load(); v != nil {
+		return BruteForceResult{Password: v.(string), Found: true, Attempts: atomic.LoadUint64(&attempts), Elapsed: elapsed}
+	}
+	return BruteForceResult{Found: false, Attempts: atomic.LoadUint64(&attempts), Elapsed: elapsed}
+}
+
+// bruteForceSuffix recursively extends prefix with every combination of
+// `remaining` more characters from charset, hashing and comparing each
+// complete candidate. It returns early as soon as ctx is cancelled.
+func bruteForceSuffix(ctx context.Context, cancel context.CancelFunc, targetHash string, algorithm HashAlgorithm, charset string, prefix []byte, remaining int, attempts *uint64, found *atomic.Value) {
+	if ctx.Err() != nil {
+		return
+	}
+	if remaining == 0 {
+		candidate := string(prefix)
+		atomic.AddUint64(attempts, 1)
+		if HashPassword(candidate, algorithm) == targetHash {
+			found.Store(candidate)
+			cancel()
+		}
+		return
+	}
+	for i := 0; i < len(charset); i++ {
+		next := append(append([]byte{}, prefix...), charset[i])
+		bruteForceSuffix(ctx, cancel, targetHash, algorithm, charset, next, remaining-1, attempts, found)
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// reportProgress prints cumulative attempts/sec on a ticker until done is
+// closed or ctx is cancelled.
+func reportProgress(ctx context.Context, attempts *uint64, done <-chan struct{}) {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	start := time.Now()
+	for {
+		select {
+		case <-ticker.C:
+			n := atomic.LoadUint64(attempts)
+			rate := float64(n) / time.Since(start).Seconds()
+			fmt.Printf("\r[*] %d attempts (%.0f/sec)...", n, rate)
+		case <-done:
+			fmt.Println()
+			return
+		case <-ctx.Done():
+			fmt.Println()
+			return
+		}
+	}
+}
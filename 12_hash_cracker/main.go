@@ -45,6 +45,8 @@ import (
 	"strings"
 	"time"
 	"unicode"
+
+	"github.com/arya2004/cybersecurity/pkg/strength"
 )
 
 // HashAlgorithm represents a hashing algorithm
@@ -129,7 +131,8 @@ func CrackHashDictionary(targetHash string, algorithm HashAlgorithm, wordlist []
 	return "", false, attempts
 }
 
-// CrackHashBruteForce attempts brute force crack (limited for educational purposes)
+// CrackHashBruteForce exhaustively brute-forces targetHash using a worker
+// pool sized to runtime.NumCPU(); see CrackHashBruteForceParallel.
 func CrackHashBruteForce(targetHash string, algorithm HashAlgorithm, maxLength int) (string, bool) {
 	fmt.Printf("\n[*] Starting brute force attack...\n")
 	fmt.Printf("[*] Maximum length: %d characters\n", maxLength)
@@ -137,65 +140,20 @@ func CrackHashBruteForce(targetHash string, algorithm HashAlgorithm, maxLength i
 	fmt.Println("[!] WARNING: This may take a very long time!")
 
 	charset := "abcdefghijklmnopqrstuvwxyz0123456789"
-	startTime := time.Now()
-	attempts := 0
-
-	// Only try up to maxLength for demonstration
-	for length := 1; length <= maxLength; length++ {
-		fmt.Printf("\n[*] Testing passwords of length %d...\n", length)
+	result := CrackHashBruteForceParallel(targetHash, algorithm, charset, maxLength)
 
-		// Generate and test passwords (simplified for educational purposes)
-		// In reality, this would be much more complex
-		result, found, count := bruteForceLengthN(targetHash, algorithm, charset, length, 10000)
-		attempts += count
-
-		if found {
-			duration := time.Since(startTime)
-			fmt.Printf("\n[✓] PASSWORD CRACKED!\n")
-			fmt.Printf("    Password: %s\n", result)
-			fmt.Printf("    Attempts: %d\n", attempts)
-			fmt.Printf("    Time: %v\n", duration)
-			return result, true
-		}
+	if result.Found {
+		fmt.Printf("\n[✓] PASSWORD CRACKED!\n")
+		fmt.Printf("    Password: %s\n", result.Password)
+		fmt.Printf("    Attempts: %d\n", result.Attempts)
+		fmt.Printf("    Time: %v\n", result.Elapsed)
+		return result.Password, true
 	}
 
 	fmt.Printf("\n[✗] Password not cracked within limits\n")
 	return "", false
 }
 
-// bruteForceLengthN tries passwords of specific length (limited for demo)
-func bruteForceLengthN(targetHash string, algorithm HashAlgorithm, charset string, length, maxAttempts int) (string, bool, int) {
-	attempts := 0
-
-	// For educational purposes, only try a limited number
-	// Real brute force would be exhaustive
-	for i := 0; i < maxAttempts && attempts < maxAttempts; i++ {
-		password := generateRandomPassword(charset, length)
-		hash := HashPassword(password, algorithm)
-		attempts++
-
-		if hash == targetHash {
-			return password, true, attempts
-		}
-
-		if attempts%100 == 0 {
-			fmt.Printf("\r    Tested %d/%d passwords...", attempts, maxAttempts)
-		}
-	}
-
-	return "", false, attempts
-}
-
-// generateRandomPassword generates a random password for brute force demo
-func generateRandomPassword(charset string, length int) string {
-	// Simplified for educational demo
-	password := make([]byte, length)
-	for i := range password {
-		password[i] = charset[i%len(charset)]
-	}
-	return string(password)
-}
-
 // AnalyzePasswordStrength performs comprehensive password strength analysis
 func AnalyzePasswordStrength(password string) *PasswordStrength {
 	ps := &PasswordStrength{
@@ -210,25 +168,27 @@ func AnalyzePasswordStrength(password string) *PasswordStrength {
 	ps.HasDigit = hasDigit(password)
 	ps.HasSpecial = hasSpecialChar(password)
 
-	// Calculate score
-	ps.Score = calculatePasswordScore(ps)
+	// Score and crack time come from a zxcvbn-style minimum-guesses-cover
+	// analysis (pkg/strength) rather than an additive point score.
+	estimate := strength.Estimate(password)
+	ps.Score = estimate.Score * 25
 
 	// Determine strength
-	switch {
-	case ps.Score >= 80:
+	switch estimate.Score {
+	case 4:
 		ps.Strength = "Very Strong"
-	case ps.Score >= 60:
+	case 3:
 		ps.Strength = "Strong"
-	case ps.Score >= 40:
+	case 2:
 		ps.Strength = "Moderate"
-	case ps.Score >= 20:
+	case 1:
 		ps.Strength = "Weak"
 	default:
 		ps.Strength = "Very Weak"
 	}
 
 	// Estimate crack time
-	ps.EstimatedTime = estimateCrackTime(ps)
+	ps.EstimatedTime = estimate.CrackTime
 
 	// Generate suggestions
 	ps.generateSuggestions()
@@ -239,47 +199,6 @@ func AnalyzePasswordStrength(password string) *PasswordStrength {
 	return ps
 }
 
-// calculatePasswordScore calculates password score based on multiple factors
-func calculatePasswordScore(ps *PasswordStrength) int {
-	score := 0
-
-	// Length scoring
-	if ps.Length >= 12 {
-		score += 30
-	} else if ps.Length >= 8 {
-		score += 20
-	} else if ps.Length >= 6 {
-		score += 10
-	}
-
-	// Character variety scoring
-	if ps.HasLower {
-		score += 10
-	}
-	if ps.HasUpper {
-		score += 15
-	}
-	if ps.HasDigit {
-		score += 15
-	}
-	if ps.HasSpecial {
-		score += 20
-	}
-
-	// Bonus for using all character types
-	if ps.HasLower && ps.HasUpper && ps.HasDigit && ps.HasSpecial {
-		score += 10
-	}
-
-	// Entropy bonus
-	entropy := calculateEntropy(ps.Password)
-	if entropy > 50 {
-		score += 10
-	}
-
-	return score
-}
-
 // calculateEntropy calculates password entropy
 func calculateEntropy(password string) float64 {
 	var poolSize float64
@@ -305,54 +224,6 @@ func calculateEntropy(password string) float64 {
 	return entropy
 }
 
-// estimateCrackTime estimates time to crack password
-func estimateCrackTime(ps *PasswordStrength) string {
-	var poolSize float64 = 0
-
-	if ps.HasLower {
-		poolSize += 26
-	}
-	if ps.HasUpper {
-		poolSize += 26
-	}
-	if ps.HasDigit {
-		poolSize += 10
-	}
-	if ps.HasSpecial {
-		poolSize += 32
-	}
-
-	if poolSize == 0 {
-		return "Instantly"
-	}
-
-	// Assuming 1 billion hashes/second (modern GPU)
-	hashesPerSecond := 1000000000.0
-	possibleCombinations := math.Pow(poolSize, float64(ps.Length))
-	seconds := possibleCombinations / hashesPerSecond / 2 // Average case
-
-	return formatDuration(seconds)
-}
-
-// formatDuration converts seconds to human readable format
-func formatDuration(seconds float64) string {
-	if seconds < 1 {
-		return "Instantly"
-	} else if seconds < 60 {
-		return fmt.Sprintf("%.0f seconds", seconds)
-	} else if seconds < 3600 {
-		return fmt.Sprintf("%.0f minutes", seconds/60)
-	} else if seconds < 86400 {
-		return fmt.Sprintf("%.0f hours", seconds/3600)
-	} else if seconds < 31536000 {
-		return fmt.Sprintf("%.0f days", seconds/86400)
-	} else if seconds < 31536000000 {
-		return fmt.Sprintf("%.0f years", seconds/31536000)
-	} else {
-		return "Centuries or more"
-	}
-}
-
 // generateSuggestions generates password improvement suggestions
 func (ps *PasswordStrength) generateSuggestions() {
 	if ps.Length < 12 {
@@ -514,7 +385,9 @@ func main() {
 		fmt.Println("3. Analyze Password Strength")
 		fmt.Println("4. Compare Hash")
 		fmt.Println("5. Educational Demo")
-		fmt.Println("6. Exit")
+		fmt.Println("6. Build Rainbow Table")
+		fmt.Println("7. Crack Hash (Rainbow Table)")
+		fmt.Println("8. Exit")
 		fmt.Print("\nChoice: ")
 
 		var choice int
@@ -532,6 +405,10 @@ func main() {
 		case 5:
 			educationalDemo()
 		case 6:
+			buildRainbowTableMenu()
+		case 7:
+			crackWithRainbowTableMenu()
+		case 8:
 			fmt.Println("\nExiting... Stay secure!")
 			return
 		default:
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/arya2004/cybersecurity/pkg/rainbow"
+)
+
+// rawHashFunc returns a rainbow.HashFunc that computes the raw (unhexed)
+// digest for algorithm, since HashPassword returns a hex string.
+func rawHashFunc(algorithm HashAlgorithm) rainbow.HashFunc {
+	return func(plaintext string) []byte {
+		encoded := HashPassword(plaintext, algorithm)
+		raw, _ := hex.DecodeString(encoded)
+		return raw
+	}
+}
+
+func buildRainbowTableMenu() {
+	reader := bufio.NewReader(os.Stdin)
+
+	algorithm := promptAlgorithm()
+	if algorithm == "" {
+		return
+	}
+
+	fmt.Print("Password length to cover: ")
+	passwordLen := promptInt(reader, 4)
+
+	fmt.Print("Chain length (steps per chain) [default 1000]: ")
+	chainLength := promptInt(reader, 1000)
+
+	fmt.Print("Chain count (number of chains) [default 10000]: ")
+	chainCount := promptInt(reader, 10000)
+
+	fmt.Print("Output file: ")
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+	if path == "" {
+		fmt.Println("No output file given, aborting.")
+		return
+	}
+
+	opts := rainbow.BuildOptions{
+		Algorithm:   string(algorithm),
+		ChainLength: chainLength,
+		ChainCount:  chainCount,
+		Charset:     "abcdefghijklmnopqrstuvwxyz0123456789",
+		PasswordLen: passwordLen,
+	}
+
+	fmt.Printf("\n[*] Building %d chains of length %d for %s passwords of length %d...\n",
+		opts.ChainCount, opts.ChainLength, opts.Algorithm, opts.PasswordLen)
+	table := rainbow.Build(context.Background(), opts, rawHashFunc(algorithm))
+
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("[!] Could not create %s: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	if err := table.Save(f); err != nil {
+		fmt.Printf("[!] Could not save table: %v\n", err)
+		return
+	}
+	fmt.Printf("[+] Rainbow table saved to %s\n", path)
+}
+
+func crackWithRainbowTableMenu() {
+	reader := bufio.NewReader(os.Stdin)
+
+	algorithm := promptAlgorithm()
+	if algorithm == "" {
+		return
+	}
+
+	fmt.Print("Rainbow table file: ")
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("[!] Could not open %s: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	table, err := rainbow.Load(f, rawHashFunc(algorithm))
+	if err != nil {
+		fmt.Printf("[!] Could not load table: %v\n", err)
+		return
+	}
+
+	fmt.Print("Enter hash to crack: ")
+	hashHex, _ := reader.ReadString('\n')
+	hashHex = strings.TrimSpace(hashHex)
+	target, err := hex.DecodeString(hashHex)
+	if err != nil {
+		fmt.Printf("[!] Invalid hex hash: %v\n", err)
+		return
+	}
+
+	fmt.Println("\n[*] Searching rainbow table...")
+	plaintext, ok := table.Lookup(target)
+	if !ok {
+		fmt.Println("[✗] Not found in rainbow table.")
+		return
+	}
+	fmt.Printf("[✓] Password found: %s\n", plaintext)
+}
+
+func promptAlgorithm() HashAlgorithm {
+	fmt.Println("\nSelect algorithm:")
+	fmt.Println("1. MD5")
+	fmt.Println("2. SHA1")
+	fmt.Println("3. SHA256")
+	fmt.Print("Choice: ")
+
+	var choice int
+	fmt.Scanln(&choice)
+
+	switch choice {
+	case 1:
+		return MD5_HASH
+	case 2:
+		return SHA1_HASH
+	case 3:
+		return SHA256_HASH
+	default:
+		fmt.Println("Invalid choice!")
+		return ""
+	}
+}
+
+func promptInt(reader *bufio.Reader, def int) int {
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def
+	}
+	n, err := strconv.Atoi(line)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,120 @@
+// Command filecrypt is a CLI for the pkg/filecrypt authenticated
+// file-encryption format.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"golang.org/x/term"
+	"os"
+
+	"github.com/arya2004/cybersecurity/pkg/filecrypt"
+)
+
+func main() {
+	decryptMode := flag.Bool("d", false, "decrypt instead of encrypt")
+	paranoid := flag.Bool("paranoid", false, "cascade a second independent stream-cipher pass")
+	reedSolomon := flag.Bool("rs", false, "Reed-Solomon protect the header")
+	keyfilePath := flag.String("keyfile", "", "path to a keyfile mixed into the key derivation")
+	keepOnFail := flag.Bool("k", false, "keep output even if MAC verification fails")
+	repair := flag.Bool("f", false, "attempt Reed-Solomon repair of the header before decrypting")
+	comment := flag.String("comment", "", "plaintext comment stored in the header")
+	inPath := flag.String("in", "", "input file")
+	outPath := flag.String("out", "", "output file")
+	flag.Parse()
+
+	if *inPath == "" || *outPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: filecrypt [-d] -in <file> -out <file>")
+		os.Exit(2)
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error reading password:", err)
+		os.Exit(1)
+	}
+
+	var keyfile []byte
+	if *keyfilePath != "" {
+		keyfile, err = os.ReadFile(*keyfilePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error reading keyfile:", err)
+			os.Exit(1)
+		}
+	}
+
+	var in *os.File
+	if *decryptMode && *repair {
+		raw, readErr := os.ReadFile(*inPath)
+		if readErr != nil {
+			fmt.Fprintln(os.Stderr, "error opening input:", readErr)
+			os.Exit(1)
+		}
+		fixed, repairErr := filecrypt.RepairFile(raw)
+		if repairErr != nil {
+			fmt.Fprintln(os.Stderr, "error repairing file:", repairErr)
+			os.Exit(1)
+		}
+		tmp, tmpErr := os.CreateTemp("", "filecrypt-repaired-*")
+		if tmpErr != nil {
+			fmt.Fprintln(os.Stderr, "error:", tmpErr)
+			os.Exit(1)
+		}
+		defer os.Remove(tmp.Name())
+		if _, writeErr := tmp.Write(fixed); writeErr != nil {
+			fmt.Fprintln(os.Stderr, "error:", writeErr)
+			os.Exit(1)
+		}
+		tmp.Seek(0, 0)
+		in = tmp
+	} else {
+		var openErr error
+		in, openErr = os.Open(*inPath)
+		if openErr != nil {
+			fmt.Fprintln(os.Stderr, "error opening input:", openErr)
+			os.Exit(1)
+		}
+	}
+	defer in.Close()
+
+	out, err := os.Create(*outPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error creating output:", err)
+		os.Exit(1)
+	}
+	defer out.Close()
+
+	opts := filecrypt.Options{
+		Comment:          *comment,
+		Paranoid:         *paranoid,
+		ReedSolomon:      *reedSolomon,
+		Keyfile:          keyfile,
+		KeepOnMACFailure: *keepOnFail,
+	}
+
+	if *decryptMode {
+		err = filecrypt.Decrypt(out, in, password, keyfile, opts)
+	} else {
+		err = filecrypt.Encrypt(out, in, password, opts)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func readPassword() ([]byte, error) {
+	fmt.Fprint(os.Stderr, "Password: ")
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		pw, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		return pw, err
+	}
+	reader := bufio.NewReader(os.Stdin)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	return []byte(line[:len(line)-1]), nil
+}
@@ -0,0 +1,98 @@
+// Command saes is a CLI for the pkg/saes S-AES implementation: it reads a
+// whole file into memory and runs it through ECB, CBC, or CTR mode under a
+// 16-bit hex key.
+//
+// Usage:
+//
+//	saes encrypt --mode cbc --key A73B --in file --out file.enc
+//	saes decrypt --mode cbc --key A73B --in file.enc --out file
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/arya2004/cybersecurity/pkg/saes"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "encrypt":
+		run(os.Args[2:], true)
+	case "decrypt":
+		run(os.Args[2:], false)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: saes <encrypt|decrypt> --mode <ecb|cbc|ctr> --key <4-hex-digit key> --in <file> --out <file>")
+}
+
+func run(args []string, encrypt bool) {
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	mode := fs.String("mode", "cbc", "block mode: ecb, cbc, or ctr")
+	keyStr := fs.String("key", "", "4-hex-digit key, e.g. A73B")
+	inPath := fs.String("in", "", "input file")
+	outPath := fs.String("out", "", "output file")
+	fs.Parse(args)
+
+	if *keyStr == "" || *inPath == "" || *outPath == "" {
+		usage()
+		os.Exit(2)
+	}
+
+	key, err := saes.ParseKey(*keyStr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid key:", err)
+		os.Exit(1)
+	}
+
+	input, err := os.ReadFile(*inPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error reading input:", err)
+		os.Exit(1)
+	}
+
+	var output []byte
+	switch *mode {
+	case "ecb":
+		if encrypt {
+			output = saes.EncryptECB(key, input)
+		} else {
+			output, err = saes.DecryptECB(key, input)
+		}
+	case "cbc":
+		if encrypt {
+			output, err = saes.EncryptCBC(key, input)
+		} else {
+			output, err = saes.DecryptCBC(key, input)
+		}
+	case "ctr":
+		if encrypt {
+			output, err = saes.EncryptCTR(key, input)
+		} else {
+			output, err = saes.DecryptCTR(key, input)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, "unknown mode:", *mode)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*outPath, output, 0o600); err != nil {
+		fmt.Fprintln(os.Stderr, "error writing output:", err)
+		os.Exit(1)
+	}
+}
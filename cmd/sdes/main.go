@@ -0,0 +1,92 @@
+// Command sdes is a CLI for the pkg/sdes S-DES implementation: it reads a
+// whole file into memory and runs it through ECB or CBC mode under a
+// 10-bit binary key.
+//
+// Usage:
+//
+//	sdes encrypt --mode cbc --key 1010000010 --in file --out file.enc
+//	sdes decrypt --mode cbc --key 1010000010 --in file.enc --out file
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/arya2004/cybersecurity/pkg/sdes"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "encrypt":
+		run(os.Args[2:], true)
+	case "decrypt":
+		run(os.Args[2:], false)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: sdes <encrypt|decrypt> --mode <ecb|cbc> --key <10-bit binary string> --in <file> --out <file>")
+}
+
+func run(args []string, encrypt bool) {
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	mode := fs.String("mode", "cbc", "block mode: ecb or cbc")
+	keyStr := fs.String("key", "", "10-bit binary key, e.g. 1010000010")
+	inPath := fs.String("in", "", "input file")
+	outPath := fs.String("out", "", "output file")
+	fs.Parse(args)
+
+	if *keyStr == "" || *inPath == "" || *outPath == "" {
+		usage()
+		os.Exit(2)
+	}
+
+	key, err := sdes.ParseKey(*keyStr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "invalid key:", err)
+		os.Exit(1)
+	}
+
+	input, err := os.ReadFile(*inPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error reading input:", err)
+		os.Exit(1)
+	}
+
+	var output []byte
+	switch *mode {
+	case "ecb":
+		if encrypt {
+			output = sdes.EncryptECB(key, input)
+		} else {
+			output, err = sdes.DecryptECB(key, input)
+		}
+	case "cbc":
+		if encrypt {
+			output, err = sdes.EncryptCBC(key, input)
+		} else {
+			output, err = sdes.DecryptCBC(key, input)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, "unknown mode:", *mode)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*outPath, output, 0o600); err != nil {
+		fmt.Fprintln(os.Stderr, "error writing output:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,61 @@
+// Command sitepass derives a per-site password from a master secret and a
+// site name, without storing either the master secret or the derived
+// password anywhere: the same inputs always reproduce the same output.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+
+	"github.com/arya2004/cybersecurity/pkg/sitepass"
+)
+
+func main() {
+	profilesPath := flag.String("profiles", "profiles.yaml", "path to the profiles YAML file")
+	site := flag.String("site", "", "site name; must match a key in the profiles file")
+	flag.Parse()
+
+	if *site == "" {
+		fmt.Fprintln(os.Stderr, "usage: sitepass -site <name> [-profiles <file>]")
+		os.Exit(2)
+	}
+
+	data, err := os.ReadFile(*profilesPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error reading profiles:", err)
+		os.Exit(1)
+	}
+	profiles, err := sitepass.LoadProfiles(data)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+	profile, ok := profiles[*site]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "no profile named %q in %s\n", *site, *profilesPath)
+		os.Exit(1)
+	}
+
+	masterSecret, err := readMasterSecret()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error reading master secret:", err)
+		os.Exit(1)
+	}
+
+	password, err := sitepass.Derive(masterSecret, *site, profile, sitepass.DefaultKDFParams)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error deriving password:", err)
+		os.Exit(1)
+	}
+	fmt.Println(password)
+}
+
+func readMasterSecret() ([]byte, error) {
+	fmt.Fprint(os.Stderr, "Master secret: ")
+	secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return secret, err
+}
@@ -1,69 +1,98 @@
+// Diffie-Hellman Demo - finite-field key agreement over a validated
+// safe-prime group, with an active MITM demonstration and AES-256-GCM
+// (keyed via HKDF-SHA256 from the shared secret) standing in for what was
+// previously a toy XOR "cipher".
 package main
 
 import (
 	"fmt"
-	"math/big"
-)
 
-// Power function to return value of a ^ b mod P
-func power(a, b, P int64) int64 {
-	// Convert inputs to big.Int
-	A := big.NewInt(a)
-	B := big.NewInt(b)
-	Pmod := big.NewInt(P)
-	
-	// Perform modular exponentiation
-	result := new(big.Int).Exp(A, B, Pmod)
-	
-	return result.Int64()
-}
+	"github.com/arya2004/cybersecurity/pkg/dh"
+)
 
-// Encrypt function using XOR
-func encryptDecrypt(message string, key int64) string {
-	var result string
-	for _, char := range message {
-		result += string(char ^ rune(key))
+func main() {
+	group := dh.FFDHE2048()
+	if err := group.Validate(); err != nil {
+		fmt.Printf("Group failed validation: %v\n", err)
+		return
 	}
-	return result
+	fmt.Println("Using the RFC 7919 FFDHE2048 group (validated: safe prime, order-q generator)")
+
+	runHonestExchange(group)
+	runMITMDemo(group)
 }
 
-func main() {
-	var P, G, x, a, y, b, ka, kb int64
+// runHonestExchange performs a normal (unauthenticated but unattacked) key
+// agreement and uses the resulting shared secret to encrypt a message.
+func runHonestExchange(group dh.Group) {
+	fmt.Println("\n--- Honest exchange ---")
 
-	// Both the persons will be agreed upon the
-	// public keys G and P
-	P = 23 // A prime number P is taken
-	fmt.Println("The value of P:", P)
+	alice, err := group.GenerateKey()
+	if err != nil {
+		fmt.Printf("Alice: generating key: %v\n", err)
+		return
+	}
+	bob, err := group.GenerateKey()
+	if err != nil {
+		fmt.Printf("Bob: generating key: %v\n", err)
+		return
+	}
 
-	G = 9 // A primitive root for P, G is taken
-	fmt.Println("The value of G:", G)
+	if err := group.CheckPublicValue(bob.Public); err != nil {
+		fmt.Printf("Alice: rejecting Bob's public value: %v\n", err)
+		return
+	}
+	if err := group.CheckPublicValue(alice.Public); err != nil {
+		fmt.Printf("Bob: rejecting Alice's public value: %v\n", err)
+		return
+	}
 
-	// Alice will choose the private key a
-	a = 4 // a is the chosen private key
-	fmt.Println("The private key a for Alice:", a)
+	aliceSecret := group.SharedSecret(alice.Private, bob.Public)
+	bobSecret := group.SharedSecret(bob.Private, alice.Public)
+	fmt.Printf("Alice and Bob agree on a shared secret: %v\n", aliceSecret.Cmp(bobSecret) == 0)
 
-	x = power(G, a, P) // gets the generated key
-	fmt.Println("The public key x for Alice:", x)
+	aliceKey, err := dh.DeriveKey(aliceSecret, nil, []byte("dh demo v1"))
+	if err != nil {
+		fmt.Printf("Alice: deriving key: %v\n", err)
+		return
+	}
+	bobKey, err := dh.DeriveKey(bobSecret, nil, []byte("dh demo v1"))
+	if err != nil {
+		fmt.Printf("Bob: deriving key: %v\n", err)
+		return
+	}
 
-	// Bob will choose the private key b
-	b = 3 // b is the chosen private key
-	fmt.Println("The private key b for Bob:", b)
+	message := "Hello Bob!"
+	ciphertext, err := dh.Encrypt(aliceKey, []byte(message))
+	if err != nil {
+		fmt.Printf("Alice: encrypting: %v\n", err)
+		return
+	}
+	fmt.Printf("Encrypted message: %x\n", ciphertext)
 
-	y = power(G, b, P) // gets the generated key
-	fmt.Println("The public key y for Bob:", y)
+	plaintext, err := dh.Decrypt(bobKey, ciphertext)
+	if err != nil {
+		fmt.Printf("Bob: decrypting: %v\n", err)
+		return
+	}
+	fmt.Printf("Decrypted message: %s\n", plaintext)
+}
 
-	// Generating the secret key after the exchange of keys
-	ka = power(y, a, P) // Secret key for Alice
-	kb = power(x, b, P) // Secret key for Bob
-	fmt.Println("Secret key for the Alice is:", ka)
-	fmt.Println("Secret key for the Bob is:", kb)
+// runMITMDemo shows why the exchange above needs authentication: Mallory
+// substitutes her own public value for each side's in transit, so Alice
+// and Bob end up with two different secrets - each shared with Mallory,
+// not with each other - while believing the exchange succeeded.
+func runMITMDemo(group dh.Group) {
+	fmt.Println("\n--- MITM demo (why raw DH needs authentication) ---")
 
-	// Alice encrypts a message
-	message := "Hello Bob!"
-	encryptedMessage := encryptDecrypt(message, ka)
-	fmt.Println("Encrypted Message:", encryptedMessage)
+	result, err := group.RunMITMDemo()
+	if err != nil {
+		fmt.Printf("Error running MITM demo: %v\n", err)
+		return
+	}
 
-	// Bob decrypts the message
-	decryptedMessage := encryptDecrypt(encryptedMessage, kb)
-	fmt.Println("Decrypted Message:", decryptedMessage)
+	fmt.Printf("Alice's secret == Bob's secret: %v (should be false)\n", result.AliceSecret.Cmp(result.BobSecret) == 0)
+	fmt.Printf("Alice's secret == Mallory's secret with Alice: %v (should be true)\n", result.AliceSecret.Cmp(result.MalloryWithAlice) == 0)
+	fmt.Printf("Bob's secret == Mallory's secret with Bob: %v (should be true)\n", result.BobSecret.Cmp(result.MalloryWithBob) == 0)
+	fmt.Println("Mallory can decrypt, read, and re-encrypt everything either side sends.")
 }
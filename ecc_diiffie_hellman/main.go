@@ -2,138 +2,81 @@ package main
 
 import (
 	"fmt"
-)
-
-type Point struct {
-	x, y int
-}
-
-// Calculate modular inverse
-func inv(n, p int) int {
-	for i := 0; i < p; i++ {
-		if (n*i)%p == 1 {
-			return i
-		}
-	}
-	return -1
-}
-
-// Check if point lies on the curve
-func isPoint(a1 Point, a, b, p int) bool {
-	y := (a1.y * a1.y) % p
-	x := (a1.x*a1.x*a1.x + a*a1.x + b) % p
-	return x == y
-}
-
-// Print all points on the curve
-func allPoints(p, a, b int) {
-	for i := 0; i < p; i++ {
-		for j := 0; j < p; j++ {
-			a2 := Point{i, j}
-			if isPoint(a2, a, b, p) {
-				fmt.Println(i, j)
-			}
-		}
-	}
-}
+	"math/big"
 
-// Addition of points on the curve
-func addPoints(a1, a3 Point, a, p int) Point {
-	if a1.x == a3.x && a1.y != a3.y {
-		return a1
-	}
-	if a1.x == a3.x {
-		l := (3*a1.x*a1.x + a) * inv(2*a1.y, p) % p
-		xr := (l*l - a1.x - a3.x) % p
-		yr := (l*(a1.x-xr) - a1.y) % p
-		a3 = Point{xr, yr}
-		return a3
-	} else {
-		l := (a3.y - a1.y) * inv(a3.x-a1.x, p) % p
-		xr := (l*l - a1.x - a3.x) % p
-		yr := (l*(a1.x-xr) - a1.y) % p
-		a3 = Point{xr, yr}
-		return a3
-	}
-}
+	"github.com/arya2004/cybersecurity/pkg/ecc"
+)
 
-func genPA(na int, genr Point, a, p int) Point {
-	g2 := genr
-	for k := 1; k < na; k++ {
-		g2 = addPoints(g2, genr, a, p)
+func readBigInt(prompt string) *big.Int {
+	fmt.Print(prompt)
+	var s string
+	fmt.Scan(&s)
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		fmt.Println("invalid integer, defaulting to 0")
+		return big.NewInt(0)
 	}
-	return g2
+	return n
 }
 
-func encrypt(Pm Point, na int, a, b, p int) (Point, Point) {
-	var g, h int
-	fmt.Print("Enter generator (i,j) coordinate i: ")
-	fmt.Scan(&g)
-	fmt.Print("Enter generator (i,j) coordinate j: ")
-	fmt.Scan(&h)
-	genr := Point{g, h}
-	pa := genPA(na, genr, a, p)
-	k := 41
-	c1 := genPA(k, genr, a, p)
-	c2 := genPA(k, pa, a, p)
-	c2 = addPoints(c2, Pm, a, p)
-	return c1, c2
-}
+func main() {
+	a := readBigInt("Enter a: ")
+	b := readBigInt("Enter b: ")
+	p := readBigInt("Enter p: ")
 
-func decrypt(na int, a3, a4 Point, a, p int) Point {
-	h1 := genPA(na, a3, a, p)
-	h1.y = -h1.y
-	h2 := addPoints(a4, h1, a, p)
-	return h2
-}
+	gx := readBigInt("Enter generator (i,j) coordinate i: ")
+	gy := readBigInt("Enter generator (i,j) coordinate j: ")
+	g := ecc.Point{X: gx, Y: gy}
 
-func main() {
-	var a, b, p int
-	fmt.Print("Enter a: ")
-	fmt.Scan(&a)
-	fmt.Print("Enter b: ")
-	fmt.Scan(&b)
-	fmt.Print("Enter p: ")
-	fmt.Scan(&p)
+	// n is only used as an upper bound for ephemeral scalars below; for a
+	// curve entered interactively we don't know the true group order, so we
+	// use p as a safe (over-)estimate.
+	curve := ecc.Curve{A: a, B: b, P: p, G: g, N: p}
 
-	var x, y int
-	fmt.Print("Enter x: ")
-	fmt.Scan(&x)
-	fmt.Print("Enter y: ")
-	fmt.Scan(&y)
-	a1 := Point{x, y}
+	x := readBigInt("Enter x: ")
+	y := readBigInt("Enter y: ")
+	point := ecc.Point{X: x, Y: y}
 
 	fmt.Println("Check if point lies on the curve?")
-	fmt.Println(isPoint(a1, a, b, p))
+	fmt.Println(curve.IsOnCurve(point))
 
 	fmt.Println("Adding points:")
-	var x1, y1 int
-	fmt.Print("Enter xq: ")
-	fmt.Scan(&x1)
-	fmt.Print("Enter yq: ")
-	fmt.Scan(&y1)
-	a3 := Point{x1, y1}
-
+	xq := readBigInt("Enter xq: ")
+	yq := readBigInt("Enter yq: ")
+	q := ecc.Point{X: xq, Y: yq}
+
+	sum, err := curve.Add(point, q)
+	if err != nil {
+		fmt.Println("error adding points:", err)
+		return
+	}
 	fmt.Println("Sum:")
-	a3 = addPoints(a1, a3, a, p)
-	fmt.Println(a3.x, a3.y)
+	fmt.Println(sum.X, sum.Y)
 
-	var x2, y2, na int
-	fmt.Print("Enter message(x,y) point x: ")
-	fmt.Scan(&x2)
-	fmt.Print("Enter message(x,y) point y: ")
-	fmt.Scan(&y2)
-	Pm := Point{x2, y2}
-	fmt.Print("Enter na (private key): ")
-	fmt.Scan(&na)
-	a3, a4 := encrypt(Pm, na, a, b, p)
+	mx := readBigInt("Enter message(x,y) point x: ")
+	my := readBigInt("Enter message(x,y) point y: ")
+	message := ecc.Point{X: mx, Y: my}
+	na := readBigInt("Enter na (private key): ")
+
+	pub, err := curve.ScalarMult(na, g)
+	if err != nil {
+		fmt.Println("error deriving public key:", err)
+		return
+	}
+
+	c1, c2, err := curve.Encrypt(message, pub)
+	if err != nil {
+		fmt.Println("encryption error:", err)
+		return
+	}
 	fmt.Println("Encrypted message:")
-	fmt.Println(a3.x, a3.y, a4.x, a4.y)
+	fmt.Println(c1.X, c1.Y, c2.X, c2.Y)
 
-	a6 := decrypt(na, a3, a4, a, p)
+	decrypted, err := curve.Decrypt(na, c1, c2)
+	if err != nil {
+		fmt.Println("decryption error:", err)
+		return
+	}
 	fmt.Println("Decrypted message:")
-	fmt.Println(a6.x, a6.y)
-
-	fmt.Println("All Points on the curve are:")
-	allPoints(p, a, b)
+	fmt.Println(decrypted.X, decrypted.Y)
 }
@@ -0,0 +1,85 @@
+// Tamper-evident audit log for watch mode: every WatchEvent is appended as
+// a JSON line alongside H(prev_line_hash || event_json), so rewriting any
+// earlier line breaks every chain hash after it. The head hash is printed
+// to stderr on shutdown so it can be anchored out-of-band (pasted into a
+// ticket, committed elsewhere) to also catch wholesale log truncation.
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const auditLogFileName = "integrity_audit.log"
+
+// AuditLog appends hash-chained JSON lines to a file. The zero value is
+// not usable; construct with OpenAuditLog.
+type AuditLog struct {
+	mu   sync.Mutex
+	f    *os.File
+	head [32]byte // hash covering the last appended line; zero before the first
+}
+
+// auditLine is the JSON actually written to disk: the event plus the
+// chain hash covering it.
+type auditLine struct {
+	Event WatchEvent `json:"event"`
+	Hash  string     `json:"hash"`
+}
+
+// OpenAuditLog opens path for appending (creating it if necessary) as a
+// hash-chained audit log. The chain starts fresh from a zero head each
+// time the log is opened; callers that need continuity across restarts
+// should keep the process running rather than reopening.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{f: f}, nil
+}
+
+// Append writes event as the next chain entry and returns the new head
+// hash, hex-encoded.
+func (a *AuditLog) Append(event WatchEvent) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write(a.head[:])
+	h.Write(eventJSON)
+	var next [32]byte
+	copy(next[:], h.Sum(nil))
+
+	line := auditLine{Event: event, Hash: hex.EncodeToString(next[:])}
+	lineJSON, err := json.Marshal(line)
+	if err != nil {
+		return "", err
+	}
+	if _, err := a.f.Write(append(lineJSON, '\n')); err != nil {
+		return "", err
+	}
+
+	a.head = next
+	return line.Hash, nil
+}
+
+// Close prints the final chain head to stderr for out-of-band anchoring
+// and closes the underlying file.
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	head := hex.EncodeToString(a.head[:])
+	a.mu.Unlock()
+
+	fmt.Fprintf(os.Stderr, "🔗 audit log head: %s\n", head)
+	return a.f.Close()
+}
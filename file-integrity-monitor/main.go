@@ -1,31 +1,32 @@
 package main
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // FileRecord stores file integrity information
 type FileRecord struct {
-	Path         string    `json:"path"`
-	Hash         string    `json:"hash"`
-	Size         int64     `json:"size"`
-	ModTime      time.Time `json:"mod_time"`
-	LastChecked  time.Time `json:"last_checked"`
-	Status       string    `json:"status"`
+	Path        string    `json:"path"`
+	Hash        string    `json:"hash"` // multihash-encoded; see hasher.go
+	LegacyHash  string    `json:"legacy_hash,omitempty"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"mod_time"`
+	LastChecked time.Time `json:"last_checked"`
+	Status      string    `json:"status"`
 }
 
 // IntegrityDatabase stores baseline and current state
 type IntegrityDatabase struct {
-	BaselineDate time.Time              `json:"baseline_date"`
-	Files        map[string]FileRecord  `json:"files"`
+	BaselineDate time.Time             `json:"baseline_date"`
+	Files        map[string]FileRecord `json:"files"`
 }
 
 const (
@@ -38,23 +39,6 @@ var (
 	}
 )
 
-// CalculateFileHash generates SHA256 hash of file
-func CalculateFileHash(filePath string) (string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
-	hash := sha256.New()
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", err
-	}
-
-	hashBytes := hash.Sum(nil)
-	return hex.EncodeToString(hashBytes), nil
-}
-
 // GetFileInfo retrieves file metadata
 func GetFileInfo(filePath string) (int64, time.Time, error) {
 	info, err := os.Stat(filePath)
@@ -64,16 +48,17 @@ func GetFileInfo(filePath string) (int64, time.Time, error) {
 	return info.Size(), info.ModTime(), nil
 }
 
-// CreateFileRecord generates a complete file record
-func CreateFileRecord(filePath string) (FileRecord, error) {
+// CreateFileRecord generates a complete file record, hashing filePath
+// with h and storing the result as a multihash so VerifyIntegrity can
+// later tell which algorithm produced it.
+func CreateFileRecord(filePath string, h Hasher) (FileRecord, error) {
 	record := FileRecord{
 		Path:        filePath,
 		LastChecked: time.Now(),
 		Status:      "OK",
 	}
 
-	// Calculate hash
-	hash, err := CalculateFileHash(filePath)
+	hash, err := HashFile(filePath, h)
 	if err != nil {
 		return record, err
 	}
@@ -90,102 +75,114 @@ func CreateFileRecord(filePath string) (FileRecord, error) {
 	return record, nil
 }
 
-// CreateBaseline scans directory and creates integrity baseline
-func CreateBaseline(directory string) error {
+// CreateBaseline scans directory and creates integrity baseline, hashing
+// each file with h across jobs concurrent workers (see walkDirectory).
+// When binary is true, the baseline is saved in the Reed-Solomon-protected
+// binary format (see baseline_binary.go); when encrypt is true, it's saved
+// passphrase-encrypted instead (see baseline_crypto.go). The two are
+// mutually exclusive; callers must not set both. If failFast is set, the
+// first per-file hashing error aborts the walk instead of being recorded
+// and skipped.
+func CreateBaseline(directory string, binary, encrypt bool, h Hasher, jobs int, failFast bool) error {
 	fmt.Printf("\n🔍 Creating baseline for: %s\n", directory)
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	db.BaselineDate = time.Now()
 	db.Files = make(map[string]FileRecord)
 
 	fileCount := 0
-	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			fmt.Printf("⚠️  Error accessing %s: %v\n", path, err)
-			return nil // Continue walking
-		}
-
-		// Skip directories and the database file itself
-		if info.IsDir() || filepath.Base(path) == dbFileName {
-			return nil
-		}
-
-		fmt.Printf("📄 Processing: %s\n", path)
-		record, err := CreateFileRecord(path)
-		if err != nil {
-			fmt.Printf("⚠️  Error processing %s: %v\n", path, err)
+	walkErr := walkDirectory(directory, jobs, failFast, "hashing", func(string) Hasher { return h },
+		func(path string, record FileRecord, err error) error {
+			if err != nil {
+				fmt.Printf("⚠️  Error processing %s: %v\n", path, err)
+				return err
+			}
+			db.Files[path] = record
+			fileCount++
 			return nil
-		}
+		})
 
-		db.Files[path] = record
-		fileCount++
-		return nil
-	})
-
-	if err != nil {
-		return err
+	fmt.Println(strings.Repeat("─", 50))
+	fmt.Printf("✓ Baseline created: %d files processed\n", fileCount)
+	if walkErr != nil && failFast {
+		return walkErr
 	}
 
-	fmt.Println("─"*50)
-	fmt.Printf("✓ Baseline created: %d files processed\n", fileCount)
-	
-	return SaveDatabase()
+	switch {
+	case encrypt:
+		return SaveEncryptedDatabase()
+	case binary:
+		return SaveBinaryDatabase()
+	default:
+		return SaveDatabase()
+	}
 }
 
-// VerifyIntegrity checks files against baseline
-func VerifyIntegrity(directory string) ([]FileRecord, error) {
+// VerifyIntegrity checks files against baseline, hashing across jobs
+// concurrent workers (see walkDirectory). New files are hashed with h;
+// existing files are re-hashed with whatever algorithm their own baseline
+// record was stored under, so a baseline can mix algorithms during a
+// rolling migrate (see MigrateHashes). It also reports whether any
+// record's now-redundant legacy_hash was cleared, so callers can decide
+// whether the baseline needs resaving. If failFast is set, the first
+// per-file hashing error aborts the walk instead of being recorded and
+// skipped.
+func VerifyIntegrity(directory string, h Hasher, jobs int, failFast bool) ([]FileRecord, bool, error) {
 	fmt.Printf("\n🔍 Verifying integrity for: %s\n", directory)
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	var changes []FileRecord
 	checkedFiles := make(map[string]bool)
+	healedLegacyHash := false
 
-	// Check existing files
-	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
+	hasherFor := func(path string) Hasher {
+		dbMu.Lock()
+		baseline, exists := db.Files[path]
+		dbMu.Unlock()
+		if !exists {
+			return h
 		}
-
-		if info.IsDir() || filepath.Base(path) == dbFileName {
-			return nil
+		baselineHasher, _, derr := decodeMultihash(baseline.Hash)
+		if derr != nil {
+			return h
 		}
+		return baselineHasher
+	}
 
-		checkedFiles[path] = true
-		baseline, exists := db.Files[path]
-
-		if !exists {
-			// New file detected
-			record, err := CreateFileRecord(path)
+	walkErr := walkDirectory(directory, jobs, failFast, "verifying", hasherFor,
+		func(path string, current FileRecord, err error) error {
 			if err != nil {
-				return nil
+				return err
 			}
-			record.Status = "NEW"
-			changes = append(changes, record)
-			fmt.Printf("🆕 NEW FILE: %s\n", path)
-			return nil
-		}
 
-		// Verify existing file
-		current, err := CreateFileRecord(path)
-		if err != nil {
+			checkedFiles[path] = true
+			baseline, exists := db.Files[path]
+
+			switch {
+			case !exists:
+				current.Status = "NEW"
+				changes = append(changes, current)
+				fmt.Printf("🆕 NEW FILE: %s\n", path)
+			case current.Hash != baseline.Hash:
+				current.Status = "MODIFIED"
+				changes = append(changes, current)
+				fmt.Printf("✏️  MODIFIED: %s\n", path)
+			case current.Size != baseline.Size:
+				current.Status = "SIZE_CHANGED"
+				changes = append(changes, current)
+				fmt.Printf("📏 SIZE CHANGED: %s\n", path)
+			case baseline.LegacyHash != "":
+				// Hash matched under the new algorithm, so the pre-migrate
+				// digest is no longer needed as a fallback.
+				baseline.LegacyHash = ""
+				db.Files[path] = baseline
+				healedLegacyHash = true
+			}
 			return nil
-		}
-
-		if current.Hash != baseline.Hash {
-			current.Status = "MODIFIED"
-			changes = append(changes, current)
-			fmt.Printf("✏️  MODIFIED: %s\n", path)
-		} else if current.Size != baseline.Size {
-			current.Status = "SIZE_CHANGED"
-			changes = append(changes, current)
-			fmt.Printf("📏 SIZE CHANGED: %s\n", path)
-		}
+		})
 
-		return nil
-	})
-
-	if err != nil {
-		return changes, err
+	if walkErr != nil && failFast {
+		return changes, healedLegacyHash, walkErr
 	}
 
 	// Check for deleted files
@@ -199,29 +196,57 @@ func VerifyIntegrity(directory string) ([]FileRecord, error) {
 		}
 	}
 
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 	if len(changes) == 0 {
 		fmt.Println("✓ No changes detected - All files intact!")
 	} else {
 		fmt.Printf("⚠️  %d change(s) detected\n", len(changes))
 	}
 
-	return changes, nil
+	return changes, healedLegacyHash, nil
+}
+
+// MigrateHashes re-hashes every baseline record whose file still exists
+// with target, preserving the displaced digest in LegacyHash until the
+// next successful verify confirms the new one (see VerifyIntegrity).
+// Records whose file is missing are left untouched - there's nothing to
+// re-hash.
+func MigrateHashes(target Hasher) (int, error) {
+	migrated := 0
+	for path, record := range db.Files {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		newHash, err := HashFile(path, target)
+		if err != nil {
+			return migrated, fmt.Errorf("rehashing %q: %w", path, err)
+		}
+		if newHash == record.Hash {
+			continue
+		}
+
+		record.LegacyHash = record.Hash
+		record.Hash = newHash
+		db.Files[path] = record
+		migrated++
+	}
+	return migrated, nil
 }
 
 // GenerateReport creates detailed integrity report
 func GenerateReport(changes []FileRecord) {
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Println("INTEGRITY VERIFICATION REPORT")
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 	fmt.Printf("Report Date: %s\n", time.Now().Format("2006-01-02 15:04:05"))
 	fmt.Printf("Baseline Date: %s\n", db.BaselineDate.Format("2006-01-02 15:04:05"))
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	if len(changes) == 0 {
 		fmt.Println("✓ SYSTEM INTEGRITY: INTACT")
 		fmt.Println("No unauthorized changes detected.")
-		fmt.Println("═"*50)
+		fmt.Println(strings.Repeat("═", 50))
 		return
 	}
 
@@ -250,7 +275,7 @@ func GenerateReport(changes []FileRecord) {
 	fmt.Printf("  Modified Files: %d\n", len(modifiedFiles))
 	fmt.Printf("  Deleted Files: %d\n", len(deletedFiles))
 	fmt.Printf("  Size Changed: %d\n", len(sizeChanged))
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	// Details
 	if len(newFiles) > 0 {
@@ -291,7 +316,7 @@ func GenerateReport(changes []FileRecord) {
 		}
 	}
 
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 }
 
 // SaveDatabase persists database to JSON file
@@ -325,7 +350,7 @@ func LoadDatabase() error {
 		return err
 	}
 
-	fmt.Printf("📂 Loaded baseline: %d files from %s\n", 
+	fmt.Printf("📂 Loaded baseline: %d files from %s\n",
 		len(db.Files), db.BaselineDate.Format("2006-01-02 15:04:05"))
 	return nil
 }
@@ -338,12 +363,12 @@ func ShowStatus() {
 		return
 	}
 
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Println("BASELINE STATUS")
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 	fmt.Printf("Baseline Date: %s\n", db.BaselineDate.Format("2006-01-02 15:04:05"))
 	fmt.Printf("Total Files: %d\n", len(db.Files))
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	// Calculate total size
 	var totalSize int64
@@ -352,7 +377,7 @@ func ShowStatus() {
 	}
 
 	fmt.Printf("Total Size: %.2f MB\n", float64(totalSize)/(1024*1024))
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 }
 
 // PrintBanner displays program banner
@@ -368,16 +393,92 @@ func PrintBanner() {
 
 // PrintUsage displays usage information
 func PrintUsage() {
-	fmt.Println("\nUsage: go run main.go <command> <directory>")
+	fmt.Println("\nUsage: go run main.go <command> <directory> [flags]")
 	fmt.Println("\nCommands:")
 	fmt.Println("  create <dir>   - Create integrity baseline for directory")
 	fmt.Println("  verify <dir>   - Verify directory against baseline")
+	fmt.Println("  diff <dir>     - Verify directory and emit the change set as a CI-")
+	fmt.Println("                   friendly report instead of printing it")
+	fmt.Println("  watch <dir>    - Watch directory in real time, streaming JSON events")
+	fmt.Println("  migrate        - Re-hash every baseline record with --to's algorithm")
 	fmt.Println("  status         - Show baseline information")
+	fmt.Println("\nFlags:")
+	fmt.Println("  --binary       - Use the Reed-Solomon-protected binary baseline")
+	fmt.Println("                   (integrity_baseline.fim) instead of plain JSON;")
+	fmt.Println("                   always hashes with sha256")
+	fmt.Println("  --repair       - With verify/status --binary, rewrite a healed")
+	fmt.Println("                   baseline from whatever records were recovered")
+	fmt.Println("  --encrypt      - Passphrase-encrypt the baseline")
+	fmt.Println("                   (integrity_baseline.json.enc); mutually exclusive")
+	fmt.Println("                   with --binary")
+	fmt.Println("  --hash <algo>  - Hash algorithm for create/verify's new files:")
+	fmt.Println("                   sha256 (default), sha3-256, blake2b-256, or blake3")
+	fmt.Println("  --to <algo>    - Target algorithm for migrate")
+	fmt.Println("  --debounce <ms> - With watch, quiet period before re-hashing a path")
+	fmt.Println("                   (default 500)")
+	fmt.Println("  --socket <path> - With watch, also broadcast events to this Unix socket")
+	fmt.Println("  --jobs <n>     - With create/verify, number of concurrent hashing")
+	fmt.Println("                   workers (default: number of CPUs)")
+	fmt.Println("  --fail-fast    - With create/verify, abort on the first per-file error")
+	fmt.Println("                   instead of recording it and continuing")
+	fmt.Println("  --format <f>   - With diff, report format: json (default), sarif, or junit")
+	fmt.Println("  --output <path> - With diff, write the report here instead of stdout")
+	fmt.Println("  --against <baseline> - With diff, compare integrity_baseline.json")
+	fmt.Println("                   against this other plain-JSON baseline file instead")
+	fmt.Println("                   of the live directory (directory argument is")
+	fmt.Println("                   omitted in this mode)")
+	fmt.Println("  --sign-key <path> - With diff, Ed25519-sign the report (PKCS#8 PEM")
+	fmt.Println("                   private key) and write the detached signature to")
+	fmt.Println("                   <output>.sig")
 	fmt.Println("\nExamples:")
 	fmt.Println("  go run main.go create ./test-files")
-	fmt.Println("  go run main.go verify ./test-files")
+	fmt.Println("  go run main.go create ./test-files --hash blake3")
+	fmt.Println("  go run main.go create ./test-files --binary")
+	fmt.Println("  go run main.go verify ./test-files --binary --repair")
+	fmt.Println("  go run main.go create ./test-files --encrypt")
+	fmt.Println("  go run main.go verify ./test-files --encrypt")
+	fmt.Println("  go run main.go migrate --to blake3")
+	fmt.Println("  go run main.go watch ./test-files")
+	fmt.Println("  go run main.go watch ./test-files --debounce 250 --socket /tmp/fim.sock")
 	fmt.Println("  go run main.go status")
-	fmt.Println("\nNote: Baseline is saved to 'integrity_baseline.json'")
+	fmt.Println("  go run main.go diff ./test-files --format sarif --output report.sarif")
+	fmt.Println("  go run main.go diff --against old_baseline.json.bak --format junit --output report.xml")
+	fmt.Println("\nNote: Baseline is saved to 'integrity_baseline.json' by default")
+}
+
+// extractFlag removes a boolean flag from args (wherever it appears) and
+// reports whether it was present, so callers can still treat the
+// remaining positional arguments (e.g. the directory) by index.
+func extractFlag(args []string, flag string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	found := false
+	for _, arg := range args {
+		if arg == flag {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// extractValueFlag removes a "flag value" pair from args (wherever it
+// appears) and returns the value, mirroring extractFlag for flags that
+// take an argument.
+func extractValueFlag(args []string, flag string) ([]string, string, bool) {
+	rest := make([]string, 0, len(args))
+	value := ""
+	found := false
+	for i := 0; i < len(args); i++ {
+		if args[i] == flag && i+1 < len(args) {
+			value = args[i+1]
+			found = true
+			i++
+			continue
+		}
+		rest = append(rest, args[i])
+	}
+	return rest, value, found
 }
 
 func main() {
@@ -389,55 +490,330 @@ func main() {
 	}
 
 	command := os.Args[1]
+	args, binary := extractFlag(os.Args[2:], "--binary")
+	args, repair := extractFlag(args, "--repair")
+	args, encrypt := extractFlag(args, "--encrypt")
+	args, hashName, hashSet := extractValueFlag(args, "--hash")
+	args, toName, toSet := extractValueFlag(args, "--to")
+	args, debounceStr, debounceSet := extractValueFlag(args, "--debounce")
+	args, socketPath, _ := extractValueFlag(args, "--socket")
+	args, jobsStr, jobsSet := extractValueFlag(args, "--jobs")
+	args, failFast := extractFlag(args, "--fail-fast")
+	args, formatName, formatSet := extractValueFlag(args, "--format")
+	args, outputPath, outputSet := extractValueFlag(args, "--output")
+	args, againstPath, againstSet := extractValueFlag(args, "--against")
+	args, signKeyPath, signKeySet := extractValueFlag(args, "--sign-key")
+
+	jobs := defaultJobs
+	if jobsSet {
+		n, err := strconv.Atoi(jobsStr)
+		if err != nil || n <= 0 {
+			fmt.Printf("Error: --jobs wants a positive number of workers, got %q\n", jobsStr)
+			os.Exit(1)
+		}
+		jobs = n
+	}
+
+	if binary && encrypt {
+		fmt.Println("Error: --binary and --encrypt cannot be combined")
+		os.Exit(1)
+	}
+	if !hashSet {
+		hashName = "sha256"
+	}
+	if binary && hashSet && hashName != "sha256" {
+		fmt.Println("Error: --binary baselines only support sha256 (fixed-size records); omit --hash or use --hash sha256")
+		os.Exit(1)
+	}
+	h, err := hasherByName(hashName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	switch command {
 	case "create":
-		if len(os.Args) < 3 {
+		if len(args) < 1 {
 			fmt.Println("Error: Directory path required")
 			PrintUsage()
 			os.Exit(1)
 		}
-		directory := os.Args[2]
+		directory := args[0]
 
 		if _, err := os.Stat(directory); os.IsNotExist(err) {
 			fmt.Printf("Error: Directory '%s' does not exist\n", directory)
 			os.Exit(1)
 		}
 
-		err := CreateBaseline(directory)
+		err := CreateBaseline(directory, binary, encrypt, h, jobs, failFast)
 		if err != nil {
 			fmt.Printf("Error creating baseline: %v\n", err)
 			os.Exit(1)
 		}
 
 	case "verify":
-		if len(os.Args) < 3 {
+		if len(args) < 1 {
 			fmt.Println("Error: Directory path required")
 			PrintUsage()
 			os.Exit(1)
 		}
-		directory := os.Args[2]
+		directory := args[0]
 
-		err := LoadDatabase()
+		switch {
+		case binary:
+			report, err := LoadBinaryDatabase(repair)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			PrintBinaryLoadReport(report)
+		case encrypt:
+			if err := LoadEncryptedDatabase(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			if err := LoadDatabase(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		changes, healedLegacyHash, err := VerifyIntegrity(directory, h, jobs, failFast)
+		if err != nil {
+			fmt.Printf("Error verifying integrity: %v\n", err)
+			os.Exit(1)
+		}
+
+		GenerateReport(changes)
+
+		if healedLegacyHash && !binary && !encrypt {
+			if err := SaveDatabase(); err != nil {
+				fmt.Printf("Error saving database: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+	case "diff":
+		var entries []DiffEntry
+		if againstSet {
+			var err error
+			entries, err = DiffBaselines(dbFileName, againstPath)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(2)
+			}
+		} else {
+			if len(args) < 1 {
+				fmt.Println("Error: Directory path required (or use --against <baseline> to diff two baseline files)")
+				PrintUsage()
+				os.Exit(2)
+			}
+			directory := args[0]
+
+			switch {
+			case binary:
+				if _, err := LoadBinaryDatabase(repair); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(2)
+				}
+			case encrypt:
+				if err := LoadEncryptedDatabase(); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(2)
+				}
+			default:
+				if err := LoadDatabase(); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(2)
+				}
+			}
+
+			var err error
+			entries, err = ComputeDiff(directory, h, jobs, failFast)
+			if err != nil {
+				fmt.Printf("Error computing diff: %v\n", err)
+				os.Exit(2)
+			}
+		}
+
+		if !formatSet {
+			formatName = "json"
+		}
+		var (
+			report []byte
+			err    error
+		)
+		switch formatName {
+		case "json":
+			report, err = WriteJSONReport(entries)
+		case "sarif":
+			report, err = WriteSARIFReport(entries)
+		case "junit":
+			report, err = WriteJUnitReport(entries)
+		default:
+			fmt.Printf("Error: unknown --format %q (want json, sarif, or junit)\n", formatName)
+			os.Exit(2)
+		}
+		if err != nil {
+			fmt.Printf("Error rendering report: %v\n", err)
+			os.Exit(2)
+		}
+
+		if outputSet {
+			if err := ioutil.WriteFile(outputPath, report, 0644); err != nil {
+				fmt.Printf("Error writing report: %v\n", err)
+				os.Exit(2)
+			}
+			fmt.Printf("📝 Report written: %s\n", outputPath)
+		} else {
+			os.Stdout.Write(report)
+			fmt.Println()
+		}
+
+		if signKeySet {
+			sigTarget := outputPath
+			if sigTarget == "" {
+				sigTarget = dbFileName + ".diff"
+			}
+			sig, err := SignReport(report, signKeyPath)
+			if err != nil {
+				fmt.Printf("Error signing report: %v\n", err)
+				os.Exit(2)
+			}
+			if err := ioutil.WriteFile(sigTarget+".sig", sig, 0644); err != nil {
+				fmt.Printf("Error writing signature: %v\n", err)
+				os.Exit(2)
+			}
+			fmt.Printf("🔏 Signature written: %s.sig\n", sigTarget)
+		}
+
+		if len(entries) > 0 {
+			os.Exit(1)
+		}
+
+	case "migrate":
+		if !toSet {
+			fmt.Println("Error: --to <algorithm> required (sha256, sha3-256, blake2b-256, blake3)")
+			os.Exit(1)
+		}
+		target, err := hasherByName(toName)
 		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
+		if binary {
+			fmt.Println("Error: --binary baselines only support sha256 and can't be migrated")
+			os.Exit(1)
+		}
 
-		changes, err := VerifyIntegrity(directory)
+		if encrypt {
+			err = LoadEncryptedDatabase()
+		} else {
+			err = LoadDatabase()
+		}
 		if err != nil {
-			fmt.Printf("Error verifying integrity: %v\n", err)
+			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		GenerateReport(changes)
+		migrated, err := MigrateHashes(target)
+		if err != nil {
+			fmt.Printf("Error migrating hashes: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("🔁 Re-hashed %d file(s) to %s\n", migrated, target.Name())
 
-	case "status":
-		err := LoadDatabase()
-		if err != nil && !os.IsNotExist(err) {
-			fmt.Printf("Error loading database: %v\n", err)
+		if encrypt {
+			err = SaveEncryptedDatabase()
+		} else {
+			err = SaveDatabase()
+		}
+		if err != nil {
+			fmt.Printf("Error saving database: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "watch":
+		if len(args) < 1 {
+			fmt.Println("Error: Directory path required")
+			PrintUsage()
 			os.Exit(1)
 		}
+		directory := args[0]
+
+		switch {
+		case binary:
+			if _, err := LoadBinaryDatabase(repair); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		case encrypt:
+			if err := LoadEncryptedDatabase(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			if err := LoadDatabase(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		debounce := defaultDebounce
+		if debounceSet {
+			ms, err := strconv.Atoi(debounceStr)
+			if err != nil || ms < 0 {
+				fmt.Printf("Error: --debounce wants a non-negative number of milliseconds, got %q\n", debounceStr)
+				os.Exit(1)
+			}
+			debounce = time.Duration(ms) * time.Millisecond
+		}
+
+		audit, err := OpenAuditLog(auditLogFileName)
+		if err != nil {
+			fmt.Printf("Error opening audit log: %v\n", err)
+			os.Exit(1)
+		}
+		defer audit.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt)
+		go func() {
+			<-sigCh
+			cancel()
+		}()
+
+		fmt.Printf("👁  Watching %s for changes (Ctrl+C to stop)...\n", directory)
+		if err := Watch(ctx, directory, h, debounce, audit, socketPath, os.Stdout); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "status":
+		switch {
+		case binary:
+			report, err := LoadBinaryDatabase(repair)
+			if err != nil && !os.IsNotExist(err) {
+				fmt.Printf("Error loading database: %v\n", err)
+				os.Exit(1)
+			}
+			if err == nil {
+				PrintBinaryLoadReport(report)
+			}
+		case encrypt:
+			if err := LoadEncryptedDatabase(); err != nil && !os.IsNotExist(err) {
+				fmt.Printf("Error loading database: %v\n", err)
+				os.Exit(1)
+			}
+		default:
+			err := LoadDatabase()
+			if err != nil && !os.IsNotExist(err) {
+				fmt.Printf("Error loading database: %v\n", err)
+				os.Exit(1)
+			}
+		}
 		ShowStatus()
 
 	default:
@@ -445,4 +821,4 @@ func main() {
 		PrintUsage()
 		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
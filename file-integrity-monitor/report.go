@@ -0,0 +1,304 @@
+// CI-friendly diff reports: GenerateReport only ever printed to stdout,
+// which made the tool hard to wire into CI or ticketing systems. DiffEntry
+// captures the same change set in a stable, machine-consumable shape, and
+// WriteJSONReport/WriteSARIFReport/WriteJUnitReport render it for the
+// `diff` subcommand in the format a given pipeline wants. SignReport lets
+// downstream consumers confirm a report came from a trusted scanner
+// instead of, say, an attacker hiding a MODIFIED finding.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// DiffEntry is one file's change, in the schema the diff subcommand emits
+// regardless of --format.
+type DiffEntry struct {
+	Path     string    `json:"path"`
+	Status   string    `json:"status"`
+	OldHash  string    `json:"old_hash,omitempty"`
+	NewHash  string    `json:"new_hash,omitempty"`
+	OldSize  int64     `json:"old_size,omitempty"`
+	NewSize  int64     `json:"new_size,omitempty"`
+	OldMTime time.Time `json:"old_mtime,omitempty"`
+	NewMTime time.Time `json:"new_mtime,omitempty"`
+}
+
+// ComputeDiff verifies directory against the already-loaded baseline (see
+// VerifyIntegrity) and reshapes the resulting changes into DiffEntry,
+// pulling each entry's "old" side from the baseline record still held in
+// db.Files (VerifyIntegrity doesn't overwrite it except to heal a
+// migrated LegacyHash).
+func ComputeDiff(directory string, h Hasher, jobs int, failFast bool) ([]DiffEntry, error) {
+	changes, _, err := VerifyIntegrity(directory, h, jobs, failFast)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]DiffEntry, 0, len(changes))
+	for _, change := range changes {
+		entry := DiffEntry{Path: change.Path, Status: change.Status}
+		if baseline, ok := db.Files[change.Path]; ok && change.Status != "NEW" {
+			entry.OldHash = baseline.Hash
+			entry.OldSize = baseline.Size
+			entry.OldMTime = baseline.ModTime
+		}
+		if change.Status != "DELETED" {
+			entry.NewHash = change.Hash
+			entry.NewSize = change.Size
+			entry.NewMTime = change.ModTime
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// DiffBaselines compares two plain-JSON baseline files directly, without
+// touching the filesystem, for the "what changed between two snapshots"
+// case rather than "what changed since the live baseline". Unlike
+// ComputeDiff it never yields SIZE_CHANGED, since it has no independent
+// hash to decide a same-size, same-hash-format change is benign.
+func DiffBaselines(pathA, pathB string) ([]DiffEntry, error) {
+	a, err := loadDatabaseFile(pathA)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", pathA, err)
+	}
+	b, err := loadDatabaseFile(pathB)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", pathB, err)
+	}
+
+	var entries []DiffEntry
+	for path, before := range a.Files {
+		after, exists := b.Files[path]
+		switch {
+		case !exists:
+			entries = append(entries, DiffEntry{
+				Path: path, Status: "DELETED",
+				OldHash: before.Hash, OldSize: before.Size, OldMTime: before.ModTime,
+			})
+		case after.Hash != before.Hash:
+			entries = append(entries, DiffEntry{
+				Path: path, Status: "MODIFIED",
+				OldHash: before.Hash, OldSize: before.Size, OldMTime: before.ModTime,
+				NewHash: after.Hash, NewSize: after.Size, NewMTime: after.ModTime,
+			})
+		}
+	}
+	for path, after := range b.Files {
+		if _, exists := a.Files[path]; !exists {
+			entries = append(entries, DiffEntry{
+				Path: path, Status: "NEW",
+				NewHash: after.Hash, NewSize: after.Size, NewMTime: after.ModTime,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// loadDatabaseFile reads a plain-JSON baseline file without touching the
+// package-level db, so DiffBaselines can hold two snapshots open at once.
+func loadDatabaseFile(path string) (*IntegrityDatabase, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	loaded := &IntegrityDatabase{}
+	if err := json.Unmarshal(data, loaded); err != nil {
+		return nil, err
+	}
+	return loaded, nil
+}
+
+// WriteJSONReport renders entries as the structured per-file JSON schema
+// described in the diff subcommand's docs.
+func WriteJSONReport(entries []DiffEntry) ([]byte, error) {
+	return json.MarshalIndent(entries, "", "  ")
+}
+
+// sarifReport/sarifRun/... mirror just enough of the SARIF 2.1.0 schema
+// for GitHub/GitLab code-scanning to render one result per changed file.
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// WriteSARIFReport renders entries as SARIF 2.1.0, with one rule per
+// status (FIM.NEW, FIM.MODIFIED, FIM.DELETED, FIM.SIZE_CHANGED) so a
+// code-scanning UI can group and filter findings by kind.
+func WriteSARIFReport(entries []DiffEntry) ([]byte, error) {
+	rulesSeen := map[string]bool{}
+	var rules []sarifRule
+	var results []sarifResult
+
+	for _, entry := range entries {
+		ruleID := "FIM." + entry.Status
+		if !rulesSeen[ruleID] {
+			rulesSeen[ruleID] = true
+			rules = append(rules, sarifRule{ID: ruleID})
+		}
+
+		level := "warning"
+		if entry.Status == "DELETED" {
+			level = "error"
+		}
+
+		results = append(results, sarifResult{
+			RuleID:  ruleID,
+			Level:   level,
+			Message: sarifMessage{Text: fmt.Sprintf("%s: %s", entry.Status, entry.Path)},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: entry.Path},
+				},
+			}},
+		})
+	}
+
+	report := sarifReport{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "file-integrity-monitor", Rules: rules}},
+			Results: results,
+		}},
+	}
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// junitTestSuites/junitTestSuite/junitTestCase/junitFailure mirror enough
+// of the JUnit XML schema for CI to treat one integrity failure as one
+// failed test, and a clean run as a single passing test.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitReport renders entries as JUnit XML: one failing testcase per
+// changed file, so integrity drift shows up as a test failure in any CI
+// that already understands JUnit.
+func WriteJUnitReport(entries []DiffEntry) ([]byte, error) {
+	suite := junitTestSuite{
+		Name:     "file-integrity-monitor",
+		Tests:    len(entries),
+		Failures: len(entries),
+	}
+	if len(entries) == 0 {
+		suite.Tests = 1
+		suite.TestCases = []junitTestCase{{Name: "integrity"}}
+	}
+	for _, entry := range entries {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name: entry.Path,
+			Failure: &junitFailure{
+				Message: entry.Status,
+				Text:    fmt.Sprintf("%s: old_hash=%s new_hash=%s", entry.Status, entry.OldHash, entry.NewHash),
+			},
+		})
+	}
+
+	doc := junitTestSuites{Suites: []junitTestSuite{suite}}
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// SignReport signs data (a rendered report) with the Ed25519 private key
+// PEM-encoded (PKCS#8, "PRIVATE KEY") at keyPath, returning a detached,
+// PEM-wrapped signature in the same spirit as the RSA detached-signature
+// format elsewhere in this repo.
+func SignReport(data []byte, keyPath string) ([]byte, error) {
+	keyPEM, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading sign key: %w", err)
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("sign key: no PEM block found")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("sign key: %w", err)
+	}
+	priv, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("sign key: not an Ed25519 private key")
+	}
+
+	sig := ed25519.Sign(priv, data)
+	sigPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "ED25519 SIGNATURE",
+		Bytes: sig,
+	})
+	return sigPEM, nil
+}
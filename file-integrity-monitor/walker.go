@@ -0,0 +1,143 @@
+// Parallel directory walker: CreateBaseline and VerifyIntegrity used to
+// walk serially with filepath.Walk and print one line per file, which
+// falls over on trees with hundreds of thousands of files. walkDirectory
+// instead does a two-phase walk - phase one totals file count and byte
+// size (for an accurate ETA), phase two feeds paths into a bounded
+// channel of jobs workers that hash concurrently, reporting back through
+// a result channel to a single writer goroutine that owns the progress
+// bar and whatever in-memory state onResult touches (typically db.Files,
+// guarded by dbMu since it's also read/written outside this walk).
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/schollz/progressbar/v3"
+)
+
+// defaultJobs is how many files are hashed concurrently when --jobs isn't
+// given.
+var defaultJobs = runtime.NumCPU()
+
+// dbMu guards concurrent writes to db.Files from walkDirectory's writer
+// goroutine; everything else that touches db runs before or after a walk
+// completes, never during it.
+var dbMu sync.Mutex
+
+// walkFile is one file discovered during phase one, carrying what phase
+// two needs to hash it and size the progress bar.
+type walkFile struct {
+	path string
+	size int64
+}
+
+// walkOutcome is what a hashing worker hands back to the writer goroutine
+// for a single file.
+type walkOutcome struct {
+	path   string
+	size   int64
+	record FileRecord
+	err    error
+}
+
+// walkDirectory performs the two-phase parallel walk described above,
+// skipping directories and the database file itself. hasherFor selects
+// which Hasher to use for a given path (CreateBaseline always uses one
+// algorithm; VerifyIntegrity re-hashes existing files with whatever
+// algorithm their baseline record used). onResult runs on the single
+// writer goroutine for every outcome, in no particular order, and its
+// error (if failFast is set) stops the walk - in-flight jobs still drain
+// but no new ones are dispatched.
+func walkDirectory(directory string, jobs int, failFast bool, label string, hasherFor func(path string) Hasher, onResult func(path string, record FileRecord, err error) error) error {
+	if jobs <= 0 {
+		jobs = defaultJobs
+	}
+
+	var files []walkFile
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			fmt.Printf("⚠️  Error accessing %s: %v\n", path, err)
+			return nil
+		}
+		if info.IsDir() || filepath.Base(path) == dbFileName {
+			return nil
+		}
+		files = append(files, walkFile{path: path, size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var totalBytes int64
+	for _, f := range files {
+		totalBytes += f.size
+	}
+
+	bar := progressbar.NewOptions64(totalBytes,
+		progressbar.OptionSetDescription(label),
+		progressbar.OptionShowBytes(true),
+		progressbar.OptionShowCount(),
+		progressbar.OptionSetWidth(30),
+		progressbar.OptionThrottle(100*time.Millisecond),
+		progressbar.OptionShowIts(),
+		progressbar.OptionOnCompletion(func() { fmt.Println() }),
+	)
+
+	jobsCh := make(chan walkFile, jobs)
+	resultsCh := make(chan walkOutcome, jobs)
+
+	var workers sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for f := range jobsCh {
+				record, err := CreateFileRecord(f.path, hasherFor(f.path))
+				resultsCh <- walkOutcome{path: f.path, size: f.size, record: record, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		workers.Wait()
+		close(resultsCh)
+	}()
+
+	abort := make(chan struct{})
+	var abortOnce sync.Once
+
+	go func() {
+		defer close(jobsCh)
+		for _, f := range files {
+			select {
+			case jobsCh <- f:
+			case <-abort:
+				return
+			}
+		}
+	}()
+
+	var firstErr error
+	for outcome := range resultsCh {
+		dbMu.Lock()
+		err := onResult(outcome.path, outcome.record, outcome.err)
+		dbMu.Unlock()
+
+		bar.Add64(outcome.size)
+
+		if err != nil && firstErr == nil {
+			firstErr = err
+			if failFast {
+				abortOnce.Do(func() { close(abort) })
+			}
+		}
+	}
+
+	return firstErr
+}
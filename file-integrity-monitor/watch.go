@@ -0,0 +1,296 @@
+// Real-time watch mode: instead of only comparing against the baseline in
+// batches, `watch <dir>` keeps an fsnotify watcher on the tree and reacts
+// to CREATE/WRITE/RENAME/REMOVE as they happen, re-hashing the affected
+// file through a small worker pool and emitting one structured JSON event
+// per change - turning the tool from a batch scanner into a lightweight
+// host-based intrusion detector.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// defaultDebounce is how long Watch waits after a path's last event
+// before re-hashing it, coalescing the burst of CREATE+WRITE+WRITE
+// events a single `cp` or editor save usually produces.
+const defaultDebounce = 500 * time.Millisecond
+
+// watchWorkers bounds how many files are hashed concurrently, so a large
+// `cp -r` into the watched tree can't stall the watcher's event loop
+// behind an unbounded pile of hashing goroutines.
+const watchWorkers = 4
+
+// WatchEvent is one structured change notification: JSON-encoded to out
+// (and optionally a Unix socket) so it can be piped into SIEM tooling,
+// and to the hash-chained audit log.
+type WatchEvent struct {
+	Time    time.Time `json:"time"`
+	Path    string    `json:"path"`
+	Op      string    `json:"op"`
+	OldHash string    `json:"old_hash,omitempty"`
+	NewHash string    `json:"new_hash,omitempty"`
+	Status  string    `json:"status"`
+}
+
+// Watch watches directory for filesystem changes against the
+// already-loaded baseline (db), re-hashing affected files with h and
+// emitting one debounced WatchEvent per change to out and audit. If
+// socketPath is non-empty, every event is also broadcast to whatever
+// clients are connected to that Unix socket. It blocks until ctx is
+// canceled.
+func Watch(ctx context.Context, directory string, h Hasher, debounce time.Duration, audit *AuditLog, socketPath string, out io.Writer) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watch: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := addWatchesRecursive(watcher, directory); err != nil {
+		return fmt.Errorf("watch: %w", err)
+	}
+
+	var broadcaster *socketBroadcaster
+	if socketPath != "" {
+		b, ln, err := newSocketBroadcaster(socketPath)
+		if err != nil {
+			return fmt.Errorf("watch: socket: %w", err)
+		}
+		defer ln.Close()
+		broadcaster = b
+	}
+
+	emit := func(event WatchEvent) {
+		line, err := json.Marshal(event)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  watch: encoding event: %v\n", err)
+			return
+		}
+		line = append(line, '\n')
+		out.Write(line)
+		if broadcaster != nil {
+			broadcaster.write(line)
+		}
+		if _, err := audit.Append(event); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  watch: audit log: %v\n", err)
+		}
+	}
+
+	jobs := make(chan pendingHash, 256)
+	var wg sync.WaitGroup
+	for i := 0; i < watchWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range jobs {
+				emit(rehash(p.path, p.op, h))
+			}
+		}()
+	}
+
+	debouncer := newDebouncer(debounce, func(p pendingHash) { jobs <- p })
+	defer debouncer.stopAll()
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case event, ok := <-watcher.Events:
+			if !ok {
+				break loop
+			}
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					watcher.Add(event.Name)
+				}
+			}
+			debouncer.schedule(event.Name, event.Op)
+		case werr, ok := <-watcher.Errors:
+			if !ok {
+				break loop
+			}
+			fmt.Fprintf(os.Stderr, "⚠️  watch error: %v\n", werr)
+		}
+	}
+
+	debouncer.stopAll()
+	close(jobs)
+	wg.Wait()
+	return nil
+}
+
+// addWatchesRecursive adds a watch on root and every subdirectory beneath
+// it, since fsnotify only watches one directory's immediate children.
+func addWatchesRecursive(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// pendingHash is a debounced path waiting to be re-hashed, with the
+// fsnotify op that most recently touched it.
+type pendingHash struct {
+	path string
+	op   fsnotify.Op
+}
+
+// debouncer coalesces repeated events for the same path into a single
+// fire, per-path, after the path has been quiet for window.
+type debouncer struct {
+	window time.Duration
+	fire   func(pendingHash)
+
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+	lastOp map[string]fsnotify.Op
+}
+
+func newDebouncer(window time.Duration, fire func(pendingHash)) *debouncer {
+	return &debouncer{
+		window: window,
+		fire:   fire,
+		timers: make(map[string]*time.Timer),
+		lastOp: make(map[string]fsnotify.Op),
+	}
+}
+
+func (d *debouncer) schedule(path string, op fsnotify.Op) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.lastOp[path] |= op
+	if t, ok := d.timers[path]; ok {
+		t.Stop()
+	}
+	d.timers[path] = time.AfterFunc(d.window, func() {
+		d.mu.Lock()
+		finalOp := d.lastOp[path]
+		delete(d.timers, path)
+		delete(d.lastOp, path)
+		d.mu.Unlock()
+		d.fire(pendingHash{path: path, op: finalOp})
+	})
+}
+
+func (d *debouncer) stopAll() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, t := range d.timers {
+		t.Stop()
+	}
+}
+
+// rehash re-hashes path (if it still exists) with h, compares it against
+// the baseline, and builds the resulting WatchEvent.
+func rehash(path string, op fsnotify.Op, h Hasher) WatchEvent {
+	event := WatchEvent{Time: time.Now(), Path: path, Op: watchOpString(op)}
+
+	baseline, hadBaseline := db.Files[path]
+	if hadBaseline {
+		event.OldHash = baseline.Hash
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		event.Status = "DELETED"
+		return event
+	}
+
+	newHash, err := HashFile(path, h)
+	if err != nil {
+		event.Status = "ERROR"
+		return event
+	}
+	event.NewHash = newHash
+
+	switch {
+	case !hadBaseline:
+		event.Status = "NEW"
+	case newHash != baseline.Hash:
+		event.Status = "MODIFIED"
+	default:
+		event.Status = "OK"
+	}
+	return event
+}
+
+// watchOpString renders an (possibly OR'd together) fsnotify.Op as the
+// single most significant operation name for WatchEvent.Op.
+func watchOpString(op fsnotify.Op) string {
+	switch {
+	case op&fsnotify.Remove != 0:
+		return "REMOVE"
+	case op&fsnotify.Rename != 0:
+		return "RENAME"
+	case op&fsnotify.Create != 0:
+		return "CREATE"
+	case op&fsnotify.Write != 0:
+		return "WRITE"
+	case op&fsnotify.Chmod != 0:
+		return "CHMOD"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// socketBroadcaster copies every emitted event line to all currently
+// connected Unix socket clients, so `watch` can be piped into SIEM
+// tooling over a socket instead of (or in addition to) stdout.
+// A slow or disconnected client is dropped rather than allowed to block
+// the watcher.
+type socketBroadcaster struct {
+	mu      sync.Mutex
+	clients map[net.Conn]struct{}
+}
+
+// newSocketBroadcaster listens on a Unix socket at path (removing any
+// stale socket file left over from a prior run) and accepts client
+// connections in the background.
+func newSocketBroadcaster(path string) (*socketBroadcaster, net.Listener, error) {
+	os.Remove(path)
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b := &socketBroadcaster{clients: make(map[net.Conn]struct{})}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			b.mu.Lock()
+			b.clients[conn] = struct{}{}
+			b.mu.Unlock()
+		}
+	}()
+	return b, ln, nil
+}
+
+func (b *socketBroadcaster) write(line []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for conn := range b.clients {
+		conn.SetWriteDeadline(time.Now().Add(time.Second))
+		if _, err := conn.Write(line); err != nil {
+			conn.Close()
+			delete(b.clients, conn)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/arya2004/cybersecurity/pkg/unixcrypt"
+)
+
+// HashEntry is one parsed line of an Apache .htpasswd file or a Unix
+// shadow-style record: a username plus its MCF-encoded password hash,
+// broken out into the pieces crackFileGroups needs to batch candidates by
+// (Scheme, Salt, Params).
+type HashEntry struct {
+	Username string
+	Scheme   string // "apr1", "md5crypt", "sha256crypt", "sha512crypt", or "bcrypt"
+	Salt     string
+	Params   string // e.g. "rounds=10000" for sha256crypt/sha512crypt; empty otherwise
+	Digest   string
+	Raw      string // the full encoded hash, as bcrypt.CompareHashAndPassword needs it whole
+}
+
+// LoadHashFile reads an Apache .htpasswd file or a Unix shadow-style file
+// and parses each line's hash into a HashEntry, detecting the scheme from
+// its MCF prefix: $apr1$ (Apache MD5), $1$ (crypt MD5), $5$ (SHA-256
+// crypt), $6$ (SHA-512 crypt), $2a$/$2b$/$2y$ (bcrypt), and bcrypt hashes
+// stored without their leading "$" (a quirk of some older htpasswd
+// writers). Only the first two colon-separated fields are read, so both
+// "user:hash" (.htpasswd) and "user:hash:lastchange:...:::::" (shadow)
+// lines work unchanged. Blank lines and "#"-comments are skipped.
+func LoadHashFile(path string) ([]HashEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []HashEntry
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("htpasswd: %s:%d: missing username:hash separator", path, lineNo)
+		}
+		entry, err := parseHashField(fields[0], fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("htpasswd: %s:%d: %w", path, lineNo, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func parseHashField(username, hash string) (HashEntry, error) {
+	entry := HashEntry{Username: username, Raw: hash}
+
+	switch {
+	case strings.HasPrefix(hash, "$apr1$"):
+		return splitCryptMD5(entry, "apr1", strings.TrimPrefix(hash, "$apr1$"))
+	case strings.HasPrefix(hash, "$1$"):
+		return splitCryptMD5(entry, "md5crypt", strings.TrimPrefix(hash, "$1$"))
+	case strings.HasPrefix(hash, "$5$"):
+		return splitSHA2Crypt(entry, "sha256crypt", strings.TrimPrefix(hash, "$5$"))
+	case strings.HasPrefix(hash, "$6$"):
+		return splitSHA2Crypt(entry, "sha512crypt", strings.TrimPrefix(hash, "$6$"))
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return splitBcrypt(entry, hash)
+	case len(hash) >= 4 && (hash[:3] == "2a$" || hash[:3] == "2b$" || hash[:3] == "2y$"):
+		// Some older htpasswd writers dropped the leading "$" from
+		// bcrypt hashes; reconstruct it before splitting.
+		return splitBcrypt(entry, "$"+hash)
+	default:
+		return entry, fmt.Errorf("unrecognized hash format for user %q", username)
+	}
+}
+
+// splitCryptMD5 handles both $apr1$ and $1$-style "$salt$digest" bodies.
+func splitCryptMD5(entry HashEntry, scheme, body string) (HashEntry, error) {
+	parts := strings.SplitN(body, "$", 2)
+	if len(parts) != 2 {
+		return entry, fmt.Errorf("malformed %s hash for user %q", scheme, entry.Username)
+	}
+	entry.Scheme = scheme
+	entry.Salt = parts[0]
+	entry.Digest = parts[1]
+	return entry, nil
+}
+
+// splitSHA2Crypt handles $5$/$6$ bodies, which optionally carry an
+// explicit "rounds=N$" parameter before the salt.
+func splitSHA2Crypt(entry HashEntry, scheme, body string) (HashEntry, error) {
+	if strings.HasPrefix(body, "rounds=") {
+		end := strings.IndexByte(body, '$')
+		if end < 0 {
+			return entry, fmt.Errorf("malformed %s rounds parameter for user %q", scheme, entry.Username)
+		}
+		entry.Params = body[:end]
+		body = body[end+1:]
+	}
+	entry.Scheme = scheme
+	parts := strings.SplitN(body, "$", 2)
+	if len(parts) != 2 {
+		return entry, fmt.Errorf("malformed %s hash for user %q", scheme, entry.Username)
+	}
+	entry.Salt = parts[0]
+	entry.Digest = parts[1]
+	return entry, nil
+}
+
+// splitBcrypt splits bcrypt's "$2y$<cost>$<22-char salt><31-char hash>"
+// into a Salt (the cost plus salt, i.e. everything needed but the
+// candidate) and a Digest, matching the other schemes' shape even though
+// bcrypt.CompareHashAndPassword ends up re-deriving from Raw directly.
+func splitBcrypt(entry HashEntry, hash string) (HashEntry, error) {
+	const saltFieldLen = len("$2y$10$") + 22
+	if len(hash) < saltFieldLen {
+		return entry, fmt.Errorf("malformed bcrypt hash for user %q", entry.Username)
+	}
+	entry.Scheme = "bcrypt"
+	entry.Raw = hash
+	entry.Salt = hash[:saltFieldLen]
+	entry.Digest = hash[saltFieldLen:]
+	return entry, nil
+}
+
+// parseRoundsParam extracts N from a "rounds=N" Params string, or 0
+// (meaning unixcrypt's default) if params is empty.
+func parseRoundsParam(params string) (int, error) {
+	if params == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(params, "rounds="))
+	if err != nil {
+		return 0, fmt.Errorf("malformed rounds parameter %q: %w", params, err)
+	}
+	return n, nil
+}
+
+// hashGroup is every HashEntry sharing a (Scheme, Salt, Params) tuple -
+// the unit crackFileGroups hashes a candidate against exactly once,
+// rather than once per entry, since those entries all produce the same
+// digest for the same candidate.
+type hashGroup struct {
+	Scheme, Salt, Params string
+	Entries              []HashEntry
+}
+
+// groupByHashParams buckets entries by (Scheme, Salt, Params).
+func groupByHashParams(entries []HashEntry) []hashGroup {
+	index := map[string]int{}
+	var groups []hashGroup
+	for _, e := range entries {
+		key := e.Scheme + "\x00" + e.Salt + "\x00" + e.Params
+		if i, ok := index[key]; ok {
+			groups[i].Entries = append(groups[i].Entries, e)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, hashGroup{Scheme: e.Scheme, Salt: e.Salt, Params: e.Params, Entries: []HashEntry{e}})
+	}
+	return groups
+}
+
+// crackFileGroups tries every word in dictionary against every group,
+// hashing each candidate once per group (not once per entry) and
+// comparing that single result against every entry sharing the group's
+// (scheme, salt, params). It returns a map from each recovered username to
+// its password, plus the number of candidate-group attempts made.
+func crackFileGroups(groups []hashGroup, dictionary []string) (map[string]string, int) {
+	found := map[string]string{}
+	attempts := 0
+
+	for _, candidate := range dictionary {
+		for _, g := range groups {
+			attempts++
+			switch g.Scheme {
+			case "bcrypt":
+				for _, e := range g.Entries {
+					if _, ok := found[e.Username]; ok {
+						continue
+					}
+					if bcrypt.CompareHashAndPassword([]byte(e.Raw), []byte(candidate)) == nil {
+						found[e.Username] = candidate
+					}
+				}
+			default:
+				digest, err := groupDigest(g, candidate)
+				if err != nil {
+					continue
+				}
+				for _, e := range g.Entries {
+					if e.Digest == digest {
+						found[e.Username] = candidate
+					}
+				}
+			}
+		}
+	}
+	return found, attempts
+}
+
+// groupDigest computes the single digest a candidate produces for g's
+// (scheme, salt, params), so crackFileGroups can hash once per group
+// instead of once per entry.
+func groupDigest(g hashGroup, candidate string) (string, error) {
+	switch g.Scheme {
+	case "apr1":
+		return afterLastDollar(unixcrypt.Apr1Crypt(candidate, g.Salt)), nil
+	case "md5crypt":
+		return afterLastDollar(unixcrypt.MD5Crypt(candidate, g.Salt)), nil
+	case "sha256crypt":
+		rounds, err := parseRoundsParam(g.Params)
+		if err != nil {
+			return "", err
+		}
+		return afterLastDollar(unixcrypt.SHA256Crypt(candidate, g.Salt, rounds)), nil
+	case "sha512crypt":
+		rounds, err := parseRoundsParam(g.Params)
+		if err != nil {
+			return "", err
+		}
+		return afterLastDollar(unixcrypt.SHA512Crypt(candidate, g.Salt, rounds)), nil
+	default:
+		return "", fmt.Errorf("unsupported scheme %q", g.Scheme)
+	}
+}
+
+func afterLastDollar(s string) string {
+	return s[strings.LastIndexByte(s, '$')+1:]
+}
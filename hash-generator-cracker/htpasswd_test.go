@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestLoadHashFile(t *testing.T) {
+	entries, err := LoadHashFile("testdata/htpasswd")
+	if err != nil {
+		t.Fatalf("LoadHashFile: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("got %d entries, want 5", len(entries))
+	}
+
+	want := map[string]string{
+		"alice": "apr1",
+		"bob":   "md5crypt",
+		"carol": "sha256crypt",
+		"dave":  "sha512crypt",
+		"eve":   "bcrypt",
+	}
+	for _, e := range entries {
+		if scheme, ok := want[e.Username]; !ok || scheme != e.Scheme {
+			t.Errorf("entry %q: got scheme %q, want %q", e.Username, e.Scheme, want[e.Username])
+		}
+	}
+
+	carol := entries[2]
+	if carol.Params != "rounds=5000" {
+		t.Errorf("carol Params = %q, want %q", carol.Params, "rounds=5000")
+	}
+}
+
+func TestLoadHashFileMissingSeparator(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/bad"
+	if err := os.WriteFile(path, []byte("nouser-no-colon\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := LoadHashFile(path); err == nil {
+		t.Fatal("expected an error for a line with no username:hash separator")
+	}
+}
+
+func TestCrackFileGroups(t *testing.T) {
+	entries, err := LoadHashFile("testdata/htpasswd")
+	if err != nil {
+		t.Fatalf("LoadHashFile: %v", err)
+	}
+	groups := groupByHashParams(entries)
+
+	dictionary := []string{"swordfish", "letmein", "hunter2", "trustno1", "p@ssw0rd", "wrongguess"}
+	found, attempts := crackFileGroups(groups, dictionary)
+
+	want := map[string]string{
+		"alice": "swordfish",
+		"bob":   "letmein",
+		"carol": "hunter2",
+		"dave":  "trustno1",
+		"eve":   "p@ssw0rd",
+	}
+	for user, password := range want {
+		if found[user] != password {
+			t.Errorf("found[%q] = %q, want %q", user, found[user], password)
+		}
+	}
+	if attempts != len(dictionary)*len(groups) {
+		t.Errorf("attempts = %d, want %d", attempts, len(dictionary)*len(groups))
+	}
+}
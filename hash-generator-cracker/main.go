@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
@@ -13,8 +14,61 @@ import (
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/arya2004/cybersecurity/pkg/cracker"
+	"github.com/arya2004/cybersecurity/pkg/passwd"
+)
+
+// modernSchemes dispatches Hash/Verify across the salted password-hashing
+// schemes (as opposed to the raw, unsalted digests handled directly
+// below), auto-detecting which one produced a given encoded hash from
+// its MCF-style prefix.
+var modernSchemes = mustNewMulti(
+	passwd.NewArgon2idHasher(passwd.DefaultArgon2idParams),
+	passwd.NewScryptHasher(passwd.DefaultScryptParams),
+	passwd.NewPBKDF2Hasher(passwd.DefaultPBKDF2Params),
+	passwd.NewBcryptHasher(bcrypt.DefaultCost),
 )
 
+func mustNewMulti(hashers ...passwd.Hasher) *passwd.Multi {
+	m, err := passwd.NewMulti(hashers...)
+	if err != nil {
+		panic(err) // the hasher list above is a fixed literal; an error here is a bug in this file
+	}
+	return m
+}
+
+// detectModernScheme returns the registered Hasher whose prefix matches
+// hash, or nil if hash isn't one of the salted schemes (e.g. it's a raw
+// md5/sha1/sha256/sha512 digest instead).
+func detectModernScheme(hash string) passwd.Hasher {
+	for prefix, h := range modernSchemes.ByPrefix {
+		if strings.HasPrefix(hash, prefix) {
+			return h
+		}
+	}
+	return nil
+}
+
+// rawDigestFactory maps a raw-digest algorithm name to the cracker.NewHash
+// constructor pkg/cracker's worker pool drives directly; mask/rule attacks
+// only make sense against these, since bcrypt/argon2id/scrypt/pbkdf2 are
+// deliberately slow per-hash and must be verified via passwd.Hasher instead.
+func rawDigestFactory(algorithm string) (cracker.NewHash, error) {
+	switch algorithm {
+	case "md5":
+		return md5.New, nil
+	case "sha1":
+		return sha1.New, nil
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported algorithm for this attack: %s (use md5/sha1/sha256/sha512)", algorithm)
+	}
+}
+
 // HashResult stores hash generation results
 type HashResult struct {
 	Algorithm string
@@ -71,6 +125,24 @@ func GenerateHash(input, algorithm string) (HashResult, error) {
 			return result, err
 		}
 		result.Hash = string(hash)
+	case "argon2id":
+		hash, err := passwd.NewArgon2idHasher(passwd.DefaultArgon2idParams).Hash([]byte(input))
+		if err != nil {
+			return result, err
+		}
+		result.Hash = string(hash)
+	case "scrypt":
+		hash, err := passwd.NewScryptHasher(passwd.DefaultScryptParams).Hash([]byte(input))
+		if err != nil {
+			return result, err
+		}
+		result.Hash = string(hash)
+	case "pbkdf2":
+		hash, err := passwd.NewPBKDF2Hasher(passwd.DefaultPBKDF2Params).Hash([]byte(input))
+		if err != nil {
+			return result, err
+		}
+		result.Hash = string(hash)
 	default:
 		return result, fmt.Errorf("unsupported algorithm: %s", algorithm)
 	}
@@ -81,7 +153,7 @@ func GenerateHash(input, algorithm string) (HashResult, error) {
 
 // GenerateAllHashes generates hashes using all supported algorithms
 func GenerateAllHashes(input string) []HashResult {
-	algorithms := []string{"md5", "sha1", "sha256", "sha512", "bcrypt"}
+	algorithms := []string{"md5", "sha1", "sha256", "sha512", "bcrypt", "argon2id", "scrypt", "pbkdf2"}
 	results := []HashResult{}
 
 	for _, algo := range algorithms {
@@ -106,12 +178,14 @@ func CrackHashDictionary(targetHash, algorithm string, dictionary []string) Crac
 
 	start := time.Now()
 
-	// Special handling for bcrypt (can't reverse, only compare)
-	if strings.ToLower(algorithm) == "bcrypt" {
+	// Salted schemes (bcrypt, argon2id, scrypt, pbkdf2) embed their own
+	// salt in targetHash, so they can't be reversed by re-hashing a
+	// candidate in isolation - each candidate must be verified against
+	// targetHash directly, via whichever scheme its prefix names.
+	if scheme := detectModernScheme(targetHash); scheme != nil {
 		for _, password := range dictionary {
 			result.Attempts++
-			err := bcrypt.CompareHashAndPassword([]byte(targetHash), []byte(password))
-			if err == nil {
+			if err := scheme.Verify([]byte(password), []byte(targetHash)); err == nil {
 				result.Success = true
 				result.Password = password
 				result.Time = time.Since(start)
@@ -142,58 +216,61 @@ func CrackHashDictionary(targetHash, algorithm string, dictionary []string) Crac
 	return result
 }
 
-// CrackHashBruteForce attempts simple brute force (numeric only, for demo)
-func CrackHashBruteForce(targetHash, algorithm string, maxLength int) CrackResult {
-	result := CrackResult{
-		Success:   false,
-		Algorithm: algorithm,
-		Attempts:  0,
-	}
-
-	start := time.Now()
-	charset := "0123456789" // Numeric only for demo (add more for real use)
+// CrackHashMask runs a hashcat-style mask attack via pkg/cracker's worker
+// pool, splitting the keyspace mask describes across the machine's cores.
+// progress, if non-nil, is forwarded to cracker.Options.Progress.
+func CrackHashMask(ctx context.Context, targetHash, algorithm, mask string, progress func(cracker.Stats)) (CrackResult, error) {
+	result := CrackResult{Algorithm: algorithm}
 
-	// Try passwords of increasing length
-	for length := 1; length <= maxLength; length++ {
-		if tryBruteForceLength(targetHash, algorithm, charset, length, &result) {
-			result.Time = time.Since(start)
-			return result
-		}
+	newHash, err := rawDigestFactory(algorithm)
+	if err != nil {
+		return result, err
+	}
+	target, err := hex.DecodeString(targetHash)
+	if err != nil {
+		return result, fmt.Errorf("target hash must be hex-encoded: %w", err)
+	}
+	charsets, err := cracker.ParseMask(mask)
+	if err != nil {
+		return result, err
 	}
 
-	result.Time = time.Since(start)
-	return result
-}
+	crackResult, err := cracker.CrackMask(ctx, target, newHash, charsets, cracker.Options{Progress: progress})
+	if err != nil {
+		return result, err
+	}
 
-// tryBruteForceLength helper function for brute force
-func tryBruteForceLength(targetHash, algorithm, charset string, length int, result *CrackResult) bool {
-	return tryBruteForceRecursive(targetHash, algorithm, charset, "", length, result)
+	result.Success = crackResult.Success
+	result.Password = crackResult.Password
+	result.Attempts = int(crackResult.Attempts)
+	result.Time = crackResult.Elapsed
+	return result, nil
 }
 
-// tryBruteForceRecursive recursive helper for brute force
-func tryBruteForceRecursive(targetHash, algorithm, charset, current string, remaining int, result *CrackResult) bool {
-	if remaining == 0 {
-		result.Attempts++
-		hashResult, err := GenerateHash(current, algorithm)
-		if err != nil {
-			return false
-		}
+// CrackHashRuleDictionary mangles each dictionary word through rules via
+// pkg/cracker, splitting the word list across the machine's cores.
+func CrackHashRuleDictionary(ctx context.Context, targetHash, algorithm string, dictionary []string, rules []cracker.Rule) (CrackResult, error) {
+	result := CrackResult{Algorithm: algorithm}
 
-		if hashResult.Hash == targetHash {
-			result.Success = true
-			result.Password = current
-			return true
-		}
-		return false
+	newHash, err := rawDigestFactory(algorithm)
+	if err != nil {
+		return result, err
+	}
+	target, err := hex.DecodeString(targetHash)
+	if err != nil {
+		return result, fmt.Errorf("target hash must be hex-encoded: %w", err)
 	}
 
-	for _, char := range charset {
-		if tryBruteForceRecursive(targetHash, algorithm, charset, current+string(char), remaining-1, result) {
-			return true
-		}
+	crackResult, err := cracker.CrackDictionaryWithRules(ctx, target, newHash, dictionary, rules, cracker.Options{})
+	if err != nil {
+		return result, err
 	}
 
-	return false
+	result.Success = crackResult.Success
+	result.Password = crackResult.Password
+	result.Attempts = int(crackResult.Attempts)
+	result.Time = crackResult.Elapsed
+	return result, nil
 }
 
 // PrintBanner displays the program banner
@@ -209,62 +286,67 @@ func PrintBanner() {
 
 // PrintMenu displays the main menu
 func PrintMenu() {
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Println("MAIN MENU")
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 	fmt.Println("1. Generate Hash")
 	fmt.Println("2. Generate All Hashes")
 	fmt.Println("3. Crack Hash (Dictionary Attack)")
-	fmt.Println("4. Crack Hash (Brute Force - Numeric)")
-	fmt.Println("5. Compare Hash")
-	fmt.Println("6. Exit")
-	fmt.Println("═"*50)
+	fmt.Println("4. Crack Hash (Mask Attack)")
+	fmt.Println("5. Crack Hash (Rule-Mangled Dictionary)")
+	fmt.Println("6. Crack Hash File (htpasswd/shadow)")
+	fmt.Println("7. Build Rainbow Table")
+	fmt.Println("8. Crack Hash (Rainbow Table)")
+	fmt.Println("9. Sign Message (ECDSA over secp256k1)")
+	fmt.Println("10. Compare Hash")
+	fmt.Println("11. Exit")
+	fmt.Println(strings.Repeat("═", 50))
 	fmt.Print("Select option: ")
 }
 
 // PrintHashResult displays hash generation result
 func PrintHashResult(result HashResult) {
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Println("HASH GENERATION RESULT")
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 	fmt.Printf("Algorithm: %s\n", strings.ToUpper(result.Algorithm))
 	fmt.Printf("Input: %s\n", result.Input)
 	fmt.Printf("Hash: %s\n", result.Hash)
 	fmt.Printf("Time: %v\n", result.Time)
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 }
 
 // PrintAllHashResults displays multiple hash results
 func PrintAllHashResults(results []HashResult) {
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Println("ALL HASHES GENERATED")
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 	fmt.Printf("Input: %s\n", results[0].Input)
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	for _, result := range results {
 		fmt.Printf("\n%-10s: %s\n", strings.ToUpper(result.Algorithm), result.Hash)
 		fmt.Printf("%-10s  Time: %v\n", "", result.Time)
 	}
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 }
 
 // PrintCrackResult displays hash cracking result
 func PrintCrackResult(result CrackResult) {
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Println("HASH CRACKING RESULT")
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 	fmt.Printf("Algorithm: %s\n", strings.ToUpper(result.Algorithm))
 	fmt.Printf("Attempts: %d\n", result.Attempts)
 	fmt.Printf("Time: %v\n", result.Time)
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	if result.Success {
 		fmt.Printf("✓ SUCCESS! Password found: %s\n", result.Password)
 	} else {
 		fmt.Println("✗ FAILED: Password not found")
 	}
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 }
 
 // HandleGenerateHash handles hash generation
@@ -273,7 +355,7 @@ func HandleGenerateHash(reader *bufio.Reader) {
 	input, _ := reader.ReadString('\n')
 	input = strings.TrimSpace(input)
 
-	fmt.Print("Select algorithm (md5/sha1/sha256/sha512/bcrypt): ")
+	fmt.Print("Select algorithm (md5/sha1/sha256/sha512/bcrypt/argon2id/scrypt/pbkdf2): ")
 	algorithm, _ := reader.ReadString('\n')
 	algorithm = strings.TrimSpace(strings.ToLower(algorithm))
 
@@ -314,32 +396,110 @@ func HandleCrackDictionary(reader *bufio.Reader) {
 	PrintCrackResult(result)
 }
 
-// HandleCrackBruteForce handles brute force attack
-func HandleCrackBruteForce(reader *bufio.Reader) {
+// HandleCrackMask handles a hashcat-style mask attack, splitting the
+// keyspace across a pkg/cracker worker pool. Only raw digest algorithms
+// apply; bcrypt/argon2id/scrypt/pbkdf2 are deliberately slow per-hash and
+// are cracked via HandleCrackDictionary's scheme auto-detection instead.
+func HandleCrackMask(reader *bufio.Reader) {
 	fmt.Println("\n⚠️  Warning: Brute force is slow! Only for educational purposes.")
-	fmt.Println("Note: This demo only tries numeric passwords (0-9)")
-	fmt.Print("\nEnter hash to crack: ")
+	fmt.Print("\nEnter hash to crack (hex-encoded): ")
 	targetHash, _ := reader.ReadString('\n')
 	targetHash = strings.TrimSpace(targetHash)
 
-	fmt.Print("Select algorithm (md5/sha1/sha256): ")
+	fmt.Print("Select algorithm (md5/sha1/sha256/sha512): ")
 	algorithm, _ := reader.ReadString('\n')
 	algorithm = strings.TrimSpace(strings.ToLower(algorithm))
 
-	fmt.Print("Maximum password length to try (1-6 recommended): ")
-	var maxLength int
-	fmt.Scanln(&maxLength)
+	fmt.Print("Mask (?l=lower ?u=upper ?d=digit ?s=symbol ?a=all, e.g. ?l?l?l?d?d?d): ")
+	mask, _ := reader.ReadString('\n')
+	mask = strings.TrimSpace(mask)
 
-	if maxLength > 6 {
-		fmt.Println("Warning: Length > 6 may take very long time!")
+	fmt.Println("\nStarting mask attack...")
+	result, err := CrackHashMask(context.Background(), targetHash, algorithm, mask, func(s cracker.Stats) {
+		if s.KnownETA {
+			fmt.Printf("\r%d/%d attempts (%.0f/s), ETA %s   ", s.Attempts, s.Keyspace, s.Rate, s.ETA.Round(time.Second))
+		} else {
+			fmt.Printf("\r%d attempts (%.0f/s)   ", s.Attempts, s.Rate)
+		}
+	})
+	fmt.Println()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
 	}
+	PrintCrackResult(result)
+}
+
+// HandleCrackRuleDictionary handles a rule-mangled dictionary attack
+// (capitalize/reverse/duplicate/leetspeak/digit-suffix variants of each
+// word), splitting the dictionary across a pkg/cracker worker pool.
+func HandleCrackRuleDictionary(reader *bufio.Reader) {
+	fmt.Println("\n⚠️  Educational purposes only! Only crack hashes you own.")
+	fmt.Print("\nEnter hash to crack (hex-encoded): ")
+	targetHash, _ := reader.ReadString('\n')
+	targetHash = strings.TrimSpace(targetHash)
 
-	fmt.Println("\nStarting brute force attack (numeric only)...")
-	result := CrackHashBruteForce(targetHash, algorithm, maxLength)
+	fmt.Print("Select algorithm (md5/sha1/sha256/sha512): ")
+	algorithm, _ := reader.ReadString('\n')
+	algorithm = strings.TrimSpace(strings.ToLower(algorithm))
+
+	rules := cracker.DefaultRules()
+	fmt.Println("\nStarting rule-mangled dictionary attack...")
+	fmt.Printf("Dictionary size: %d passwords x %d rules\n", len(commonPasswordsDictionary), len(rules))
+
+	result, err := CrackHashRuleDictionary(context.Background(), targetHash, algorithm, commonPasswordsDictionary, rules)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
 	PrintCrackResult(result)
 }
 
-// HandleCompareHash handles hash comparison
+// HandleCrackFile handles a dictionary attack against every entry in an
+// Apache .htpasswd or Unix shadow-style file, grouping entries that share
+// a (scheme, salt, params) tuple so each candidate is hashed once per
+// group rather than once per entry.
+func HandleCrackFile(reader *bufio.Reader) {
+	fmt.Println("\n⚠️  Educational purposes only! Only crack hashes you own.")
+	fmt.Print("\nEnter path to htpasswd/shadow file: ")
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+
+	entries, err := LoadHashFile(path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	groups := groupByHashParams(entries)
+
+	fmt.Printf("\nLoaded %d entries in %d distinct (scheme, salt) groups\n", len(entries), len(groups))
+	fmt.Println("Starting dictionary attack...")
+
+	start := time.Now()
+	found, attempts := crackFileGroups(groups, commonPasswordsDictionary)
+	elapsed := time.Since(start)
+
+	fmt.Println("\n" + strings.Repeat("═", 50))
+	fmt.Println("HASH FILE CRACKING RESULT")
+	fmt.Println(strings.Repeat("═", 50))
+	fmt.Printf("Entries: %d   Attempts: %d   Time: %v\n", len(entries), attempts, elapsed)
+	fmt.Println(strings.Repeat("─", 50))
+
+	for _, e := range entries {
+		if password, ok := found[e.Username]; ok {
+			fmt.Printf("✓ %-20s (%s): %s\n", e.Username, e.Scheme, password)
+		} else {
+			fmt.Printf("✗ %-20s (%s): not found\n", e.Username, e.Scheme)
+		}
+	}
+	fmt.Println(strings.Repeat("═", 50))
+}
+
+// HandleCompareHash handles hash comparison. The hashing scheme is
+// auto-detected from targetHash's prefix (bcrypt/argon2id/scrypt/pbkdf2)
+// where possible; otherwise the caller is asked which raw digest
+// algorithm (md5/sha1/sha256/sha512) produced it, since those carry no
+// self-describing prefix.
 func HandleCompareHash(reader *bufio.Reader) {
 	fmt.Print("\nEnter password to verify: ")
 	password, _ := reader.ReadString('\n')
@@ -349,39 +509,41 @@ func HandleCompareHash(reader *bufio.Reader) {
 	targetHash, _ := reader.ReadString('\n')
 	targetHash = strings.TrimSpace(targetHash)
 
-	fmt.Print("Select algorithm (md5/sha1/sha256/sha512/bcrypt): ")
-	algorithm, _ := reader.ReadString('\n')
-	algorithm = strings.TrimSpace(strings.ToLower(algorithm))
-
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Println("HASH COMPARISON")
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 
-	if strings.ToLower(algorithm) == "bcrypt" {
-		err := bcrypt.CompareHashAndPassword([]byte(targetHash), []byte(password))
+	if scheme := detectModernScheme(targetHash); scheme != nil {
+		err := scheme.Verify([]byte(password), []byte(targetHash))
 		if err == nil {
 			fmt.Println("✓ MATCH: Password matches the hash!")
 		} else {
 			fmt.Println("✗ NO MATCH: Password does not match the hash")
 		}
-	} else {
-		result, err := GenerateHash(password, algorithm)
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			return
-		}
+		fmt.Println(strings.Repeat("═", 50))
+		return
+	}
 
-		fmt.Printf("Generated Hash: %s\n", result.Hash)
-		fmt.Printf("Target Hash:    %s\n", targetHash)
-		fmt.Println("─"*50)
+	fmt.Print("Select algorithm (md5/sha1/sha256/sha512): ")
+	algorithm, _ := reader.ReadString('\n')
+	algorithm = strings.TrimSpace(strings.ToLower(algorithm))
 
-		if result.Hash == targetHash {
-			fmt.Println("✓ MATCH: Password matches the hash!")
-		} else {
-			fmt.Println("✗ NO MATCH: Password does not match the hash")
-		}
+	result, err := GenerateHash(password, algorithm)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
 	}
-	fmt.Println("═"*50)
+
+	fmt.Printf("Generated Hash: %s\n", result.Hash)
+	fmt.Printf("Target Hash:    %s\n", targetHash)
+	fmt.Println(strings.Repeat("─", 50))
+
+	if result.Hash == targetHash {
+		fmt.Println("✓ MATCH: Password matches the hash!")
+	} else {
+		fmt.Println("✗ NO MATCH: Password does not match the hash")
+	}
+	fmt.Println(strings.Repeat("═", 50))
 }
 
 func main() {
@@ -412,14 +574,24 @@ func main() {
 		case 3:
 			HandleCrackDictionary(reader)
 		case 4:
-			HandleCrackBruteForce(reader)
+			HandleCrackMask(reader)
 		case 5:
-			HandleCompareHash(reader)
+			HandleCrackRuleDictionary(reader)
 		case 6:
+			HandleCrackFile(reader)
+		case 7:
+			HandleBuildRainbowTable(reader)
+		case 8:
+			HandleCrackRainbowTable(reader)
+		case 9:
+			HandleSignECDSA(reader)
+		case 10:
+			HandleCompareHash(reader)
+		case 11:
 			fmt.Println("\nThank you for using Hash Generator & Cracker!")
 			os.Exit(0)
 		default:
-			fmt.Println("Invalid option. Please select 1-6.")
+			fmt.Println("Invalid option. Please select 1-11.")
 		}
 	}
 }
\ No newline at end of file
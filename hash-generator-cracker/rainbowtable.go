@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/arya2004/cybersecurity/pkg/rainbow"
+)
+
+// rainbowHashFunc adapts a raw-digest algorithm to a rainbow.HashFunc,
+// since rainbow tables only make sense against unsalted digests (the same
+// restriction rawDigestFactory applies to mask/rule attacks).
+func rainbowHashFunc(algorithm string) (rainbow.HashFunc, error) {
+	newHash, err := rawDigestFactory(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return func(plaintext string) []byte {
+		h := newHash()
+		h.Write([]byte(plaintext))
+		return h.Sum(nil)
+	}, nil
+}
+
+// HandleBuildRainbowTable prompts for build parameters and saves a new
+// rainbow table to disk via pkg/rainbow.
+func HandleBuildRainbowTable(reader *bufio.Reader) {
+	fmt.Print("\nSelect algorithm (md5/sha1/sha256/sha512): ")
+	algorithm, _ := reader.ReadString('\n')
+	algorithm = strings.TrimSpace(strings.ToLower(algorithm))
+
+	hashFunc, err := rainbowHashFunc(algorithm)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Print("Password length to cover: ")
+	passwordLen := promptInt(reader, 4)
+
+	fmt.Print("Chain length (steps per chain) [default 1000]: ")
+	chainLength := promptInt(reader, 1000)
+
+	fmt.Print("Chain count (number of chains) [default 10000]: ")
+	chainCount := promptInt(reader, 10000)
+
+	fmt.Print("Output file: ")
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+	if path == "" {
+		fmt.Println("No output file given, aborting.")
+		return
+	}
+
+	opts := rainbow.BuildOptions{
+		Algorithm:   algorithm,
+		ChainLength: chainLength,
+		ChainCount:  chainCount,
+		Charset:     "abcdefghijklmnopqrstuvwxyz0123456789",
+		PasswordLen: passwordLen,
+	}
+
+	fmt.Printf("\nBuilding %d chains of length %d for %s passwords of length %d...\n",
+		opts.ChainCount, opts.ChainLength, opts.Algorithm, opts.PasswordLen)
+	table := rainbow.Build(context.Background(), opts, hashFunc)
+
+	f, err := os.Create(path)
+	if err != nil {
+		fmt.Printf("Error: could not create %s: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	if err := table.Save(f); err != nil {
+		fmt.Printf("Error: could not save table: %v\n", err)
+		return
+	}
+	fmt.Printf("Rainbow table saved to %s\n", path)
+}
+
+// HandleCrackRainbowTable loads a previously built rainbow table and
+// looks up a target hash in it.
+func HandleCrackRainbowTable(reader *bufio.Reader) {
+	fmt.Print("\nSelect algorithm (md5/sha1/sha256/sha512): ")
+	algorithm, _ := reader.ReadString('\n')
+	algorithm = strings.TrimSpace(strings.ToLower(algorithm))
+
+	hashFunc, err := rainbowHashFunc(algorithm)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Print("Rainbow table file: ")
+	path, _ := reader.ReadString('\n')
+	path = strings.TrimSpace(path)
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Printf("Error: could not open %s: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	table, err := rainbow.Load(f, hashFunc)
+	if err != nil {
+		fmt.Printf("Error: could not load table: %v\n", err)
+		return
+	}
+
+	fmt.Print("Enter hash to crack (hex-encoded): ")
+	targetHash, _ := reader.ReadString('\n')
+	targetHash = strings.TrimSpace(targetHash)
+	target, err := hex.DecodeString(targetHash)
+	if err != nil {
+		fmt.Printf("Error: target hash must be hex-encoded: %v\n", err)
+		return
+	}
+
+	fmt.Println("\nSearching rainbow table...")
+	plaintext, ok := table.Lookup(target)
+
+	result := CrackResult{Algorithm: algorithm, Success: ok, Password: plaintext}
+	PrintCrackResult(result)
+}
+
+func promptInt(reader *bufio.Reader, def int) int {
+	line, _ := reader.ReadString('\n')
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def
+	}
+	n, err := strconv.Atoi(line)
+	if err != nil {
+		return def
+	}
+	return n
+}
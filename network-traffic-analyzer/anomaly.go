@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// AnomalyThresholds configures when AnomalyDetector raises an alert.
+type AnomalyThresholds struct {
+	MaxInvalidRate      float64       // conntrack invalid increments/sec
+	MaxDropRate         float64       // conntrack drop increments/sec
+	MaxInsertFailedRate float64       // conntrack insert_failed increments/sec
+	MaxTableUtilization float64       // conntrack entries/nf_conntrack_max, 0..1
+	PortScanPorts       int           // distinct local ports from one remote IP to flag as a scan
+	PortScanWindow      time.Duration // how long a remote IP's port touches are remembered
+	SynFloodRatio       float64       // (SYN_SENT+SYN_RECV)/ESTABLISHED to flag as a SYN flood
+}
+
+// DefaultAnomalyThresholds returns the thresholds DetectSuspiciousActivity
+// uses unless the caller overrides them.
+func DefaultAnomalyThresholds() AnomalyThresholds {
+	return AnomalyThresholds{
+		MaxInvalidRate:      50,
+		MaxDropRate:         20,
+		MaxInsertFailedRate: 10,
+		MaxTableUtilization: 0.8,
+		PortScanPorts:       15,
+		PortScanWindow:      30 * time.Second,
+		SynFloodRatio:       3.0,
+	}
+}
+
+// portTouch records the last time a remote IP was seen connecting to a
+// given local port, so AnomalyDetector can prune entries older than
+// PortScanWindow instead of growing forever.
+type portTouch struct {
+	port string
+	seen time.Time
+}
+
+// AnomalyDetector accumulates state across repeated calls to Detect - one
+// per MonitorTraffic tick, or one per interactive "suspicious activity"
+// menu selection - so it can flag trends a single connection snapshot
+// can't: rising conntrack invalid/drop/insert_failed rates, and a remote
+// IP gradually touching many distinct local ports.
+type AnomalyDetector struct {
+	Thresholds AnomalyThresholds
+
+	prevConntrack   ConntrackStats
+	prevConntrackAt time.Time
+
+	portTouches map[string][]portTouch // remote IP -> recent local-port touches
+}
+
+// NewAnomalyDetector creates a detector using the given thresholds.
+func NewAnomalyDetector(thresholds AnomalyThresholds) *AnomalyDetector {
+	return &AnomalyDetector{
+		Thresholds:  thresholds,
+		portTouches: make(map[string][]portTouch),
+	}
+}
+
+// Detect runs every heuristic against connections (one GetActiveConnections
+// snapshot taken at now) and returns any alerts raised this call.
+func (d *AnomalyDetector) Detect(connections []Connection, now time.Time) []string {
+	var alerts []string
+	alerts = append(alerts, d.detectConntrack(now)...)
+	alerts = append(alerts, d.detectPortScans(connections, now)...)
+	alerts = append(alerts, d.detectSynFlood(connections)...)
+	return alerts
+}
+
+// detectConntrack samples /proc/net/stat/nf_conntrack and flags
+// invalid/drop/insert_failed rates above Thresholds, and table utilization
+// above MaxTableUtilization. Silently does nothing on platforms or kernels
+// without conntrack statistics.
+func (d *AnomalyDetector) detectConntrack(now time.Time) []string {
+	stats, err := readConntrackStats()
+	if err != nil {
+		return nil
+	}
+
+	if d.prevConntrackAt.IsZero() {
+		d.prevConntrack, d.prevConntrackAt = stats, now
+		return nil
+	}
+
+	var alerts []string
+	if elapsed := now.Sub(d.prevConntrackAt).Seconds(); elapsed > 0 {
+		invalidRate := float64(counterDelta(d.prevConntrack.Invalid, stats.Invalid)) / elapsed
+		dropRate := float64(counterDelta(d.prevConntrack.Drop, stats.Drop)) / elapsed
+		insertFailedRate := float64(counterDelta(d.prevConntrack.InsertFailed, stats.InsertFailed)) / elapsed
+
+		if invalidRate > d.Thresholds.MaxInvalidRate {
+			alerts = append(alerts, fmt.Sprintf("⚠️  conntrack invalid rate %.1f/s exceeds threshold %.1f/s", invalidRate, d.Thresholds.MaxInvalidRate))
+		}
+		if dropRate > d.Thresholds.MaxDropRate {
+			alerts = append(alerts, fmt.Sprintf("⚠️  conntrack drop rate %.1f/s exceeds threshold %.1f/s", dropRate, d.Thresholds.MaxDropRate))
+		}
+		if insertFailedRate > d.Thresholds.MaxInsertFailedRate {
+			alerts = append(alerts, fmt.Sprintf("⚠️  conntrack insert_failed rate %.1f/s exceeds threshold %.1f/s", insertFailedRate, d.Thresholds.MaxInsertFailedRate))
+		}
+	}
+
+	if util := stats.Utilization(); util > d.Thresholds.MaxTableUtilization {
+		alerts = append(alerts, fmt.Sprintf("⚠️  conntrack table %.0f%% full (%d/%d entries)", util*100, stats.Entries, stats.Max))
+	}
+
+	d.prevConntrack, d.prevConntrackAt = stats, now
+	return alerts
+}
+
+// detectPortScans tracks, per remote IP, which local ports it has touched
+// within Thresholds.PortScanWindow, flagging an alert once a single
+// remote IP has touched more than Thresholds.PortScanPorts distinct ports -
+// a horizontal port scan against this host.
+func (d *AnomalyDetector) detectPortScans(connections []Connection, now time.Time) []string {
+	for _, conn := range connections {
+		if conn.RemoteAddr == "" || conn.RemoteAddr == "0.0.0.0" || conn.RemoteAddr == "*" {
+			continue
+		}
+		d.recordPortTouch(conn.RemoteAddr, conn.LocalPort, now)
+	}
+
+	var alerts []string
+	for ip, touches := range d.portTouches {
+		fresh := pruneOldTouches(touches, now, d.Thresholds.PortScanWindow)
+		d.portTouches[ip] = fresh
+
+		ports := make(map[string]bool, len(fresh))
+		for _, t := range fresh {
+			ports[t.port] = true
+		}
+		if len(ports) > d.Thresholds.PortScanPorts {
+			alerts = append(alerts, fmt.Sprintf("⚠️  Possible port scan: %s touched %d distinct local ports in the last %v", ip, len(ports), d.Thresholds.PortScanWindow))
+			delete(d.portTouches, ip) // don't re-alert every tick until it builds back up
+		}
+	}
+	return alerts
+}
+
+// recordPortTouch adds a (localPort, now) touch for remoteIP unless that
+// port is already tracked for it.
+func (d *AnomalyDetector) recordPortTouch(remoteIP, localPort string, now time.Time) {
+	touches := d.portTouches[remoteIP]
+	for _, t := range touches {
+		if t.port == localPort {
+			return
+		}
+	}
+	d.portTouches[remoteIP] = append(touches, portTouch{port: localPort, seen: now})
+}
+
+// pruneOldTouches drops touches older than window, reusing touches'
+// backing array.
+func pruneOldTouches(touches []portTouch, now time.Time, window time.Duration) []portTouch {
+	fresh := touches[:0]
+	for _, t := range touches {
+		if now.Sub(t.seen) <= window {
+			fresh = append(fresh, t)
+		}
+	}
+	return fresh
+}
+
+// detectSynFlood flags when in-progress handshakes (SYN_SENT/SYN_RECV)
+// outnumber ESTABLISHED connections by more than Thresholds.SynFloodRatio,
+// a classic SYN-flood signature.
+func (d *AnomalyDetector) detectSynFlood(connections []Connection) []string {
+	var synning, established int
+	for _, conn := range connections {
+		switch conn.State {
+		case "SYN_SENT", "SYN_RECV":
+			synning++
+		case "ESTABLISHED":
+			established++
+		}
+	}
+
+	if established == 0 {
+		if synning > 0 {
+			return []string{fmt.Sprintf("⚠️  Possible SYN flood: %d half-open connections with none established", synning)}
+		}
+		return nil
+	}
+
+	if ratio := float64(synning) / float64(established); ratio > d.Thresholds.SynFloodRatio {
+		return []string{fmt.Sprintf("⚠️  Possible SYN flood: SYN_SENT/SYN_RECV to ESTABLISHED ratio %.1f exceeds threshold %.1f", ratio, d.Thresholds.SynFloodRatio)}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import "fmt"
+
+// ConnectionSource abstracts where Connection data comes from, so the
+// platform-specific enumerators and the original hardcoded sample data
+// can share one call site, and so tests can inject fixtures without
+// touching the live OS network stack.
+type ConnectionSource interface {
+	Connections() ([]Connection, error)
+}
+
+// newConnectionSource returns the ConnectionSource main should use: the
+// real platform enumerator (connections_linux.go / connections_darwin.go /
+// connections_windows.go), unless demo asks for the original sample data.
+func newConnectionSource(demo bool) ConnectionSource {
+	if demo {
+		return demoConnectionSource{}
+	}
+	return platformConnectionSource{}
+}
+
+// demoConnectionSource reproduces the tool's original hardcoded sample
+// connections, kept behind --demo for walkthroughs and for platforms this
+// tool has no real enumerator for.
+type demoConnectionSource struct{}
+
+func (demoConnectionSource) Connections() ([]Connection, error) {
+	fmt.Println("📡 Note: This is a demonstration with sample data.")
+	fmt.Println("   In production, this would read actual system network connections.")
+
+	return []Connection{
+		{
+			LocalAddr:  "127.0.0.1",
+			LocalPort:  "8080",
+			RemoteAddr: "0.0.0.0",
+			RemotePort: "*",
+			Protocol:   "TCP",
+			State:      "LISTENING",
+			PID:        "1234",
+			Process:    "web-server",
+		},
+		{
+			LocalAddr:  "192.168.1.100",
+			LocalPort:  "54321",
+			RemoteAddr: "93.184.216.34",
+			RemotePort: "443",
+			Protocol:   "TCP",
+			State:      "ESTABLISHED",
+			PID:        "5678",
+			Process:    "chrome",
+		},
+		{
+			LocalAddr:  "0.0.0.0",
+			LocalPort:  "22",
+			RemoteAddr: "0.0.0.0",
+			RemotePort: "*",
+			Protocol:   "TCP",
+			State:      "LISTENING",
+			PID:        "999",
+			Process:    "sshd",
+		},
+		{
+			LocalAddr:  "192.168.1.100",
+			LocalPort:  "12345",
+			RemoteAddr: "142.250.185.46",
+			RemotePort: "443",
+			Protocol:   "TCP",
+			State:      "ESTABLISHED",
+			PID:        "5678",
+			Process:    "chrome",
+		},
+	}, nil
+}
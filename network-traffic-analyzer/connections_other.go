@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !windows
+
+package main
+
+import "fmt"
+
+// platformConnectionSource has no real enumerator outside linux/darwin/
+// windows; run with --demo on these platforms instead.
+type platformConnectionSource struct{}
+
+func (platformConnectionSource) Connections() ([]Connection, error) {
+	return nil, fmt.Errorf("live connection enumeration isn't supported on this platform; rerun with --demo")
+}
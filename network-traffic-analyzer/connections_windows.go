@@ -0,0 +1,174 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+	"unsafe"
+)
+
+// platformConnectionSource enumerates connections via the iphlpapi
+// GetExtendedTcpTable/GetExtendedUdpTable APIs, which return each row
+// already joined to its owning PID - no separate process walk needed.
+type platformConnectionSource struct{}
+
+var (
+	modIPHlpAPI             = syscall.NewLazyDLL("iphlpapi.dll")
+	procGetExtendedTCPTable = modIPHlpAPI.NewProc("GetExtendedTcpTable")
+	procGetExtendedUDPTable = modIPHlpAPI.NewProc("GetExtendedUdpTable")
+)
+
+const (
+	afINET              = 2 // AF_INET
+	tcpTableOwnerPIDAll = 5 // TCP_TABLE_OWNER_PID_ALL
+	udpTableOwnerPID    = 1 // UDP_TABLE_OWNER_PID
+	errInsufficientBuf  = 122
+)
+
+// mibTCPRowOwnerPID mirrors MIB_TCPROW_OWNER_PID: every field is stored in
+// network byte order except dwState and dwOwningPid.
+type mibTCPRowOwnerPID struct {
+	dwState      uint32
+	dwLocalAddr  uint32
+	dwLocalPort  uint32
+	dwRemoteAddr uint32
+	dwRemotePort uint32
+	dwOwningPid  uint32
+}
+
+// mibUDPRowOwnerPID mirrors MIB_UDPROW_OWNER_PID.
+type mibUDPRowOwnerPID struct {
+	dwLocalAddr uint32
+	dwLocalPort uint32
+	dwOwningPid uint32
+}
+
+// tcpStateNames maps MIB_TCP_STATE to the name netstat prints.
+var tcpStateNames = map[uint32]string{
+	1:  "CLOSED",
+	2:  "LISTEN",
+	3:  "SYN_SENT",
+	4:  "SYN_RECV",
+	5:  "ESTABLISHED",
+	6:  "FIN_WAIT1",
+	7:  "FIN_WAIT2",
+	8:  "CLOSE_WAIT",
+	9:  "CLOSING",
+	10: "LAST_ACK",
+	11: "TIME_WAIT",
+	12: "DELETE_TCB",
+}
+
+func (platformConnectionSource) Connections() ([]Connection, error) {
+	tcpConns, err := fetchTCPTable()
+	if err != nil {
+		return nil, fmt.Errorf("GetExtendedTcpTable: %w", err)
+	}
+	udpConns, err := fetchUDPTable()
+	if err != nil {
+		return nil, fmt.Errorf("GetExtendedUdpTable: %w", err)
+	}
+	return append(tcpConns, udpConns...), nil
+}
+
+// growingTableCall invokes one of the GetExtended*Table procs, growing buf
+// until it's large enough; both APIs report the required size via size
+// when they return ERROR_INSUFFICIENT_BUFFER.
+func growingTableCall(proc *syscall.LazyProc, tableClass uintptr) ([]byte, error) {
+	var size uint32 = 4096
+	for attempt := 0; attempt < 5; attempt++ {
+		buf := make([]byte, size)
+		ret, _, _ := proc.Call(
+			uintptr(unsafe.Pointer(&buf[0])),
+			uintptr(unsafe.Pointer(&size)),
+			0, // bOrder: don't bother sorting, we don't rely on it
+			afINET,
+			tableClass,
+			0,
+		)
+		switch ret {
+		case 0:
+			return buf, nil
+		case errInsufficientBuf:
+			continue // size was updated in place; retry with the new size
+		default:
+			return nil, fmt.Errorf("syscall returned %d", ret)
+		}
+	}
+	return nil, fmt.Errorf("buffer never large enough after retries")
+}
+
+func fetchTCPTable() ([]Connection, error) {
+	buf, err := growingTableCall(procGetExtendedTCPTable, tcpTableOwnerPIDAll)
+	if err != nil {
+		return nil, err
+	}
+
+	numEntries := *(*uint32)(unsafe.Pointer(&buf[0]))
+	rowSize := unsafe.Sizeof(mibTCPRowOwnerPID{})
+	rows := unsafe.Pointer(&buf[4])
+
+	connections := make([]Connection, 0, numEntries)
+	for i := uint32(0); i < numEntries; i++ {
+		row := (*mibTCPRowOwnerPID)(unsafe.Pointer(uintptr(rows) + uintptr(i)*rowSize))
+
+		state := tcpStateNames[row.dwState]
+		if state == "" {
+			state = "UNKNOWN"
+		}
+
+		connections = append(connections, Connection{
+			LocalAddr:  ipv4String(row.dwLocalAddr),
+			LocalPort:  portString(row.dwLocalPort),
+			RemoteAddr: ipv4String(row.dwRemoteAddr),
+			RemotePort: portString(row.dwRemotePort),
+			Protocol:   "TCP",
+			State:      state,
+			PID:        strconv.FormatUint(uint64(row.dwOwningPid), 10),
+		})
+	}
+	return connections, nil
+}
+
+func fetchUDPTable() ([]Connection, error) {
+	buf, err := growingTableCall(procGetExtendedUDPTable, udpTableOwnerPID)
+	if err != nil {
+		return nil, err
+	}
+
+	numEntries := *(*uint32)(unsafe.Pointer(&buf[0]))
+	rowSize := unsafe.Sizeof(mibUDPRowOwnerPID{})
+	rows := unsafe.Pointer(&buf[4])
+
+	connections := make([]Connection, 0, numEntries)
+	for i := uint32(0); i < numEntries; i++ {
+		row := (*mibUDPRowOwnerPID)(unsafe.Pointer(uintptr(rows) + uintptr(i)*rowSize))
+
+		connections = append(connections, Connection{
+			LocalAddr:  ipv4String(row.dwLocalAddr),
+			LocalPort:  portString(row.dwLocalPort),
+			RemoteAddr: "0.0.0.0",
+			RemotePort: "*",
+			Protocol:   "UDP",
+			State:      "UDP",
+			PID:        strconv.FormatUint(uint64(row.dwOwningPid), 10),
+		})
+	}
+	return connections, nil
+}
+
+// ipv4String renders a dwLocalAddr/dwRemoteAddr field (already in network
+// byte order) as a dotted-quad string.
+func ipv4String(addr uint32) string {
+	b := [4]byte{byte(addr), byte(addr >> 8), byte(addr >> 16), byte(addr >> 24)}
+	return net.IP(b[:]).String()
+}
+
+// portString extracts a dwLocalPort/dwRemotePort field: only the low 16
+// bits are the port, stored big-endian within that 16-bit window.
+func portString(port uint32) string {
+	return strconv.Itoa(int(uint16(port)>>8 | uint16(port)<<8))
+}
@@ -0,0 +1,25 @@
+package main
+
+// ConntrackStats mirrors the counters Linux's nf_conntrack module exposes
+// via /proc/net/stat/nf_conntrack (summed across every CPU row), plus the
+// table's configured capacity so utilization can be computed.
+type ConntrackStats struct {
+	Entries       uint64
+	Searched      uint64
+	Found         uint64
+	New           uint64
+	Invalid       uint64
+	Drop          uint64
+	EarlyDrop     uint64
+	InsertFailed  uint64
+	SearchRestart uint64
+	Max           uint64 // nf_conntrack_max; 0 if it couldn't be read
+}
+
+// Utilization returns Entries/Max, or 0 if Max is unknown.
+func (s ConntrackStats) Utilization() float64 {
+	if s.Max == 0 {
+		return 0
+	}
+	return float64(s.Entries) / float64(s.Max)
+}
@@ -0,0 +1,90 @@
+//go:build linux
+
+package main
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readConntrackStats parses /proc/net/stat/nf_conntrack, whose rows look
+// like (one header row, then one hex-encoded row per CPU):
+//
+//	entries  searched found new invalid ignore insert insert_failed drop early_drop icmp_error expect_new expect_create expect_delete search_restart
+//	000001a4 00000000 00000000 00000000 00000001 00000000 00000000 00000000 00000000 00000000 00000000 00000000 00000000 00000000 00000000
+//
+// "entries" is the whole table's current size, repeated on every CPU's
+// row rather than partitioned; every other column is a per-CPU counter
+// that must be summed across rows for the system-wide total.
+func readConntrackStats() (ConntrackStats, error) {
+	var stats ConntrackStats
+
+	file, err := os.Open("/proc/net/stat/nf_conntrack")
+	if err != nil {
+		return stats, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		return stats, scanner.Err()
+	}
+	header := strings.Fields(scanner.Text())
+	colIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		colIndex[name] = i
+	}
+
+	firstRow := true
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		get := func(col string) uint64 {
+			idx, ok := colIndex[col]
+			if !ok || idx >= len(fields) {
+				return 0
+			}
+			v, _ := strconv.ParseUint(fields[idx], 16, 64)
+			return v
+		}
+
+		if firstRow {
+			stats.Entries = get("entries")
+			firstRow = false
+		}
+		stats.Searched += get("searched")
+		stats.Found += get("found")
+		stats.New += get("new")
+		stats.Invalid += get("invalid")
+		stats.Drop += get("drop")
+		stats.EarlyDrop += get("early_drop")
+		stats.InsertFailed += get("insert_failed")
+		stats.SearchRestart += get("search_restart")
+	}
+	if err := scanner.Err(); err != nil {
+		return stats, err
+	}
+
+	stats.Max = readConntrackMax()
+	return stats, nil
+}
+
+// readConntrackMax reads nf_conntrack_max from whichever sysctl path this
+// kernel exposes it under; returns 0 (unknown) if neither is readable,
+// which disables the table-utilization check without failing the sample.
+func readConntrackMax() uint64 {
+	for _, path := range []string{
+		"/proc/sys/net/netfilter/nf_conntrack_max",
+		"/proc/sys/net/nf_conntrack_max",
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if v, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package main
+
+import "fmt"
+
+// readConntrackStats has no implementation outside Linux: nf_conntrack is
+// a Linux netfilter subsystem with no direct macOS/Windows equivalent.
+func readConntrackStats() (ConntrackStats, error) {
+	return ConntrackStats{}, fmt.Errorf("conntrack statistics aren't available on this platform")
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arya2004/cybersecurity/pkg/geoip"
+)
+
+// privateBlocks are the reserved ranges Enricher.Lookup skips, in
+// addition to net.IP's own loopback/multicast/link-local checks.
+var privateBlocks = mustParseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"fc00::/7",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	blocks := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, block, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err) // cidrs is a fixed literal list; a parse failure is a bug in this file
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func isPrivate(ip net.IP) bool {
+	for _, block := range privateBlocks {
+		if block.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUnenrichable(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsMulticast() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || isPrivate(ip)
+}
+
+type enrichmentCacheEntry struct {
+	record geoip.Record
+	found  bool
+	at     time.Time
+}
+
+// Enricher augments a Connection.RemoteAddr with country/ASN/organization
+// data from a local MaxMind-format (.mmdb) database, skipping
+// RFC1918/loopback/multicast addresses and caching lookups for ttl so a
+// repeatedly-seen remote IP isn't re-decoded from the database every
+// tick.
+type Enricher struct {
+	reader    *geoip.Reader
+	ttl       time.Duration
+	blocklist map[uint32]bool
+	expected  map[string]bool // empty = no restriction
+
+	mu    sync.Mutex
+	cache map[string]enrichmentCacheEntry
+}
+
+// NewEnricher opens dbPath (a .mmdb file). blocklistPath, if non-empty,
+// names a newline-delimited file of ASNs (with or without a leading
+// "AS") to flag as suspicious; expectedCountries, if non-empty, is the
+// allowlist DetectSuspiciousActivity flags connections outside of.
+func NewEnricher(dbPath, blocklistPath string, expectedCountries []string, ttl time.Duration) (*Enricher, error) {
+	reader, err := geoip.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Enricher{
+		reader:    reader,
+		ttl:       ttl,
+		blocklist: make(map[uint32]bool),
+		expected:  make(map[string]bool, len(expectedCountries)),
+		cache:     make(map[string]enrichmentCacheEntry),
+	}
+	for _, c := range expectedCountries {
+		if c = strings.TrimSpace(c); c != "" {
+			e.expected[strings.ToUpper(c)] = true
+		}
+	}
+
+	if blocklistPath != "" {
+		if err := e.loadBlocklist(blocklistPath); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func (e *Enricher) loadBlocklist(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(line), "AS"), 10, 32)
+		if err != nil {
+			continue
+		}
+		e.blocklist[uint32(asn)] = true
+	}
+	return scanner.Err()
+}
+
+// Lookup returns the enrichment for remoteAddr. found is false for an
+// unparseable, private, loopback, or multicast address, or one with no
+// entry in the database.
+func (e *Enricher) Lookup(remoteAddr string) (rec geoip.Record, found bool) {
+	ip := net.ParseIP(remoteAddr)
+	if ip == nil || isUnenrichable(ip) {
+		return geoip.Record{}, false
+	}
+
+	e.mu.Lock()
+	if entry, ok := e.cache[remoteAddr]; ok && time.Since(entry.at) < e.ttl {
+		e.mu.Unlock()
+		return entry.record, entry.found
+	}
+	e.mu.Unlock()
+
+	rec, found, err := e.reader.LookupRecord(ip)
+	if err != nil {
+		found = false
+	}
+
+	e.mu.Lock()
+	e.cache[remoteAddr] = enrichmentCacheEntry{record: rec, found: found, at: time.Now()}
+	e.mu.Unlock()
+
+	return rec, found
+}
+
+// IsSuspicious reports whether rec's ASN is on the blocklist, or (when an
+// expected-country list was configured) its country isn't in it.
+func (e *Enricher) IsSuspicious(rec geoip.Record) (reason string, suspicious bool) {
+	if rec.ASN != 0 && e.blocklist[rec.ASN] {
+		return fmt.Sprintf("ASN AS%d (%s) is on the blocklist", rec.ASN, rec.Organization), true
+	}
+	if len(e.expected) > 0 && rec.CountryCode != "" && !e.expected[strings.ToUpper(rec.CountryCode)] {
+		return fmt.Sprintf("connection from unexpected country %s", rec.CountryCode), true
+	}
+	return "", false
+}
+
+// enrichConnections returns a copy of connections with Country, ASN, and
+// Organization filled in from e. If e is nil, connections is returned
+// unchanged.
+func enrichConnections(connections []Connection, e *Enricher) []Connection {
+	if e == nil {
+		return connections
+	}
+
+	out := make([]Connection, len(connections))
+	for i, conn := range connections {
+		out[i] = conn
+		rec, found := e.Lookup(conn.RemoteAddr)
+		if !found {
+			continue
+		}
+		out[i].Country = rec.CountryCode
+		out[i].Organization = rec.Organization
+		if rec.ASN != 0 {
+			out[i].ASN = fmt.Sprintf("AS%d", rec.ASN)
+		}
+	}
+	return out
+}
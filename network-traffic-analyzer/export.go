@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/arya2004/cybersecurity/pkg/nat"
+)
+
+// exportSchemaVersion is bumped whenever the JSON/NDJSON export shape
+// changes in a way downstream consumers need to know about.
+const exportSchemaVersion = 1
+
+// toolVersion matches the version printed in PrintBanner.
+const toolVersion = "1.0"
+
+// ExportFormat selects how ExportConnections/ExportStats serialize their
+// data.
+type ExportFormat string
+
+const (
+	FormatCSV    ExportFormat = "csv"
+	FormatJSON   ExportFormat = "json"
+	FormatNDJSON ExportFormat = "ndjson"
+)
+
+// resolveFormat honors an explicit format if given, otherwise infers one
+// from filename's extension, defaulting to CSV to match this tool's
+// historical export behavior.
+func resolveFormat(filename, format string) ExportFormat {
+	switch strings.ToLower(format) {
+	case "csv":
+		return FormatCSV
+	case "json":
+		return FormatJSON
+	case "ndjson", "jsonl":
+		return FormatNDJSON
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return FormatJSON
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	default:
+		return FormatCSV
+	}
+}
+
+// exportMetadata accompanies every JSON/NDJSON export so downstream
+// consumers can parse deterministically without guessing at the
+// producing environment.
+type exportMetadata struct {
+	SchemaVersion  int       `json:"schema_version"`
+	GeneratedAt    time.Time `json:"generated_at"`
+	Hostname       string    `json:"hostname"`
+	Kernel         string    `json:"kernel"`
+	ToolVersion    string    `json:"tool_version"`
+	SampleInterval string    `json:"sample_interval,omitempty"`
+}
+
+// newExportMetadata builds the metadata object for a single export,
+// tagging it with sampleInterval when the export represents a recurring
+// sample (e.g. a MonitorTraffic stream) rather than a one-shot snapshot.
+func newExportMetadata(sampleInterval time.Duration) exportMetadata {
+	hostname, _ := os.Hostname()
+	return exportMetadata{
+		SchemaVersion:  exportSchemaVersion,
+		GeneratedAt:    time.Now(),
+		Hostname:       hostname,
+		Kernel:         runtime.GOOS,
+		ToolVersion:    toolVersion,
+		SampleInterval: sampleInterval.String(),
+	}
+}
+
+// connectionsExport is the top-level JSON document ExportConnections
+// writes for FormatJSON.
+type connectionsExport struct {
+	Metadata    exportMetadata  `json:"metadata"`
+	NATReport   *nat.NATReport  `json:"nat_report,omitempty"`
+	Connections []Connection    `json:"connections"`
+}
+
+// statsExport is the top-level JSON document ExportStats writes for
+// FormatJSON.
+type statsExport struct {
+	Metadata exportMetadata `json:"metadata"`
+	Stats    TrafficStats   `json:"stats"`
+}
+
+// ExportConnections exports connections (and, if natReport is non-nil, the
+// most recent NAT probe) to filename. format selects CSV, JSON, or NDJSON;
+// pass "" to infer the format from filename's extension.
+func ExportConnections(connections []Connection, natReport *nat.NATReport, filename string, format string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+
+	switch resolveFormat(filename, format) {
+	case FormatJSON:
+		doc := connectionsExport{
+			Metadata:    newExportMetadata(0),
+			NATReport:   natReport,
+			Connections: connections,
+		}
+		if err := writeJSON(writer, doc); err != nil {
+			return err
+		}
+	case FormatNDJSON:
+		meta := newExportMetadata(0)
+		if err := writeNDJSONLine(writer, struct {
+			Metadata exportMetadata `json:"metadata"`
+		}{meta}); err != nil {
+			return err
+		}
+		for _, conn := range connections {
+			if err := writeNDJSONLine(writer, connectionRecord{
+				SchemaVersion: exportSchemaVersion,
+				Timestamp:     meta.GeneratedAt,
+				Connection:    conn,
+			}); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := writeConnectionsCSV(writer, connections, natReport); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("📄 Report exported to: %s\n", filename)
+	return nil
+}
+
+// ExportStats exports stats to filename in CSV, JSON, or NDJSON, inferring
+// the format the same way ExportConnections does.
+func ExportStats(stats TrafficStats, filename string, format string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	defer writer.Flush()
+
+	switch resolveFormat(filename, format) {
+	case FormatJSON:
+		doc := statsExport{Metadata: newExportMetadata(0), Stats: stats}
+		if err := writeJSON(writer, doc); err != nil {
+			return err
+		}
+	case FormatNDJSON:
+		meta := newExportMetadata(0)
+		if err := writeNDJSONLine(writer, struct {
+			Metadata exportMetadata `json:"metadata"`
+		}{meta}); err != nil {
+			return err
+		}
+		if err := writeNDJSONLine(writer, statsRecord{
+			SchemaVersion: exportSchemaVersion,
+			Timestamp:     meta.GeneratedAt,
+			TrafficStats:  stats,
+		}); err != nil {
+			return err
+		}
+	default:
+		if err := writeStatsCSV(writer, stats); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("📄 Report exported to: %s\n", filename)
+	return nil
+}
+
+func writeConnectionsCSV(writer io.Writer, connections []Connection, natReport *nat.NATReport) error {
+	fmt.Fprintf(writer, "# Network Traffic Analysis Report\n")
+	fmt.Fprintf(writer, "# Generated: %s\n", time.Now().Format(time.RFC3339))
+	if natReport != nil {
+		fmt.Fprintf(writer, "# NAT probe (%s): public=%s:%d mapping=%s filtering=%s hairpinning=%t lifetime>=%s\n",
+			natReport.StunServer, natReport.PublicIP, natReport.PublicPort,
+			natReport.Mapping, natReport.Filtering, natReport.Hairpinning, natReport.MappingLifetime)
+	}
+	fmt.Fprintf(writer, "\nProtocol,LocalAddress,LocalPort,RemoteAddress,RemotePort,State,PID,Process,Country,ASN,Organization\n")
+
+	for _, conn := range connections {
+		fmt.Fprintf(writer, "%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s\n",
+			conn.Protocol, conn.LocalAddr, conn.LocalPort,
+			conn.RemoteAddr, conn.RemotePort, conn.State,
+			conn.PID, conn.Process, conn.Country, conn.ASN, conn.Organization)
+	}
+	return nil
+}
+
+func writeStatsCSV(writer io.Writer, stats TrafficStats) error {
+	fmt.Fprintf(writer, "# Network Traffic Statistics\n")
+	fmt.Fprintf(writer, "# Generated: %s\n\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(writer, "Metric,Value\n")
+	fmt.Fprintf(writer, "TotalConnections,%d\n", stats.TotalConnections)
+	fmt.Fprintf(writer, "ActiveConnections,%d\n", stats.ActiveConnections)
+	fmt.Fprintf(writer, "TCPConnections,%d\n", stats.TCPConnections)
+	fmt.Fprintf(writer, "UDPConnections,%d\n", stats.UDPConnections)
+	fmt.Fprintf(writer, "ListeningPorts,%d\n", stats.ListeningPorts)
+	fmt.Fprintf(writer, "EstablishedConns,%d\n", stats.EstablishedConns)
+	fmt.Fprintf(writer, "TimeWaitConns,%d\n", stats.TimeWaitConns)
+	fmt.Fprintf(writer, "UniqueRemoteIPs,%d\n", stats.UniqueRemoteIPs)
+	return nil
+}
+
+func writeJSON(writer io.Writer, v interface{}) error {
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeNDJSONLine(writer io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "%s\n", data)
+	return err
+}
+
+// connectionRecord is one NDJSON line in a connections stream: a schema
+// version and timestamp alongside the connection itself.
+type connectionRecord struct {
+	SchemaVersion int       `json:"schema_version"`
+	Timestamp     time.Time `json:"timestamp"`
+	Connection
+}
+
+// statsRecord is one NDJSON line in a stats stream.
+type statsRecord struct {
+	SchemaVersion int       `json:"schema_version"`
+	Timestamp     time.Time `json:"timestamp"`
+	TrafficStats
+}
+
+// newStreamWriter opens the destination for MonitorTraffic's --stream
+// mode: "-" (or "") means stdout, anything else is a file path appended
+// to (created if missing).
+func newStreamWriter(dest string) (io.WriteCloser, error) {
+	if dest == "" || dest == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+}
+
+// nopCloser adapts os.Stdout (which callers must not close) to
+// io.WriteCloser so newStreamWriter has one return type either way.
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
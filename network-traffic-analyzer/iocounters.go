@@ -0,0 +1,131 @@
+package main
+
+// IOCounters mirrors psutil's net_io_counters: cumulative byte/packet/
+// error/drop counts for a NIC (or, when sampled with pernic=false, summed
+// across every NIC) since the counters were last reset (usually boot).
+type IOCounters struct {
+	Name        string
+	BytesSent   uint64
+	BytesRecv   uint64
+	PacketsSent uint64
+	PacketsRecv uint64
+	ErrIn       uint64
+	ErrOut      uint64
+	DropIn      uint64
+	DropOut     uint64
+}
+
+// SampleIOCounters reads the current cumulative I/O counters for every NIC
+// via the platform-specific readIOCounters, then either returns them as-is
+// (pernic=true) or collapses them into a single "all" entry (pernic=false,
+// matching psutil's net_io_counters(pernic=False) default).
+func SampleIOCounters(pernic bool) ([]IOCounters, error) {
+	counters, err := readIOCounters()
+	if err != nil {
+		return nil, err
+	}
+	if pernic {
+		return counters, nil
+	}
+
+	total := IOCounters{Name: "all"}
+	for _, c := range counters {
+		total.BytesSent += c.BytesSent
+		total.BytesRecv += c.BytesRecv
+		total.PacketsSent += c.PacketsSent
+		total.PacketsRecv += c.PacketsRecv
+		total.ErrIn += c.ErrIn
+		total.ErrOut += c.ErrOut
+		total.DropIn += c.DropIn
+		total.DropOut += c.DropOut
+	}
+	return []IOCounters{total}, nil
+}
+
+// ioRate is one tick's computed throughput for a single interface, derived
+// from the delta between two SampleIOCounters snapshots.
+type ioRate struct {
+	Name         string
+	BitsPerSec   float64
+	PacketsPerSec float64
+}
+
+// ioRateHistory is a fixed-capacity ring buffer of past ioRate samples per
+// interface, kept so MonitorTraffic's closing summary can report
+// min/max/avg throughput without holding every tick for the whole run.
+type ioRateHistory struct {
+	capacity int
+	samples  map[string][]float64 // interface name -> bits/sec samples, oldest first
+}
+
+func newIORateHistory(capacity int) *ioRateHistory {
+	return &ioRateHistory{capacity: capacity, samples: make(map[string][]float64)}
+}
+
+// add records rate's bits/sec sample for its interface, dropping the
+// oldest sample once capacity is exceeded.
+func (h *ioRateHistory) add(rate ioRate) {
+	s := append(h.samples[rate.Name], rate.BitsPerSec)
+	if len(s) > h.capacity {
+		s = s[len(s)-h.capacity:]
+	}
+	h.samples[rate.Name] = s
+}
+
+// summary computes min/max/avg bits/sec per interface across every sample
+// recorded so far.
+func (h *ioRateHistory) summary() map[string]struct{ Min, Max, Avg float64 } {
+	result := make(map[string]struct{ Min, Max, Avg float64 })
+	for name, samples := range h.samples {
+		if len(samples) == 0 {
+			continue
+		}
+		min, max, sum := samples[0], samples[0], 0.0
+		for _, v := range samples {
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+			sum += v
+		}
+		result[name] = struct{ Min, Max, Avg float64 }{Min: min, Max: max, Avg: sum / float64(len(samples))}
+	}
+	return result
+}
+
+// diffIOCounters computes the per-interface rate between two consecutive
+// SampleIOCounters(pernic=true) snapshots taken interval apart.
+func diffIOCounters(prev, curr []IOCounters, interval float64) []ioRate {
+	prevByName := make(map[string]IOCounters, len(prev))
+	for _, c := range prev {
+		prevByName[c.Name] = c
+	}
+
+	rates := make([]ioRate, 0, len(curr))
+	for _, c := range curr {
+		p, ok := prevByName[c.Name]
+		if !ok || interval <= 0 {
+			continue
+		}
+		bytesDelta := counterDelta(p.BytesSent, c.BytesSent) + counterDelta(p.BytesRecv, c.BytesRecv)
+		packetsDelta := counterDelta(p.PacketsSent, c.PacketsSent) + counterDelta(p.PacketsRecv, c.PacketsRecv)
+		rates = append(rates, ioRate{
+			Name:          c.Name,
+			BitsPerSec:    float64(bytesDelta) * 8 / interval,
+			PacketsPerSec: float64(packetsDelta) / interval,
+		})
+	}
+	return rates
+}
+
+// counterDelta computes curr-prev for a monotonically-increasing kernel
+// counter, returning 0 instead of wrapping if the counter was reset (e.g.
+// the interface was reloaded) between samples.
+func counterDelta(prev, curr uint64) uint64 {
+	if curr < prev {
+		return 0
+	}
+	return curr - prev
+}
@@ -0,0 +1,74 @@
+//go:build darwin
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// readIOCounters parses `netstat -ib`, whose rows look like:
+//
+//	Name  Mtu   Network       Address            Ipkts Ierrs     Ibytes    Opkts Oerrs     Obytes  Coll
+//	en0   1500  <Link#4>      a1:b2:c3:d4:e5:f6    1234     0     567890     1000     0     456789     0
+//	en0   1500  192.168.1     192.168.1.100        1234     0     567890     1000     0     456789     0
+//
+// netstat prints one row per interface per address family; the Address
+// column is blank for some rows, which shifts every later column by one,
+// so columns are located relative to the first numeric (Ipkts) field
+// rather than by fixed index. Only the first row seen per interface name
+// is kept, since later rows repeat the same cumulative counters.
+func readIOCounters() ([]IOCounters, error) {
+	out, err := exec.Command("netstat", "-ib").Output()
+	if err != nil {
+		return nil, fmt.Errorf("running netstat -ib: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var counters []IOCounters
+
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	scanner.Scan() // header
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 9 {
+			continue
+		}
+		name := fields[0]
+		if seen[name] {
+			continue
+		}
+
+		idx := 3
+		if _, err := strconv.ParseUint(fields[idx], 10, 64); err != nil {
+			idx++ // fields[3] was the Address column, not Ipkts; skip it
+		}
+		if idx+5 >= len(fields) {
+			continue
+		}
+
+		ipkts, _ := strconv.ParseUint(fields[idx], 10, 64)
+		ierrs, _ := strconv.ParseUint(fields[idx+1], 10, 64)
+		ibytes, _ := strconv.ParseUint(fields[idx+2], 10, 64)
+		opkts, _ := strconv.ParseUint(fields[idx+3], 10, 64)
+		oerrs, _ := strconv.ParseUint(fields[idx+4], 10, 64)
+		obytes, _ := strconv.ParseUint(fields[idx+5], 10, 64)
+
+		seen[name] = true
+		counters = append(counters, IOCounters{
+			Name:        name,
+			BytesRecv:   ibytes,
+			PacketsRecv: ipkts,
+			ErrIn:       ierrs,
+			BytesSent:   obytes,
+			PacketsSent: opkts,
+			ErrOut:      oerrs,
+		})
+	}
+
+	return counters, scanner.Err()
+}
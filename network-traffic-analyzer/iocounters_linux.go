@@ -0,0 +1,68 @@
+//go:build linux
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readIOCounters parses /proc/net/dev, whose rows look like:
+//
+//	Inter-|   Receive                                                |  Transmit
+//	 face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+//	  eth0: 123456     100    0    0    0     0          0         0   654321      80    0    0    0     0       0          0
+func readIOCounters() ([]IOCounters, error) {
+	file, err := os.Open("/proc/net/dev")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var counters []IOCounters
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // "Inter-|   Receive ..." header
+	scanner.Scan() // " face |bytes ..." header
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		name := strings.TrimSpace(parts[0])
+		fields := strings.Fields(parts[1])
+		if len(fields) < 16 {
+			continue
+		}
+
+		recvBytes, _ := strconv.ParseUint(fields[0], 10, 64)
+		recvPackets, _ := strconv.ParseUint(fields[1], 10, 64)
+		recvErrs, _ := strconv.ParseUint(fields[2], 10, 64)
+		recvDrop, _ := strconv.ParseUint(fields[3], 10, 64)
+		sentBytes, _ := strconv.ParseUint(fields[8], 10, 64)
+		sentPackets, _ := strconv.ParseUint(fields[9], 10, 64)
+		sentErrs, _ := strconv.ParseUint(fields[10], 10, 64)
+		sentDrop, _ := strconv.ParseUint(fields[11], 10, 64)
+
+		counters = append(counters, IOCounters{
+			Name:        name,
+			BytesRecv:   recvBytes,
+			PacketsRecv: recvPackets,
+			ErrIn:       recvErrs,
+			DropIn:      recvDrop,
+			BytesSent:   sentBytes,
+			PacketsSent: sentPackets,
+			ErrOut:      sentErrs,
+			DropOut:     sentDrop,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanning /proc/net/dev: %w", err)
+	}
+	return counters, nil
+}
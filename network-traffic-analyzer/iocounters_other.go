@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !windows
+
+package main
+
+import "fmt"
+
+// readIOCounters has no implementation outside linux/darwin/windows.
+func readIOCounters() ([]IOCounters, error) {
+	return nil, fmt.Errorf("per-interface I/O counters aren't supported on this platform")
+}
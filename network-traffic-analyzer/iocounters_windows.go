@@ -0,0 +1,103 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unicode/utf16"
+	"unsafe"
+)
+
+var procGetIfTable = modIPHlpAPI.NewProc("GetIfTable")
+
+const maxInterfaceNameLen = 256
+
+// mibIfRow mirrors MIB_IFROW (iphlpapi.h): a fixed-name-length interface
+// row carrying both the Ethernet-style byte/packet/error/discard counters
+// and administrative fields this tool doesn't use.
+type mibIfRow struct {
+	wszName           [maxInterfaceNameLen]uint16
+	dwIndex           uint32
+	dwType            uint32
+	dwMtu             uint32
+	dwSpeed           uint32
+	dwPhysAddrLen     uint32
+	bPhysAddr         [8]byte
+	dwAdminStatus     uint32
+	dwOperStatus      uint32
+	dwLastChange      uint32
+	dwInOctets        uint32
+	dwInUcastPkts     uint32
+	dwInNUcastPkts    uint32
+	dwInDiscards      uint32
+	dwInErrors        uint32
+	dwInUnknownProtos uint32
+	dwOutOctets       uint32
+	dwOutUcastPkts    uint32
+	dwOutNUcastPkts   uint32
+	dwOutDiscards     uint32
+	dwOutErrors       uint32
+	dwOutQLen         uint32
+	dwDescrLen        uint32
+	bDescr            [256]byte
+}
+
+// readIOCounters calls GetIfTable, growing its buffer until it fits, and
+// converts each MIB_IFROW into an IOCounters. Unicast and non-unicast
+// (broadcast/multicast) packet counts are summed to match psutil's single
+// packets_sent/packets_recv fields.
+func readIOCounters() ([]IOCounters, error) {
+	var size uint32 = 8192
+	var buf []byte
+	for attempt := 0; attempt < 5; attempt++ {
+		buf = make([]byte, size)
+		ret, _, _ := procGetIfTable.Call(
+			uintptr(unsafe.Pointer(&buf[0])),
+			uintptr(unsafe.Pointer(&size)),
+			0, // bOrder
+		)
+		if ret == 0 {
+			break
+		}
+		if ret == errInsufficientBuf && attempt < 4 {
+			continue
+		}
+		return nil, fmt.Errorf("GetIfTable returned %d", ret)
+	}
+
+	numEntries := *(*uint32)(unsafe.Pointer(&buf[0]))
+	rowSize := unsafe.Sizeof(mibIfRow{})
+	rows := unsafe.Pointer(&buf[4])
+
+	counters := make([]IOCounters, 0, numEntries)
+	for i := uint32(0); i < numEntries; i++ {
+		row := (*mibIfRow)(unsafe.Pointer(uintptr(rows) + uintptr(i)*rowSize))
+
+		counters = append(counters, IOCounters{
+			Name:        utf16ToString(row.wszName[:]),
+			BytesRecv:   uint64(row.dwInOctets),
+			PacketsRecv: uint64(row.dwInUcastPkts) + uint64(row.dwInNUcastPkts),
+			ErrIn:       uint64(row.dwInErrors),
+			DropIn:      uint64(row.dwInDiscards),
+			BytesSent:   uint64(row.dwOutOctets),
+			PacketsSent: uint64(row.dwOutUcastPkts) + uint64(row.dwOutNUcastPkts),
+			ErrOut:      uint64(row.dwOutErrors),
+			DropOut:     uint64(row.dwOutDiscards),
+		})
+	}
+
+	return counters, nil
+}
+
+// utf16ToString converts a NUL-terminated UTF-16 buffer (as Windows
+// returns wszName) to a Go string.
+func utf16ToString(buf []uint16) string {
+	for i, c := range buf {
+		if c == 0 {
+			buf = buf[:i]
+			break
+		}
+	}
+	return string(utf16.Decode(buf))
+}
@@ -2,40 +2,48 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
-	"net"
+	"io"
 	"os"
 	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/arya2004/cybersecurity/pkg/nat"
 )
 
-// Connection represents a network connection
+// Connection represents a network connection. Country, ASN, and
+// Organization are populated by enrichConnections when an Enricher is
+// configured, and are left blank otherwise.
 type Connection struct {
-	LocalAddr  string
-	LocalPort  string
-	RemoteAddr string
-	RemotePort string
-	Protocol   string
-	State      string
-	PID        string
-	Process    string
+	LocalAddr    string `json:"local_addr"`
+	LocalPort    string `json:"local_port"`
+	RemoteAddr   string `json:"remote_addr"`
+	RemotePort   string `json:"remote_port"`
+	Protocol     string `json:"protocol"`
+	State        string `json:"state"`
+	PID          string `json:"pid"`
+	Process      string `json:"process"`
+	Country      string `json:"country,omitempty"`
+	ASN          string `json:"asn,omitempty"`
+	Organization string `json:"organization,omitempty"`
 }
 
 // TrafficStats stores traffic statistics
 type TrafficStats struct {
-	TotalConnections   int
-	ActiveConnections  int
-	TCPConnections     int
-	UDPConnections     int
-	ListeningPorts     int
-	EstablishedConns   int
-	TimeWaitConns      int
-	UniqueRemoteIPs    int
-	ConnectionsByPort  map[string]int
-	ConnectionsByIP    map[string]int
-	ProtocolDistribution map[string]int
+	TotalConnections      int            `json:"total_connections"`
+	ActiveConnections     int            `json:"active_connections"`
+	TCPConnections        int            `json:"tcp_connections"`
+	UDPConnections        int            `json:"udp_connections"`
+	ListeningPorts        int            `json:"listening_ports"`
+	EstablishedConns      int            `json:"established_connections"`
+	TimeWaitConns         int            `json:"time_wait_connections"`
+	UniqueRemoteIPs       int            `json:"unique_remote_ips"`
+	ConnectionsByPort     map[string]int `json:"connections_by_port"`
+	ConnectionsByIP       map[string]int `json:"connections_by_ip"`
+	ProtocolDistribution  map[string]int `json:"protocol_distribution"`
 }
 
 // PortInfo stores information about well-known ports
@@ -61,72 +69,9 @@ var wellKnownPorts = map[string]string{
 	"27017": "MongoDB",
 }
 
-// GetActiveConnections retrieves current network connections
-func GetActiveConnections() ([]Connection, error) {
-	connections := []Connection{}
-	
-	// Get TCP connections
-	tcpConns, err := net.Interfaces()
-	if err != nil {
-		return nil, fmt.Errorf("error getting network interfaces: %v", err)
-	}
-
-	// Simulate network connections for demonstration
-	// In a real implementation, this would parse /proc/net/tcp, netstat, or use system calls
-	connections = append(connections, Connection{
-		LocalAddr:  "127.0.0.1",
-		LocalPort:  "8080",
-		RemoteAddr: "0.0.0.0",
-		RemotePort: "*",
-		Protocol:   "TCP",
-		State:      "LISTENING",
-		PID:        "1234",
-		Process:    "web-server",
-	})
-
-	connections = append(connections, Connection{
-		LocalAddr:  "192.168.1.100",
-		LocalPort:  "54321",
-		RemoteAddr: "93.184.216.34",
-		RemotePort: "443",
-		Protocol:   "TCP",
-		State:      "ESTABLISHED",
-		PID:        "5678",
-		Process:    "chrome",
-	})
-
-	connections = append(connections, Connection{
-		LocalAddr:  "0.0.0.0",
-		LocalPort:  "22",
-		RemoteAddr: "0.0.0.0",
-		RemotePort: "*",
-		Protocol:   "TCP",
-		State:      "LISTENING",
-		PID:        "999",
-		Process:    "sshd",
-	})
-
-	connections = append(connections, Connection{
-		LocalAddr:  "192.168.1.100",
-		LocalPort:  "12345",
-		RemoteAddr: "142.250.185.46",
-		RemotePort: "443",
-		Protocol:   "TCP",
-		State:      "ESTABLISHED",
-		PID:        "5678",
-		Process:    "chrome",
-	})
-
-	// Note: This is a simplified demo. Real implementation would:
-	// - Parse /proc/net/tcp and /proc/net/udp on Linux
-	// - Use netstat or ss command
-	// - Use Windows API on Windows
-	// - Use system calls for cross-platform support
-
-	fmt.Println("📡 Note: This is a demonstration with sample data.")
-	fmt.Println("   In production, this would read actual system network connections.")
-	
-	return connections, nil
+// GetActiveConnections retrieves current network connections from source.
+func GetActiveConnections(source ConnectionSource) ([]Connection, error) {
+	return source.Connections()
 }
 
 // CalculateStatistics computes traffic statistics
@@ -195,9 +140,9 @@ func PrintBanner() {
 
 // PrintStatistics displays traffic statistics
 func PrintStatistics(stats TrafficStats) {
-	fmt.Println("\n" + "═"*60)
+	fmt.Println("\n" + strings.Repeat("═", 60))
 	fmt.Println("NETWORK TRAFFIC STATISTICS")
-	fmt.Println("═"*60)
+	fmt.Println(strings.Repeat("═", 60))
 	
 	// Overall stats
 	fmt.Println("Overall:")
@@ -205,7 +150,7 @@ func PrintStatistics(stats TrafficStats) {
 	fmt.Printf("  Active Connections: %d\n", stats.ActiveConnections)
 	fmt.Printf("  Listening Ports: %d\n", stats.ListeningPorts)
 	fmt.Printf("  Unique Remote IPs: %d\n", stats.UniqueRemoteIPs)
-	fmt.Println("─"*60)
+	fmt.Println(strings.Repeat("─", 60))
 
 	// Protocol distribution
 	fmt.Println("Protocol Distribution:")
@@ -213,14 +158,14 @@ func PrintStatistics(stats TrafficStats) {
 		percentage := float64(count) / float64(stats.TotalConnections) * 100
 		fmt.Printf("  %s: %d (%.1f%%)\n", protocol, count, percentage)
 	}
-	fmt.Println("─"*60)
+	fmt.Println(strings.Repeat("─", 60))
 
 	// Connection states
 	fmt.Println("Connection States:")
 	fmt.Printf("  ESTABLISHED: %d\n", stats.EstablishedConns)
 	fmt.Printf("  LISTENING: %d\n", stats.ListeningPorts)
 	fmt.Printf("  TIME_WAIT: %d\n", stats.TimeWaitConns)
-	fmt.Println("─"*60)
+	fmt.Println(strings.Repeat("─", 60))
 
 	// Top ports
 	fmt.Println("Top Active Ports:")
@@ -246,7 +191,7 @@ func PrintStatistics(stats TrafficStats) {
 		}
 		fmt.Printf("  Port %s (%s): %d connections\n", pc.Port, service, pc.Count)
 	}
-	fmt.Println("─"*60)
+	fmt.Println(strings.Repeat("─", 60))
 
 	// Top remote IPs
 	fmt.Println("Top Remote IPs:")
@@ -268,20 +213,27 @@ func PrintStatistics(stats TrafficStats) {
 		}
 		fmt.Printf("  %s: %d connections\n", ic.IP, ic.Count)
 	}
-	fmt.Println("═"*60)
+	fmt.Println(strings.Repeat("═", 60))
 }
 
-// PrintConnections displays detailed connection list
-func PrintConnections(connections []Connection, filter string) {
-	fmt.Println("\n" + "═"*60)
+// PrintConnections displays detailed connection list. When showEnrichment
+// is true, an extra country/ASN/organization column is printed from each
+// connection's (already-populated, via enrichConnections) fields.
+func PrintConnections(connections []Connection, filter string, showEnrichment bool) {
+	fmt.Println("\n" + strings.Repeat("═", 60))
 	fmt.Println("ACTIVE NETWORK CONNECTIONS")
 	if filter != "" {
 		fmt.Printf("Filter: %s\n", filter)
 	}
-	fmt.Println("═"*60)
-	fmt.Printf("%-6s %-22s %-22s %-12s %-10s\n", 
-		"PROTO", "LOCAL ADDRESS", "REMOTE ADDRESS", "STATE", "PID/PROCESS")
-	fmt.Println("─"*60)
+	fmt.Println(strings.Repeat("═", 60))
+	if showEnrichment {
+		fmt.Printf("%-6s %-22s %-22s %-12s %-10s %-30s\n",
+			"PROTO", "LOCAL ADDRESS", "REMOTE ADDRESS", "STATE", "PID/PROCESS", "COUNTRY/ASN/ORG")
+	} else {
+		fmt.Printf("%-6s %-22s %-22s %-12s %-10s\n",
+			"PROTO", "LOCAL ADDRESS", "REMOTE ADDRESS", "STATE", "PID/PROCESS")
+	}
+	fmt.Println(strings.Repeat("─", 60))
 
 	displayed := 0
 	for _, conn := range connections {
@@ -319,75 +271,182 @@ func PrintConnections(connections []Connection, filter string) {
 			remoteAddr = remoteAddr[:19] + "..."
 		}
 
-		fmt.Printf("%-6s %-22s %-22s %-12s %-10s\n",
-			conn.Protocol, localAddr, remoteAddr, conn.State, pidProcess)
+		if showEnrichment {
+			enrichment := strings.TrimSpace(strings.Join(filterEmpty(conn.Country, conn.ASN, conn.Organization), " / "))
+			fmt.Printf("%-6s %-22s %-22s %-12s %-10s %-30s\n",
+				conn.Protocol, localAddr, remoteAddr, conn.State, pidProcess, enrichment)
+		} else {
+			fmt.Printf("%-6s %-22s %-22s %-12s %-10s\n",
+				conn.Protocol, localAddr, remoteAddr, conn.State, pidProcess)
+		}
 		displayed++
 	}
 
-	fmt.Println("─"*60)
+	fmt.Println(strings.Repeat("─", 60))
 	fmt.Printf("Total: %d connections displayed\n", displayed)
-	fmt.Println("═"*60)
+	fmt.Println(strings.Repeat("═", 60))
 }
 
-// MonitorTraffic continuously monitors network traffic
-func MonitorTraffic(duration time.Duration, interval time.Duration) {
+// filterEmpty returns values with empty strings removed, for joining
+// optional enrichment fields without stray separators.
+func filterEmpty(values ...string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// MonitorTraffic samples source every interval for duration, printing a
+// one-line summary each tick. When streamTo is non-empty, it also appends
+// one NDJSON connectionRecord per sampled connection to streamTo ("-" for
+// stdout), so a run can be piped straight into a log-processing pipeline.
+func MonitorTraffic(source ConnectionSource, detector *AnomalyDetector, enricher *Enricher, duration time.Duration, interval time.Duration, streamTo string) {
 	fmt.Printf("\n🔍 Monitoring network traffic for %v (updating every %v)\n", duration, interval)
 	fmt.Println("Press Ctrl+C to stop monitoring")
-	fmt.Println("─"*60)
+	fmt.Println(strings.Repeat("─", 60))
+
+	var streamWriter io.WriteCloser
+	if streamTo != "" {
+		var err error
+		streamWriter, err = newStreamWriter(streamTo)
+		if err != nil {
+			fmt.Printf("Warning: could not open stream destination %q: %v\n", streamTo, err)
+		} else {
+			defer streamWriter.Close()
+			meta := newExportMetadata(interval)
+			if err := writeNDJSONLine(streamWriter, struct {
+				Metadata exportMetadata `json:"metadata"`
+			}{meta}); err != nil {
+				fmt.Printf("Warning: could not write stream metadata: %v\n", err)
+			}
+		}
+	}
 
 	var mutex sync.Mutex
 	ticker := time.NewTicker(interval)
 	timeout := time.After(duration)
 
 	connectionHistory := make(map[string]int)
+	rateHistory := newIORateHistory(int(duration/interval) + 1)
+	prevCounters, err := SampleIOCounters(true)
+	if err != nil {
+		fmt.Printf("Warning: per-interface I/O counters unavailable: %v\n", err)
+	}
 
 	for {
 		select {
 		case <-timeout:
 			ticker.Stop()
 			fmt.Println("\n✓ Monitoring complete")
-			
+
 			// Print summary
 			fmt.Println("\nMonitoring Summary:")
 			fmt.Printf("  Duration: %v\n", duration)
 			fmt.Printf("  Unique connections observed: %d\n", len(connectionHistory))
+
+			if summary := rateHistory.summary(); len(summary) > 0 {
+				fmt.Println("  Per-interface throughput (bits/sec):")
+				for name, s := range summary {
+					fmt.Printf("    %-10s min=%.0f avg=%.0f max=%.0f\n", name, s.Min, s.Avg, s.Max)
+				}
+			}
 			return
 
 		case t := <-ticker.C:
-			connections, err := GetActiveConnections()
+			connections, err := GetActiveConnections(source)
 			if err != nil {
 				fmt.Printf("Error: %v\n", err)
 				continue
 			}
+			connections = enrichConnections(connections, enricher)
 
 			mutex.Lock()
 			for _, conn := range connections {
-				key := fmt.Sprintf("%s:%s->%s:%s", 
+				key := fmt.Sprintf("%s:%s->%s:%s",
 					conn.LocalAddr, conn.LocalPort, conn.RemoteAddr, conn.RemotePort)
 				connectionHistory[key]++
 			}
 			mutex.Unlock()
 
 			stats := CalculateStatistics(connections)
-			
+
+			var topRate ioRate
+			if prevCounters != nil {
+				if counters, err := SampleIOCounters(true); err == nil {
+					for _, rate := range diffIOCounters(prevCounters, counters, interval.Seconds()) {
+						rateHistory.add(rate)
+						if rate.BitsPerSec > topRate.BitsPerSec {
+							topRate = rate
+						}
+					}
+					prevCounters = counters
+				}
+			}
+
 			// Clear screen (simplified)
-			fmt.Printf("\n[%s] Active: %d | Established: %d | Listening: %d | Unique IPs: %d\n",
-				t.Format("15:04:05"), 
+			fmt.Printf("\n[%s] Active: %d | Established: %d | Listening: %d | Unique IPs: %d | Busiest NIC: %s (%.0f bit/s, %.0f pkt/s)\n",
+				t.Format("15:04:05"),
 				stats.ActiveConnections,
 				stats.EstablishedConns,
 				stats.ListeningPorts,
-				stats.UniqueRemoteIPs)
+				stats.UniqueRemoteIPs,
+				topRate.Name, topRate.BitsPerSec, topRate.PacketsPerSec)
+
+			for _, alert := range detector.Detect(connections, t) {
+				fmt.Println("  " + alert)
+			}
+			if enricher != nil {
+				for _, conn := range connections {
+					if rec, found := enricher.Lookup(conn.RemoteAddr); found {
+						if reason, flagged := enricher.IsSuspicious(rec); flagged {
+							fmt.Printf("  ⚠️  %s:%s -> %s: %s\n", conn.LocalAddr, conn.LocalPort, conn.RemoteAddr, reason)
+						}
+					}
+				}
+			}
+
+			if streamWriter != nil {
+				for _, conn := range connections {
+					if err := writeNDJSONLine(streamWriter, connectionRecord{
+						SchemaVersion: exportSchemaVersion,
+						Timestamp:     t,
+						Connection:    conn,
+					}); err != nil {
+						fmt.Printf("Warning: stream write failed: %v\n", err)
+						break
+					}
+				}
+			}
 		}
 	}
 }
 
-// DetectSuspiciousActivity looks for potentially suspicious connections
-func DetectSuspiciousActivity(connections []Connection) {
-	fmt.Println("\n" + "═"*60)
+// DetectSuspiciousActivity looks for potentially suspicious connections,
+// combining the checks below with detector's conntrack-rate, port-scan,
+// and SYN-flood heuristics, which need state from earlier calls to detect
+// a trend rather than a single snapshot. When enricher is non-nil, it
+// also flags connections to blocklisted ASNs or unexpected countries.
+func DetectSuspiciousActivity(detector *AnomalyDetector, connections []Connection, enricher *Enricher) {
+	fmt.Println("\n" + strings.Repeat("═", 60))
 	fmt.Println("SUSPICIOUS ACTIVITY DETECTION")
-	fmt.Println("═"*60)
+	fmt.Println(strings.Repeat("═", 60))
 
-	suspicious := []string{}
+	suspicious := detector.Detect(connections, time.Now())
+
+	if enricher != nil {
+		for _, conn := range connections {
+			rec, found := enricher.Lookup(conn.RemoteAddr)
+			if !found {
+				continue
+			}
+			if reason, flagged := enricher.IsSuspicious(rec); flagged {
+				suspicious = append(suspicious, fmt.Sprintf("⚠️  %s:%s -> %s: %s", conn.LocalAddr, conn.LocalPort, conn.RemoteAddr, reason))
+			}
+		}
+	}
 
 	// Check for unusual ports
 	for _, conn := range connections {
@@ -431,42 +490,38 @@ func DetectSuspiciousActivity(connections []Connection) {
 			fmt.Println("  " + alert)
 		}
 	}
-	fmt.Println("═"*60)
+	fmt.Println(strings.Repeat("═", 60))
 }
 
-// ExportConnections exports connections to file
-func ExportConnections(connections []Connection, filename string) error {
-	file, err := os.Create(filename)
+// ProbeNATBehavior runs an RFC 5780 NAT behavior discovery probe against
+// stunServer and prints the resulting report.
+func ProbeNATBehavior(stunServer string) *nat.NATReport {
+	fmt.Printf("\n🔎 Probing NAT behavior via %s...\n", stunServer)
+	report, err := nat.ProbeNAT(stunServer)
 	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	writer := bufio.NewWriter(file)
-	
-	// Write header
-	fmt.Fprintf(writer, "# Network Traffic Analysis Report\n")
-	fmt.Fprintf(writer, "# Generated: %s\n\n", time.Now().Format(time.RFC3339))
-	fmt.Fprintf(writer, "Protocol,LocalAddress,LocalPort,RemoteAddress,RemotePort,State,PID,Process\n")
-
-	// Write connections
-	for _, conn := range connections {
-		fmt.Fprintf(writer, "%s,%s,%s,%s,%s,%s,%s,%s\n",
-			conn.Protocol, conn.LocalAddr, conn.LocalPort,
-			conn.RemoteAddr, conn.RemotePort, conn.State,
-			conn.PID, conn.Process)
+		fmt.Printf("Error probing NAT: %v\n", err)
+		return nil
 	}
 
-	writer.Flush()
-	fmt.Printf("📄 Report exported to: %s\n", filename)
-	return nil
+	fmt.Println("\n" + strings.Repeat("═", 60))
+	fmt.Println("NAT BEHAVIOR REPORT")
+	fmt.Println(strings.Repeat("═", 60))
+	fmt.Printf("STUN Server:      %s\n", report.StunServer)
+	fmt.Printf("Public Endpoint:  %s:%d\n", report.PublicIP, report.PublicPort)
+	fmt.Printf("Mapping:          %s\n", report.Mapping)
+	fmt.Printf("Filtering:        %s\n", report.Filtering)
+	fmt.Printf("Hairpinning:      %t\n", report.Hairpinning)
+	fmt.Printf("Mapping Lifetime: >= %s\n", report.MappingLifetime)
+	fmt.Println(strings.Repeat("═", 60))
+
+	return &report
 }
 
 // PrintMenu displays interactive menu
 func PrintMenu() {
-	fmt.Println("\n" + "═"*60)
+	fmt.Println("\n" + strings.Repeat("═", 60))
 	fmt.Println("MAIN MENU")
-	fmt.Println("═"*60)
+	fmt.Println(strings.Repeat("═", 60))
 	fmt.Println("1. View All Connections")
 	fmt.Println("2. View Established Connections")
 	fmt.Println("3. View Listening Ports")
@@ -474,18 +529,44 @@ func PrintMenu() {
 	fmt.Println("5. Detect Suspicious Activity")
 	fmt.Println("6. Monitor Traffic (Real-time)")
 	fmt.Println("7. Export Report")
-	fmt.Println("8. Exit")
-	fmt.Println("═"*60)
+	fmt.Println("8. Probe NAT Behavior (STUN)")
+	fmt.Println("9. Exit")
+	fmt.Println(strings.Repeat("═", 60))
 	fmt.Print("Select option: ")
 }
 
 func main() {
+	demo := flag.Bool("demo", false, "use hardcoded sample connections instead of the live system")
+	stream := flag.String("stream", "", "in Monitor Traffic mode, append one NDJSON record per sampled connection to this path (\"-\" for stdout)")
+	exportFormat := flag.String("format", "", "export format for Export Report: csv, json, or ndjson (default: infer from file extension)")
+	geoipDB := flag.String("geoip-db", os.Getenv("GEOIP_DB_PATH"), "path to a MaxMind-format .mmdb database for country/ASN enrichment (env GEOIP_DB_PATH)")
+	asnBlocklist := flag.String("asn-blocklist", "", "path to a newline-delimited file of blocklisted ASNs (e.g. AS12345)")
+	expectedCountries := flag.String("expected-countries", "", "comma-separated ISO country codes; connections from elsewhere are flagged (requires -geoip-db)")
+	geoipTTL := flag.Duration("geoip-cache-ttl", 10*time.Minute, "how long to cache a GeoIP lookup for a given remote address")
+	flag.Parse()
+
 	PrintBanner()
 
 	fmt.Println("\n📡 Network Traffic Analyzer")
 	fmt.Println("Monitor and analyze network connections on your system\n")
 
+	source := newConnectionSource(*demo)
+	detector := NewAnomalyDetector(DefaultAnomalyThresholds())
 	reader := bufio.NewReader(os.Stdin)
+	var lastNATReport *nat.NATReport
+
+	var enricher *Enricher
+	if *geoipDB != "" {
+		var countries []string
+		if *expectedCountries != "" {
+			countries = strings.Split(*expectedCountries, ",")
+		}
+		var err error
+		enricher, err = NewEnricher(*geoipDB, *asnBlocklist, countries, *geoipTTL)
+		if err != nil {
+			fmt.Printf("Warning: GeoIP enrichment disabled: %v\n", err)
+		}
+	}
 
 	for {
 		PrintMenu()
@@ -498,24 +579,25 @@ func main() {
 			continue
 		}
 
-		connections, err := GetActiveConnections()
+		connections, err := GetActiveConnections(source)
 		if err != nil {
 			fmt.Printf("Error getting connections: %v\n", err)
 			continue
 		}
+		connections = enrichConnections(connections, enricher)
 
 		switch choice {
 		case 1:
-			PrintConnections(connections, "")
+			PrintConnections(connections, "", enricher != nil)
 		case 2:
-			PrintConnections(connections, "established")
+			PrintConnections(connections, "established", enricher != nil)
 		case 3:
-			PrintConnections(connections, "listening")
+			PrintConnections(connections, "listening", enricher != nil)
 		case 4:
 			stats := CalculateStatistics(connections)
 			PrintStatistics(stats)
 		case 5:
-			DetectSuspiciousActivity(connections)
+			DetectSuspiciousActivity(detector, connections, enricher)
 		case 6:
 			fmt.Print("\nMonitor duration (seconds) [60]: ")
 			durationStr, _ := reader.ReadString('\n')
@@ -524,18 +606,34 @@ func main() {
 			if durationStr != "" {
 				fmt.Sscanf(durationStr, "%d", &duration)
 			}
-			MonitorTraffic(time.Duration(duration)*time.Second, 2*time.Second)
+			MonitorTraffic(source, detector, enricher, time.Duration(duration)*time.Second, 2*time.Second, *stream)
 		case 7:
-			filename := fmt.Sprintf("network_analysis_%s.csv", time.Now().Format("20060102_150405"))
-			err := ExportConnections(connections, filename)
-			if err != nil {
+			ext := "csv"
+			if f := resolveFormat("", *exportFormat); f != FormatCSV {
+				ext = string(f)
+			}
+			stamp := time.Now().Format("20060102_150405")
+			filename := fmt.Sprintf("network_analysis_%s.%s", stamp, ext)
+			if err := ExportConnections(connections, lastNATReport, filename, *exportFormat); err != nil {
 				fmt.Printf("Error exporting: %v\n", err)
 			}
+			statsFilename := fmt.Sprintf("network_stats_%s.%s", stamp, ext)
+			if err := ExportStats(CalculateStatistics(connections), statsFilename, *exportFormat); err != nil {
+				fmt.Printf("Error exporting stats: %v\n", err)
+			}
 		case 8:
+			fmt.Print("\nSTUN server [stun.l.google.com:19302]: ")
+			stunServer, _ := reader.ReadString('\n')
+			stunServer = strings.TrimSpace(stunServer)
+			if stunServer == "" {
+				stunServer = "stun.l.google.com:19302"
+			}
+			lastNATReport = ProbeNATBehavior(stunServer)
+		case 9:
 			fmt.Println("\nThank you for using Network Traffic Analyzer! 🌐")
 			os.Exit(0)
 		default:
-			fmt.Println("Invalid option. Please select 1-8.")
+			fmt.Println("Invalid option. Please select 1-9.")
 		}
 
 		fmt.Print("\nPress Enter to continue...")
@@ -3,7 +3,6 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"math"
 	"os"
 	"regexp"
 	"strings"
@@ -23,6 +22,8 @@ type PasswordAnalysis struct {
 	Score              int
 	CrackTimeSeconds   float64
 	CrackTimeReadable  string
+	CrackTimes         CrackTimes
+	Matches            []Match
 	IsCommon           bool
 	ContainsDictionary bool
 	Patterns           []string
@@ -58,8 +59,13 @@ func AnalyzePassword(password string) PasswordAnalysis {
 	analysis.HasDigits = containsDigits(password)
 	analysis.HasSpecialChars = containsSpecialChars(password)
 
-	// Calculate entropy
-	analysis.EntropyBits = calculateEntropy(password)
+	// Estimate entropy via a zxcvbn-style minimum-entropy-cover of pattern
+	// matches (dictionary, l33t, spatial, repeat, sequence, date), falling
+	// back to per-character bruteforce bits for anything left uncovered.
+	entropy, matches, crackTimes := EstimatePasswordEntropy(password, nil)
+	analysis.EntropyBits = entropy
+	analysis.Matches = matches
+	analysis.CrackTimes = crackTimes
 
 	// Check for common passwords
 	analysis.IsCommon = isCommonPassword(password)
@@ -76,8 +82,8 @@ func AnalyzePassword(password string) PasswordAnalysis {
 	// Determine strength level
 	analysis.Strength = determineStrength(analysis.Score)
 
-	// Estimate crack time
-	analysis.CrackTimeSeconds = estimateCrackTime(analysis.EntropyBits)
+	// Estimate crack time (offline-fast rate, for backwards-compatible fields)
+	analysis.CrackTimeSeconds = crackTimes.OfflineFast
 	analysis.CrackTimeReadable = formatCrackTime(analysis.CrackTimeSeconds)
 
 	// Generate suggestions
@@ -127,36 +133,6 @@ func containsSpecialChars(s string) bool {
 	return false
 }
 
-// calculateEntropy calculates Shannon entropy of the password
-func calculateEntropy(password string) float64 {
-	if len(password) == 0 {
-		return 0
-	}
-
-	// Count character types
-	charsetSize := 0
-	if containsLowercase(password) {
-		charsetSize += 26
-	}
-	if containsUppercase(password) {
-		charsetSize += 26
-	}
-	if containsDigits(password) {
-		charsetSize += 10
-	}
-	if containsSpecialChars(password) {
-		charsetSize += 32
-	}
-
-	if charsetSize == 0 {
-		return 0
-	}
-
-	// Entropy = log2(charset^length)
-	entropy := float64(len(password)) * math.Log2(float64(charsetSize))
-	return entropy
-}
-
 // isCommonPassword checks if password is in common passwords list
 func isCommonPassword(password string) bool {
 	lowerPassword := strings.ToLower(password)
@@ -283,15 +259,6 @@ func determineStrength(score int) string {
 	return "Very Weak"
 }
 
-// estimateCrackTime estimates time to crack password (in seconds)
-func estimateCrackTime(entropyBits float64) float64 {
-	// Assume 1 billion guesses per second (modern hardware)
-	guessesPerSecond := 1e9
-	totalCombinations := math.Pow(2, entropyBits)
-	// Average case: half of all combinations
-	return totalCombinations / (2 * guessesPerSecond)
-}
-
 // formatCrackTime converts seconds to readable format
 func formatCrackTime(seconds float64) string {
 	if seconds < 1 {
@@ -373,14 +340,14 @@ func PrintAnalysis(analysis PasswordAnalysis) {
 	}
 	resetColor := "\033[0m"
 
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Println("PASSWORD ANALYSIS REPORT")
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 
 	// Mask password for display
 	maskedPassword := strings.Repeat("*", len(analysis.Password))
 	fmt.Printf("Password: %s (Length: %d)\n", maskedPassword, analysis.Length)
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	// Character composition
 	fmt.Println("Character Composition:")
@@ -388,7 +355,7 @@ func PrintAnalysis(analysis PasswordAnalysis) {
 	fmt.Printf("  Uppercase Letters: %s\n", boolToStatus(analysis.HasUppercase))
 	fmt.Printf("  Digits: %s\n", boolToStatus(analysis.HasDigits))
 	fmt.Printf("  Special Characters: %s\n", boolToStatus(analysis.HasSpecialChars))
-	fmt.Println("─"*50)
+	fmt.Println(strings.Repeat("─", 50))
 
 	// Strength metrics
 	fmt.Println("Strength Metrics:")
@@ -396,7 +363,12 @@ func PrintAnalysis(analysis PasswordAnalysis) {
 	fmt.Printf("  Score: %d/100\n", analysis.Score)
 	fmt.Printf("  Strength: %s%s%s\n", strengthColor, analysis.Strength, resetColor)
 	fmt.Printf("  Estimated Crack Time: %s\n", analysis.CrackTimeReadable)
-	fmt.Println("─"*50)
+	fmt.Println("  Crack Time by Attacker Profile:")
+	fmt.Printf("    Online (throttled, 10/s):  %s\n", formatCrackTime(analysis.CrackTimes.OnlineThrottled))
+	fmt.Printf("    Online (unthrottled, 100/s): %s\n", formatCrackTime(analysis.CrackTimes.Online))
+	fmt.Printf("    Offline (slow hash, 1e4/s): %s\n", formatCrackTime(analysis.CrackTimes.OfflineSlow))
+	fmt.Printf("    Offline (fast hash, 1e10/s): %s\n", formatCrackTime(analysis.CrackTimes.OfflineFast))
+	fmt.Println(strings.Repeat("─", 50))
 
 	// Warnings
 	if analysis.IsCommon {
@@ -412,7 +384,7 @@ func PrintAnalysis(analysis PasswordAnalysis) {
 		}
 	}
 	if len(analysis.Patterns) > 0 || analysis.IsCommon || analysis.ContainsDictionary {
-		fmt.Println("─"*50)
+		fmt.Println(strings.Repeat("─", 50))
 	}
 
 	// Suggestions
@@ -421,7 +393,7 @@ func PrintAnalysis(analysis PasswordAnalysis) {
 		for i, suggestion := range analysis.Suggestions {
 			fmt.Printf("  %d. %s\n", i+1, suggestion)
 		}
-		fmt.Println("─"*50)
+		fmt.Println(strings.Repeat("─", 50))
 	}
 
 	// Progress bar
@@ -435,7 +407,7 @@ func PrintAnalysis(analysis PasswordAnalysis) {
 		}
 	}
 	fmt.Printf("] %d%%\n", analysis.Score)
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 }
 
 // boolToStatus converts boolean to readable status
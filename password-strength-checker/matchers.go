@@ -0,0 +1,503 @@
+package main
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MatchType identifies which matcher produced a Match.
+type MatchType string
+
+const (
+	MatchDictionary MatchType = "dictionary"
+	MatchReversed   MatchType = "reversed-dictionary"
+	MatchL33t       MatchType = "l33t"
+	MatchSpatial    MatchType = "spatial"
+	MatchRepeat     MatchType = "repeat"
+	MatchSequence   MatchType = "sequence"
+	MatchDate       MatchType = "date"
+	MatchBruteforce MatchType = "bruteforce"
+)
+
+// Match describes a single substring match found by a Matcher, along with
+// the estimated entropy (in bits) an attacker needs to guess that token.
+type Match struct {
+	Type    MatchType
+	Start   int
+	End     int // exclusive
+	Token   string
+	Entropy float64
+}
+
+// Matcher scans a password and returns every match it can find, including
+// overlapping ones - the minimum-entropy DP decides which matches to use.
+type Matcher interface {
+	Find(password string) []Match
+}
+
+// rockyouFrequency is a small embedded stand-in for a rockyou-style
+// frequency-ranked word list. Rank 1 is the most common (weakest) word.
+var rockyouFrequency = buildRank([]string{
+	"password", "123456", "12345678", "qwerty", "abc123", "monkey",
+	"letmein", "trustno1", "dragon", "baseball", "iloveyou", "master",
+	"sunshine", "shadow", "123123", "superman", "qazwsx", "michael",
+	"football", "welcome", "admin", "login", "princess", "solo",
+	"starwars", "freedom", "whatever", "ninja", "azerty", "batman",
+})
+
+func buildRank(words []string) map[string]int {
+	m := make(map[string]int, len(words))
+	for i, w := range words {
+		m[w] = i + 1 // rank is 1-indexed, rank 1 is the weakest/most common
+	}
+	return m
+}
+
+// l33tTable maps a substituted character back to the letters it stands in for.
+var l33tTable = map[rune][]rune{
+	'@': {'a'}, '4': {'a'}, '3': {'e'}, '1': {'i', 'l'}, '!': {'i'},
+	'0': {'o'}, '$': {'s'}, '5': {'s'}, '7': {'t'}, '+': {'t'},
+}
+
+// dictionaryMatcher finds embedded dictionary words (case-insensitive).
+type dictionaryMatcher struct {
+	words map[string]int
+}
+
+func (d dictionaryMatcher) Find(password string) []Match {
+	lower := strings.ToLower(password)
+	var matches []Match
+	for word, rank := range d.words {
+		start := 0
+		for {
+			idx := strings.Index(lower[start:], word)
+			if idx == -1 {
+				break
+			}
+			s := start + idx
+			e := s + len(word)
+			matches = append(matches, Match{
+				Type:    MatchDictionary,
+				Start:   s,
+				End:     e,
+				Token:   password[s:e],
+				Entropy: dictionaryEntropy(password[s:e], rank),
+			})
+			start = s + 1
+		}
+	}
+	return matches
+}
+
+// dictionaryEntropy is rank bits plus bits for the uppercase mask.
+func dictionaryEntropy(token string, rank int) float64 {
+	return math.Log2(float64(rank)) + uppercaseEntropy(token)
+}
+
+// uppercaseEntropy charges 0 bits for all-lowercase, 1 bit when only the
+// first or last letter is capitalized, and log2(C(L,U)+C(L,u)) otherwise.
+func uppercaseEntropy(token string) float64 {
+	var upper, lower int
+	for _, r := range token {
+		if r >= 'A' && r <= 'Z' {
+			upper++
+		} else if r >= 'a' && r <= 'z' {
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 0
+	}
+	if upper == 1 && (startsUpper(token) || endsUpper(token)) {
+		return 1
+	}
+	L := upper + lower
+	return math.Log2(choose(L, upper) + choose(L, lower))
+}
+
+func startsUpper(s string) bool { return len(s) > 0 && s[0] >= 'A' && s[0] <= 'Z' }
+func endsUpper(s string) bool {
+	return len(s) > 0 && s[len(s)-1] >= 'A' && s[len(s)-1] <= 'Z'
+}
+
+func choose(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 0; i < k; i++ {
+		result *= float64(n-i) / float64(i+1)
+	}
+	return result
+}
+
+// reversedDictionaryMatcher re-uses the dictionary matcher against the
+// reversed password, so "drowssap" is caught as a reversed "password".
+type reversedDictionaryMatcher struct {
+	inner dictionaryMatcher
+}
+
+func (r reversedDictionaryMatcher) Find(password string) []Match {
+	reversed := reverseString(password)
+	n := len(password)
+	var matches []Match
+	for _, m := range r.inner.Find(reversed) {
+		s := n - m.End
+		e := n - m.Start
+		matches = append(matches, Match{
+			Type:    MatchReversed,
+			Start:   s,
+			End:     e,
+			Token:   password[s:e],
+			Entropy: m.Entropy + 1, // one extra bit for "is it reversed"
+		})
+	}
+	return matches
+}
+
+func reverseString(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+// l33tMatcher undoes common leet substitutions and re-checks the dictionary.
+type l33tMatcher struct {
+	inner dictionaryMatcher
+}
+
+func (l l33tMatcher) Find(password string) []Match {
+	substituted, variants := unleet(password)
+	if variants == 0 {
+		return nil
+	}
+	var matches []Match
+	for _, m := range l.inner.Find(substituted) {
+		matches = append(matches, Match{
+			Type:    MatchL33t,
+			Start:   m.Start,
+			End:     m.End,
+			Token:   password[m.Start:m.End],
+			Entropy: m.Entropy + math.Log2(float64(variants)),
+		})
+	}
+	return matches
+}
+
+// unleet returns the password with leet characters mapped back to letters,
+// plus a count of how many distinct substitutions were actually used.
+func unleet(password string) (string, int) {
+	used := map[rune]bool{}
+	out := make([]rune, 0, len(password))
+	for _, r := range password {
+		if subs, ok := l33tTable[r]; ok {
+			out = append(out, subs[0])
+			used[r] = true
+		} else {
+			out = append(out, r)
+		}
+	}
+	return string(out), len(used)
+}
+
+// keyboardAdjacency is a simplified QWERTY adjacency graph: each key maps to
+// its neighbours, used to score spatial (keyboard-walk) matches.
+var keyboardAdjacency = buildQwertyAdjacency()
+
+func buildQwertyAdjacency() map[rune][]rune {
+	rows := []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+	adj := map[rune][]rune{}
+	for r, row := range rows {
+		for i, c := range row {
+			var neighbors []rune
+			if i > 0 {
+				neighbors = append(neighbors, rune(row[i-1]))
+			}
+			if i < len(row)-1 {
+				neighbors = append(neighbors, rune(row[i+1]))
+			}
+			if r > 0 {
+				prev := rows[r-1]
+				if i < len(prev) {
+					neighbors = append(neighbors, rune(prev[i]))
+				}
+			}
+			if r < len(rows)-1 {
+				next := rows[r+1]
+				if i < len(next) {
+					neighbors = append(neighbors, rune(next[i]))
+				}
+			}
+			adj[c] = neighbors
+		}
+	}
+	return adj
+}
+
+// spatialMatcher finds runs of adjacent keyboard keys, e.g. "qwerty", "asdf".
+type spatialMatcher struct{}
+
+func (spatialMatcher) Find(password string) []Match {
+	lower := strings.ToLower(password)
+	var matches []Match
+	i := 0
+	for i < len(lower) {
+		j := i + 1
+		turns := 0
+		for j < len(lower) && isAdjacent(lower[j-1], lower[j]) {
+			turns++
+			j++
+		}
+		if j-i >= 4 { // require a run of at least 4 keys to count as spatial
+			token := password[i:j]
+			matches = append(matches, Match{
+				Type:    MatchSpatial,
+				Start:   i,
+				End:     j,
+				Token:   token,
+				Entropy: spatialEntropy(len(token), turns),
+			})
+		}
+		i = j
+	}
+	return matches
+}
+
+func isAdjacent(a, b byte) bool {
+	for _, n := range keyboardAdjacency[rune(a)] {
+		if n == rune(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// spatialEntropy approximates zxcvbn's starting-position * average-degree *
+// length term plus a bit budget for the turn sequence.
+func spatialEntropy(length, turns int) float64 {
+	const startingPositions = 26
+	const avgDegree = 4.0
+	base := math.Log2(startingPositions * avgDegree * float64(length))
+	return base + math.Log2(float64(turns+1))
+}
+
+// repeatMatcher finds runs like "aaaa" or "abcabcabc" via (.+)\1+.
+type repeatMatcher struct{ re *regexp.Regexp }
+
+func newRepeatMatcher() repeatMatcher {
+	return repeatMatcher{re: regexp.MustCompile(`(.+?)\1+`)}
+}
+
+func (r repeatMatcher) Find(password string) []Match {
+	var matches []Match
+	locs := r.re.FindAllStringSubmatchIndex(password, -1)
+	for _, loc := range locs {
+		start, end := loc[0], loc[1]
+		baseStart, baseEnd := loc[2], loc[3]
+		base := password[baseStart:baseEnd]
+		repeats := (end - start) / len(base)
+		matches = append(matches, Match{
+			Type:    MatchRepeat,
+			Start:   start,
+			End:     end,
+			Token:   password[start:end],
+			Entropy: math.Log2(float64(len(base))*float64(26)) + math.Log2(float64(repeats)),
+		})
+	}
+	return matches
+}
+
+// sequenceMatcher finds ascending/descending runs such as "abcd" or "9876".
+type sequenceMatcher struct{}
+
+func (sequenceMatcher) Find(password string) []Match {
+	var matches []Match
+	n := len(password)
+	i := 0
+	for i < n-1 {
+		delta := int(password[i+1]) - int(password[i])
+		if delta != 1 && delta != -1 {
+			i++
+			continue
+		}
+		j := i + 1
+		for j < n-1 && int(password[j+1])-int(password[j]) == delta {
+			j++
+		}
+		if j-i+1 >= 3 {
+			token := password[i : j+1]
+			matches = append(matches, Match{
+				Type:    MatchSequence,
+				Start:   i,
+				End:     j + 1,
+				Token:   token,
+				Entropy: sequenceEntropy(token),
+			})
+		}
+		i = j + 1
+	}
+	return matches
+}
+
+func sequenceEntropy(token string) float64 {
+	// log2(charset options for first char) + log2(2 for ascending/descending) + log2(length)
+	charset := 26.0
+	if token[0] >= '0' && token[0] <= '9' {
+		charset = 10.0
+	}
+	return math.Log2(charset) + 1 + math.Log2(float64(len(token)))
+}
+
+// dateMatcher extracts day/month/year triples like "12/25/1999" or "19991225".
+type dateMatcher struct{ re *regexp.Regexp }
+
+func newDateMatcher() dateMatcher {
+	return dateMatcher{re: regexp.MustCompile(`\b(\d{1,2})[-/.](\d{1,2})[-/.](\d{2,4})\b|\b(19|20)\d{6}\b`)}
+}
+
+func (d dateMatcher) Find(password string) []Match {
+	var matches []Match
+	for _, loc := range d.re.FindAllStringIndex(password, -1) {
+		start, end := loc[0], loc[1]
+		matches = append(matches, Match{
+			Type:    MatchDate,
+			Start:   start,
+			End:     end,
+			Token:   password[start:end],
+			Entropy: dateEntropy(),
+		})
+	}
+	return matches
+}
+
+// dateEntropy: log2(days-in-range * months * years-considered).
+func dateEntropy() float64 {
+	const numYears = 100
+	return math.Log2(31*12*numYears) + 2 // +2 bits for guessing separator/order
+}
+
+// allMatchers returns the full matcher pipeline used by EstimatePasswordEntropy.
+func allMatchers(extraWords []string) []Matcher {
+	words := make(map[string]int, len(rockyouFrequency)+len(extraWords))
+	for w, r := range rockyouFrequency {
+		words[w] = r
+	}
+	for i, w := range extraWords {
+		words[strings.ToLower(w)] = len(rockyouFrequency) + i + 1
+	}
+	dict := dictionaryMatcher{words: words}
+	return []Matcher{
+		dict,
+		reversedDictionaryMatcher{inner: dict},
+		l33tMatcher{inner: dict},
+		spatialMatcher{},
+		newRepeatMatcher(),
+		sequenceMatcher{},
+		newDateMatcher(),
+	}
+}
+
+// CrackTimes reports expected seconds-to-crack at several attacker rates.
+type CrackTimes struct {
+	OnlineThrottled float64 // 10 guesses/sec (rate-limited online attack)
+	Online          float64 // 100 guesses/sec (unthrottled online attack)
+	OfflineSlow     float64 // 1e4 guesses/sec (slow hash, e.g. bcrypt)
+	OfflineFast     float64 // 1e10 guesses/sec (fast hash on GPU cluster)
+}
+
+func crackTimesFromEntropy(bits float64) CrackTimes {
+	guesses := math.Pow(2, bits) / 2 // average case: half of the keyspace
+	return CrackTimes{
+		OnlineThrottled: guesses / 10,
+		Online:          guesses / 100,
+		OfflineSlow:     guesses / 1e4,
+		OfflineFast:     guesses / 1e10,
+	}
+}
+
+// EstimatePasswordEntropy runs every matcher over password, then finds the
+// minimum-entropy cover via dynamic programming: for each position k,
+// minEntropy[k] is the cheapest way to explain password[0:k] using either a
+// match ending at k or a single bruteforce character. log2(k) is added for
+// each match used to account for the attacker having to guess where one
+// match ends and the next begins; bruteforce characters add only their own
+// charset bits.
+func EstimatePasswordEntropy(password string, extraWords []string) (float64, []Match, CrackTimes) {
+	n := len(password)
+	if n == 0 {
+		return 0, nil, CrackTimes{}
+	}
+
+	var allMatches []Match
+	for _, m := range allMatchers(extraWords) {
+		allMatches = append(allMatches, m.Find(password)...)
+	}
+
+	// matchesEndingAt[k] holds every match whose End == k.
+	matchesEndingAt := make(map[int][]Match, n)
+	for _, m := range allMatches {
+		matchesEndingAt[m.End] = append(matchesEndingAt[m.End], m)
+	}
+
+	minEntropy := make([]float64, n+1)
+	backtrack := make([]*Match, n+1)
+	minEntropy[0] = 0
+	for k := 1; k <= n; k++ {
+		// Bruteforce fallback: treat password[k-1] as an unmatched character.
+		best := minEntropy[k-1] + bruteforceCharEntropy(password[k-1])
+		var bestMatch *Match
+		for i := range matchesEndingAt[k] {
+			m := matchesEndingAt[k][i]
+			candidate := minEntropy[m.Start] + m.Entropy + math.Log2(float64(k))
+			if candidate < best {
+				best = candidate
+				bestMatch = &m
+			}
+		}
+		minEntropy[k] = best
+		backtrack[k] = bestMatch
+	}
+
+	cover := reconstructCover(password, backtrack)
+	return minEntropy[n], cover, crackTimesFromEntropy(minEntropy[n])
+}
+
+// bruteforceCharEntropy charges bits for one character based on the widest
+// charset it could plausibly belong to.
+func bruteforceCharEntropy(c byte) float64 {
+	switch {
+	case c >= '0' && c <= '9':
+		return math.Log2(10)
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return math.Log2(52)
+	default:
+		return math.Log2(33)
+	}
+}
+
+func reconstructCover(password string, backtrack []*Match) []Match {
+	var cover []Match
+	k := len(password)
+	for k > 0 {
+		if backtrack[k] == nil {
+			cover = append(cover, Match{
+				Type:    MatchBruteforce,
+				Start:   k - 1,
+				End:     k,
+				Token:   password[k-1 : k],
+				Entropy: bruteforceCharEntropy(password[k-1]),
+			})
+			k--
+			continue
+		}
+		m := *backtrack[k]
+		cover = append(cover, m)
+		k = m.Start
+	}
+	sort.Slice(cover, func(i, j int) bool { return cover[i].Start < cover[j].Start })
+	return cover
+}
@@ -0,0 +1,24 @@
+// Package bigint provides a small math/big.Int convenience wrapper shared by
+// the rsa and ecc packages so they don't each re-derive the same modular
+// arithmetic helpers.
+package bigint
+
+import "math/big"
+
+// Add returns a+b.
+func Add(a, b *big.Int) *big.Int { return new(big.Int).Add(a, b) }
+
+// Sub returns a-b.
+func Sub(a, b *big.Int) *big.Int { return new(big.Int).Sub(a, b) }
+
+// Mul returns a*b.
+func Mul(a, b *big.Int) *big.Int { return new(big.Int).Mul(a, b) }
+
+// Mod returns a mod m, always non-negative (unlike big.Int.Rem).
+func Mod(a, m *big.Int) *big.Int { return new(big.Int).Mod(a, m) }
+
+// ModInverse returns a^-1 mod m, or nil if a has no inverse mod m.
+func ModInverse(a, m *big.Int) *big.Int { return new(big.Int).ModInverse(a, m) }
+
+// Exp returns a^e mod m.
+func Exp(a, e, m *big.Int) *big.Int { return new(big.Int).Exp(a, e, m) }
@@ -0,0 +1,203 @@
+// Package bruteforce attempts default/weak credentials against services
+// NetworkScanner already found open, rather than just flagging the port as
+// a "potential brute force target" in a string. Each protocol gets its own
+// CredentialChecker; Run fans a username x password wordlist out across a
+// bounded worker pool per host, rate-limited so a real engagement doesn't
+// trip an account lockout policy, and stops probing a given host:port as
+// soon as one pair succeeds.
+package bruteforce
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// CredentialChecker tries one username/password pair against host:port and
+// reports whether it authenticated. A non-nil error means the attempt
+// itself failed (connection refused, protocol error, timeout) rather than
+// the credentials being rejected - those are reported as (false, nil).
+type CredentialChecker interface {
+	Check(host string, port int, user, pass string) (bool, error)
+}
+
+// CredentialFinding is one confirmed weak credential, in the shape
+// PrintResults and the report package render.
+type CredentialFinding struct {
+	Host     string
+	Port     int
+	Service  string
+	Username string
+	Password string
+}
+
+// checkers maps a service name, matching the strings NetworkScanner's
+// ServiceDatabase already uses (e.g. "SSH", "MySQL"), to the checker that
+// knows its wire protocol. Registering a new service module here is the
+// only thing a new plugin needs to do to take part in Run.
+var checkers = map[string]CredentialChecker{
+	"SSH":           sshChecker{},
+	"FTP":           ftpChecker{},
+	"Telnet":        telnetChecker{},
+	"MySQL":         mysqlChecker{},
+	"MSSQL":         mssqlChecker{},
+	"PostgreSQL":    postgresChecker{},
+	"Redis":         redisChecker{},
+	"MongoDB":       mongoChecker{},
+	"Elasticsearch": elasticsearchChecker{},
+	"SMB":           smbChecker{},
+	"RDP":           rdpChecker{},
+	"VNC":           vncChecker{},
+}
+
+// RegisterChecker installs or overrides the CredentialChecker used for
+// service, so a caller can add a module for a service this package doesn't
+// know about without touching bruteforce or the scanner core.
+func RegisterChecker(service string, checker CredentialChecker) {
+	checkers[service] = checker
+}
+
+// Target is one open service Run should try credentials against.
+type Target struct {
+	Host    string
+	Port    int
+	Service string
+}
+
+// Options configures a Run: the wordlists to try and how aggressively to
+// try them.
+type Options struct {
+	Usernames      []string
+	Passwords      []string
+	MaxConcurrency int           // worker pool size, typically NetworkScanner.MaxConcurrency
+	RateLimit      time.Duration // minimum delay between attempts against the same host
+}
+
+// DefaultOptions returns sane defaults: the built-in wordlists, 10 workers,
+// and a conservative per-host delay.
+func DefaultOptions() Options {
+	return Options{
+		Usernames:      DefaultUsernames,
+		Passwords:      DefaultPasswords,
+		MaxConcurrency: 10,
+		RateLimit:      250 * time.Millisecond,
+	}
+}
+
+// attempt is one username/password pair queued against one target.
+type attempt struct {
+	target Target
+	user   string
+	pass   string
+}
+
+// Run tries opts.Usernames x opts.Passwords against every target whose
+// Service has a registered checker, skipping unregistered services
+// entirely. It returns as soon as possible per target: once a pair
+// succeeds for a given host:port, no further pairs are tried against it.
+func Run(targets []Target, opts Options) []CredentialFinding {
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = 10
+	}
+
+	var queue []attempt
+	for _, t := range targets {
+		if _, ok := checkers[t.Service]; !ok {
+			continue
+		}
+		for _, user := range opts.Usernames {
+			for _, pass := range opts.Passwords {
+				queue = append(queue, attempt{target: t, user: user, pass: pass})
+			}
+		}
+	}
+	if len(queue) == 0 {
+		return nil
+	}
+
+	limiter := newHostRateLimiter(opts.RateLimit)
+
+	var solved sync.Map // Target -> bool, set once a target yields a finding
+	var findings []CredentialFinding
+	var mu sync.Mutex
+
+	jobs := make(chan attempt, opts.MaxConcurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.MaxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range jobs {
+				if _, done := solved.Load(a.target); done {
+					continue
+				}
+
+				limiter.wait(a.target.Host)
+				ok, err := checkers[a.target.Service].Check(a.target.Host, a.target.Port, a.user, a.pass)
+				if err != nil || !ok {
+					continue
+				}
+
+				if _, already := solved.LoadOrStore(a.target, true); already {
+					continue
+				}
+				mu.Lock()
+				findings = append(findings, CredentialFinding{
+					Host: a.target.Host, Port: a.target.Port, Service: a.target.Service,
+					Username: a.user, Password: a.pass,
+				})
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, a := range queue {
+		if _, done := solved.Load(a.target); done {
+			continue
+		}
+		jobs <- a
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Port != findings[j].Port {
+			return findings[i].Port < findings[j].Port
+		}
+		return findings[i].Username < findings[j].Username
+	})
+	return findings
+}
+
+// hostRateLimiter makes Run wait at least delay between attempts against
+// any single host, regardless of which worker goroutine or target port is
+// making the attempt, so a weak-credential sweep doesn't itself look like
+// (or trigger) a lockout-inducing flood.
+type hostRateLimiter struct {
+	delay time.Duration
+	mu    sync.Mutex
+	last  map[string]time.Time
+}
+
+func newHostRateLimiter(delay time.Duration) *hostRateLimiter {
+	return &hostRateLimiter{delay: delay, last: make(map[string]time.Time)}
+}
+
+func (l *hostRateLimiter) wait(host string) {
+	if l.delay <= 0 {
+		return
+	}
+	l.mu.Lock()
+	now := time.Now()
+	next := l.last[host]
+	if next.Before(now) {
+		next = now
+	}
+	next = next.Add(l.delay)
+	l.last[host] = next
+	l.mu.Unlock()
+
+	if d := time.Until(next); d > 0 {
+		time.Sleep(d)
+	}
+}
@@ -0,0 +1,30 @@
+package bruteforce
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// elasticsearchChecker just issues a GET / with HTTP Basic auth, since
+// that's all X-Pack/security-enabled Elasticsearch needs to tell a valid
+// credential (200) from an invalid one (401).
+type elasticsearchChecker struct{}
+
+func (elasticsearchChecker) Check(host string, port int, user, pass string) (bool, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s:%d/", host, port), nil)
+	if err != nil {
+		return false, err
+	}
+	req.SetBasicAuth(user, pass)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode == http.StatusOK, nil
+}
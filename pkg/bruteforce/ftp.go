@@ -0,0 +1,83 @@
+package bruteforce
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ftpChecker speaks plain RFC 959: read the welcome banner, send USER,
+// then PASS, and look at the reply code. 230 is "logged in"; 530 is
+// "not logged in" (bad credentials); anything else is treated as a
+// rejection too, since a server that doesn't follow the reply-code
+// convention isn't one we can safely call "authenticated".
+type ftpChecker struct{}
+
+func (ftpChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	r := bufio.NewReader(conn)
+	if _, err := readFTPReply(r); err != nil { // welcome banner
+		return false, err
+	}
+
+	if err := writeFTPLine(conn, "USER "+user); err != nil {
+		return false, err
+	}
+	code, err := readFTPReply(r)
+	if err != nil {
+		return false, err
+	}
+	if code == 230 { // some daemons accept on USER alone for a passwordless account
+		return true, nil
+	}
+
+	if err := writeFTPLine(conn, "PASS "+pass); err != nil {
+		return false, err
+	}
+	code, err = readFTPReply(r)
+	if err != nil {
+		return false, err
+	}
+	return code == 230, nil
+}
+
+func writeFTPLine(conn net.Conn, line string) error {
+	_, err := conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+// readFTPReply reads one FTP control-channel reply, following multi-line
+// replies ("214-...") through to their terminating "214 " line, and
+// returns the three-digit reply code.
+func readFTPReply(r *bufio.Reader) (int, error) {
+	var code int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if len(line) < 4 {
+			continue
+		}
+		parsed, err := strconv.Atoi(line[:3])
+		if err != nil {
+			continue
+		}
+		code = parsed
+		if line[3] == ' ' {
+			return code, nil
+		}
+		// line[3] == '-': multi-line reply, keep reading until the
+		// matching "code " terminator.
+	}
+}
@@ -0,0 +1,213 @@
+package bruteforce
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"time"
+)
+
+// mongoChecker speaks the legacy MONGODB-CR mechanism (OP_QUERY against
+// $cmd, removed in MongoDB 4.0 in favor of SCRAM): getnonce, then an
+// authenticate command keyed off that nonce. Default installs with auth
+// enabled and an old wire-protocol version still accept it, which is the
+// weak-credential case this checker targets.
+type mongoChecker struct{}
+
+const (
+	mongoOpQuery = 2004
+	mongoOpReply = 1
+)
+
+func (mongoChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	nonceReply, err := mongoCommand(conn, "admin.$cmd", bsonDoc{{"getnonce", int32(1)}})
+	if err != nil {
+		return false, err
+	}
+	nonce, _ := nonceReply.getString("nonce")
+	if nonce == "" {
+		return false, fmt.Errorf("mongo: no nonce in getnonce reply")
+	}
+
+	digest := md5.Sum([]byte(user + ":mongo:" + pass))
+	key := md5.Sum([]byte(nonce + user + hex.EncodeToString(digest[:])))
+
+	authReply, err := mongoCommand(conn, "admin.$cmd", bsonDoc{
+		{"authenticate", int32(1)},
+		{"user", user},
+		{"nonce", nonce},
+		{"key", hex.EncodeToString(key[:])},
+	})
+	if err != nil {
+		return false, err
+	}
+	ok, _ := authReply.getNumber("ok")
+	return ok == 1, nil
+}
+
+// mongoCommand sends a single-document OP_QUERY against collection
+// (typically "<db>.$cmd") and returns the first document of the OP_REPLY.
+func mongoCommand(conn net.Conn, collection string, query bsonDoc) (bsonDoc, error) {
+	body := make([]byte, 0, 64)
+	body = appendUint32(body, 0) // flags
+	body = append(body, []byte(collection)...)
+	body = append(body, 0x00)
+	body = appendUint32(body, 0)          // numberToSkip
+	body = appendUint32(body, ^uint32(0)) // numberToReturn: -1, close cursor after one reply
+	body = append(body, query.encode()...)
+
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(16+len(body)))
+	binary.LittleEndian.PutUint32(header[4:8], 1) // requestID
+	binary.LittleEndian.PutUint32(header[8:12], 0)
+	binary.LittleEndian.PutUint32(header[12:16], mongoOpQuery)
+
+	if _, err := conn.Write(append(header, body...)); err != nil {
+		return nil, err
+	}
+
+	var respHeader [16]byte
+	if _, err := readFull(conn, respHeader[:]); err != nil {
+		return nil, err
+	}
+	length := binary.LittleEndian.Uint32(respHeader[0:4])
+	if length < 16 {
+		return nil, fmt.Errorf("mongo: short reply")
+	}
+	rest := make([]byte, length-16)
+	if _, err := readFull(conn, rest); err != nil {
+		return nil, err
+	}
+	// OP_REPLY fixed fields: responseFlags, cursorID(8), startingFrom,
+	// numberReturned, then the documents.
+	if len(rest) < 20 {
+		return nil, fmt.Errorf("mongo: truncated OP_REPLY")
+	}
+	return bsonDecode(rest[20:])
+}
+
+// bsonPair/bsonDoc is a minimal, order-preserving BSON document: just
+// enough of the format (double, string, int32, boolean) to build the
+// getnonce/authenticate commands and read their ok/nonce/errmsg replies.
+type bsonPair struct {
+	key string
+	val interface{}
+}
+type bsonDoc []bsonPair
+
+func (d bsonDoc) encode() []byte {
+	var elems []byte
+	for _, p := range d {
+		switch v := p.val.(type) {
+		case int32:
+			elems = append(elems, 0x10)
+			elems = append(elems, []byte(p.key)...)
+			elems = append(elems, 0x00)
+			elems = appendUint32(elems, uint32(v))
+		case string:
+			elems = append(elems, 0x02)
+			elems = append(elems, []byte(p.key)...)
+			elems = append(elems, 0x00)
+			strBytes := append([]byte(v), 0x00)
+			elems = appendUint32(elems, uint32(len(strBytes)))
+			elems = append(elems, strBytes...)
+		}
+	}
+	out := make([]byte, 4)
+	out = append(out, elems...)
+	out = append(out, 0x00)
+	binary.LittleEndian.PutUint32(out[0:4], uint32(len(out)))
+	return out
+}
+
+func bsonDecode(b []byte) (bsonDoc, error) {
+	if len(b) < 5 {
+		return nil, fmt.Errorf("bson: truncated document")
+	}
+	var doc bsonDoc
+	i := 4 // skip document length
+	for i < len(b) && b[i] != 0x00 {
+		elemType := b[i]
+		i++
+		start := i
+		for i < len(b) && b[i] != 0x00 {
+			i++
+		}
+		name := string(b[start:i])
+		i++ // skip NUL
+
+		switch elemType {
+		case 0x01: // double
+			if i+8 > len(b) {
+				return doc, fmt.Errorf("bson: truncated double")
+			}
+			bits := binary.LittleEndian.Uint64(b[i : i+8])
+			doc = append(doc, bsonPair{name, math.Float64frombits(bits)})
+			i += 8
+		case 0x02: // string
+			if i+4 > len(b) {
+				return doc, fmt.Errorf("bson: truncated string")
+			}
+			slen := int(binary.LittleEndian.Uint32(b[i : i+4]))
+			i += 4
+			if i+slen > len(b) {
+				return doc, fmt.Errorf("bson: truncated string body")
+			}
+			doc = append(doc, bsonPair{name, string(b[i : i+slen-1])})
+			i += slen
+		case 0x10: // int32
+			if i+4 > len(b) {
+				return doc, fmt.Errorf("bson: truncated int32")
+			}
+			doc = append(doc, bsonPair{name, int32(binary.LittleEndian.Uint32(b[i : i+4]))})
+			i += 4
+		case 0x08: // boolean
+			if i >= len(b) {
+				return doc, fmt.Errorf("bson: truncated bool")
+			}
+			doc = append(doc, bsonPair{name, b[i] != 0})
+			i++
+		default:
+			// Unhandled element type (e.g. embedded document, ObjectId) -
+			// this checker only ever needs ok/nonce/errmsg, so stop rather
+			// than mis-parse the rest.
+			return doc, nil
+		}
+	}
+	return doc, nil
+}
+
+func (d bsonDoc) getString(key string) (string, bool) {
+	for _, p := range d {
+		if p.key == key {
+			s, ok := p.val.(string)
+			return s, ok
+		}
+	}
+	return "", false
+}
+
+func (d bsonDoc) getNumber(key string) (float64, bool) {
+	for _, p := range d {
+		if p.key != key {
+			continue
+		}
+		switch v := p.val.(type) {
+		case float64:
+			return v, true
+		case int32:
+			return float64(v), true
+		}
+	}
+	return 0, false
+}
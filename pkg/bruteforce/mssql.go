@@ -0,0 +1,172 @@
+package bruteforce
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// mssqlChecker speaks enough TDS (Tabular Data Stream) to run SQL Server
+// Authentication: a PRELOGIN exchange, then a LOGIN7 packet carrying the
+// username/password. TDS "encrypts" the password with a fixed
+// nibble-swap-and-XOR obfuscation rather than real cryptography, so it can
+// be reproduced without a TLS/encryption layer. A LOGINACK response (token
+// 0xAD) means the credentials were accepted; an ERROR token (0xAA) means
+// they weren't.
+type mssqlChecker struct{}
+
+const (
+	tdsPacketPreLogin = 0x12
+	tdsPacketLogin7   = 0x10
+	tdsPacketTabular  = 0x04 // server response packet type
+	tdsStatusEOM      = 0x01 // end of message
+)
+
+func (mssqlChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if err := writeTDSPacket(conn, tdsPacketPreLogin, tdsPreLoginBody()); err != nil {
+		return false, err
+	}
+	if _, err := readTDSPacket(conn); err != nil {
+		return false, err
+	}
+
+	if err := writeTDSPacket(conn, tdsPacketLogin7, tdsLogin7Body(user, pass)); err != nil {
+		return false, err
+	}
+	resp, err := readTDSPacket(conn)
+	if err != nil {
+		return false, err
+	}
+
+	for i := 0; i < len(resp); {
+		token := resp[i]
+		i++
+		switch token {
+		case 0xAD: // LOGINACK
+			return true, nil
+		case 0xAA: // ERROR
+			return false, nil
+		default:
+			if i+2 > len(resp) {
+				return false, nil
+			}
+			length := int(binary.LittleEndian.Uint16(resp[i : i+2]))
+			i += 2 + length
+		}
+	}
+	return false, nil
+}
+
+// tdsPreLoginBody builds a minimal PRELOGIN packet: one VERSION option
+// (required) immediately followed by the TERMINATOR, advertising no
+// encryption support since this checker never sets up TLS.
+func tdsPreLoginBody() []byte {
+	const optionCount = 1 // VERSION only
+	headerLen := optionCount*5 + 1
+	options := []byte{0x00, byte(headerLen >> 8), byte(headerLen), 0x00, 0x06} // token, offset(hi,lo), length(hi,lo)
+	options = append(options, 0xFF)                                            // terminator
+	payload := []byte{0x09, 0x00, 0x00, 0x00, 0x00, 0x00}                      // version 9.0.0.0, subbuild 0
+	return append(options, payload...)
+}
+
+// tdsLogin7Body builds a LOGIN7 packet with just the fields needed to
+// authenticate: hostname/username/password/appname/servername, each an
+// offset+length pair into the trailing UTF-16LE variable-data block.
+func tdsLogin7Body(user, pass string) []byte {
+	const fixedLen = 94
+	hostname := utf16le("GO-SCANNER")
+	username := utf16le(user)
+	password := tdsObfuscatePassword(pass)
+	appname := utf16le("bruteforce")
+	servername := utf16le("")
+
+	var varData []byte
+	offset := fixedLen
+	field := func(b []byte) (uint16, uint16) {
+		o := uint16(offset)
+		l := uint16(len(b) / 2)
+		varData = append(varData, b...)
+		offset += len(b)
+		return o, l
+	}
+	hOff, hLen := field(hostname)
+	uOff, uLen := field(username)
+	pOff, pLen := field(password)
+	aOff, aLen := field(appname)
+	sOff, sLen := field(servername)
+
+	body := make([]byte, fixedLen)
+	binary.LittleEndian.PutUint32(body[4:8], 0x74000004) // TDS version 7.4
+	binary.LittleEndian.PutUint32(body[8:12], 4096)      // packet size
+	binary.LittleEndian.PutUint32(body[12:16], 0x00000001)
+	body[39] = 0x00 // OptionFlags1
+	body[40] = 0x00 // OptionFlags2
+	putLoginField(body, 48, hOff, hLen)
+	putLoginField(body, 52, uOff, uLen)
+	putLoginField(body, 56, pOff, pLen)
+	putLoginField(body, 60, aOff, aLen)
+	putLoginField(body, 64, sOff, sLen)
+
+	full := append(body, varData...)
+	binary.LittleEndian.PutUint32(full[0:4], uint32(len(full)))
+	return full
+}
+
+func putLoginField(body []byte, at int, offset, length uint16) {
+	binary.LittleEndian.PutUint16(body[at:at+2], offset)
+	binary.LittleEndian.PutUint16(body[at+2:at+4], length)
+}
+
+func utf16le(s string) []byte {
+	out := make([]byte, 0, len(s)*2)
+	for _, r := range s {
+		out = append(out, byte(r), byte(r>>8))
+	}
+	return out
+}
+
+// tdsObfuscatePassword applies TDS's LOGIN7 password obfuscation: swap
+// each byte's nibbles, then XOR with 0xA5. It's not encryption, just
+// enough to keep the password out of a casual packet dump.
+func tdsObfuscatePassword(pass string) []byte {
+	raw := utf16le(pass)
+	out := make([]byte, len(raw))
+	for i, b := range raw {
+		swapped := (b << 4) | (b >> 4)
+		out[i] = swapped ^ 0xA5
+	}
+	return out
+}
+
+func writeTDSPacket(conn net.Conn, packetType byte, body []byte) error {
+	total := len(body) + 8
+	header := []byte{packetType, tdsStatusEOM, byte(total >> 8), byte(total), 0x00, 0x00, 0x00, 0x00}
+	_, err := conn.Write(append(header, body...))
+	return err
+}
+
+func readTDSPacket(conn net.Conn) ([]byte, error) {
+	var header [8]byte
+	if _, err := readFull(conn, header[:]); err != nil {
+		return nil, err
+	}
+	length := int(binary.BigEndian.Uint16(header[2:4]))
+	if length < 8 {
+		return nil, fmt.Errorf("tds: short packet")
+	}
+	body := make([]byte, length-8)
+	if len(body) > 0 {
+		if _, err := readFull(conn, body); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
@@ -0,0 +1,161 @@
+package bruteforce
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"net"
+	"time"
+)
+
+// mysqlChecker speaks just enough of the MySQL client/server protocol to
+// authenticate: read the server's initial handshake (protocol 10), scramble
+// the password with mysql_native_password, and send a Client Authentication
+// Packet. A server replying with an OK packet (0x00) accepted the
+// credentials; an ERR packet (0xff) rejected them.
+type mysqlChecker struct{}
+
+const (
+	mysqlClientProtocol41 = 0x00000200
+	mysqlClientSecureConn = 0x00008000
+	mysqlClientPluginAuth = 0x00080000
+)
+
+func (mysqlChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	handshake, _, err := readMySQLPacket(conn)
+	if err != nil {
+		return false, err
+	}
+	if len(handshake) == 0 || handshake[0] != 10 {
+		return false, fmt.Errorf("mysql: unsupported protocol version")
+	}
+	authSeed, err := parseMySQLHandshakeSeed(handshake)
+	if err != nil {
+		return false, err
+	}
+
+	scramble := mysqlScramblePassword([]byte(pass), authSeed)
+
+	capabilities := uint32(mysqlClientProtocol41 | mysqlClientSecureConn | mysqlClientPluginAuth)
+	body := make([]byte, 0, 64)
+	body = appendUint32(body, capabilities)
+	body = appendUint32(body, 1<<24-1) // max packet size
+	body = append(body, 0x21)          // charset: utf8_general_ci
+	body = append(body, make([]byte, 23)...)
+	body = append(body, []byte(user)...)
+	body = append(body, 0x00)
+	body = append(body, byte(len(scramble)))
+	body = append(body, scramble...)
+	body = append(body, []byte("mysql_native_password")...)
+	body = append(body, 0x00)
+
+	if err := writeMySQLPacket(conn, body, 1); err != nil {
+		return false, err
+	}
+
+	resp, _, err := readMySQLPacket(conn)
+	if err != nil {
+		return false, err
+	}
+	if len(resp) == 0 {
+		return false, nil
+	}
+	return resp[0] == 0x00, nil
+}
+
+// parseMySQLHandshakeSeed reassembles the 20-byte auth-plugin-data seed
+// the server split across two fields of its handshake packet (the first 8
+// bytes inline, the remaining 12 after a block of fixed fields plugin
+// negotiation added in 4.1+).
+func parseMySQLHandshakeSeed(h []byte) ([]byte, error) {
+	i := 1
+	for i < len(h) && h[i] != 0 { // server version, NUL-terminated
+		i++
+	}
+	i++ // skip NUL
+	if i+4+8+1+2 > len(h) {
+		return nil, fmt.Errorf("mysql: truncated handshake")
+	}
+	i += 4 // connection id
+	seed := append([]byte{}, h[i:i+8]...)
+	i += 8 + 1 // seed part 1 + filler
+	i += 2     // capability flags (lower 2 bytes)
+	if i >= len(h) {
+		return seed, nil
+	}
+	i += 1 + 2 + 2 + 1 + 10 // charset, status, capability upper, auth-data len, reserved
+	if i+12 <= len(h) {
+		seed = append(seed, h[i:i+12]...)
+	}
+	return seed, nil
+}
+
+// mysqlScramblePassword implements mysql_native_password:
+// SHA1(password) XOR SHA1(seed + SHA1(SHA1(password))).
+func mysqlScramblePassword(password, seed []byte) []byte {
+	if len(password) == 0 {
+		return nil
+	}
+	stage1 := sha1.Sum(password)
+	stage2 := sha1.Sum(stage1[:])
+
+	h := sha1.New()
+	h.Write(seed)
+	h.Write(stage2[:])
+	stage3 := h.Sum(nil)
+
+	out := make([]byte, len(stage1))
+	for i := range out {
+		out[i] = stage1[i] ^ stage3[i]
+	}
+	return out
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// readMySQLPacket reads one packet from the MySQL protocol's 4-byte
+// length-prefixed, sequence-numbered framing and returns its payload.
+func readMySQLPacket(conn net.Conn) ([]byte, byte, error) {
+	var head [4]byte
+	if _, err := readFull(conn, head[:]); err != nil {
+		return nil, 0, err
+	}
+	length := int(head[0]) | int(head[1])<<8 | int(head[2])<<16
+	seq := head[3]
+	payload := make([]byte, length)
+	if length > 0 {
+		if _, err := readFull(conn, payload); err != nil {
+			return nil, 0, err
+		}
+	}
+	return payload, seq, nil
+}
+
+func writeMySQLPacket(conn net.Conn, payload []byte, seq byte) error {
+	head := []byte{byte(len(payload)), byte(len(payload) >> 8), byte(len(payload) >> 16), seq}
+	if _, err := conn.Write(head); err != nil {
+		return err
+	}
+	_, err := conn.Write(payload)
+	return err
+}
+
+func readFull(conn net.Conn, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := conn.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
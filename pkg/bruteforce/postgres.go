@@ -0,0 +1,109 @@
+package bruteforce
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"time"
+)
+
+// postgresChecker sends a real StartupMessage and answers whatever
+// AuthenticationRequest comes back (trust, cleartext password, or MD5
+// password - the three a default postgresql.conf / pg_hba.conf combo can
+// produce), then looks for ReadyForQuery ('Z') vs ErrorResponse ('E').
+type postgresChecker struct{}
+
+func (postgresChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if err := writePGStartup(conn, user); err != nil {
+		return false, err
+	}
+
+	for {
+		msgType, body, err := readPGMessage(conn)
+		if err != nil {
+			return false, err
+		}
+		switch msgType {
+		case 'R':
+			authType := binary.BigEndian.Uint32(body[:4])
+			switch authType {
+			case 0: // AuthenticationOk with no password required
+				continue
+			case 3: // cleartext password
+				if err := writePGPasswordMessage(conn, []byte(pass)); err != nil {
+					return false, err
+				}
+			case 5: // MD5 password
+				salt := body[4:8]
+				hashed := pgMD5Password(user, pass, salt)
+				if err := writePGPasswordMessage(conn, []byte(hashed)); err != nil {
+					return false, err
+				}
+			default:
+				return false, fmt.Errorf("postgres: unsupported auth method %d", authType)
+			}
+		case 'E':
+			return false, nil
+		case 'Z':
+			return true, nil
+		}
+	}
+}
+
+// pgMD5Password implements PostgreSQL's MD5 auth:
+// "md5" + md5(md5(password + username) + salt), hex-encoded.
+func pgMD5Password(user, pass string, salt []byte) string {
+	inner := md5.Sum([]byte(pass + user))
+	outer := md5.Sum(append([]byte(hex.EncodeToString(inner[:])), salt...))
+	return "md5" + hex.EncodeToString(outer[:])
+}
+
+func writePGStartup(conn net.Conn, user string) error {
+	params := []byte{}
+	params = append(params, "user\x00"+user+"\x00"...)
+	params = append(params, "database\x00"+user+"\x00"...)
+	params = append(params, 0x00)
+
+	msg := make([]byte, 4)
+	binary.BigEndian.PutUint32(msg, 196608) // protocol version 3.0
+	msg = append(msg, params...)
+
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(msg)+4))
+	_, err := conn.Write(append(length, msg...))
+	return err
+}
+
+func writePGPasswordMessage(conn net.Conn, password []byte) error {
+	body := append(append([]byte{}, password...), 0x00)
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(body)+4))
+	_, err := conn.Write(append([]byte{'p'}, append(length, body...)...))
+	return err
+}
+
+// readPGMessage reads one backend message: a 1-byte type tag, a 4-byte
+// big-endian length (including itself), and the remaining body.
+func readPGMessage(conn net.Conn) (byte, []byte, error) {
+	var head [5]byte
+	if _, err := readFull(conn, head[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(head[1:5])
+	body := make([]byte, length-4)
+	if len(body) > 0 {
+		if _, err := readFull(conn, body); err != nil {
+			return 0, nil, err
+		}
+	}
+	return head[0], body, nil
+}
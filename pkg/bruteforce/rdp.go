@@ -0,0 +1,101 @@
+package bruteforce
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// rdpChecker performs the X.224 Connection Request/Confirm RDP starts
+// every session with, and inspects whether the server demands Network
+// Level Authentication (CredSSP) before the RDP protocol itself begins.
+// It cannot go further than that: actually testing a credential over RDP
+// requires a full CredSSP/SPNEGO/NTLM (or Kerberos) exchange, which is
+// out of scope for a lightweight weak-credential sweep. If the server
+// requires NLA, this checker reports ErrNLARequired rather than guessing;
+// if it allows the legacy (non-NLA) security layer, any credential "looks"
+// acceptable at this stage since Windows defers the actual logon prompt
+// to the session itself, so this checker can only confirm the *service*
+// is reachable and not NLA-gated - it never reports a password as valid.
+type rdpChecker struct{}
+
+// ErrNLARequired is returned when the target enforces Network Level
+// Authentication, which this checker doesn't implement.
+var ErrNLARequired = errors.New("rdp: target requires Network Level Authentication (CredSSP), not checked")
+
+func (rdpChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write(rdpX224ConnectionRequest()); err != nil {
+		return false, err
+	}
+
+	resp := make([]byte, 4096)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return false, err
+	}
+	resp = resp[:n]
+
+	if rdpRequiresNLA(resp) {
+		return false, ErrNLARequired
+	}
+
+	// No NLA gate, but without implementing the legacy RDP Security
+	// Commencement handshake and GCC Conference Create this checker has
+	// no way to actually submit user/pass, so it honestly reports "not
+	// verified" rather than a false positive.
+	return false, fmt.Errorf("rdp: legacy security layer detected, credential verification not implemented")
+}
+
+// rdpX224ConnectionRequest builds a TPKT-framed X.224 Connection Request
+// carrying an RDP Negotiation Request PDU that advertises support for
+// every protocol (standard RDP security, TLS, and CredSSP/NLA) so the
+// server's reply reveals which one it insists on.
+func rdpX224ConnectionRequest() []byte {
+	negReq := []byte{
+		0x01,       // type: RDP_NEG_REQ
+		0x00,       // flags
+		0x08, 0x00, // length: 8
+		0x03, 0x00, 0x00, 0x00, // requestedProtocols: PROTOCOL_SSL | PROTOCOL_HYBRID
+	}
+
+	x224 := []byte{
+		0x00,       // length indicator (patched below)
+		0xE0,       // CR (Connection Request) + CDT
+		0x00, 0x00, // dst-ref
+		0x00, 0x00, // src-ref
+		0x00, // class/options
+	}
+	x224 = append(x224, negReq...)
+	x224[0] = byte(len(x224) - 1)
+
+	tpkt := []byte{0x03, 0x00, 0x00, 0x00} // version, reserved, length (patched below)
+	tpkt = append(tpkt, x224...)
+	length := len(tpkt)
+	tpkt[2] = byte(length >> 8)
+	tpkt[3] = byte(length)
+	return tpkt
+}
+
+// rdpRequiresNLA inspects an RDP Negotiation Response/Failure PDU (after
+// the 4-byte TPKT header and 7-byte fixed X.224 CC fields) for
+// RDP_NEG_FAILURE with failureCode SSL_WITH_USER_AUTH_REQUIRED (0x00000005),
+// the signal that NLA is mandatory.
+func rdpRequiresNLA(resp []byte) bool {
+	if len(resp) < 19 {
+		return false
+	}
+	negType := resp[11]
+	if negType != 0x03 { // RDP_NEG_FAILURE
+		return false
+	}
+	failureCode := uint32(resp[15]) | uint32(resp[16])<<8 | uint32(resp[17])<<16 | uint32(resp[18])<<24
+	return failureCode == 0x00000005
+}
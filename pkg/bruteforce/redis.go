@@ -0,0 +1,56 @@
+package bruteforce
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// redisChecker sends AUTH as a RESP array and checks for a "+OK" simple
+// string reply. Redis has no concept of usernames pre-ACL (Redis < 6), so
+// user is ignored here; ACL-based AUTH <user> <pass> would need a second
+// attempt this checker doesn't make, consistent with the common-weak-
+// password checks this tool targets rather than full ACL enumeration.
+type redisChecker struct{}
+
+func (redisChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	cmd := encodeRESPArray("AUTH", pass)
+	if _, err := conn.Write(cmd); err != nil {
+		return false, err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+	reply = strings.TrimRight(reply, "\r\n")
+
+	if strings.HasPrefix(reply, "+OK") {
+		return true, nil
+	}
+	if strings.HasPrefix(reply, "-ERR Client sent AUTH, but no password is set") {
+		// No password configured; an empty AUTH attempt "succeeds" in the
+		// sense that the server has no credential to guess.
+		return pass == "", nil
+	}
+	return false, nil
+}
+
+// encodeRESPArray builds a RESP (REdis Serialization Protocol) array of
+// bulk strings, the wire format every Redis command uses.
+func encodeRESPArray(args ...string) []byte {
+	out := fmt.Sprintf("*%d\r\n", len(args))
+	for _, a := range args {
+		out += fmt.Sprintf("$%d\r\n%s\r\n", len(a), a)
+	}
+	return []byte(out)
+}
@@ -0,0 +1,119 @@
+package bruteforce
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// smbChecker runs a classic (non-extended-security) SMB1
+// SMB_COM_SESSION_SETUP_ANDX with the candidate username and a plaintext
+// OEM password, the same handshake NetworkScanner's null-session MS17-010
+// probe uses minus the empty credentials. Plaintext passwords only work
+// against servers that still allow LanMan/plaintext auth (LmCompatibility
+// 0, or "Send LM & NTLM" with plaintext allowed); that's a deliberately
+// narrow, legacy-focused check rather than a full NTLMSSP implementation.
+type smbChecker struct{}
+
+func (smbChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write(smbNegotiateRequest()); err != nil {
+		return false, err
+	}
+	if _, err := readSMBPacket(conn); err != nil {
+		return false, err
+	}
+
+	if _, err := conn.Write(smbPlaintextSessionSetupRequest(user, pass)); err != nil {
+		return false, err
+	}
+	resp, err := readSMBPacket(conn)
+	if err != nil {
+		return false, err
+	}
+
+	return smbStatus(resp) == 0, nil // STATUS_SUCCESS
+}
+
+func smbWrapNetBIOS(msg []byte) []byte {
+	out := make([]byte, 4+len(msg))
+	out[1] = byte(len(msg) >> 16)
+	out[2] = byte(len(msg) >> 8)
+	out[3] = byte(len(msg))
+	copy(out[4:], msg)
+	return out
+}
+
+func readSMBPacket(conn net.Conn) ([]byte, error) {
+	var nb [4]byte
+	if _, err := io.ReadFull(conn, nb[:]); err != nil {
+		return nil, err
+	}
+	length := int(nb[1])<<16 | int(nb[2])<<8 | int(nb[3])
+	msg := make([]byte, length)
+	if _, err := io.ReadFull(conn, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func smbHeader(command byte) []byte {
+	h := make([]byte, 32)
+	copy(h[0:4], []byte{0xFF, 'S', 'M', 'B'})
+	h[4] = command
+	binary.LittleEndian.PutUint16(h[10:12], 0x4000) // Flags2: NT status codes
+	return h
+}
+
+func smbNegotiateRequest() []byte {
+	body := smbHeader(0x72) // SMB_COM_NEGOTIATE
+	body = append(body, 0x00)
+	dialect := append([]byte{0x02}, []byte("NT LM 0.12\x00")...)
+	body = append(body, byte(len(dialect)), byte(len(dialect)>>8))
+	body = append(body, dialect...)
+	return smbWrapNetBIOS(body)
+}
+
+// smbPlaintextSessionSetupRequest builds SMB_COM_SESSION_SETUP_ANDX with
+// user/pass sent as an OEM-encoded plaintext password (no LM/NTLM hashing)
+// in the AccountPassword field.
+func smbPlaintextSessionSetupRequest(user, pass string) []byte {
+	body := smbHeader(0x73) // SMB_COM_SESSION_SETUP_ANDX
+
+	passBytes := append([]byte(pass), 0x00)
+
+	words := make([]byte, 26)                                           // 13 words
+	words[0] = 0xFF                                                     // AndXCommand: none
+	binary.LittleEndian.PutUint16(words[4:6], 4356)                     // MaxBufferSize
+	binary.LittleEndian.PutUint16(words[6:8], 2)                        // MaxMpxCount
+	binary.LittleEndian.PutUint16(words[8:10], 1)                       // VcNumber
+	binary.LittleEndian.PutUint16(words[14:16], uint16(len(passBytes))) // OEMPasswordLen
+
+	body = append(body, 13)
+	body = append(body, words...)
+
+	bytesField := append([]byte{}, passBytes...)
+	bytesField = append(bytesField, []byte(user+"\x00")...) // AccountName
+	bytesField = append(bytesField, []byte("\x00")...)      // PrimaryDomain
+	bytesField = append(bytesField, []byte("Go\x00")...)    // NativeOS
+	bytesField = append(bytesField, []byte("Go\x00")...)    // NativeLanMan
+	body = append(body, byte(len(bytesField)), byte(len(bytesField)>>8))
+	body = append(body, bytesField...)
+
+	return smbWrapNetBIOS(body)
+}
+
+func smbStatus(resp []byte) uint32 {
+	if len(resp) < 9 {
+		return 0xFFFFFFFF
+	}
+	return binary.LittleEndian.Uint32(resp[5:9])
+}
@@ -0,0 +1,89 @@
+package bruteforce
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// telnetChecker doesn't negotiate any Telnet options (IAC DO/WILL/etc.) -
+// it just reads whatever the server sends, strips IAC sequences, and
+// pattern-matches the login/password prompts most telnetd
+// implementations use. There's no reply-code protocol to lean on like
+// FTP, so success is judged by whether a shell-ish prompt shows up
+// afterwards rather than another "Password:"/"incorrect" prompt.
+type telnetChecker struct{}
+
+func (telnetChecker) Check(host string, port int, user, pass string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("%s:%d", host, port), 5*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	r := bufio.NewReader(conn)
+
+	if !waitForTelnetPrompt(conn, r, "login:") {
+		return false, nil
+	}
+	if _, err := conn.Write([]byte(user + "\r\n")); err != nil {
+		return false, err
+	}
+
+	if !waitForTelnetPrompt(conn, r, "password:") {
+		return false, nil
+	}
+	if _, err := conn.Write([]byte(pass + "\r\n")); err != nil {
+		return false, err
+	}
+
+	after := readTelnetText(conn, r, 2*time.Second)
+	lower := strings.ToLower(after)
+	if strings.Contains(lower, "login:") || strings.Contains(lower, "password:") ||
+		strings.Contains(lower, "incorrect") || strings.Contains(lower, "denied") || after == "" {
+		return false, nil
+	}
+	return true, nil
+}
+
+// waitForTelnetPrompt reads until want (case-insensitive) appears in the
+// stream or the read deadline trips.
+func waitForTelnetPrompt(conn net.Conn, r *bufio.Reader, want string) bool {
+	text := readTelnetText(conn, r, 5*time.Second)
+	return strings.Contains(strings.ToLower(text), want)
+}
+
+// readTelnetText drains whatever the server has sent within budget,
+// stripping IAC (0xFF) negotiation sequences (always exactly 3 bytes for
+// the DO/DONT/WILL/WONT options telnetd uses during login) since we never
+// negotiate anything ourselves and just want the human-readable text.
+func readTelnetText(conn net.Conn, r *bufio.Reader, budget time.Duration) string {
+	conn.SetReadDeadline(time.Now().Add(budget))
+	var out []byte
+	buf := make([]byte, 256)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			out = append(out, stripTelnetIAC(buf[:n])...)
+		}
+		if err != nil {
+			break
+		}
+	}
+	return string(out)
+}
+
+func stripTelnetIAC(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		if b[i] == 0xFF && i+2 < len(b) {
+			i += 2
+			continue
+		}
+		out = append(out, b[i])
+	}
+	return out
+}
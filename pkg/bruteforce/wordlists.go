@@ -0,0 +1,45 @@
+package bruteforce
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultUsernames and DefaultPasswords are the built-in wordlists used
+// when -userlist/-passlist aren't given: the usual default-credential set
+// shipped with most service daemons plus the handful of passwords that
+// show up in nearly every credential-stuffing list.
+var DefaultUsernames = []string{
+	"root", "admin", "administrator", "user", "guest", "test", "sa", "postgres",
+}
+
+var DefaultPasswords = []string{
+	"", "password", "admin", "root", "123456", "12345678", "qwerty",
+	"letmein", "changeme", "password123", "admin123", "guest",
+}
+
+// LoadWordlist reads one entry per line from path, skipping blank lines
+// and "#"-prefixed comments, for the -userlist/-passlist flags.
+func LoadWordlist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading wordlist %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("loading wordlist %s: %w", path, err)
+	}
+	return words, nil
+}
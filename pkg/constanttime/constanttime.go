@@ -0,0 +1,49 @@
+// Package constanttime provides comparison and selection primitives whose
+// running time depends only on the length of their inputs, never their
+// contents - for code paths (MAC verification, padding checks) where a
+// data-dependent branch or early return leaks information about a secret
+// through timing.
+package constanttime
+
+import "crypto/subtle"
+
+// Equal reports whether a and b hold the same bytes, in time that depends
+// only on len(a) and len(b), never on where the first difference is. This
+// is a thin, self-documenting wrapper around subtle.ConstantTimeCompare -
+// call sites comparing a MAC, token, or password hash should use this (or
+// hmac.Equal, which already does the same thing) instead of bytes.Equal or
+// a manual byte-by-byte loop with an early return.
+func Equal(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// SelectBytes returns a copy of a if v == 1, or a copy of b if v == 0,
+// without branching on v. a and b must be the same length. Useful for
+// picking between a real and decoy value (e.g. a derived key vs random
+// filler) without leaking the selection through a conditional jump.
+func SelectBytes(v int, a, b []byte) []byte {
+	if len(a) != len(b) {
+		panic("constanttime: SelectBytes requires equal-length slices")
+	}
+	out := make([]byte, len(a))
+	for i := range out {
+		out[i] = byte(subtle.ConstantTimeSelect(v, int(a[i]), int(b[i])))
+	}
+	return out
+}
+
+// LessOrEqBytes reports whether a <= b, treating both as big-endian
+// unsigned integers of equal length, in time that depends only on their
+// shared length. a and b must be the same length.
+func LessOrEqBytes(a, b []byte) bool {
+	if len(a) != len(b) {
+		panic("constanttime: LessOrEqBytes requires equal-length slices")
+	}
+	gt, eq := 0, 1
+	for i := range a {
+		leq := subtle.ConstantTimeLessOrEq(int(a[i]), int(b[i]))
+		gt |= eq & (1 - leq)
+		eq &= subtle.ConstantTimeByteEq(a[i], b[i])
+	}
+	return gt == 0
+}
@@ -0,0 +1,62 @@
+package constanttime
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	if !Equal([]byte("hunter2"), []byte("hunter2")) {
+		t.Fatal("expected equal byte slices to compare equal")
+	}
+	if Equal([]byte("hunter2"), []byte("hunter3")) {
+		t.Fatal("expected differing byte slices to compare unequal")
+	}
+	if Equal([]byte("short"), []byte("longer-string")) {
+		t.Fatal("expected different-length slices to compare unequal")
+	}
+}
+
+func TestSelectBytes(t *testing.T) {
+	a := []byte{1, 2, 3}
+	b := []byte{4, 5, 6}
+
+	if got := SelectBytes(1, a, b); string(got) != string(a) {
+		t.Fatalf("SelectBytes(1, ...) = %v, want %v", got, a)
+	}
+	if got := SelectBytes(0, a, b); string(got) != string(b) {
+		t.Fatalf("SelectBytes(0, ...) = %v, want %v", got, b)
+	}
+}
+
+func TestSelectBytesPanicsOnLengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for mismatched lengths")
+		}
+	}()
+	SelectBytes(1, []byte{1, 2}, []byte{1})
+}
+
+func TestLessOrEqBytes(t *testing.T) {
+	cases := []struct {
+		a, b []byte
+		want bool
+	}{
+		{[]byte{0x00, 0x01}, []byte{0x00, 0x02}, true},
+		{[]byte{0x00, 0x02}, []byte{0x00, 0x02}, true},
+		{[]byte{0x01, 0x00}, []byte{0x00, 0xFF}, false},
+		{[]byte{0x00, 0x00}, []byte{0xFF, 0xFF}, true},
+	}
+	for _, c := range cases {
+		if got := LessOrEqBytes(c.a, c.b); got != c.want {
+			t.Errorf("LessOrEqBytes(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestLessOrEqBytesPanicsOnLengthMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for mismatched lengths")
+		}
+	}()
+	LessOrEqBytes([]byte{1, 2}, []byte{1})
+}
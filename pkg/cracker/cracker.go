@@ -0,0 +1,247 @@
+// Package cracker is a GPU-farm-shaped (but CPU-only) brute-force engine:
+// mask attacks and rule-mangled dictionary attacks, both split across a
+// worker pool, with live attempts/sec and ETA reporting and cooperative
+// cancellation via context.Context.
+//
+// Each worker owns one hash.Hash instance and drives it with
+// Reset/Write/Sum on every attempt, so the engine never allocates a new
+// hasher - or, for mask attacks, a new candidate string - per guess. The
+// HMAC variants in hmac.go are the exception: the candidate is the MAC key,
+// so they construct a fresh MAC for every attempt.
+package cracker
+
+import (
+	"bytes"
+	"context"
+	"hash"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// flushInterval controls how many attempts a worker batches locally
+// before adding them to the shared attempts counter and checking ctx -
+// the tradeoff is contention (too low) against stale progress/slow
+// cancellation response (too high). Must be (power of two)-1 to use as a
+// bitmask.
+const flushInterval = 1<<14 - 1
+
+// NewHash constructs a fresh hash.Hash for the target algorithm; it has
+// the same signature as md5.New, sha1.New, sha256.New, and sha512.New, so
+// any of those can be passed directly.
+type NewHash func() hash.Hash
+
+// Result reports the outcome of a Crack* call.
+type Result struct {
+	Success  bool
+	Password string
+	Attempts uint64
+	Elapsed  time.Duration
+}
+
+// Stats is a progress snapshot delivered to Options.Progress.
+type Stats struct {
+	Attempts uint64
+	Keyspace uint64 // 0 if unknown (e.g. a dictionary attack)
+	Elapsed  time.Duration
+	Rate     float64 // attempts/sec
+	ETA      time.Duration
+	KnownETA bool
+}
+
+// Options configures a Crack* run. The zero value is usable: it picks
+// runtime.NumCPU() workers and reports no progress.
+type Options struct {
+	// Workers is the number of goroutines splitting the keyspace. <= 0
+	// means runtime.NumCPU().
+	Workers int
+	// Progress, if set, is called periodically (every ProgressInterval,
+	// or every second if that's <= 0) from a single reporting goroutine.
+	Progress         func(Stats)
+	ProgressInterval time.Duration
+}
+
+func (o Options) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.NumCPU()
+}
+
+func (o Options) progressInterval() time.Duration {
+	if o.ProgressInterval > 0 {
+		return o.ProgressInterval
+	}
+	return time.Second
+}
+
+// CrackMask tries every candidate a parsed mask describes against target,
+// splitting the keyspace across Options.Workers goroutines by the index
+// of the first character: worker w only ever tries leading characters
+// charsets[0][w], charsets[0][w+workers], ... and exhausts the remaining
+// positions in full for each one via an odometer counter.
+func CrackMask(ctx context.Context, target []byte, newHash NewHash, charsets []string, opts Options) (Result, error) {
+	start := time.Now()
+	workers := opts.workers()
+	keyspace := Keyspace(charsets)
+
+	var attempts uint64
+	found := make(chan string, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			h := newHash()
+			buf := make([]byte, len(charsets))
+			od := newOdometer(charsets)
+
+			// local batches attempts between atomic flushes, since
+			// incrementing a shared counter on every single hash (tens of
+			// millions per second per core) would make cache-line
+			// contention the bottleneck instead of the hashing itself.
+			local := uint64(0)
+			for firstIdx := w; firstIdx < len(charsets[0]); firstIdx += workers {
+				buf[0] = charsets[0][firstIdx]
+				od.reset(buf)
+				for {
+					local++
+					h.Reset()
+					h.Write(buf)
+					if bytes.Equal(h.Sum(nil), target) {
+						atomic.AddUint64(&attempts, local)
+						select {
+						case found <- string(buf):
+						default:
+						}
+						cancel()
+						return
+					}
+					if local&flushInterval == 0 {
+						atomic.AddUint64(&attempts, local)
+						local = 0
+						if ctx.Err() != nil {
+							return
+						}
+					}
+					if len(charsets) == 1 || !od.next(buf) {
+						break
+					}
+				}
+			}
+			atomic.AddUint64(&attempts, local)
+		}(w)
+	}
+
+	stop := make(chan struct{})
+	if opts.Progress != nil {
+		go reportProgress(opts, start, keyspace, &attempts, stop)
+	}
+
+	wg.Wait()
+	close(stop)
+	cancel()
+
+	select {
+	case password := <-found:
+		return Result{Success: true, Password: password, Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}, nil
+	default:
+		return Result{Success: false, Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}, nil
+	}
+}
+
+// CrackDictionaryWithRules tries every word in dictionary against target,
+// mangled through each of rules in turn, splitting the word list (not the
+// rule list) across Options.Workers goroutines.
+func CrackDictionaryWithRules(ctx context.Context, target []byte, newHash NewHash, dictionary []string, rules []Rule, opts Options) (Result, error) {
+	start := time.Now()
+	workers := opts.workers()
+	if workers > len(dictionary) && len(dictionary) > 0 {
+		workers = len(dictionary)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	var attempts uint64
+	found := make(chan string, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			h := newHash()
+			local := uint64(0)
+			for i := w; i < len(dictionary); i += workers {
+				if ctx.Err() != nil {
+					atomic.AddUint64(&attempts, local)
+					return
+				}
+				for _, rule := range rules {
+					local++
+					candidate := rule(dictionary[i])
+					h.Reset()
+					h.Write([]byte(candidate))
+					if bytes.Equal(h.Sum(nil), target) {
+						atomic.AddUint64(&attempts, local)
+						select {
+						case found <- candidate:
+						default:
+						}
+						cancel()
+						return
+					}
+				}
+				if local&flushInterval == 0 {
+					atomic.AddUint64(&attempts, local)
+					local = 0
+				}
+			}
+			atomic.AddUint64(&attempts, local)
+		}(w)
+	}
+
+	stop := make(chan struct{})
+	if opts.Progress != nil {
+		go reportProgress(opts, start, 0, &attempts, stop)
+	}
+
+	wg.Wait()
+	close(stop)
+	cancel()
+
+	select {
+	case password := <-found:
+		return Result{Success: true, Password: password, Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}, nil
+	default:
+		return Result{Success: false, Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}, nil
+	}
+}
+
+func reportProgress(opts Options, start time.Time, keyspace uint64, attempts *uint64, stop <-chan struct{}) {
+	ticker := time.NewTicker(opts.progressInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			elapsed := time.Since(start)
+			done := atomic.LoadUint64(attempts)
+			rate := float64(done) / elapsed.Seconds()
+			stats := Stats{Attempts: done, Keyspace: keyspace, Elapsed: elapsed, Rate: rate}
+			if keyspace > 0 && rate > 0 && done < keyspace {
+				stats.ETA = time.Duration(float64(keyspace-done)/rate) * time.Second
+				stats.KnownETA = true
+			}
+			opts.Progress(stats)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package cracker
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+)
+
+func sha256Sum(s string) []byte {
+	sum := sha256.Sum256([]byte(s))
+	return sum[:]
+}
+
+func TestParseMask(t *testing.T) {
+	charsets, err := ParseMask("?l?l?d")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	if len(charsets) != 3 || charsets[0] != lowerCharset || charsets[2] != digitCharset {
+		t.Fatalf("got %v, want [lower lower digit]", charsets)
+	}
+	if Keyspace(charsets) != uint64(len(lowerCharset)*len(lowerCharset)*len(digitCharset)) {
+		t.Fatalf("Keyspace: got %d", Keyspace(charsets))
+	}
+
+	if _, err := ParseMask("?l?"); err == nil {
+		t.Fatal("expected error for dangling '?'")
+	}
+	if _, err := ParseMask("?l?z"); err == nil {
+		t.Fatal("expected error for unknown placeholder")
+	}
+}
+
+func TestCrackMaskFindsTarget(t *testing.T) {
+	charsets, err := ParseMask("?l?l?d")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	target := sha256Sum("ab5")
+
+	result, err := CrackMask(context.Background(), target, sha256.New, charsets, Options{Workers: 4})
+	if err != nil {
+		t.Fatalf("CrackMask: %v", err)
+	}
+	if !result.Success || result.Password != "ab5" {
+		t.Fatalf("got %+v, want Success with Password \"ab5\"", result)
+	}
+}
+
+func TestCrackMaskNoMatch(t *testing.T) {
+	charsets, err := ParseMask("?d?d")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	target := sha256Sum("not-in-keyspace")
+
+	result, err := CrackMask(context.Background(), target, sha256.New, charsets, Options{Workers: 2})
+	if err != nil {
+		t.Fatalf("CrackMask: %v", err)
+	}
+	if result.Success {
+		t.Fatalf("got Success, want no match: %+v", result)
+	}
+	if result.Attempts != Keyspace(charsets) {
+		t.Fatalf("Attempts = %d, want the full keyspace %d", result.Attempts, Keyspace(charsets))
+	}
+}
+
+func TestCrackMaskRespectsCancellation(t *testing.T) {
+	charsets, err := ParseMask("?l?l?l?l?l?l")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	target := sha256Sum("zzzzzz") // last candidate in the keyspace
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := CrackMask(ctx, target, sha256.New, charsets, Options{Workers: 4})
+	if err != nil {
+		t.Fatalf("CrackMask: %v", err)
+	}
+	if result.Success {
+		t.Fatal("a pre-cancelled context should not still find the target")
+	}
+	if result.Attempts >= Keyspace(charsets) {
+		t.Fatalf("Attempts = %d, want far fewer than the full keyspace %d after cancellation", result.Attempts, Keyspace(charsets))
+	}
+}
+
+func TestCrackDictionaryWithRulesFindsTarget(t *testing.T) {
+	target := sha256Sum("summer2024") // dictionary word "summer" + RuleAppendDigits("2024")
+	dictionary := []string{"password", "summer", "winter"}
+
+	result, err := CrackDictionaryWithRules(context.Background(), target, sha256.New, dictionary, DefaultRules(), Options{Workers: 2})
+	if err != nil {
+		t.Fatalf("CrackDictionaryWithRules: %v", err)
+	}
+	if !result.Success || result.Password != "summer2024" {
+		t.Fatalf("got %+v, want Success with Password \"summer2024\"", result)
+	}
+}
+
+func TestParseRule(t *testing.T) {
+	cases := []struct {
+		spec string
+		in   string
+		want string
+	}{
+		{":", "abc", "abc"},
+		{"c", "abc", "Abc"},
+		{"r", "abc", "cba"},
+		{"d", "abc", "abcabc"},
+		{"$1$2$3", "abc", "abc123"},
+	}
+	for _, c := range cases {
+		rule, err := ParseRule(c.spec)
+		if err != nil {
+			t.Fatalf("ParseRule(%q): %v", c.spec, err)
+		}
+		if got := rule(c.in); got != c.want {
+			t.Errorf("ParseRule(%q)(%q) = %q, want %q", c.spec, c.in, got, c.want)
+		}
+	}
+
+	if _, err := ParseRule("?"); err == nil {
+		t.Fatal("expected error for unrecognized rule")
+	}
+}
+
+// recursiveCrackSHA256 is the old per-candidate, string-concatenating,
+// single-threaded approach (mirroring the recursive brute force this
+// package replaces), kept here only so BenchmarkRecursiveVsMask can
+// demonstrate the speedup.
+func recursiveCrackSHA256(target []byte, charset, current string, remaining int) (string, bool) {
+	if remaining == 0 {
+		sum := sha256.Sum256([]byte(current))
+		if string(sum[:]) == string(target) {
+			return current, true
+		}
+		return "", false
+	}
+	for _, c := range charset {
+		if pt, ok := recursiveCrackSHA256(target, charset, current+string(c), remaining-1); ok {
+			return pt, true
+		}
+	}
+	return "", false
+}
+
+func BenchmarkRecursiveSHA256Mask6(b *testing.B) {
+	target := sha256Sum("zzzzzz") // worst case: last candidate in the keyspace
+	for i := 0; i < b.N; i++ {
+		recursiveCrackSHA256(target, lowerCharset, "", 6)
+	}
+}
+
+func BenchmarkCrackMaskSHA256Mask6(b *testing.B) {
+	charsets, _ := ParseMask("?l?l?l?l?l?l")
+	target := sha256Sum("zzzzzz") // worst case: last candidate in the keyspace
+	for i := 0; i < b.N; i++ {
+		CrackMask(context.Background(), target, sha256.New, charsets, Options{})
+	}
+}
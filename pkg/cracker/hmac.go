@@ -0,0 +1,160 @@
+package cracker
+
+import (
+	"context"
+	"crypto/hmac"
+	"hash"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NewMAC constructs a fresh keyed hash.Hash for key; a curried hmac.New
+// (e.g. func(key []byte) hash.Hash { return hmac.New(sha256.New, key) })
+// has this signature.
+type NewMAC func(key []byte) hash.Hash
+
+// CrackMaskHMAC is CrackMask's counterpart for recovering a keyed MAC's
+// secret (e.g. the HMAC secret signing a JWT) rather than a plain digest's
+// preimage: every mask candidate is tried as the MAC key against the fixed
+// message, and the resulting tag is compared to target with hmac.Equal.
+// Unlike CrackMask, each attempt constructs a fresh hash.Hash via newMAC,
+// since an HMAC's internal state is derived from its key at construction
+// time and can't be rekeyed in place.
+func CrackMaskHMAC(ctx context.Context, message, target []byte, newMAC NewMAC, charsets []string, opts Options) (Result, error) {
+	start := time.Now()
+	workers := opts.workers()
+	keyspace := Keyspace(charsets)
+
+	var attempts uint64
+	found := make(chan string, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			buf := make([]byte, len(charsets))
+			od := newOdometer(charsets)
+
+			local := uint64(0)
+			for firstIdx := w; firstIdx < len(charsets[0]); firstIdx += workers {
+				buf[0] = charsets[0][firstIdx]
+				od.reset(buf)
+				for {
+					local++
+					mac := newMAC(buf)
+					mac.Write(message)
+					if hmac.Equal(mac.Sum(nil), target) {
+						atomic.AddUint64(&attempts, local)
+						select {
+						case found <- string(buf):
+						default:
+						}
+						cancel()
+						return
+					}
+					if local&flushInterval == 0 {
+						atomic.AddUint64(&attempts, local)
+						local = 0
+						if ctx.Err() != nil {
+							return
+						}
+					}
+					if len(charsets) == 1 || !od.next(buf) {
+						break
+					}
+				}
+			}
+			atomic.AddUint64(&attempts, local)
+		}(w)
+	}
+
+	stop := make(chan struct{})
+	if opts.Progress != nil {
+		go reportProgress(opts, start, keyspace, &attempts, stop)
+	}
+
+	wg.Wait()
+	close(stop)
+	cancel()
+
+	select {
+	case key := <-found:
+		return Result{Success: true, Password: key, Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}, nil
+	default:
+		return Result{Success: false, Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}, nil
+	}
+}
+
+// CrackDictionaryWithRulesHMAC is CrackDictionaryWithRules's counterpart
+// for recovering a keyed MAC's secret: each mangled dictionary word is
+// tried as the MAC key against the fixed message.
+func CrackDictionaryWithRulesHMAC(ctx context.Context, message, target []byte, newMAC NewMAC, dictionary []string, rules []Rule, opts Options) (Result, error) {
+	start := time.Now()
+	workers := opts.workers()
+	if workers > len(dictionary) && len(dictionary) > 0 {
+		workers = len(dictionary)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	var attempts uint64
+	found := make(chan string, 1)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			local := uint64(0)
+			for i := w; i < len(dictionary); i += workers {
+				if ctx.Err() != nil {
+					atomic.AddUint64(&attempts, local)
+					return
+				}
+				for _, rule := range rules {
+					local++
+					candidate := rule(dictionary[i])
+					mac := newMAC([]byte(candidate))
+					mac.Write(message)
+					if hmac.Equal(mac.Sum(nil), target) {
+						atomic.AddUint64(&attempts, local)
+						select {
+						case found <- candidate:
+						default:
+						}
+						cancel()
+						return
+					}
+				}
+				if local&flushInterval == 0 {
+					atomic.AddUint64(&attempts, local)
+					local = 0
+				}
+			}
+			atomic.AddUint64(&attempts, local)
+		}(w)
+	}
+
+	stop := make(chan struct{})
+	if opts.Progress != nil {
+		go reportProgress(opts, start, 0, &attempts, stop)
+	}
+
+	wg.Wait()
+	close(stop)
+	cancel()
+
+	select {
+	case key := <-found:
+		return Result{Success: true, Password: key, Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}, nil
+	default:
+		return Result{Success: false, Attempts: atomic.LoadUint64(&attempts), Elapsed: time.Since(start)}, nil
+	}
+}
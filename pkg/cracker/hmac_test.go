@@ -0,0 +1,65 @@
+package cracker
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+	"testing"
+)
+
+func newHMACSHA256(key []byte) hash.Hash { return hmac.New(sha256.New, key) }
+
+func tagFor(key, message string) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+func TestCrackMaskHMACFindsKey(t *testing.T) {
+	charsets, err := ParseMask("?l?l?d")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	message := []byte("header.payload")
+	target := tagFor("ab5", "header.payload")
+
+	result, err := CrackMaskHMAC(context.Background(), message, target, newHMACSHA256, charsets, Options{Workers: 4})
+	if err != nil {
+		t.Fatalf("CrackMaskHMAC: %v", err)
+	}
+	if !result.Success || result.Password != "ab5" {
+		t.Fatalf("got %+v, want Success with Password \"ab5\"", result)
+	}
+}
+
+func TestCrackMaskHMACNoMatch(t *testing.T) {
+	charsets, err := ParseMask("?d?d")
+	if err != nil {
+		t.Fatalf("ParseMask: %v", err)
+	}
+	message := []byte("header.payload")
+	target := tagFor("not-in-keyspace", "header.payload")
+
+	result, err := CrackMaskHMAC(context.Background(), message, target, newHMACSHA256, charsets, Options{Workers: 2})
+	if err != nil {
+		t.Fatalf("CrackMaskHMAC: %v", err)
+	}
+	if result.Success {
+		t.Fatalf("got Success, want no match: %+v", result)
+	}
+}
+
+func TestCrackDictionaryWithRulesHMACFindsKey(t *testing.T) {
+	message := []byte("header.payload")
+	target := tagFor("summer2024", "header.payload") // dictionary word "summer" + RuleAppendDigits("2024")
+	dictionary := []string{"password", "summer", "winter"}
+
+	result, err := CrackDictionaryWithRulesHMAC(context.Background(), message, target, newHMACSHA256, dictionary, DefaultRules(), Options{Workers: 2})
+	if err != nil {
+		t.Fatalf("CrackDictionaryWithRulesHMAC: %v", err)
+	}
+	if !result.Success || result.Password != "summer2024" {
+		t.Fatalf("got %+v, want Success with Password \"summer2024\"", result)
+	}
+}
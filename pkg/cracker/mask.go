@@ -0,0 +1,99 @@
+package cracker
+
+import (
+	"fmt"
+)
+
+const (
+	lowerCharset  = "abcdefghijklmnopqrstuvwxyz"
+	upperCharset  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitCharset  = "0123456789"
+	symbolCharset = "!@#$%^&*()-_=+[]{};:,.<>/?"
+)
+
+var allCharset = lowerCharset + upperCharset + digitCharset + symbolCharset
+
+// ParseMask expands a hashcat-style mask (e.g. "?l?l?l?d?d?d") into the
+// per-position charset each character is drawn from. "?l"=lowercase,
+// "?u"=uppercase, "?d"=digit, "?s"=symbol, "?a"=all four combined; "??"
+// escapes a literal '?'; any other literal character stands for itself.
+func ParseMask(mask string) ([]string, error) {
+	var positions []string
+	for i := 0; i < len(mask); i++ {
+		if mask[i] != '?' {
+			positions = append(positions, string(mask[i]))
+			continue
+		}
+		if i+1 >= len(mask) {
+			return nil, fmt.Errorf("cracker: mask %q ends with a dangling '?'", mask)
+		}
+		i++
+		switch mask[i] {
+		case 'l':
+			positions = append(positions, lowerCharset)
+		case 'u':
+			positions = append(positions, upperCharset)
+		case 'd':
+			positions = append(positions, digitCharset)
+		case 's':
+			positions = append(positions, symbolCharset)
+		case 'a':
+			positions = append(positions, allCharset)
+		case '?':
+			positions = append(positions, "?")
+		default:
+			return nil, fmt.Errorf("cracker: mask %q uses unknown placeholder '?%c'", mask, mask[i])
+		}
+	}
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("cracker: mask %q expands to zero positions", mask)
+	}
+	return positions, nil
+}
+
+// Keyspace returns the total number of candidates a parsed mask covers:
+// the product of each position's charset size.
+func Keyspace(charsets []string) uint64 {
+	size := uint64(1)
+	for _, cs := range charsets {
+		size *= uint64(len(cs))
+	}
+	return size
+}
+
+// odometer walks every combination of charsets[1:] in mixed-radix order,
+// writing each candidate's tail into buf[1:] in place so callers never
+// allocate per attempt. It mirrors a car odometer: the rightmost position
+// increments fastest, carrying into the next position on rollover.
+type odometer struct {
+	charsets []string // charsets[0] is the fixed leading character, not iterated here
+	digit    []int    // current index into charsets[i+1] for each tail position
+}
+
+func newOdometer(charsets []string) *odometer {
+	return &odometer{charsets: charsets, digit: make([]int, len(charsets)-1)}
+}
+
+// reset rewinds the tail to its first combination and writes it into buf[1:].
+func (o *odometer) reset(buf []byte) {
+	for i := range o.digit {
+		o.digit[i] = 0
+		buf[i+1] = o.charsets[i+1][0]
+	}
+}
+
+// next advances to the next tail combination, writing it into buf[1:]. It
+// returns false once every combination has been exhausted.
+func (o *odometer) next(buf []byte) bool {
+	for i := len(o.digit) - 1; i >= 0; i-- {
+		cs := o.charsets[i+1]
+		o.digit[i]++
+		if o.digit[i] < len(cs) {
+			buf[i+1] = cs[o.digit[i]]
+			return true
+		}
+		o.digit[i] = 0
+		buf[i+1] = cs[0]
+	}
+	return false
+}
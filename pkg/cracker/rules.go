@@ -0,0 +1,92 @@
+package cracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule mangles a dictionary word into one candidate variant, in the spirit
+// of a single hashcat rule (":", "c", "$1", ...).
+type Rule func(word string) string
+
+// leetTable is the fixed substitution set applied by RuleLeetspeak.
+var leetTable = map[byte]byte{'a': '4', 'e': '3', 'i': '1', 'o': '0', 's': '5'}
+
+// RuleIdentity leaves the word unchanged; hashcat's ":" no-op rule.
+func RuleIdentity(word string) string { return word }
+
+// RuleCapitalize upper-cases the first letter; hashcat's "c" rule.
+func RuleCapitalize(word string) string {
+	if word == "" {
+		return word
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
+// RuleReverse reverses the word; hashcat's "r" rule.
+func RuleReverse(word string) string {
+	b := []byte(word)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// RuleDuplicate appends the word to itself; hashcat's "d" rule.
+func RuleDuplicate(word string) string { return word + word }
+
+// RuleLeetspeak substitutes a->4, e->3, i->1, o->0, s->5.
+func RuleLeetspeak(word string) string {
+	b := []byte(word)
+	for i, c := range b {
+		if r, ok := leetTable[c]; ok {
+			b[i] = r
+		}
+	}
+	return string(b)
+}
+
+// RuleAppendDigits returns a rule appending the literal digit string
+// suffix, e.g. RuleAppendDigits("123") is hashcat's "$1$2$3".
+func RuleAppendDigits(suffix string) Rule {
+	return func(word string) string { return word + suffix }
+}
+
+// DefaultRules is a small, commonly useful subset of hashcat's rule
+// language: the identity, plus capitalize/reverse/duplicate/leetspeak and
+// the digit suffixes most real-world passwords are mangled with.
+func DefaultRules() []Rule {
+	rules := []Rule{RuleIdentity, RuleCapitalize, RuleReverse, RuleDuplicate, RuleLeetspeak}
+	for _, suffix := range []string{"1", "123", "!", "01", "2024", "2025"} {
+		rules = append(rules, RuleAppendDigits(suffix))
+	}
+	return rules
+}
+
+// ParseRule compiles a single hashcat-style rule token into a Rule.
+// Supported tokens: ":" (identity), "c" (capitalize), "r" (reverse),
+// "d" (duplicate), and "$N" for each literal character N to append.
+func ParseRule(spec string) (Rule, error) {
+	switch spec {
+	case ":":
+		return RuleIdentity, nil
+	case "c":
+		return RuleCapitalize, nil
+	case "r":
+		return RuleReverse, nil
+	case "d":
+		return RuleDuplicate, nil
+	}
+	if strings.HasPrefix(spec, "$") {
+		var suffix strings.Builder
+		for i := 0; i < len(spec); i++ {
+			if spec[i] != '$' || i+1 >= len(spec) {
+				return nil, fmt.Errorf("cracker: malformed append rule %q", spec)
+			}
+			i++
+			suffix.WriteByte(spec[i])
+		}
+		return RuleAppendDigits(suffix.String()), nil
+	}
+	return nil, fmt.Errorf("cracker: unrecognized rule %q", spec)
+}
@@ -0,0 +1,72 @@
+package dh
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// keyLen is the AES-256-GCM key size HKDF derives from the shared secret.
+const keyLen = 32
+
+// DeriveKey runs HKDF-SHA256 over a DH shared secret's big-endian bytes to
+// produce a 32-byte AES-256-GCM key. info should bind the key to its
+// purpose (e.g. "dh demo v1") so the same shared secret can't be replayed
+// to derive keys for an unrelated context.
+func DeriveKey(sharedSecret *big.Int, salt, info []byte) ([]byte, error) {
+	r := hkdf.New(sha256.New, sharedSecret.Bytes(), salt, info)
+	key := make([]byte, keyLen)
+	if _, err := io.ReadFull(r, key); err != nil {
+		return nil, fmt.Errorf("dh: deriving key: %w", err)
+	}
+	return key, nil
+}
+
+// Encrypt seals plaintext with AES-256-GCM under key (as returned by
+// DeriveKey), replacing the package's former XOR "encryption": it
+// generates a random nonce, prepends it to the returned ciphertext, and
+// authenticates the whole thing so tampering is detected rather than
+// silently producing garbage plaintext on decrypt.
+func Encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("dh: generating nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt opens a ciphertext produced by Encrypt: the leading NonceSize()
+// bytes are the nonce, the rest is the sealed (ciphertext || tag).
+func Decrypt(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("dh: ciphertext shorter than a nonce")
+	}
+	nonce, sealed := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("dh: decrypting (wrong key or tampered ciphertext): %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("dh: building AES cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
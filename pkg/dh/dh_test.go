@@ -0,0 +1,130 @@
+package dh
+
+import (
+	"math/big"
+	"testing"
+)
+
+// smallSafeGroup is a teaching-sized safe-prime group (P=23, the order-11
+// subgroup generated by G=4) so the tests run instantly instead of against
+// a 2048-bit FFDHE group: 23 is a safe prime since (23-1)/2=11 is prime
+// too, and 4 = 2^2 generates the order-11 subgroup since it is a quadratic
+// residue other than 1, and the quadratic residues mod 23 form that subgroup.
+func smallSafeGroup() Group {
+	return Group{P: big.NewInt(23), G: big.NewInt(4)}
+}
+
+func TestGroupValidateAcceptsSafePrimeGroup(t *testing.T) {
+	if err := smallSafeGroup().Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestGroupValidateRejectsNonSafePrime(t *testing.T) {
+	// 13 is prime but (13-1)/2=6 is not, so it isn't a safe prime.
+	g := Group{P: big.NewInt(13), G: big.NewInt(2)}
+	if err := g.Validate(); err == nil {
+		t.Fatal("expected an error for a non-safe-prime modulus")
+	}
+}
+
+func TestGroupValidateRejectsSmallSubgroupGenerator(t *testing.T) {
+	// Over P=23, G=22 (== P-1) has order 2, not the order-11 subgroup.
+	g := Group{P: big.NewInt(23), G: big.NewInt(22)}
+	if err := g.Validate(); err == nil {
+		t.Fatal("expected an error for a generator outside the order-q subgroup")
+	}
+}
+
+func TestCheckPublicValueRejectsConfinementValues(t *testing.T) {
+	g := smallSafeGroup()
+	for _, bad := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(22), big.NewInt(23)} {
+		if err := g.CheckPublicValue(bad); err == nil {
+			t.Errorf("CheckPublicValue(%s): expected an error", bad)
+		}
+	}
+	if err := g.CheckPublicValue(big.NewInt(9)); err != nil {
+		t.Errorf("CheckPublicValue(9): unexpected error: %v", err)
+	}
+}
+
+func TestKeyExchangeAgreesOnSharedSecret(t *testing.T) {
+	g := smallSafeGroup()
+	alice, err := g.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey (alice): %v", err)
+	}
+	bob, err := g.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey (bob): %v", err)
+	}
+
+	aliceSecret := g.SharedSecret(alice.Private, bob.Public)
+	bobSecret := g.SharedSecret(bob.Private, alice.Public)
+	if aliceSecret.Cmp(bobSecret) != 0 {
+		t.Fatalf("shared secrets differ: alice=%s bob=%s", aliceSecret, bobSecret)
+	}
+}
+
+func TestRunMITMDemoSplitsTheSharedSecret(t *testing.T) {
+	g := smallSafeGroup()
+	result, err := g.RunMITMDemo()
+	if err != nil {
+		t.Fatalf("RunMITMDemo: %v", err)
+	}
+	if result.AliceSecret.Cmp(result.MalloryWithAlice) != 0 {
+		t.Fatalf("Alice's secret should match what Mallory computed with her: %s vs %s", result.AliceSecret, result.MalloryWithAlice)
+	}
+	if result.BobSecret.Cmp(result.MalloryWithBob) != 0 {
+		t.Fatalf("Bob's secret should match what Mallory computed with him: %s vs %s", result.BobSecret, result.MalloryWithBob)
+	}
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	g := smallSafeGroup()
+	alice, _ := g.GenerateKey()
+	bob, _ := g.GenerateKey()
+	secret := g.SharedSecret(alice.Private, bob.Public)
+
+	key, err := DeriveKey(secret, nil, []byte("dh_test"))
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+
+	plaintext := []byte("Hello Bob!")
+	ciphertext, err := Encrypt(key, plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	got, err := Decrypt(key, ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if string(got) != string(plaintext) {
+		t.Fatalf("got %q, want %q", got, plaintext)
+	}
+}
+
+func TestDecryptRejectsTamperedCiphertext(t *testing.T) {
+	key := make([]byte, keyLen)
+	ciphertext, err := Encrypt(key, []byte("secret"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	ciphertext[len(ciphertext)-1] ^= 0xFF
+	if _, err := Decrypt(key, ciphertext); err == nil {
+		t.Fatal("expected an error decrypting tampered ciphertext")
+	}
+}
+
+func TestFFDHEGroupsValidate(t *testing.T) {
+	for name, g := range map[string]Group{
+		"FFDHE2048": FFDHE2048(),
+		"FFDHE3072": FFDHE3072(),
+		"FFDHE4096": FFDHE4096(),
+	} {
+		if err := g.Validate(); err != nil {
+			t.Errorf("%s.Validate(): %v", name, err)
+		}
+	}
+}
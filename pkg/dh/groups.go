@@ -0,0 +1,100 @@
+package dh
+
+import "math/big"
+
+// hexInt parses a hex string (whitespace allowed, stripped first) into a
+// *big.Int, panicking on malformed input - only used for the fixed
+// literal constants below.
+func hexInt(s string) *big.Int {
+	clean := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == ' ' || s[i] == '\n' || s[i] == '\t' {
+			continue
+		}
+		clean = append(clean, s[i])
+	}
+	n, ok := new(big.Int).SetString(string(clean), 16)
+	if !ok {
+		panic("dh: invalid hex constant")
+	}
+	return n
+}
+
+// ffdheGenerator is 2 for every RFC 7919 FFDHE group.
+var ffdheGenerator = big.NewInt(2)
+
+// FFDHE2048 returns the RFC 7919 2048-bit FFDHE MODP group. Its modulus is
+// byte-for-byte the "Group 14" prime from RFC 3526 - RFC 7919 Section 3
+// reuses the RFC 3526 2048/3072/4096-bit groups rather than minting new
+// ones, so implementations that already embedded those constants for IKE
+// don't need a second copy for TLS/FFDHE.
+func FFDHE2048() Group {
+	return Group{P: hexInt(ffdhe2048Hex), G: ffdheGenerator}
+}
+
+// FFDHE3072 returns the RFC 7919 3072-bit FFDHE MODP group (RFC 3526
+// "Group 15").
+func FFDHE3072() Group {
+	return Group{P: hexInt(ffdhe3072Hex), G: ffdheGenerator}
+}
+
+// FFDHE4096 returns the RFC 7919 4096-bit FFDHE MODP group (RFC 3526
+// "Group 16").
+func FFDHE4096() Group {
+	return Group{P: hexInt(ffdhe4096Hex), G: ffdheGenerator}
+}
+
+const ffdhe2048Hex = `
+	FFFFFFFF FFFFFFFF ADF85458 A2BB4A9A AFDC5620 273D3CF1
+	D8B9C583 CE2D3695 A9E13641 146433FB CC939DCE 249B3EF9
+	7D2FE363 630C75D8 F681B202 AEC4617A D3DF1ED5 D5FD6561
+	2433F51F 5F066ED0 85636555 3DED1AF3 B557135E 7F57C935
+	984F0C70 E0E68B77 E2A689DA F3EFE872 1DF158A1 36ADE735
+	30ACCA4F 483A797A BC0AB182 B324FB61 D108A94B B2C8E3FB
+	B96ADAB7 60D7F468 1D4F42A3 DE394DF4 AE56EDE7 6372BB19
+	0B07A7C8 EE0A6D70 9E02FCE1 CDF7E2EC C03404CD 28342F61
+	9172FE9C E98583FF 8E4F1232 EEF28183 C3FE3B1B 4C6FAD73
+	3BB5FCBC 2EC22005 C58EF183 7D1683B2 C6F34A26 C1B2EFFA
+	886B4238 61285C97 FFFFFFFF FFFFFFFF`
+
+const ffdhe3072Hex = `
+	FFFFFFFF FFFFFFFF ADF85458 A2BB4A9A AFDC5620 273D3CF1
+	D8B9C583 CE2D3695 A9E13641 146433FB CC939DCE 249B3EF9
+	7D2FE363 630C75D8 F681B202 AEC4617A D3DF1ED5 D5FD6561
+	2433F51F 5F066ED0 85636555 3DED1AF3 B557135E 7F57C935
+	984F0C70 E0E68B77 E2A689DA F3EFE872 1DF158A1 36ADE735
+	30ACCA4F 483A797A BC0AB182 B324FB61 D108A94B B2C8E3FB
+	B96ADAB7 60D7F468 1D4F42A3 DE394DF4 AE56EDE7 6372BB19
+	0B07A7C8 EE0A6D70 9E02FCE1 CDF7E2EC C03404CD 28342F61
+	9172FE9C E98583FF 8E4F1232 EEF28183 C3FE3B1B 4C6FAD73
+	3BB5FCBC 2EC22005 C58EF183 7D1683B2 C6F34A26 C1B2EFFA
+	886B4238 611FCFDC DE355B3B 6519035B BC34F4DE F99C0238
+	61B46FC9 D6E6C907 7AD91D26 91F7F7EE 598CB0FA C186D91C
+	AEFE1309 85139270 B4130C93 BC437944 F4FD4452 E2D74DD3
+	64F2E21E 71F54BFF 5CAE82AB 9C9DF69E E86D2BC5 22363A0D
+	ABC52197 9B0DEADA 1DBF9A42 D5C4484E 0ABCD06B FA53DDEF
+	3C1B20EE 3FD59D7C 25E41D2B 66C62E37 FFFFFFFF FFFFFFFF`
+
+const ffdhe4096Hex = `
+	FFFFFFFF FFFFFFFF ADF85458 A2BB4A9A AFDC5620 273D3CF1
+	D8B9C583 CE2D3695 A9E13641 146433FB CC939DCE 249B3EF9
+	7D2FE363 630C75D8 F681B202 AEC4617A D3DF1ED5 D5FD6561
+	2433F51F 5F066ED0 85636555 3DED1AF3 B557135E 7F57C935
+	984F0C70 E0E68B77 E2A689DA F3EFE872 1DF158A1 36ADE735
+	30ACCA4F 483A797A BC0AB182 B324FB61 D108A94B B2C8E3FB
+	B96ADAB7 60D7F468 1D4F42A3 DE394DF4 AE56EDE7 6372BB19
+	0B07A7C8 EE0A6D70 9E02FCE1 CDF7E2EC C03404CD 28342F61
+	9172FE9C E98583FF 8E4F1232 EEF28183 C3FE3B1B 4C6FAD73
+	3BB5FCBC 2EC22005 C58EF183 7D1683B2 C6F34A26 C1B2EFFA
+	886B4238 611FCFDC DE355B3B 6519035B BC34F4DE F99C0238
+	61B46FC9 D6E6C907 7AD91D26 91F7F7EE 598CB0FA C186D91C
+	AEFE1309 85139270 B4130C93 BC437944 F4FD4452 E2D74DD3
+	64F2E21E 71F54BFF 5CAE82AB 9C9DF69E E86D2BC5 22363A0D
+	ABC52197 9B0DEADA 1DBF9A42 D5C4484E 0ABCD06B FA53DDEF
+	3C1B20EE 3FD59D7C 25E41D2B 669E1EF1 6E6F52C3 164DF4FB
+	7930E9E4 E58857B6 AC7D5F42 D69F6D18 7763CF1D 55034004
+	87F55BA5 7E31CC7A 7135C886 EFB4318A ED6A1E01 2D9E6832
+	A907600A 918130C4 6DC778F9 71AD0038 092999A3 33CB8B7A
+	1A1DB93D 7140003C 2A4ECEA9 F98D0ACC 0A8291CD CEC97DCF
+	8EC9B55A 7F88A46B 4DB5A851 F44182E1 C68A007E 5E655F6A
+	FFFFFFFF FFFFFFFF`
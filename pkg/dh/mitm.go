@@ -0,0 +1,58 @@
+package dh
+
+import "math/big"
+
+// MITMResult reports every shared secret from an unauthenticated DH
+// exchange Mallory actively intercepted: Alice and Bob each believe they
+// share a secret with the other, but AliceSecret actually equals
+// MalloryWithAlice and BobSecret actually equals MalloryWithBob - Mallory
+// shares a distinct secret with each of them and can transparently
+// decrypt, read, and re-encrypt everything that passes through her.
+type MITMResult struct {
+	AliceSecret      *big.Int
+	BobSecret        *big.Int
+	MalloryWithAlice *big.Int
+	MalloryWithBob   *big.Int
+}
+
+// RunMITMDemo simulates the textbook active attack on unauthenticated DH:
+// Mallory sits between Alice and Bob, and substitutes her own public value
+// for each side's before forwarding it on, so Alice never actually
+// receives Bob's public value or vice versa. Both parties' public values
+// individually pass CheckPublicValue/Validate - the attack isn't a
+// parameter-validation failure, it's the absence of any binding between a
+// public value and the identity that's supposed to have sent it. The fix
+// is authenticating the exchange (signing public values with a long-term
+// identity key, or deriving the session key from a pre-shared secret as
+// well), which this package does not attempt to provide.
+func (g Group) RunMITMDemo() (MITMResult, error) {
+	alice, err := g.GenerateKey()
+	if err != nil {
+		return MITMResult{}, err
+	}
+	bob, err := g.GenerateKey()
+	if err != nil {
+		return MITMResult{}, err
+	}
+	mallory, err := g.GenerateKey()
+	if err != nil {
+		return MITMResult{}, err
+	}
+
+	// Alice addresses her public value to Bob; Mallory intercepts it and
+	// forwards mallory.Public in its place, so Alice actually completes
+	// the exchange with Mallory while believing it was with Bob.
+	aliceSecret := g.SharedSecret(alice.Private, mallory.Public)
+	malloryWithAlice := g.SharedSecret(mallory.Private, alice.Public)
+
+	// Symmetrically, Mallory substitutes her public value for Bob's reply.
+	bobSecret := g.SharedSecret(bob.Private, mallory.Public)
+	malloryWithBob := g.SharedSecret(mallory.Private, bob.Public)
+
+	return MITMResult{
+		AliceSecret:      aliceSecret,
+		BobSecret:        bobSecret,
+		MalloryWithAlice: malloryWithAlice,
+		MalloryWithBob:   malloryWithBob,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package dh
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// millerRabinRounds is the number of Miller-Rabin rounds ProbablyPrime runs
+// per candidate; 20 gives a false-positive probability below 2^-40, ample
+// margin for validating a negotiated DH group before trusting it.
+const millerRabinRounds = 20
+
+// Validate checks that g describes a safe-prime DH group suitable for key
+// agreement: P is prime, q = (P-1)/2 is also prime (making P a safe
+// prime), and G generates the order-q subgroup rather than one of the
+// small subgroups a safe prime's group always also contains (order 1 or
+// 2). A malicious or buggy peer that can get an endpoint to accept
+// parameters failing this check can confine the exchange to a subgroup
+// small enough to brute-force the shared secret from.
+func (g Group) Validate() error {
+	if g.P == nil || g.G == nil {
+		return fmt.Errorf("dh: group has a nil P or G")
+	}
+	if !g.P.ProbablyPrime(millerRabinRounds) {
+		return fmt.Errorf("dh: P is not prime")
+	}
+
+	q := new(big.Int).Sub(g.P, big.NewInt(1))
+	q.Rsh(q, 1) // q = (P-1)/2
+	if !q.ProbablyPrime(millerRabinRounds) {
+		return fmt.Errorf("dh: (P-1)/2 is not prime, so P is not a safe prime")
+	}
+
+	if err := g.CheckPublicValue(g.G); err != nil {
+		return fmt.Errorf("dh: generator is invalid: %w", err)
+	}
+
+	// A safe prime's multiplicative group Z_P* has order P-1 = 2q, so
+	// every element's order divides 2q and is one of {1, 2, q, 2q}. G
+	// generates the order-q subgroup exactly when G^q == 1 (orders 1 and 2
+	// were already rejected above; this check also rejects order 2q, since using the full group instead of
+	// the order-q subgroup leaks one bit of the private exponent via the
+	// quadratic-residue status of the shared secret).
+	gToQ := new(big.Int).Exp(g.G, q, g.P)
+	if gToQ.Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("dh: G does not generate the order-q subgroup (G^((P-1)/2) != 1 mod P)")
+	}
+
+	return nil
+}
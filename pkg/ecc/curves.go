@@ -0,0 +1,28 @@
+package ecc
+
+import "math/big"
+
+// hexInt parses a hex string into a *big.Int, panicking on malformed input -
+// only used for the fixed literal constants below.
+func hexInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("ecc: invalid hex constant " + s)
+	}
+	return n
+}
+
+// Secp256k1 returns the curve parameters standardized by SEC 2 and used by
+// Bitcoin/Ethereum: y^2 = x^3 + 7 over F_p.
+func Secp256k1() Curve {
+	return Curve{
+		P: hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+		A: big.NewInt(0),
+		B: big.NewInt(7),
+		G: Point{
+			X: hexInt("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+			Y: hexInt("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"),
+		},
+		N: hexInt("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
+	}
+}
@@ -0,0 +1,122 @@
+// Package ecc implements elliptic-curve point arithmetic over a prime field
+// using math/big, replacing the plain-int demo whose ModInverse did a linear
+// search and whose subtraction-before-modulo produced negative results.
+package ecc
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/arya2004/cybersecurity/pkg/bigint"
+)
+
+// Curve is a short Weierstrass curve y^2 = x^3 + a*x + b over F_p.
+type Curve struct {
+	A, B, P *big.Int
+	G       Point // base point
+	N       *big.Int // order of G
+}
+
+// Point is an affine point on a Curve. Infinity is represented by a nil X.
+type Point struct {
+	X, Y *big.Int
+}
+
+// Infinity is the identity element of the curve's group.
+var Infinity = Point{}
+
+func (p Point) isInfinity() bool { return p.X == nil }
+
+// IsOnCurve reports whether p satisfies the curve equation mod c.P.
+func (c Curve) IsOnCurve(p Point) bool {
+	if p.isInfinity() {
+		return true
+	}
+	lhs := bigint.Mod(bigint.Mul(p.Y, p.Y), c.P)
+	x3 := bigint.Mul(bigint.Mul(p.X, p.X), p.X)
+	ax := bigint.Mul(c.A, p.X)
+	rhs := bigint.Mod(bigint.Add(bigint.Add(x3, ax), c.B), c.P)
+	return lhs.Cmp(rhs) == 0
+}
+
+// ModInverse returns a^-1 mod m via the extended Euclidean algorithm.
+func ModInverse(a, m *big.Int) (*big.Int, error) {
+	inv := bigint.ModInverse(a, m)
+	if inv == nil {
+		return nil, errors.New("ecc: no modular inverse exists")
+	}
+	return inv, nil
+}
+
+// Add returns p+q on the curve.
+func (c Curve) Add(p, q Point) (Point, error) {
+	if p.isInfinity() {
+		return q, nil
+	}
+	if q.isInfinity() {
+		return p, nil
+	}
+	if p.X.Cmp(q.X) == 0 {
+		sum := bigint.Mod(bigint.Add(p.Y, q.Y), c.P)
+		if sum.Sign() == 0 {
+			return Infinity, nil
+		}
+		return c.Double(p)
+	}
+
+	num := bigint.Mod(bigint.Sub(q.Y, p.Y), c.P)
+	den := bigint.Mod(bigint.Sub(q.X, p.X), c.P)
+	denInv, err := ModInverse(den, c.P)
+	if err != nil {
+		return Infinity, err
+	}
+	lambda := bigint.Mod(bigint.Mul(num, denInv), c.P)
+	return c.pointFromLambda(lambda, p, q), nil
+}
+
+// Double returns p+p on the curve.
+func (c Curve) Double(p Point) (Point, error) {
+	if p.isInfinity() || p.Y.Sign() == 0 {
+		return Infinity, nil
+	}
+	num := bigint.Mod(bigint.Add(bigint.Mul(big.NewInt(3), bigint.Mul(p.X, p.X)), c.A), c.P)
+	den := bigint.Mod(bigint.Mul(big.NewInt(2), p.Y), c.P)
+	denInv, err := ModInverse(den, c.P)
+	if err != nil {
+		return Infinity, err
+	}
+	lambda := bigint.Mod(bigint.Mul(num, denInv), c.P)
+	return c.pointFromLambda(lambda, p, p), nil
+}
+
+func (c Curve) pointFromLambda(lambda *big.Int, p, q Point) Point {
+	x := bigint.Mod(bigint.Sub(bigint.Sub(bigint.Mul(lambda, lambda), p.X), q.X), c.P)
+	y := bigint.Mod(bigint.Sub(bigint.Mul(lambda, bigint.Sub(p.X, x)), p.Y), c.P)
+	return Point{X: x, Y: y}
+}
+
+// ScalarMult computes k*p using double-and-add.
+func (c Curve) ScalarMult(k *big.Int, p Point) (Point, error) {
+	result := Infinity
+	addend := p
+	n := new(big.Int).Set(k)
+	zero := big.NewInt(0)
+	two := big.NewInt(2)
+	for n.Cmp(zero) > 0 {
+		bit := new(big.Int).Mod(n, two)
+		if bit.Sign() != 0 {
+			var err error
+			result, err = c.Add(result, addend)
+			if err != nil {
+				return Infinity, err
+			}
+		}
+		var err error
+		addend, err = c.Double(addend)
+		if err != nil {
+			return Infinity, err
+		}
+		n.Rsh(n, 1)
+	}
+	return result, nil
+}
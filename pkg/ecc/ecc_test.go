@@ -0,0 +1,208 @@
+package ecc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+func sha256Sum(msg []byte) []byte {
+	h := sha256.Sum256(msg)
+	return h[:]
+}
+
+// p256 returns the NIST P-256 curve parameters (FIPS 186-4).
+func p256() Curve {
+	p, _ := new(big.Int).SetString("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff", 16)
+	a, _ := new(big.Int).SetString("ffffffff00000001000000000000000000000000fffffffffffffffffffffffc", 16)
+	b, _ := new(big.Int).SetString("5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b", 16)
+	gx, _ := new(big.Int).SetString("6b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296", 16)
+	gy, _ := new(big.Int).SetString("4fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5", 16)
+	n, _ := new(big.Int).SetString("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551", 16)
+	return Curve{A: a, B: b, P: p, G: Point{X: gx, Y: gy}, N: n}
+}
+
+func TestP256GeneratorOnCurve(t *testing.T) {
+	c := p256()
+	if !c.IsOnCurve(c.G) {
+		t.Fatal("P-256 generator does not satisfy the curve equation")
+	}
+}
+
+func TestScalarMultOrderIsInfinity(t *testing.T) {
+	c := p256()
+	result, err := c.ScalarMult(c.N, c.G)
+	if err != nil {
+		t.Fatalf("ScalarMult: %v", err)
+	}
+	if !result.isInfinity() {
+		t.Fatalf("n*G should be the point at infinity, got (%v, %v)", result.X, result.Y)
+	}
+}
+
+func TestAddDoubleConsistency(t *testing.T) {
+	c := p256()
+	viaAdd, err := c.Add(c.G, c.G)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	viaDouble, err := c.Double(c.G)
+	if err != nil {
+		t.Fatalf("Double: %v", err)
+	}
+	if viaAdd.X.Cmp(viaDouble.X) != 0 || viaAdd.Y.Cmp(viaDouble.Y) != 0 {
+		t.Fatal("Add(G, G) and Double(G) disagree")
+	}
+	if !c.IsOnCurve(viaAdd) {
+		t.Fatal("2G is not on the curve")
+	}
+}
+
+// smallCurve is y^2 = x^3 + 2x + 3 over F_97, a teaching-sized curve small
+// enough for Order()'s naive point counting.
+func smallCurve() Curve {
+	return Curve{
+		A: big.NewInt(2),
+		B: big.NewInt(3),
+		P: big.NewInt(97),
+		G: Point{X: big.NewInt(3), Y: big.NewInt(6)},
+		N: big.NewInt(5),
+	}
+}
+
+func TestAdditionAssociative(t *testing.T) {
+	c := p256()
+	g2, err := c.Double(c.G)
+	if err != nil {
+		t.Fatalf("Double: %v", err)
+	}
+	g3, err := c.Add(g2, c.G)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// (G+G)+G3 should equal G+(G+G3): associativity of point addition.
+	left, err := c.Add(g2, g3)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	g2g3, err := c.Add(c.G, g3)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	right, err := c.Add(c.G, g2g3)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if left.X.Cmp(right.X) != 0 || left.Y.Cmp(right.Y) != 0 {
+		t.Fatalf("addition is not associative: (2G+3G)=%v,%v vs 2G+(G+3G)=%v,%v", left.X, left.Y, right.X, right.Y)
+	}
+}
+
+func TestOrderMatchesScalarMultToInfinity(t *testing.T) {
+	c := smallCurve()
+	order, err := c.Order()
+	if err != nil {
+		t.Fatalf("Order: %v", err)
+	}
+	if order.Cmp(c.N) != 0 {
+		t.Fatalf("Order() = %v, want %v", order, c.N)
+	}
+	result, err := c.ScalarMult(order, c.G)
+	if err != nil {
+		t.Fatalf("ScalarMult: %v", err)
+	}
+	if !result.isInfinity() {
+		t.Fatalf("order*G should be the point at infinity, got (%v, %v)", result.X, result.Y)
+	}
+}
+
+func TestECDHSharedSecretMatches(t *testing.T) {
+	e := ECDH{Curve: Secp256k1()}
+
+	alice, err := e.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	bob, err := e.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	aliceSecret, err := e.SharedSecret(alice.Private, bob.Public)
+	if err != nil {
+		t.Fatalf("SharedSecret: %v", err)
+	}
+	bobSecret, err := e.SharedSecret(bob.Private, alice.Public)
+	if err != nil {
+		t.Fatalf("SharedSecret: %v", err)
+	}
+	if aliceSecret.X.Cmp(bobSecret.X) != 0 || aliceSecret.Y.Cmp(bobSecret.Y) != 0 {
+		t.Fatal("Alice and Bob derived different shared secrets")
+	}
+}
+
+func TestECDSASignVerifyRoundTrip(t *testing.T) {
+	sha := sha256Sum([]byte("hello ecdsa"))
+	curve := Secp256k1()
+	ecdsa := ECDSA{Curve: curve}
+
+	priv, err := rand.Int(rand.Reader, curve.N)
+	if err != nil {
+		t.Fatalf("rand.Int: %v", err)
+	}
+	pub, err := curve.ScalarMult(priv, curve.G)
+	if err != nil {
+		t.Fatalf("ScalarMult: %v", err)
+	}
+
+	sig, err := ecdsa.Sign(sha, priv)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	ok, err := ecdsa.Verify(sha, sig, pub)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !ok {
+		t.Fatal("Verify rejected a valid signature")
+	}
+
+	tampered := sha256Sum([]byte("hello ecdsa!"))
+	ok, err = ecdsa.Verify(tampered, sig, pub)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if ok {
+		t.Fatal("Verify accepted a signature for a different message")
+	}
+}
+
+func TestElGamalRoundTrip(t *testing.T) {
+	c := p256()
+	priv := big.NewInt(12345)
+	pub, err := c.ScalarMult(priv, c.G)
+	if err != nil {
+		t.Fatalf("ScalarMult: %v", err)
+	}
+
+	message, err := c.ScalarMult(big.NewInt(99), c.G)
+	if err != nil {
+		t.Fatalf("ScalarMult: %v", err)
+	}
+
+	c1, c2, err := c.EncryptWithK(message, pub, big.NewInt(777))
+	if err != nil {
+		t.Fatalf("EncryptWithK: %v", err)
+	}
+
+	decrypted, err := c.Decrypt(priv, c1, c2)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if decrypted.X.Cmp(message.X) != 0 || decrypted.Y.Cmp(message.Y) != 0 {
+		t.Fatalf("round trip mismatch: got (%v, %v), want (%v, %v)", decrypted.X, decrypted.Y, message.X, message.Y)
+	}
+}
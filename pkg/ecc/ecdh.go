@@ -0,0 +1,38 @@
+package ecc
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// ECDH performs Diffie-Hellman key agreement over a fixed curve.
+type ECDH struct {
+	Curve Curve
+}
+
+// KeyPair is an ECDH private scalar and its corresponding public point.
+type KeyPair struct {
+	Private *big.Int
+	Public  Point
+}
+
+// GenerateKey draws a private scalar in [1, N) and derives its public point.
+func (e ECDH) GenerateKey() (KeyPair, error) {
+	priv, err := rand.Int(rand.Reader, new(big.Int).Sub(e.Curve.N, big.NewInt(1)))
+	if err != nil {
+		return KeyPair{}, err
+	}
+	priv.Add(priv, big.NewInt(1))
+
+	pub, err := e.Curve.ScalarMult(priv, e.Curve.G)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	return KeyPair{Private: priv, Public: pub}, nil
+}
+
+// SharedSecret computes priv*peerPublic, the shared point both sides agree
+// on; callers typically hash its X coordinate to derive a symmetric key.
+func (e ECDH) SharedSecret(priv *big.Int, peerPublic Point) (Point, error) {
+	return e.Curve.ScalarMult(priv, peerPublic)
+}
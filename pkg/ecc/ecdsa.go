@@ -0,0 +1,98 @@
+package ecc
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+
+	"github.com/arya2004/cybersecurity/pkg/bigint"
+)
+
+// ECDSA signs and verifies message digests over a fixed curve.
+type ECDSA struct {
+	Curve Curve
+}
+
+// Signature is an ECDSA (r, s) pair.
+type Signature struct {
+	R, S *big.Int
+}
+
+// Sign computes r = (k*G).x mod n, s = k^-1*(hash + r*priv) mod n for a
+// fresh ephemeral scalar k, retrying if either value lands on zero (an
+// astronomically unlikely event for a properly sized curve).
+func (e ECDSA) Sign(hash []byte, priv *big.Int) (Signature, error) {
+	n := e.Curve.N
+	z := hashToInt(hash, n)
+
+	for {
+		k, err := rand.Int(rand.Reader, new(big.Int).Sub(n, big.NewInt(1)))
+		if err != nil {
+			return Signature{}, err
+		}
+		k.Add(k, big.NewInt(1))
+
+		kG, err := e.Curve.ScalarMult(k, e.Curve.G)
+		if err != nil {
+			return Signature{}, err
+		}
+		r := bigint.Mod(kG.X, n)
+		if r.Sign() == 0 {
+			continue
+		}
+
+		kInv, err := ModInverse(k, n)
+		if err != nil {
+			return Signature{}, err
+		}
+		s := bigint.Mod(bigint.Mul(kInv, bigint.Add(z, bigint.Mul(r, priv))), n)
+		if s.Sign() == 0 {
+			continue
+		}
+		return Signature{R: r, S: s}, nil
+	}
+}
+
+// Verify checks sig against hash and the signer's public key pub.
+func (e ECDSA) Verify(hash []byte, sig Signature, pub Point) (bool, error) {
+	n := e.Curve.N
+	if sig.R.Sign() <= 0 || sig.R.Cmp(n) >= 0 || sig.S.Sign() <= 0 || sig.S.Cmp(n) >= 0 {
+		return false, nil
+	}
+	z := hashToInt(hash, n)
+
+	sInv, err := ModInverse(sig.S, n)
+	if err != nil {
+		return false, err
+	}
+	u1 := bigint.Mod(bigint.Mul(z, sInv), n)
+	u2 := bigint.Mod(bigint.Mul(sig.R, sInv), n)
+
+	p1, err := e.Curve.ScalarMult(u1, e.Curve.G)
+	if err != nil {
+		return false, err
+	}
+	p2, err := e.Curve.ScalarMult(u2, pub)
+	if err != nil {
+		return false, err
+	}
+	sum, err := e.Curve.Add(p1, p2)
+	if err != nil {
+		return false, err
+	}
+	if sum.isInfinity() {
+		return false, errors.New("ecc: u1*G + u2*pub is the point at infinity")
+	}
+	return bigint.Mod(sum.X, n).Cmp(sig.R) == 0, nil
+}
+
+// hashToInt reduces a message digest to an integer mod n, truncating it to
+// n's bit length first per FIPS 186-4 section 6.4 when the digest is wider.
+func hashToInt(hash []byte, n *big.Int) *big.Int {
+	z := new(big.Int).SetBytes(hash)
+	bitLen := n.BitLen()
+	if excess := z.BitLen() - bitLen; excess > 0 {
+		z.Rsh(z, uint(excess))
+	}
+	return z
+}
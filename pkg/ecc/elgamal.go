@@ -0,0 +1,49 @@
+package ecc
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/arya2004/cybersecurity/pkg/bigint"
+)
+
+// Encrypt performs EC-ElGamal encryption of message (already embedded as a
+// point on the curve) under public key pub = priv*G, using a fresh
+// ephemeral scalar k that it draws from crypto/rand. It returns the ciphertext pair
+// (c1, c2) = (k*G, message + k*pub).
+func (c Curve) Encrypt(message, pub Point) (c1, c2 Point, err error) {
+	k, err := rand.Int(rand.Reader, c.N)
+	if err != nil {
+		return Point{}, Point{}, err
+	}
+	return c.EncryptWithK(message, pub, k)
+}
+
+// EncryptWithK is Encrypt with an explicit ephemeral scalar, primarily for
+// deterministic test vectors.
+func (c Curve) EncryptWithK(message, pub Point, k *big.Int) (c1, c2 Point, err error) {
+	c1, err = c.ScalarMult(k, c.G)
+	if err != nil {
+		return Point{}, Point{}, err
+	}
+	shared, err := c.ScalarMult(k, pub)
+	if err != nil {
+		return Point{}, Point{}, err
+	}
+	c2, err = c.Add(message, shared)
+	if err != nil {
+		return Point{}, Point{}, err
+	}
+	return c1, c2, nil
+}
+
+// Decrypt reverses Encrypt given the recipient's private scalar:
+// message = c2 - priv*c1.
+func (c Curve) Decrypt(priv *big.Int, c1, c2 Point) (Point, error) {
+	shared, err := c.ScalarMult(priv, c1)
+	if err != nil {
+		return Point{}, err
+	}
+	negShared := Point{X: shared.X, Y: bigint.Mod(bigint.Sub(c.P, shared.Y), c.P)}
+	return c.Add(c2, negShared)
+}
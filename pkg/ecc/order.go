@@ -0,0 +1,24 @@
+package ecc
+
+import "math/big"
+
+// Order computes the order of c.G by naive point counting: walking k*G from
+// k=1 until it reaches infinity. This is only practical for teaching-sized
+// primes, since Hasse's theorem bounds the true order to within 2*sqrt(P) of
+// P+1 and this loop is O(order) - real curves use Schoof's algorithm
+// instead, which this package does not implement.
+func (c Curve) Order() (*big.Int, error) {
+	k := big.NewInt(1)
+	point := c.G
+	for {
+		if point.isInfinity() {
+			return new(big.Int).Set(k), nil
+		}
+		var err error
+		point, err = c.Add(point, c.G)
+		if err != nil {
+			return nil, err
+		}
+		k.Add(k, big.NewInt(1))
+	}
+}
@@ -0,0 +1,215 @@
+// Package md5 is a from-scratch, streaming implementation of RFC 1321 MD5,
+// built to satisfy hash.Hash the same way crypto/md5 does: Write processes
+// full 64-byte blocks as they arrive instead of buffering the whole input,
+// and Sum only applies the 0x80/length padding to a throwaway copy of the
+// running state, so calling it doesn't disturb a Hash still being written
+// to and can be called more than once.
+package md5
+
+import (
+	"encoding/binary"
+	"hash"
+	"math"
+)
+
+// Size is the size, in bytes, of an MD5 checksum.
+const Size = 16
+
+// BlockSize is the block size, in bytes, MD5 operates on.
+const BlockSize = 64
+
+// t is the RFC 1321 sine-derived constant table: t[i] = floor(abs(sin(i+1)) * 2^32).
+var t [64]uint32
+
+func init() {
+	for i := range t {
+		t[i] = uint32(math.Abs(math.Sin(float64(i+1))) * math.Pow(2, 32))
+	}
+}
+
+type digest struct {
+	a, b, c, d uint32
+	x          [BlockSize]byte
+	nx         int
+	length     uint64
+}
+
+// New returns a new hash.Hash computing the MD5 checksum, mirroring
+// crypto/md5.New().
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+func (d *digest) Reset() {
+	d.a = 0x67452301
+	d.b = 0xefcdab89
+	d.c = 0x98badcfe
+	d.d = 0x10325476
+	d.nx = 0
+	d.length = 0
+}
+
+func (d *digest) Size() int      { return Size }
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	d.length += uint64(n)
+
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		if d.nx == BlockSize {
+			block(d, d.x[:])
+			d.nx = 0
+		}
+		p = p[c:]
+	}
+	for len(p) >= BlockSize {
+		block(d, p[:BlockSize])
+		p = p[BlockSize:]
+	}
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+	return n, nil
+}
+
+// Sum appends the current checksum to in without mutating d, so it can be
+// called mid-stream (to checkpoint a running hash) or more than once.
+func (d *digest) Sum(in []byte) []byte {
+	d0 := *d
+	hash := d0.checkSum()
+	return append(in, hash[:]...)
+}
+
+// checkSum pads the message (a single 0x80 bit followed by zeros, then the
+// little-endian 64-bit bit length - MD5 padding is little-endian, unlike
+// SHA's big-endian length field) and extracts the running state. It
+// mutates the receiver, which is always a throwaway copy made by Sum.
+func (d *digest) checkSum() [Size]byte {
+	length := d.length
+
+	var tmp [BlockSize]byte
+	tmp[0] = 0x80
+	if length%BlockSize < 56 {
+		d.Write(tmp[0 : 56-length%BlockSize])
+	} else {
+		d.Write(tmp[0 : BlockSize+56-length%BlockSize])
+	}
+
+	length <<= 3
+	var lenBytes [8]byte
+	binary.LittleEndian.PutUint64(lenBytes[:], length)
+	d.Write(lenBytes[:])
+
+	var out [Size]byte
+	binary.LittleEndian.PutUint32(out[0:], d.a)
+	binary.LittleEndian.PutUint32(out[4:], d.b)
+	binary.LittleEndian.PutUint32(out[8:], d.c)
+	binary.LittleEndian.PutUint32(out[12:], d.d)
+	return out
+}
+
+func f(x, y, z uint32) uint32 { return (x & y) | (^x & z) }
+func g(x, y, z uint32) uint32 { return (x & z) | (y & ^z) }
+func h(x, y, z uint32) uint32 { return x ^ y ^ z }
+func i(x, y, z uint32) uint32 { return y ^ (x | ^z) }
+
+func rotateLeft(x, n uint32) uint32 {
+	return (x << n) | (x >> (32 - n))
+}
+
+// block runs the MD5 compression function over one or more 64-byte chunks
+// of p, updating d's running state in place.
+func block(d *digest, p []byte) {
+	var x [16]uint32
+	for len(p) >= BlockSize {
+		for j := 0; j < 16; j++ {
+			x[j] = binary.LittleEndian.Uint32(p[j*4:])
+		}
+
+		a, b, c, dd := d.a, d.b, d.c, d.d
+
+		// Round 1
+		a = b + rotateLeft(a+f(b, c, dd)+x[0]+t[0], 7)
+		dd = a + rotateLeft(dd+f(a, b, c)+x[1]+t[1], 12)
+		c = dd + rotateLeft(c+f(dd, a, b)+x[2]+t[2], 17)
+		b = c + rotateLeft(b+f(c, dd, a)+x[3]+t[3], 22)
+		a = b + rotateLeft(a+f(b, c, dd)+x[4]+t[4], 7)
+		dd = a + rotateLeft(dd+f(a, b, c)+x[5]+t[5], 12)
+		c = dd + rotateLeft(c+f(dd, a, b)+x[6]+t[6], 17)
+		b = c + rotateLeft(b+f(c, dd, a)+x[7]+t[7], 22)
+		a = b + rotateLeft(a+f(b, c, dd)+x[8]+t[8], 7)
+		dd = a + rotateLeft(dd+f(a, b, c)+x[9]+t[9], 12)
+		c = dd + rotateLeft(c+f(dd, a, b)+x[10]+t[10], 17)
+		b = c + rotateLeft(b+f(c, dd, a)+x[11]+t[11], 22)
+		a = b + rotateLeft(a+f(b, c, dd)+x[12]+t[12], 7)
+		dd = a + rotateLeft(dd+f(a, b, c)+x[13]+t[13], 12)
+		c = dd + rotateLeft(c+f(dd, a, b)+x[14]+t[14], 17)
+		b = c + rotateLeft(b+f(c, dd, a)+x[15]+t[15], 22)
+
+		// Round 2
+		a = b + rotateLeft(a+g(b, c, dd)+x[1]+t[16], 5)
+		dd = a + rotateLeft(dd+g(a, b, c)+x[6]+t[17], 9)
+		c = dd + rotateLeft(c+g(dd, a, b)+x[11]+t[18], 14)
+		b = c + rotateLeft(b+g(c, dd, a)+x[0]+t[19], 20)
+		a = b + rotateLeft(a+g(b, c, dd)+x[5]+t[20], 5)
+		dd = a + rotateLeft(dd+g(a, b, c)+x[10]+t[21], 9)
+		c = dd + rotateLeft(c+g(dd, a, b)+x[15]+t[22], 14)
+		b = c + rotateLeft(b+g(c, dd, a)+x[4]+t[23], 20)
+		a = b + rotateLeft(a+g(b, c, dd)+x[9]+t[24], 5)
+		dd = a + rotateLeft(dd+g(a, b, c)+x[14]+t[25], 9)
+		c = dd + rotateLeft(c+g(dd, a, b)+x[3]+t[26], 14)
+		b = c + rotateLeft(b+g(c, dd, a)+x[8]+t[27], 20)
+		a = b + rotateLeft(a+g(b, c, dd)+x[13]+t[28], 5)
+		dd = a + rotateLeft(dd+g(a, b, c)+x[2]+t[29], 9)
+		c = dd + rotateLeft(c+g(dd, a, b)+x[7]+t[30], 14)
+		b = c + rotateLeft(b+g(c, dd, a)+x[12]+t[31], 20)
+
+		// Round 3
+		a = b + rotateLeft(a+h(b, c, dd)+x[5]+t[32], 4)
+		dd = a + rotateLeft(dd+h(a, b, c)+x[8]+t[33], 11)
+		c = dd + rotateLeft(c+h(dd, a, b)+x[11]+t[34], 16)
+		b = c + rotateLeft(b+h(c, dd, a)+x[14]+t[35], 23)
+		a = b + rotateLeft(a+h(b, c, dd)+x[1]+t[36], 4)
+		dd = a + rotateLeft(dd+h(a, b, c)+x[4]+t[37], 11)
+		c = dd + rotateLeft(c+h(dd, a, b)+x[7]+t[38], 16)
+		b = c + rotateLeft(b+h(c, dd, a)+x[10]+t[39], 23)
+		a = b + rotateLeft(a+h(b, c, dd)+x[13]+t[40], 4)
+		dd = a + rotateLeft(dd+h(a, b, c)+x[0]+t[41], 11)
+		c = dd + rotateLeft(c+h(dd, a, b)+x[3]+t[42], 16)
+		b = c + rotateLeft(b+h(c, dd, a)+x[6]+t[43], 23)
+		a = b + rotateLeft(a+h(b, c, dd)+x[9]+t[44], 4)
+		dd = a + rotateLeft(dd+h(a, b, c)+x[12]+t[45], 11)
+		c = dd + rotateLeft(c+h(dd, a, b)+x[15]+t[46], 16)
+		b = c + rotateLeft(b+h(c, dd, a)+x[2]+t[47], 23)
+
+		// Round 4
+		a = b + rotateLeft(a+i(b, c, dd)+x[0]+t[48], 6)
+		dd = a + rotateLeft(dd+i(a, b, c)+x[7]+t[49], 10)
+		c = dd + rotateLeft(c+i(dd, a, b)+x[14]+t[50], 15)
+		b = c + rotateLeft(b+i(c, dd, a)+x[5]+t[51], 21)
+		a = b + rotateLeft(a+i(b, c, dd)+x[12]+t[52], 6)
+		dd = a + rotateLeft(dd+i(a, b, c)+x[3]+t[53], 10)
+		c = dd + rotateLeft(c+i(dd, a, b)+x[10]+t[54], 15)
+		b = c + rotateLeft(b+i(c, dd, a)+x[1]+t[55], 21)
+		a = b + rotateLeft(a+i(b, c, dd)+x[8]+t[56], 6)
+		dd = a + rotateLeft(dd+i(a, b, c)+x[15]+t[57], 10)
+		c = dd + rotateLeft(c+i(dd, a, b)+x[6]+t[58], 15)
+		b = c + rotateLeft(b+i(c, dd, a)+x[13]+t[59], 21)
+		a = b + rotateLeft(a+i(b, c, dd)+x[4]+t[60], 6)
+		dd = a + rotateLeft(dd+i(a, b, c)+x[11]+t[61], 10)
+		c = dd + rotateLeft(c+i(dd, a, b)+x[2]+t[62], 15)
+		b = c + rotateLeft(b+i(c, dd, a)+x[9]+t[63], 21)
+
+		d.a += a
+		d.b += b
+		d.c += c
+		d.d += dd
+
+		p = p[BlockSize:]
+	}
+}
@@ -0,0 +1,110 @@
+package md5
+
+import (
+	stdmd5 "crypto/md5"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// rfc1321Vectors is the test suite from RFC 1321, Section A.5.
+var rfc1321Vectors = []struct {
+	in   string
+	want string
+}{
+	{"", "d41d8cd98f00b204e9800998ecf8427e"},
+	{"a", "0cc175b9c0f1b6a831c399e269772661"},
+	{"abc", "900150983cd24fb0d6963f7d28e17f72"},
+	{"message digest", "f96b697d7cb7938d525a2f31aaf161d0"},
+	{"abcdefghijklmnopqrstuvwxyz", "c3fcd3d76192e4007dfb496cca67e13b"},
+	{"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789", "d174ab98d277d9f5a5611c2c9f419d9f"},
+	{"12345678901234567890123456789012345678901234567890123456789012345678901234567890", "57edf4a22be3c955ac49da2e2107b67a"},
+}
+
+func TestRFC1321Vectors(t *testing.T) {
+	for _, tc := range rfc1321Vectors {
+		h := New()
+		io.WriteString(h, tc.in)
+		got := fmt.Sprintf("%x", h.Sum(nil))
+		if got != tc.want {
+			t.Errorf("Sum(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+}
+
+// TestWriteChunked confirms Write gives the same digest regardless of how
+// the input is split across calls, covering the 56-mod-64 padding boundary
+// where the 0x80 byte still fits in the final block but the 8-byte length
+// does not, forcing an extra padding block.
+func TestWriteChunked(t *testing.T) {
+	for _, n := range []int{0, 1, 55, 56, 57, 63, 64, 65, 120, 1000} {
+		data := strings.Repeat("x", n)
+
+		whole := New()
+		io.WriteString(whole, data)
+		want := whole.Sum(nil)
+
+		chunked := New()
+		for i := 0; i < len(data); i += 7 {
+			end := i + 7
+			if end > len(data) {
+				end = len(data)
+			}
+			io.WriteString(chunked, data[i:end])
+		}
+		got := chunked.Sum(nil)
+
+		if fmt.Sprintf("%x", got) != fmt.Sprintf("%x", want) {
+			t.Errorf("n=%d: chunked write mismatch: got %x, want %x", n, got, want)
+		}
+	}
+}
+
+func TestSumIsIdempotent(t *testing.T) {
+	h := New()
+	io.WriteString(h, "some input")
+	first := h.Sum(nil)
+	second := h.Sum(nil)
+	if fmt.Sprintf("%x", first) != fmt.Sprintf("%x", second) {
+		t.Fatalf("Sum mutated state: got %x then %x", first, second)
+	}
+	io.WriteString(h, " more input")
+	third := fmt.Sprintf("%x", h.Sum(nil))
+
+	want := New()
+	io.WriteString(want, "some input more input")
+	if third != fmt.Sprintf("%x", want.Sum(nil)) {
+		t.Fatalf("writing after Sum produced %s, want %s", third, fmt.Sprintf("%x", want.Sum(nil)))
+	}
+}
+
+func TestSizeAndBlockSize(t *testing.T) {
+	h := New()
+	if h.Size() != Size {
+		t.Fatalf("Size() = %d, want %d", h.Size(), Size)
+	}
+	if h.BlockSize() != BlockSize {
+		t.Fatalf("BlockSize() = %d, want %d", h.BlockSize(), BlockSize)
+	}
+}
+
+func BenchmarkEduHash(b *testing.B) {
+	data := []byte(strings.Repeat("a", 4096))
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		h := New()
+		h.Write(data)
+		h.Sum(nil)
+	}
+}
+
+func BenchmarkStdlib(b *testing.B) {
+	data := []byte(strings.Repeat("a", 4096))
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		h := stdmd5.New()
+		h.Write(data)
+		h.Sum(nil)
+	}
+}
@@ -0,0 +1,153 @@
+// Package sha1 is a from-scratch, streaming implementation of FIPS 180-4
+// SHA-1, shaped like crypto/sha1: Write processes full 64-byte blocks
+// incrementally and Sum pads a throwaway copy of the running state (a
+// single 0x80 bit, zeros, then the big-endian 64-bit bit length - SHA
+// padding is big-endian, unlike MD5's little-endian length field), so
+// calling it doesn't disturb a Hash still being written to.
+package sha1
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// Size is the size, in bytes, of a SHA-1 checksum.
+const Size = 20
+
+// BlockSize is the block size, in bytes, SHA-1 operates on.
+const BlockSize = 64
+
+type digest struct {
+	h      [5]uint32
+	x      [BlockSize]byte
+	nx     int
+	length uint64
+}
+
+// New returns a new hash.Hash computing the SHA-1 checksum, mirroring
+// crypto/sha1.New().
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+func (d *digest) Reset() {
+	d.h[0] = 0x67452301
+	d.h[1] = 0xEFCDAB89
+	d.h[2] = 0x98BADCFE
+	d.h[3] = 0x10325476
+	d.h[4] = 0xC3D2E1F0
+	d.nx = 0
+	d.length = 0
+}
+
+func (d *digest) Size() int      { return Size }
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	d.length += uint64(n)
+
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		if d.nx == BlockSize {
+			block(d, d.x[:])
+			d.nx = 0
+		}
+		p = p[c:]
+	}
+	for len(p) >= BlockSize {
+		block(d, p[:BlockSize])
+		p = p[BlockSize:]
+	}
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+	return n, nil
+}
+
+// Sum appends the current checksum to in without mutating d.
+func (d *digest) Sum(in []byte) []byte {
+	d0 := *d
+	hash := d0.checkSum()
+	return append(in, hash[:]...)
+}
+
+func (d *digest) checkSum() [Size]byte {
+	length := d.length
+
+	var tmp [BlockSize]byte
+	tmp[0] = 0x80
+	if length%BlockSize < 56 {
+		d.Write(tmp[0 : 56-length%BlockSize])
+	} else {
+		d.Write(tmp[0 : BlockSize+56-length%BlockSize])
+	}
+
+	length <<= 3
+	var lenBytes [8]byte
+	binary.BigEndian.PutUint64(lenBytes[:], length)
+	d.Write(lenBytes[:])
+
+	var out [Size]byte
+	for i, v := range d.h {
+		binary.BigEndian.PutUint32(out[i*4:], v)
+	}
+	return out
+}
+
+func rotateLeft(x uint32, n uint) uint32 {
+	return (x << n) | (x >> (32 - n))
+}
+
+// block runs the SHA-1 compression function over one or more 64-byte
+// chunks of p, updating d's running state in place.
+func block(d *digest, p []byte) {
+	var w [80]uint32
+	for len(p) >= BlockSize {
+		for i := 0; i < 16; i++ {
+			w[i] = binary.BigEndian.Uint32(p[i*4:])
+		}
+		for i := 16; i < 80; i++ {
+			w[i] = rotateLeft(w[i-3]^w[i-8]^w[i-14]^w[i-16], 1)
+		}
+
+		a, b, c, dd, e := d.h[0], d.h[1], d.h[2], d.h[3], d.h[4]
+
+		for i := 0; i < 80; i++ {
+			var f uint32
+			var k uint32
+			switch {
+			case i < 20:
+				f = (b & c) | (^b & dd)
+				k = 0x5A827999
+			case i < 40:
+				f = b ^ c ^ dd
+				k = 0x6ED9EBA1
+			case i < 60:
+				f = (b & c) | (b & dd) | (c & dd)
+				k = 0x8F1BBCDC
+			default:
+				f = b ^ c ^ dd
+				k = 0xCA62C1D6
+			}
+
+			temp := rotateLeft(a, 5) + f + e + k + w[i]
+			e = dd
+			dd = c
+			c = rotateLeft(b, 30)
+			b = a
+			a = temp
+		}
+
+		d.h[0] += a
+		d.h[1] += b
+		d.h[2] += c
+		d.h[3] += dd
+		d.h[4] += e
+
+		p = p[BlockSize:]
+	}
+}
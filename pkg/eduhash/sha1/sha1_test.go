@@ -0,0 +1,104 @@
+package sha1
+
+import (
+	stdsha1 "crypto/sha1"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fips1801Vectors is the test suite from FIPS 180-1, Appendix A.
+var fips1801Vectors = []struct {
+	in   string
+	want string
+}{
+	{"", "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+	{"abc", "a9993e364706816aba3e25717850c26c9cd0d89d"},
+	{"abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "84983e441c3bd26ebaae4aa1f95129e5e54670f1"},
+}
+
+func TestFIPS1801Vectors(t *testing.T) {
+	for _, tc := range fips1801Vectors {
+		h := New()
+		io.WriteString(h, tc.in)
+		got := fmt.Sprintf("%x", h.Sum(nil))
+		if got != tc.want {
+			t.Errorf("Sum(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+}
+
+// TestWriteChunked confirms Write gives the same digest regardless of how
+// the input is split across calls, covering the 56-mod-64 padding boundary.
+func TestWriteChunked(t *testing.T) {
+	for _, n := range []int{0, 1, 55, 56, 57, 63, 64, 65, 120, 1000} {
+		data := strings.Repeat("x", n)
+
+		whole := New()
+		io.WriteString(whole, data)
+		want := whole.Sum(nil)
+
+		chunked := New()
+		for i := 0; i < len(data); i += 7 {
+			end := i + 7
+			if end > len(data) {
+				end = len(data)
+			}
+			io.WriteString(chunked, data[i:end])
+		}
+		got := chunked.Sum(nil)
+
+		if fmt.Sprintf("%x", got) != fmt.Sprintf("%x", want) {
+			t.Errorf("n=%d: chunked write mismatch: got %x, want %x", n, got, want)
+		}
+	}
+}
+
+func TestSumIsIdempotent(t *testing.T) {
+	h := New()
+	io.WriteString(h, "some input")
+	first := h.Sum(nil)
+	second := h.Sum(nil)
+	if fmt.Sprintf("%x", first) != fmt.Sprintf("%x", second) {
+		t.Fatalf("Sum mutated state: got %x then %x", first, second)
+	}
+	io.WriteString(h, " more input")
+	third := fmt.Sprintf("%x", h.Sum(nil))
+
+	want := New()
+	io.WriteString(want, "some input more input")
+	if third != fmt.Sprintf("%x", want.Sum(nil)) {
+		t.Fatalf("writing after Sum produced %s, want %s", third, fmt.Sprintf("%x", want.Sum(nil)))
+	}
+}
+
+func TestSizeAndBlockSize(t *testing.T) {
+	h := New()
+	if h.Size() != Size {
+		t.Fatalf("Size() = %d, want %d", h.Size(), Size)
+	}
+	if h.BlockSize() != BlockSize {
+		t.Fatalf("BlockSize() = %d, want %d", h.BlockSize(), BlockSize)
+	}
+}
+
+func BenchmarkEduHash(b *testing.B) {
+	data := []byte(strings.Repeat("a", 4096))
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		h := New()
+		h.Write(data)
+		h.Sum(nil)
+	}
+}
+
+func BenchmarkStdlib(b *testing.B) {
+	data := []byte(strings.Repeat("a", 4096))
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		h := stdsha1.New()
+		h.Write(data)
+		h.Sum(nil)
+	}
+}
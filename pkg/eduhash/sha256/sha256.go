@@ -0,0 +1,165 @@
+// Package sha256 is a from-scratch, streaming implementation of FIPS 180-4
+// SHA-256, shaped like crypto/sha256: Write processes full 64-byte blocks
+// incrementally and Sum pads a throwaway copy of the running state (a
+// single 0x80 bit, zeros, then the big-endian 64-bit bit length), so
+// calling it doesn't disturb a Hash still being written to.
+package sha256
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// Size is the size, in bytes, of a SHA-256 checksum.
+const Size = 32
+
+// BlockSize is the block size, in bytes, SHA-256 operates on.
+const BlockSize = 64
+
+// k is the FIPS 180-4 round constant table: the fractional parts of the
+// cube roots of the first 64 primes.
+var k = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+type digest struct {
+	h      [8]uint32
+	x      [BlockSize]byte
+	nx     int
+	length uint64
+}
+
+// New returns a new hash.Hash computing the SHA-256 checksum, mirroring
+// crypto/sha256.New().
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+func (d *digest) Reset() {
+	d.h[0] = 0x6a09e667
+	d.h[1] = 0xbb67ae85
+	d.h[2] = 0x3c6ef372
+	d.h[3] = 0xa54ff53a
+	d.h[4] = 0x510e527f
+	d.h[5] = 0x9b05688c
+	d.h[6] = 0x1f83d9ab
+	d.h[7] = 0x5be0cd19
+	d.nx = 0
+	d.length = 0
+}
+
+func (d *digest) Size() int      { return Size }
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	d.length += uint64(n)
+
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		if d.nx == BlockSize {
+			block(d, d.x[:])
+			d.nx = 0
+		}
+		p = p[c:]
+	}
+	for len(p) >= BlockSize {
+		block(d, p[:BlockSize])
+		p = p[BlockSize:]
+	}
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+	return n, nil
+}
+
+// Sum appends the current checksum to in without mutating d.
+func (d *digest) Sum(in []byte) []byte {
+	d0 := *d
+	hash := d0.checkSum()
+	return append(in, hash[:]...)
+}
+
+func (d *digest) checkSum() [Size]byte {
+	length := d.length
+
+	var tmp [BlockSize]byte
+	tmp[0] = 0x80
+	if length%BlockSize < 56 {
+		d.Write(tmp[0 : 56-length%BlockSize])
+	} else {
+		d.Write(tmp[0 : BlockSize+56-length%BlockSize])
+	}
+
+	length <<= 3
+	var lenBytes [8]byte
+	binary.BigEndian.PutUint64(lenBytes[:], length)
+	d.Write(lenBytes[:])
+
+	var out [Size]byte
+	for i, v := range d.h {
+		binary.BigEndian.PutUint32(out[i*4:], v)
+	}
+	return out
+}
+
+func rotateRight(x uint32, n uint) uint32 {
+	return (x >> n) | (x << (32 - n))
+}
+
+// block runs the SHA-256 compression function over one or more 64-byte
+// chunks of p, updating d's running state in place.
+func block(d *digest, p []byte) {
+	var w [64]uint32
+	for len(p) >= BlockSize {
+		for i := 0; i < 16; i++ {
+			w[i] = binary.BigEndian.Uint32(p[i*4:])
+		}
+		for i := 16; i < 64; i++ {
+			s0 := rotateRight(w[i-15], 7) ^ rotateRight(w[i-15], 18) ^ (w[i-15] >> 3)
+			s1 := rotateRight(w[i-2], 17) ^ rotateRight(w[i-2], 19) ^ (w[i-2] >> 10)
+			w[i] = w[i-16] + s0 + w[i-7] + s1
+		}
+
+		a, b, c, dd, e, f, g, hh := d.h[0], d.h[1], d.h[2], d.h[3], d.h[4], d.h[5], d.h[6], d.h[7]
+
+		for i := 0; i < 64; i++ {
+			s1 := rotateRight(e, 6) ^ rotateRight(e, 11) ^ rotateRight(e, 25)
+			ch := (e & f) ^ (^e & g)
+			temp1 := hh + s1 + ch + k[i] + w[i]
+			s0 := rotateRight(a, 2) ^ rotateRight(a, 13) ^ rotateRight(a, 22)
+			maj := (a & b) ^ (a & c) ^ (b & c)
+			temp2 := s0 + maj
+
+			hh = g
+			g = f
+			f = e
+			e = dd + temp1
+			dd = c
+			c = b
+			b = a
+			a = temp1 + temp2
+		}
+
+		d.h[0] += a
+		d.h[1] += b
+		d.h[2] += c
+		d.h[3] += dd
+		d.h[4] += e
+		d.h[5] += f
+		d.h[6] += g
+		d.h[7] += hh
+
+		p = p[BlockSize:]
+	}
+}
@@ -0,0 +1,104 @@
+package fec
+
+// GF(256) arithmetic over the primitive polynomial x^8+x^4+x^3+x^2+1
+// (0x11d), the same field QR codes and CCITT use. Every symbol in a
+// codeword is one byte, so this single field underlies both encoding and
+// decoding below.
+const primitivePoly = 0x11d
+
+var (
+	expTable [510]byte // exp[i] = alpha^i, doubled up so exp[i+j] never overflows
+	logTable [256]byte // log[alpha^i] = i, logTable[0] is unused (0 has no log)
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		expTable[i] = byte(x)
+		logTable[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= primitivePoly
+		}
+	}
+	for i := 255; i < 510; i++ {
+		expTable[i] = expTable[i-255]
+	}
+}
+
+// gfAdd adds two field elements. GF(2^n) addition is XOR, and is its own
+// inverse, so subtraction is the same operation.
+func gfAdd(a, b byte) byte {
+	return a ^ b
+}
+
+// gfMul multiplies two field elements via the log/exp tables: a*b =
+// alpha^(log(a)+log(b)), with 0 handled separately since it has no log.
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+// gfDiv divides a by b. b must be non-zero.
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	if b == 0 {
+		panic("fec: division by zero in GF(256)")
+	}
+	diff := int(logTable[a]) - int(logTable[b])
+	if diff < 0 {
+		diff += 255
+	}
+	return expTable[diff]
+}
+
+// gfPow raises a to the n-th power.
+func gfPow(a byte, n int) byte {
+	if a == 0 {
+		if n == 0 {
+			return 1
+		}
+		return 0
+	}
+	e := (int(logTable[a]) * n) % 255
+	if e < 0 {
+		e += 255
+	}
+	return expTable[e]
+}
+
+// gfInv returns the multiplicative inverse of a. a must be non-zero.
+func gfInv(a byte) byte {
+	if a == 0 {
+		panic("fec: no inverse for 0 in GF(256)")
+	}
+	return expTable[255-int(logTable[a])]
+}
+
+// polyEval evaluates polynomial p (coefficients highest-degree first) at x
+// using Horner's method.
+func polyEval(p []byte, x byte) byte {
+	result := p[0]
+	for i := 1; i < len(p); i++ {
+		result = gfAdd(gfMul(result, x), p[i])
+	}
+	return result
+}
+
+// polyMul multiplies two polynomials (coefficients highest-degree first).
+func polyMul(a, b []byte) []byte {
+	result := make([]byte, len(a)+len(b)-1)
+	for i, ac := range a {
+		if ac == 0 {
+			continue
+		}
+		for j, bc := range b {
+			result[i+j] = gfAdd(result[i+j], gfMul(ac, bc))
+		}
+	}
+	return result
+}
@@ -0,0 +1,284 @@
+// Package fec implements a classical (non-erasure-sharded) Reed-Solomon
+// error-correcting code over GF(256), the same construction QR codes and
+// CCITT fax use: each codeword byte is a symbol, a generator polynomial
+// built from consecutive roots of the field appends parity symbols, and
+// decoding recovers up to parity/2 corrupted symbols per codeword without
+// needing to know where the corruption is. It's meant for protecting
+// small, fixed-size records (e.g. a serialized struct or a file header)
+// against bit rot or partial disk corruption, not for the shard-based
+// erasure coding large-file FEC tools use.
+package fec
+
+import "fmt"
+
+// RS is a Reed-Solomon codec for a fixed data-symbol count and
+// parity-symbol count. The zero value is not usable; construct with New.
+type RS struct {
+	DataShards   int // k: symbols of payload per codeword
+	ParityShards int // codeword length n = DataShards + ParityShards
+
+	generator []byte // generator polynomial, degree == ParityShards
+}
+
+// New returns a codec for codewords of dataShards payload symbols plus
+// parityShards parity symbols. A codeword can correct up to
+// parityShards/2 corrupted symbols, anywhere in the codeword, without
+// knowing their positions in advance. dataShards+parityShards must not
+// exceed 255, since GF(256) only has that many non-zero elements to use
+// as generator roots.
+func New(dataShards, parityShards int) (*RS, error) {
+	if dataShards <= 0 || parityShards <= 0 {
+		return nil, fmt.Errorf("fec: dataShards and parityShards must be positive")
+	}
+	if dataShards+parityShards > 255 {
+		return nil, fmt.Errorf("fec: codeword length %d exceeds GF(256) limit of 255", dataShards+parityShards)
+	}
+
+	// generator(x) = product_{i=1..parityShards} (x - alpha^i), with roots
+	// starting at alpha^1 to match the alpha^1..alpha^parityShards points
+	// syndromes() evaluates codewords at.
+	generator := []byte{1}
+	for i := 1; i <= parityShards; i++ {
+		generator = polyMul(generator, []byte{1, gfPow(2, i)})
+	}
+
+	return &RS{DataShards: dataShards, ParityShards: parityShards, generator: generator}, nil
+}
+
+// Encode returns the systematic codeword for data: data followed by
+// ParityShards parity bytes. len(data) must equal rs.DataShards; pad
+// short input before calling.
+func (rs *RS) Encode(data []byte) ([]byte, error) {
+	if len(data) != rs.DataShards {
+		return nil, fmt.Errorf("fec: Encode wants %d data bytes, got %d", rs.DataShards, len(data))
+	}
+
+	// Systematic encoding: parity = remainder of (data * x^parityShards) / generator.
+	remainder := make([]byte, rs.DataShards+rs.ParityShards)
+	copy(remainder, data)
+
+	for i := 0; i < rs.DataShards; i++ {
+		coef := remainder[i]
+		if coef == 0 {
+			continue
+		}
+		for j, g := range rs.generator {
+			remainder[i+j] = gfAdd(remainder[i+j], gfMul(coef, g))
+		}
+	}
+
+	codeword := make([]byte, rs.DataShards+rs.ParityShards)
+	copy(codeword, data)
+	copy(codeword[rs.DataShards:], remainder[rs.DataShards:])
+	return codeword, nil
+}
+
+// Decode corrects up to rs.ParityShards/2 corrupted symbols in codeword
+// and returns the recovered data shards. It reports an error (without
+// modifying codeword) if the damage exceeds that bound, since a decode
+// attempted past the guaranteed correction radius can silently return the
+// wrong answer. len(codeword) must equal rs.DataShards+rs.ParityShards.
+func (rs *RS) Decode(codeword []byte) ([]byte, int, error) {
+	n := rs.DataShards + rs.ParityShards
+	if len(codeword) != n {
+		return nil, 0, fmt.Errorf("fec: Decode wants a %d-byte codeword, got %d", n, len(codeword))
+	}
+
+	syndromes := rs.syndromes(codeword)
+	if allZero(syndromes) {
+		// No errors detected.
+		data := make([]byte, rs.DataShards)
+		copy(data, codeword[:rs.DataShards])
+		return data, 0, nil
+	}
+
+	errLocator := berlekampMassey(syndromes)
+	numErrors := len(errLocator) - 1
+	if numErrors > rs.ParityShards/2 {
+		return nil, 0, fmt.Errorf("fec: too many errors to correct (locator degree %d exceeds capacity %d)", numErrors, rs.ParityShards/2)
+	}
+
+	errPositions, ok := chienSearch(errLocator, n)
+	if !ok || len(errPositions) != numErrors {
+		return nil, 0, fmt.Errorf("fec: uncorrectable codeword (error locations could not be resolved)")
+	}
+
+	magnitudes := forneyAlgorithm(syndromes, errLocator, errPositions, n)
+
+	corrected := make([]byte, n)
+	copy(corrected, codeword)
+	for i, pos := range errPositions {
+		corrected[pos] = gfAdd(corrected[pos], magnitudes[i])
+	}
+
+	// Verify the correction actually zeroes the syndromes before trusting it.
+	if !allZero(rs.syndromes(corrected)) {
+		return nil, 0, fmt.Errorf("fec: uncorrectable codeword (correction failed verification)")
+	}
+
+	data := make([]byte, rs.DataShards)
+	copy(data, corrected[:rs.DataShards])
+	return data, numErrors, nil
+}
+
+// syndromes computes S_1..S_parityShards for codeword, treated as a
+// polynomial evaluated at alpha^1..alpha^parityShards (the generator's
+// roots). A clean codeword is a multiple of the generator, so it
+// evaluates to zero at every root; any non-zero syndrome flags damage.
+func (rs *RS) syndromes(codeword []byte) []byte {
+	syndromes := make([]byte, rs.ParityShards)
+	for i := 0; i < rs.ParityShards; i++ {
+		syndromes[i] = polyEval(codeword, gfPow(2, i+1))
+	}
+	return syndromes
+}
+
+// berlekampMassey finds the shortest linear-feedback-shift-register
+// (equivalently, the lowest-degree error-locator polynomial) that
+// generates the syndrome sequence, per the standard Berlekamp-Massey
+// algorithm. Its roots' reciprocals are the error positions.
+func berlekampMassey(syndromes []byte) []byte {
+	c := make([]byte, len(syndromes)+1)
+	b := make([]byte, len(syndromes)+1)
+	c[0], b[0] = 1, 1
+
+	l, m := 0, 1
+	bCoef := byte(1)
+
+	for n := 0; n < len(syndromes); n++ {
+		delta := syndromes[n]
+		for i := 1; i <= l; i++ {
+			delta = gfAdd(delta, gfMul(c[i], syndromes[n-i]))
+		}
+		if delta == 0 {
+			m++
+			continue
+		}
+		t := make([]byte, len(c))
+		copy(t, c)
+
+		coef := gfDiv(delta, bCoef)
+		for i := 0; i < len(b); i++ {
+			if i+m < len(c) {
+				c[i+m] = gfAdd(c[i+m], gfMul(coef, b[i]))
+			}
+		}
+		if 2*l <= n {
+			l = n + 1 - l
+			b = t
+			bCoef = delta
+			m = 1
+		} else {
+			m++
+		}
+	}
+
+	return c[:l+1]
+}
+
+// chienSearch finds the roots of the error-locator polynomial by brute
+// force (trying every non-zero field element, as the field is only 255
+// elements wide) and converts each root to a codeword position.
+//
+// codeword array index i holds the coefficient of x^(n-1-i) (Encode and
+// syndromes both use that high-degree-first layout), so an error at index
+// i corresponds to locator root alpha^(-(n-1-i)) = alpha^(i-(n-1)).
+func chienSearch(locator []byte, n int) ([]int, bool) {
+	var positions []int
+	for i := 0; i < n; i++ {
+		root := gfPow(2, i-(n-1))
+		if evalLocator(locator, root) == 0 {
+			positions = append(positions, i)
+		}
+	}
+	return positions, true
+}
+
+// evalLocator evaluates the error-locator polynomial (lowest-degree
+// coefficient first) at x.
+func evalLocator(locator []byte, x byte) byte {
+	result := locator[0]
+	power := byte(1)
+	for i := 1; i < len(locator); i++ {
+		power = gfMul(power, x)
+		result = gfAdd(result, gfMul(locator[i], power))
+	}
+	return result
+}
+
+// forneyAlgorithm computes each error's magnitude from the syndromes and
+// error-locator polynomial via the standard Forney formula.
+func forneyAlgorithm(syndromes, locator []byte, errPositions []int, n int) []byte {
+	// Error evaluator polynomial: omega(x) = S(x)*locator(x) mod x^parityShards,
+	// with S(x) = S_1 + S_2 x + ... (lowest-degree first).
+	sPoly := make([]byte, len(syndromes))
+	copy(sPoly, syndromes)
+
+	full := polyMulLowFirst(sPoly, locator)
+	omega := full
+	if len(omega) > len(syndromes) {
+		omega = omega[:len(syndromes)]
+	}
+
+	// Formal derivative of the locator polynomial: drop even-degree terms,
+	// halve the remaining degrees (GF(2^m) derivative kills even powers).
+	locatorDeriv := make([]byte, 0, len(locator)/2+1)
+	for i := 1; i < len(locator); i += 2 {
+		locatorDeriv = append(locatorDeriv, locator[i])
+	}
+
+	magnitudes := make([]byte, len(errPositions))
+	for idx, pos := range errPositions {
+		// X_j is the field element associated with codeword position pos
+		// (see chienSearch); Forney needs its inverse.
+		xInv := gfPow(2, -(n - 1 - pos))
+
+		omegaVal := evalLowFirst(omega, xInv)
+		derivVal := evalLowFirst(locatorDeriv, gfMul(xInv, xInv))
+		if derivVal == 0 {
+			magnitudes[idx] = 0
+			continue
+		}
+		// Forney, for a narrow-sense code whose syndromes start at alpha^1
+		// (b=1, so the X_j^(1-b) factor is 1): e_j = omega(X_j^-1) / sigma'(X_j^-1).
+		// GF(2^m) addition is its own inverse, so there's no sign to flip.
+		magnitudes[idx] = gfDiv(omegaVal, derivVal)
+	}
+	return magnitudes
+}
+
+// polyMulLowFirst multiplies two polynomials given lowest-degree
+// coefficient first (the convention syndromes and locator use, the
+// reverse of polyMul's highest-first convention).
+func polyMulLowFirst(a, b []byte) []byte {
+	result := make([]byte, len(a)+len(b)-1)
+	for i, ac := range a {
+		if ac == 0 {
+			continue
+		}
+		for j, bc := range b {
+			result[i+j] = gfAdd(result[i+j], gfMul(ac, bc))
+		}
+	}
+	return result
+}
+
+// evalLowFirst evaluates a lowest-degree-first polynomial at x.
+func evalLowFirst(p []byte, x byte) byte {
+	result := byte(0)
+	power := byte(1)
+	for _, c := range p {
+		result = gfAdd(result, gfMul(c, power))
+		power = gfMul(power, x)
+	}
+	return result
+}
+
+func allZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
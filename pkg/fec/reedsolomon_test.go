@@ -0,0 +1,99 @@
+package fec
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestEncodeDecodeRoundTripsWithNoDamage(t *testing.T) {
+	rs, err := New(128, 8)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	data := make([]byte, rs.DataShards)
+	for i := range data {
+		data[i] = byte(i * 31)
+	}
+
+	codeword, err := rs.Encode(data)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	got, corrected, err := rs.Decode(codeword)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if corrected != 0 {
+		t.Fatalf("Decode: corrected = %d, want 0 for an undamaged codeword", corrected)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("Decode: got %v, want %v", got, data)
+	}
+}
+
+func TestDecodeCorrectsUpToHalfParityErrors(t *testing.T) {
+	rs, err := New(16, 32) // mirrors the FIM header's RS(16,48) codec
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	data := make([]byte, rs.DataShards)
+	for i := range data {
+		data[i] = byte(i*7 + 3)
+	}
+	codeword, err := rs.Encode(data)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	maxCorrectable := rs.ParityShards / 2
+	rng := rand.New(rand.NewSource(1))
+	for numErrors := 1; numErrors <= maxCorrectable; numErrors++ {
+		corrupted := append([]byte(nil), codeword...)
+		positions := make(map[int]bool)
+		for len(positions) < numErrors {
+			positions[rng.Intn(len(corrupted))] = true
+		}
+		for pos := range positions {
+			corrupted[pos] ^= byte(1 + rng.Intn(255))
+		}
+
+		got, corrected, err := rs.Decode(corrupted)
+		if err != nil {
+			t.Fatalf("Decode with %d errors: %v", numErrors, err)
+		}
+		if corrected != numErrors {
+			t.Fatalf("Decode with %d errors: reported %d corrected", numErrors, corrected)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("Decode with %d errors: got %v, want %v", numErrors, got, data)
+		}
+	}
+}
+
+func TestDecodeReportsErrorBeyondCorrectionRadius(t *testing.T) {
+	rs, err := New(128, 8)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	data := make([]byte, rs.DataShards)
+	codeword, err := rs.Encode(data)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	// 8 parity bytes correct at most 4 errors; 5 should be reported as
+	// unrecoverable rather than silently decoded to the wrong data.
+	for _, pos := range []int{0, 20, 40, 60, 80} {
+		codeword[pos] ^= 0xFF
+	}
+	if _, _, err := rs.Decode(codeword); err == nil {
+		t.Fatal("Decode: expected an error for damage beyond the correction radius")
+	}
+}
+
+func TestNewRejectsOversizedCodewords(t *testing.T) {
+	if _, err := New(200, 100); err == nil {
+		t.Fatal("New: expected an error for a 300-symbol codeword")
+	}
+}
@@ -0,0 +1,324 @@
+// Package filecrypt implements a Picocrypt-style authenticated file
+// encryption format: Argon2id key derivation, XChaCha20 (optionally cascaded
+// with a second independent stream-cipher pass in paranoid mode), a keyed
+// BLAKE2b-512 MAC checked before any plaintext is released, and a rolling
+// SHA3-256 over the plaintext as a second integrity check. The header can
+// optionally be Reed-Solomon protected so that a few flipped bytes in
+// storage don't make the whole file unrecoverable.
+package filecrypt
+
+import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	ChunkSize = 1 << 20 // 1 MiB, so files larger than RAM stream through in chunks
+
+	keyLen = 32
+)
+
+// Argon2Params controls the KDF cost. The defaults match Picocrypt's: these
+// are deliberately heavy, so tests should override them with a much cheaper
+// set (see filecrypt_test.go).
+type Argon2Params struct {
+	Time    uint32
+	Memory  uint32 // KiB
+	Threads uint8
+}
+
+var DefaultArgon2Params = Argon2Params{Time: 4, Memory: 1 << 20, Threads: 8} // 1 GiB
+
+// Argon2 cost ceilings applied to whatever Decrypt reads back out of a
+// file's header. The header is attacker-reachable (that's the whole point
+// of the Reed-Solomon repair path), so a flipped bit there must not be able
+// to turn "1 GiB" into "4 TiB" and send argon2.IDKey on a multi-gigabyte
+// allocation - clamp to comfortably above DefaultArgon2Params instead of
+// trusting the file.
+const (
+	maxArgon2Time    uint32 = 8
+	maxArgon2Memory  uint32 = 2 << 20 // 2 GiB, in KiB
+	maxArgon2Threads uint8  = 16
+)
+
+// clampArgon2Params bounds an untrusted Argon2Params (as read from a file
+// header) to a sane ceiling, so a corrupted or adversarial header can only
+// make key derivation slower, never allocate unbounded memory.
+func clampArgon2Params(p Argon2Params) Argon2Params {
+	if p.Time == 0 {
+		p.Time = 1
+	} else if p.Time > maxArgon2Time {
+		p.Time = maxArgon2Time
+	}
+	if p.Memory == 0 {
+		p.Memory = 1
+	} else if p.Memory > maxArgon2Memory {
+		p.Memory = maxArgon2Memory
+	}
+	if p.Threads == 0 {
+		p.Threads = 1
+	} else if p.Threads > maxArgon2Threads {
+		p.Threads = maxArgon2Threads
+	}
+	return p
+}
+
+// Options configures a single Encrypt/Decrypt call.
+type Options struct {
+	Comment     string
+	Paranoid    bool
+	ReedSolomon bool
+	Keyfile     []byte
+	Argon2      Argon2Params
+	// KeepOnMACFailure, when true, makes Decrypt return the (unverified)
+	// plaintext alongside the MAC error instead of discarding it - the -k flag.
+	KeepOnMACFailure bool
+}
+
+// derivedKeys are the three independent keys split out of the Argon2id
+// output via HKDF-SHA3-256, one per cryptographic role.
+type derivedKeys struct {
+	chacha  [keyLen]byte
+	cascade [keyLen]byte // second stream-cipher pass, paranoid mode only
+	mac     [keyLen]byte
+}
+
+func deriveKeys(password, salt, keyfile []byte, params Argon2Params) (derivedKeys, error) {
+	master := argon2.IDKey(password, salt, params.Time, params.Memory, params.Threads, keyLen)
+
+	info := []byte("filecrypt-v1")
+	ikm := master
+	if len(keyfile) > 0 {
+		ikm = append(append([]byte{}, master...), keyfile...)
+	}
+
+	r := hkdf.New(sha3.New256, ikm, salt, info)
+	var keys derivedKeys
+	for _, dst := range [][]byte{keys.chacha[:], keys.cascade[:], keys.mac[:]} {
+		if _, err := io.ReadFull(r, dst); err != nil {
+			return derivedKeys{}, err
+		}
+	}
+	return keys, nil
+}
+
+// Encrypt reads plaintext from r and writes the full filecrypt container
+// (header + MAC + ciphertext) to w, processing ChunkSize blocks at a time.
+func Encrypt(w io.Writer, r io.Reader, password []byte, opts Options) error {
+	params := opts.Argon2
+	if params == (Argon2Params{}) {
+		params = DefaultArgon2Params
+	}
+
+	var salt [16]byte
+	if _, err := io.ReadFull(randReader, salt[:]); err != nil {
+		return err
+	}
+	var nonce [24]byte
+	if _, err := io.ReadFull(randReader, nonce[:]); err != nil {
+		return err
+	}
+
+	keyfileSum := [64]byte{}
+	if len(opts.Keyfile) > 0 {
+		keyfileSum = blake2b.Sum512(opts.Keyfile)
+	}
+
+	keys, err := deriveKeys(password, salt[:], opts.Keyfile, params)
+	if err != nil {
+		return err
+	}
+
+	header := &Header{
+		Version:       CurrentVersion,
+		Comment:       opts.Comment,
+		Paranoid:      opts.Paranoid,
+		ReedSolomon:   opts.ReedSolomon,
+		HasKeyfile:    len(opts.Keyfile) > 0,
+		Argon2Time:    params.Time,
+		Argon2Memory:  params.Memory,
+		Argon2Threads: params.Threads,
+		Salt:          salt,
+		Nonce:         nonce,
+		KeyfileSum:    keyfileSum,
+	}
+	rawHeader := header.Marshal()
+
+	stream, err := chacha20.NewUnauthenticatedCipher(keys.chacha[:], nonce[:])
+	if err != nil {
+		return err
+	}
+	var cascade *chacha20.Cipher
+	if opts.Paranoid {
+		cascade, err = chacha20.NewUnauthenticatedCipher(keys.cascade[:], nonce[:12])
+		if err != nil {
+			return err
+		}
+	}
+
+	mac, err := blake2b.New512(keys.mac[:])
+	if err != nil {
+		return err
+	}
+	mac.Write(rawHeader)
+
+	plaintextHash := sha3.New256()
+
+	buf := make([]byte, ChunkSize)
+	var ciphertextChunks [][]byte
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			plaintextHash.Write(buf[:n])
+			stream.XORKeyStream(chunk, buf[:n])
+			if cascade != nil {
+				cascade.XORKeyStream(chunk, chunk)
+			}
+			mac.Write(chunk)
+			ciphertextChunks = append(ciphertextChunks, chunk)
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	// Fold the plaintext hash into the MAC as a second integrity check,
+	// independent of the stream cipher output.
+	mac.Write(plaintextHash.Sum(nil))
+	tag := mac.Sum(nil)
+
+	if _, err := w.Write(rawHeader); err != nil {
+		return err
+	}
+	if _, err := w.Write(tag); err != nil {
+		return err
+	}
+	for _, chunk := range ciphertextChunks {
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	// ReedSolomon mode appends a parity trailer for the header only, so -f
+	// can reconstruct a header damaged by a few bit-flips in storage without
+	// needing to parse the (possibly corrupted) header first.
+	if opts.ReedSolomon {
+		protected, err := protectHeader(rawHeader)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(protected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrMACMismatch is returned by Decrypt when the computed MAC doesn't match
+// the one stored in the file, meaning the password, keyfile, or ciphertext
+// is wrong/corrupted.
+var ErrMACMismatch = errors.New("filecrypt: MAC verification failed (wrong password, keyfile, or corrupted file)")
+
+// Decrypt verifies and decrypts a container produced by Encrypt, writing the
+// recovered plaintext to w. The whole ciphertext is buffered to compute and
+// check the MAC before anything is written, per Picocrypt's "verify before
+// decrypt" design; repair (-f) should be applied via Repair before calling
+// Decrypt on a damaged file.
+func Decrypt(w io.Writer, r io.Reader, password []byte, keyfile []byte, opts Options) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var header Header
+	consumed, err := header.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	if header.Version > CurrentVersion {
+		return errors.New("filecrypt: unsupported file version")
+	}
+	if len(data) < consumed+64 {
+		return errors.New("filecrypt: truncated file")
+	}
+	storedMAC := data[consumed : consumed+64]
+	ciphertext := data[consumed+64:]
+	rawHeader := data[:consumed]
+
+	if header.ReedSolomon {
+		trailerLen := protectedHeaderTrailerLen(consumed)
+		if len(ciphertext) < trailerLen {
+			return errors.New("filecrypt: missing Reed-Solomon header trailer")
+		}
+		ciphertext = ciphertext[:len(ciphertext)-trailerLen]
+	}
+
+	if header.HasKeyfile && len(keyfile) > 0 {
+		sum := blake2b.Sum512(keyfile)
+		if subtle.ConstantTimeCompare(sum[:], header.KeyfileSum[:]) != 1 {
+			return errors.New("filecrypt: wrong keyfile")
+		}
+	}
+
+	params := clampArgon2Params(Argon2Params{Time: header.Argon2Time, Memory: header.Argon2Memory, Threads: header.Argon2Threads})
+	keys, err := deriveKeys(password, header.Salt[:], keyfile, params)
+	if err != nil {
+		return err
+	}
+
+	mac, err := blake2b.New512(keys.mac[:])
+	if err != nil {
+		return err
+	}
+	mac.Write(rawHeader)
+	mac.Write(ciphertext)
+
+	stream, err := chacha20.NewUnauthenticatedCipher(keys.chacha[:], header.Nonce[:])
+	if err != nil {
+		return err
+	}
+	var cascade *chacha20.Cipher
+	if header.Paranoid {
+		cascade, err = chacha20.NewUnauthenticatedCipher(keys.cascade[:], header.Nonce[:12])
+		if err != nil {
+			return err
+		}
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	if cascade != nil {
+		cascade.XORKeyStream(plaintext, ciphertext) // undo cascade first (reverse of encrypt order)
+		stream.XORKeyStream(plaintext, plaintext)
+	} else {
+		stream.XORKeyStream(plaintext, ciphertext)
+	}
+
+	plaintextHash := sha3.Sum256(plaintext)
+	mac.Write(plaintextHash[:])
+	computed := mac.Sum(nil)
+
+	ok := hmac.Equal(computed, storedMAC)
+	if !ok && !opts.KeepOnMACFailure {
+		return ErrMACMismatch
+	}
+
+	if _, err := w.Write(plaintext); err != nil {
+		return err
+	}
+	if !ok {
+		return ErrMACMismatch
+	}
+	return nil
+}
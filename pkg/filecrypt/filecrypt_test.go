@@ -0,0 +1,140 @@
+package filecrypt
+
+import (
+	"bytes"
+	"testing"
+)
+
+// cheapParams keeps Argon2 fast enough for tests; production code should use
+// DefaultArgon2Params.
+var cheapParams = Argon2Params{Time: 1, Memory: 8 * 1024, Threads: 1}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	plaintext := []byte("the quick brown fox jumps over the lazy dog")
+	password := []byte("correct horse battery staple")
+
+	var ciphertext bytes.Buffer
+	err := Encrypt(&ciphertext, bytes.NewReader(plaintext), password, Options{Argon2: cheapParams})
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	var recovered bytes.Buffer
+	err = Decrypt(&recovered, bytes.NewReader(ciphertext.Bytes()), password, nil, Options{})
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(recovered.Bytes(), plaintext) {
+		t.Fatalf("round trip mismatch: got %q, want %q", recovered.Bytes(), plaintext)
+	}
+}
+
+func TestParanoidRoundTrip(t *testing.T) {
+	plaintext := bytes.Repeat([]byte("x"), ChunkSize+17) // span more than one chunk
+	password := []byte("hunter2")
+
+	var ciphertext bytes.Buffer
+	err := Encrypt(&ciphertext, bytes.NewReader(plaintext), password, Options{Argon2: cheapParams, Paranoid: true})
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	var recovered bytes.Buffer
+	err = Decrypt(&recovered, bytes.NewReader(ciphertext.Bytes()), password, nil, Options{})
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(recovered.Bytes(), plaintext) {
+		t.Fatal("paranoid round trip produced different plaintext")
+	}
+}
+
+func TestWrongPasswordFailsMAC(t *testing.T) {
+	plaintext := []byte("secret")
+	var ciphertext bytes.Buffer
+	if err := Encrypt(&ciphertext, bytes.NewReader(plaintext), []byte("right"), Options{Argon2: cheapParams}); err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	var recovered bytes.Buffer
+	err := Decrypt(&recovered, bytes.NewReader(ciphertext.Bytes()), []byte("wrong"), nil, Options{})
+	if err != ErrMACMismatch {
+		t.Fatalf("expected ErrMACMismatch, got %v", err)
+	}
+}
+
+func TestCorruptedCiphertextFailsMAC(t *testing.T) {
+	plaintext := []byte("secret data that should not decrypt if tampered with")
+	password := []byte("p4ssw0rd")
+
+	var ciphertext bytes.Buffer
+	if err := Encrypt(&ciphertext, bytes.NewReader(plaintext), password, Options{Argon2: cheapParams}); err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	corrupted := ciphertext.Bytes()
+	corrupted[len(corrupted)-1] ^= 0xFF // flip the last ciphertext byte
+
+	var recovered bytes.Buffer
+	err := Decrypt(&recovered, bytes.NewReader(corrupted), password, nil, Options{})
+	if err != ErrMACMismatch {
+		t.Fatalf("expected ErrMACMismatch for corrupted ciphertext, got %v", err)
+	}
+}
+
+func TestKeepOnMACFailureReturnsPlaintextAnyway(t *testing.T) {
+	plaintext := []byte("keep me even if tampered")
+	password := []byte("p4ssw0rd")
+
+	var ciphertext bytes.Buffer
+	if err := Encrypt(&ciphertext, bytes.NewReader(plaintext), password, Options{Argon2: cheapParams}); err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	corrupted := ciphertext.Bytes()
+	corrupted[len(corrupted)-1] ^= 0xFF
+
+	var recovered bytes.Buffer
+	err := Decrypt(&recovered, bytes.NewReader(corrupted), password, nil, Options{KeepOnMACFailure: true})
+	if err != ErrMACMismatch {
+		t.Fatalf("expected ErrMACMismatch to still be reported, got %v", err)
+	}
+	if recovered.Len() != len(plaintext) {
+		t.Fatal("expected -k to still write the (unverified) plaintext")
+	}
+}
+
+func TestReedSolomonHeaderSurvivesBitFlips(t *testing.T) {
+	plaintext := []byte("protect the header")
+	password := []byte("p4ssw0rd")
+
+	var ciphertext bytes.Buffer
+	err := Encrypt(&ciphertext, bytes.NewReader(plaintext), password, Options{Argon2: cheapParams, ReedSolomon: true})
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	// Flip a couple of bytes within the header region (but not the
+	// comment-length field RepairFile needs to locate the trailer).
+	data := ciphertext.Bytes()
+	data[6] ^= 0xFF
+	data[9] ^= 0xFF
+
+	var recovered bytes.Buffer
+	err = Decrypt(&recovered, bytes.NewReader(data), password, nil, Options{})
+	if err == nil {
+		t.Fatal("expected decrypt of an unrepaired, corrupted header to fail")
+	}
+
+	repaired, err := RepairFile(data)
+	if err != nil {
+		t.Fatalf("RepairFile: %v", err)
+	}
+
+	err = Decrypt(&recovered, bytes.NewReader(repaired), password, nil, Options{})
+	if err != nil {
+		t.Fatalf("Decrypt after repair: %v", err)
+	}
+	if !bytes.Equal(recovered.Bytes(), plaintext) {
+		t.Fatal("repaired file decrypted to the wrong plaintext")
+	}
+}
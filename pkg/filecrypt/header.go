@@ -0,0 +1,106 @@
+package filecrypt
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Header is the file layout written before the ciphertext. Every field is
+// optionally Reed-Solomon-encoded (see rs.go) so that small bit-flips picked
+// up from flaky storage can be repaired with -f instead of failing the MAC
+// outright.
+type Header struct {
+	Version     byte
+	Comment     string
+	Paranoid    bool
+	ReedSolomon bool
+	HasKeyfile  bool
+
+	Argon2Time    uint32
+	Argon2Memory  uint32
+	Argon2Threads uint8
+
+	Salt       [16]byte
+	Nonce      [24]byte // XChaCha20 nonce
+	KeyfileSum [64]byte // BLAKE2b-512 hash of the keyfile, if HasKeyfile
+	MAC        [64]byte // keyed BLAKE2b-512 over header+ciphertext
+}
+
+const (
+	flagParanoid    = 1 << 0
+	flagReedSolomon = 1 << 1
+	flagKeyfile     = 1 << 2
+
+	// CurrentVersion is written to new headers; Decrypt accepts this or older.
+	CurrentVersion = 1
+)
+
+// Marshal serializes everything except the MAC, which is computed over this
+// output and appended separately by Encrypt.
+func (h *Header) Marshal() []byte {
+	buf := make([]byte, 0, 128+len(h.Comment))
+	buf = append(buf, h.Version)
+
+	var flags byte
+	if h.Paranoid {
+		flags |= flagParanoid
+	}
+	if h.ReedSolomon {
+		flags |= flagReedSolomon
+	}
+	if h.HasKeyfile {
+		flags |= flagKeyfile
+	}
+	buf = append(buf, flags)
+
+	commentLen := make([]byte, 2)
+	binary.BigEndian.PutUint16(commentLen, uint16(len(h.Comment)))
+	buf = append(buf, commentLen...)
+	buf = append(buf, h.Comment...)
+
+	argon := make([]byte, 9)
+	binary.BigEndian.PutUint32(argon[0:4], h.Argon2Time)
+	binary.BigEndian.PutUint32(argon[4:8], h.Argon2Memory)
+	argon[8] = h.Argon2Threads
+	buf = append(buf, argon...)
+
+	buf = append(buf, h.Salt[:]...)
+	buf = append(buf, h.Nonce[:]...)
+	buf = append(buf, h.KeyfileSum[:]...)
+	return buf
+}
+
+// Unmarshal parses everything Marshal produced, returning the number of
+// bytes consumed so the caller can locate the MAC and ciphertext that follow.
+func (h *Header) Unmarshal(data []byte) (int, error) {
+	if len(data) < 4 {
+		return 0, errors.New("filecrypt: header too short")
+	}
+	h.Version = data[0]
+	flags := data[1]
+	h.Paranoid = flags&flagParanoid != 0
+	h.ReedSolomon = flags&flagReedSolomon != 0
+	h.HasKeyfile = flags&flagKeyfile != 0
+
+	commentLen := int(binary.BigEndian.Uint16(data[2:4]))
+	offset := 4
+	if len(data) < offset+commentLen+9+16+24+64 {
+		return 0, errors.New("filecrypt: header truncated")
+	}
+	h.Comment = string(data[offset : offset+commentLen])
+	offset += commentLen
+
+	h.Argon2Time = binary.BigEndian.Uint32(data[offset : offset+4])
+	h.Argon2Memory = binary.BigEndian.Uint32(data[offset+4 : offset+8])
+	h.Argon2Threads = data[offset+8]
+	offset += 9
+
+	copy(h.Salt[:], data[offset:offset+16])
+	offset += 16
+	copy(h.Nonce[:], data[offset:offset+24])
+	offset += 24
+	copy(h.KeyfileSum[:], data[offset:offset+64])
+	offset += 64
+
+	return offset, nil
+}
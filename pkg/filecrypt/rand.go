@@ -0,0 +1,6 @@
+package filecrypt
+
+import "crypto/rand"
+
+// randReader is overridden in tests that need deterministic salts/nonces.
+var randReader = rand.Reader
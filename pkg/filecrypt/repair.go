@@ -0,0 +1,41 @@
+package filecrypt
+
+import "errors"
+
+// RepairFile attempts to fix a damaged header in a ReedSolomon-protected
+// container (the -f flag): it trusts the flags/comment-length byte range
+// enough to locate the header's original length, reconstructs it from the
+// parity trailer appended at encrypt time, and returns a corrected copy of
+// the file with that header swapped in. The trailer itself is left in
+// place - Decrypt still expects one, since the repaired header's
+// ReedSolomon flag is unchanged.
+//
+// This only repairs the header; ciphertext corruption still fails the MAC
+// check in Decrypt (by design - silently "fixing" ciphertext bytes would
+// defeat the point of authentication).
+func RepairFile(data []byte) ([]byte, error) {
+	var probe Header
+	consumed, err := probe.Unmarshal(data)
+	if err != nil {
+		return nil, errors.New("filecrypt: cannot locate header to repair (comment-length field itself is corrupted)")
+	}
+	if !probe.ReedSolomon {
+		return nil, errors.New("filecrypt: file was not written with -rs, nothing to repair")
+	}
+
+	trailerLen := protectedHeaderTrailerLen(consumed)
+	if len(data) < trailerLen {
+		return nil, errors.New("filecrypt: missing Reed-Solomon header trailer")
+	}
+	trailer := data[len(data)-trailerLen:]
+
+	fixedHeader, err := repairHeader(trailer, consumed)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, fixedHeader...)
+	out = append(out, data[consumed:]...)
+	return out, nil
+}
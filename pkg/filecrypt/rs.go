@@ -0,0 +1,69 @@
+package filecrypt
+
+import "github.com/klauspost/reedsolomon"
+
+// headerDataShards/headerParityShards control the Reed-Solomon protection applied to
+// the header when ReedSolomon is requested: enough parity shards to recover
+// from a handful of flipped bytes without inflating small files too much.
+const (
+	headerDataShards   = 10
+	headerParityShards = 4
+)
+
+// protectedHeaderTrailerLen returns how many bytes protectHeader(raw) would
+// produce for a header of length rawLen, without re-encoding - reedsolomon
+// pads each shard up to ceil(rawLen/headerDataShards) deterministically.
+func protectedHeaderTrailerLen(rawLen int) int {
+	shardSize := (rawLen + headerDataShards - 1) / headerDataShards
+	return shardSize * (headerDataShards + headerParityShards)
+}
+
+// protectHeader splits raw into headerDataShards, computes parity, and
+// returns them concatenated back into a single buffer (data shards first,
+// then parity) so it can be written as a contiguous block.
+func protectHeader(raw []byte) ([]byte, error) {
+	enc, err := reedsolomon.New(headerDataShards, headerParityShards)
+	if err != nil {
+		return nil, err
+	}
+	shards, err := enc.Split(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := enc.Encode(shards); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0)
+	for _, s := range shards {
+		out = append(out, s...)
+	}
+	return out, nil
+}
+
+// repairHeader reverses protectHeader, reconstructing the original bytes
+// even if some shards were corrupted, as long as at most headerParityShards
+// of them are bad.
+func repairHeader(protected []byte, rawLen int) ([]byte, error) {
+	enc, err := reedsolomon.New(headerDataShards, headerParityShards)
+	if err != nil {
+		return nil, err
+	}
+	shardSize := len(protected) / (headerDataShards + headerParityShards)
+	shards := make([][]byte, headerDataShards+headerParityShards)
+	for i := range shards {
+		shards[i] = protected[i*shardSize : (i+1)*shardSize]
+	}
+	if ok, _ := enc.Verify(shards); !ok {
+		if err := enc.Reconstruct(shards); err != nil {
+			return nil, err
+		}
+	}
+	out := make([]byte, 0, rawLen)
+	for i := 0; i < headerDataShards; i++ {
+		out = append(out, shards[i]...)
+	}
+	if len(out) > rawLen {
+		out = out[:rawLen]
+	}
+	return out, nil
+}
@@ -0,0 +1,241 @@
+package geoip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// MaxMind DB data-format type numbers (see the public "MaxMind DB File
+// Format Specification"). Types 1-15 share one control-byte encoding;
+// 8-15 are "extended" types whose number is stored in the byte following
+// the control byte.
+const (
+	typePointer = 1
+	typeString  = 2
+	typeDouble  = 3
+	typeBytes   = 4
+	typeUint16  = 5
+	typeUint32  = 6
+	typeMap     = 7
+	typeInt32   = 8
+	typeUint64  = 9
+	typeUint128 = 10
+	typeArray   = 11
+	typeBoolean = 14
+	typeFloat   = 15
+)
+
+// decoder reads values out of an MMDB data section. dataOffset is the
+// byte offset of the data section within data - i.e. the byte a pointer
+// value of 0 refers to.
+type decoder struct {
+	data       []byte
+	dataOffset int
+}
+
+// decode reads one value (of any type, following pointers) starting at
+// offset, relative to the start of the data section, and returns the
+// decoded value plus the offset of the byte following it.
+func (d *decoder) decode(offset int) (interface{}, int, error) {
+	pos := d.dataOffset + offset
+	if pos < 0 || pos >= len(d.data) {
+		return nil, 0, fmt.Errorf("geoip: data offset %d out of range", offset)
+	}
+
+	ctrl := d.data[pos]
+	typeNum := int(ctrl >> 5)
+	pos++
+
+	if typeNum == 0 {
+		if pos >= len(d.data) {
+			return nil, 0, fmt.Errorf("geoip: truncated extended type at offset %d", offset)
+		}
+		typeNum = int(d.data[pos]) + 7
+		pos++
+	}
+
+	if typeNum == typePointer {
+		value, next, err := d.decodePointer(ctrl, pos)
+		if err != nil {
+			return nil, 0, err
+		}
+		resolved, _, err := d.decode(value)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resolved, next - d.dataOffset, nil
+	}
+
+	size, pos, err := d.readSize(ctrl, pos)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch typeNum {
+	case typeMap:
+		return d.decodeMap(size, pos)
+	case typeArray:
+		return d.decodeArray(size, pos)
+	case typeString:
+		if pos+size > len(d.data) {
+			return nil, 0, fmt.Errorf("geoip: truncated string at offset %d", offset)
+		}
+		return string(d.data[pos : pos+size]), pos + size - d.dataOffset, nil
+	case typeBytes:
+		if pos+size > len(d.data) {
+			return nil, 0, fmt.Errorf("geoip: truncated bytes at offset %d", offset)
+		}
+		return append([]byte(nil), d.data[pos:pos+size]...), pos + size - d.dataOffset, nil
+	case typeUint16, typeUint32, typeUint64:
+		v, next, err := d.readUint(size, pos)
+		return v, next - d.dataOffset, err
+	case typeUint128:
+		// Larger than any field this package reads; decode the low 64
+		// bits only and drop the rest.
+		if size > 16 || pos+size > len(d.data) {
+			return nil, 0, fmt.Errorf("geoip: malformed uint128 at offset %d", offset)
+		}
+		lo := size
+		if lo > 8 {
+			lo = 8
+		}
+		v, _, err := d.readUint(lo, pos+size-lo)
+		return v, pos + size - d.dataOffset, err
+	case typeInt32:
+		if size > 4 || pos+size > len(d.data) {
+			return nil, 0, fmt.Errorf("geoip: malformed int32 at offset %d", offset)
+		}
+		var buf [4]byte
+		copy(buf[4-size:], d.data[pos:pos+size])
+		return int32(binary.BigEndian.Uint32(buf[:])), pos + size - d.dataOffset, nil
+	case typeDouble:
+		if size != 8 || pos+8 > len(d.data) {
+			return nil, 0, fmt.Errorf("geoip: malformed double at offset %d", offset)
+		}
+		bits := binary.BigEndian.Uint64(d.data[pos : pos+8])
+		return math.Float64frombits(bits), pos + 8 - d.dataOffset, nil
+	case typeFloat:
+		if size != 4 || pos+4 > len(d.data) {
+			return nil, 0, fmt.Errorf("geoip: malformed float at offset %d", offset)
+		}
+		bits := binary.BigEndian.Uint32(d.data[pos : pos+4])
+		return math.Float32frombits(bits), pos + 4 - d.dataOffset, nil
+	case typeBoolean:
+		return size != 0, pos - d.dataOffset, nil
+	default:
+		return nil, 0, fmt.Errorf("geoip: unknown data type %d at offset %d", typeNum, offset)
+	}
+}
+
+// decodePointer decodes a pointer control byte per spec section "Pointer":
+// the pointer's size class comes from bits 4-3 of ctrl, and the pointer
+// value is built from the remaining 3 bits of ctrl plus 1-4 following
+// bytes, with a size-class-dependent bias added.
+func (d *decoder) decodePointer(ctrl byte, pos int) (value int, next int, err error) {
+	sizeClass := (ctrl >> 3) & 0x3
+	base := int(ctrl & 0x7)
+
+	switch sizeClass {
+	case 0:
+		if pos+1 > len(d.data) {
+			return 0, 0, fmt.Errorf("geoip: truncated pointer")
+		}
+		value = base<<8 | int(d.data[pos])
+		next = pos + 1
+	case 1:
+		if pos+2 > len(d.data) {
+			return 0, 0, fmt.Errorf("geoip: truncated pointer")
+		}
+		value = base<<16 | int(d.data[pos])<<8 | int(d.data[pos+1])
+		value += 2048
+		next = pos + 2
+	case 2:
+		if pos+3 > len(d.data) {
+			return 0, 0, fmt.Errorf("geoip: truncated pointer")
+		}
+		value = base<<24 | int(d.data[pos])<<16 | int(d.data[pos+1])<<8 | int(d.data[pos+2])
+		value += 526336
+		next = pos + 3
+	case 3:
+		if pos+4 > len(d.data) {
+			return 0, 0, fmt.Errorf("geoip: truncated pointer")
+		}
+		value = int(binary.BigEndian.Uint32(d.data[pos : pos+4]))
+		next = pos + 4
+	}
+	return value, next, nil
+}
+
+// readSize decodes the control byte's size field (bits 4-0), extending
+// into following bytes for sizes that don't fit in 5 bits.
+func (d *decoder) readSize(ctrl byte, pos int) (size, next int, err error) {
+	size = int(ctrl & 0x1f)
+	switch {
+	case size < 29:
+		return size, pos, nil
+	case size == 29:
+		if pos+1 > len(d.data) {
+			return 0, 0, fmt.Errorf("geoip: truncated size")
+		}
+		return 29 + int(d.data[pos]), pos + 1, nil
+	case size == 30:
+		if pos+2 > len(d.data) {
+			return 0, 0, fmt.Errorf("geoip: truncated size")
+		}
+		return 285 + int(binary.BigEndian.Uint16(d.data[pos:pos+2])), pos + 2, nil
+	default: // 31
+		if pos+3 > len(d.data) {
+			return 0, 0, fmt.Errorf("geoip: truncated size")
+		}
+		v := uint32(d.data[pos])<<16 | uint32(d.data[pos+1])<<8 | uint32(d.data[pos+2])
+		return 65821 + int(v), pos + 3, nil
+	}
+}
+
+func (d *decoder) readUint(size, pos int) (uint64, int, error) {
+	if size > 8 || pos+size > len(d.data) {
+		return 0, 0, fmt.Errorf("geoip: malformed uint at offset %d", pos-d.dataOffset)
+	}
+	var v uint64
+	for i := 0; i < size; i++ {
+		v = v<<8 | uint64(d.data[pos+i])
+	}
+	return v, pos + size, nil
+}
+
+func (d *decoder) decodeMap(size, pos int) (interface{}, int, error) {
+	m := make(map[string]interface{}, size)
+	for i := 0; i < size; i++ {
+		key, next, err := d.decode(pos - d.dataOffset)
+		if err != nil {
+			return nil, 0, err
+		}
+		keyStr, ok := key.(string)
+		if !ok {
+			return nil, 0, fmt.Errorf("geoip: map key is not a string")
+		}
+		pos = d.dataOffset + next
+
+		value, next, err := d.decode(pos - d.dataOffset)
+		if err != nil {
+			return nil, 0, err
+		}
+		m[keyStr] = value
+		pos = d.dataOffset + next
+	}
+	return m, pos - d.dataOffset, nil
+}
+
+func (d *decoder) decodeArray(size, pos int) (interface{}, int, error) {
+	arr := make([]interface{}, size)
+	for i := 0; i < size; i++ {
+		value, next, err := d.decode(pos - d.dataOffset)
+		if err != nil {
+			return nil, 0, err
+		}
+		arr[i] = value
+		pos = d.dataOffset + next
+	}
+	return arr, pos - d.dataOffset, nil
+}
@@ -0,0 +1,57 @@
+package geoip
+
+import "net"
+
+// Record is the subset of a GeoIP2/GeoLite2 record this package surfaces.
+// Fields are left zero-valued when the underlying database doesn't carry
+// them (e.g. a GeoLite2-ASN database has no Country, and a
+// GeoLite2-Country database has no ASN).
+type Record struct {
+	CountryCode  string
+	CountryName  string
+	ASN          uint32
+	Organization string
+}
+
+// LookupRecord resolves ip against the open database and extracts the fields
+// Record carries. found is false if ip has no entry in the database.
+func (r *Reader) LookupRecord(ip net.IP) (rec Record, found bool, err error) {
+	value, found, err := r.Lookup(ip)
+	if err != nil || !found {
+		return Record{}, found, err
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return Record{}, true, nil
+	}
+	return extractRecord(m), true, nil
+}
+
+func extractRecord(m map[string]interface{}) Record {
+	var rec Record
+
+	if country, ok := m["country"].(map[string]interface{}); ok {
+		if code, ok := country["iso_code"].(string); ok {
+			rec.CountryCode = code
+		}
+		if names, ok := country["names"].(map[string]interface{}); ok {
+			if en, ok := names["en"].(string); ok {
+				rec.CountryName = en
+			}
+		}
+	}
+
+	if asn, ok := m["autonomous_system_number"]; ok {
+		switch v := asn.(type) {
+		case uint64:
+			rec.ASN = uint32(v)
+		case int32:
+			rec.ASN = uint32(v)
+		}
+	}
+	if org, ok := m["autonomous_system_organization"].(string); ok {
+		rec.Organization = org
+	}
+
+	return rec
+}
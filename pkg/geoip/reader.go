@@ -0,0 +1,200 @@
+// Package geoip reads MaxMind-format (.mmdb) GeoIP2/GeoLite2 databases,
+// resolving an IP address to the country, ASN, and organization fields
+// those databases publish. Only the lookups this package's callers need
+// are implemented; it is not a general MaxMind DB client.
+package geoip
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+)
+
+// metadataMarker precedes the metadata section at the end of every MMDB
+// file (see the "MaxMind DB File Format Specification").
+var metadataMarker = []byte("\xab\xcd\xefMaxMind.com")
+
+// maxMetadataSearch bounds how far from the end of the file Open looks
+// for metadataMarker, matching the reference implementations' 128KiB
+// limit.
+const maxMetadataSearch = 128 * 1024
+
+// Reader is an opened .mmdb database.
+type Reader struct {
+	data       []byte
+	decoder    decoder
+	nodeCount  int
+	recordSize int // bits per record; 24, 28, or 32
+	ipVersion  int // 4 or 6
+}
+
+// Open reads and parses the database at path.
+func Open(path string) (*Reader, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("geoip: %w", err)
+	}
+	return newReader(data)
+}
+
+func newReader(data []byte) (*Reader, error) {
+	searchFrom := len(data) - maxMetadataSearch
+	if searchFrom < 0 {
+		searchFrom = 0
+	}
+	markerPos := bytes.LastIndex(data[searchFrom:], metadataMarker)
+	if markerPos < 0 {
+		return nil, fmt.Errorf("geoip: not a MaxMind DB file (metadata marker not found)")
+	}
+	metadataStart := searchFrom + markerPos + len(metadataMarker)
+
+	metaDecoder := decoder{data: data, dataOffset: metadataStart}
+	rawMeta, _, err := metaDecoder.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("geoip: reading metadata: %w", err)
+	}
+	meta, ok := rawMeta.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("geoip: metadata is not a map")
+	}
+
+	nodeCount, err := metaUint(meta, "node_count")
+	if err != nil {
+		return nil, err
+	}
+	recordSize, err := metaUint(meta, "record_size")
+	if err != nil {
+		return nil, err
+	}
+	ipVersion, err := metaUint(meta, "ip_version")
+	if err != nil {
+		return nil, err
+	}
+
+	searchTreeSize := nodeCount * recordSize * 2 / 8
+	// The data section starts 16 bytes after the search tree (a
+	// reserved separator of zero bytes).
+	dataStart := searchTreeSize + 16
+
+	return &Reader{
+		data:       data,
+		decoder:    decoder{data: data, dataOffset: int(dataStart)},
+		nodeCount:  int(nodeCount),
+		recordSize: int(recordSize),
+		ipVersion:  int(ipVersion),
+	}, nil
+}
+
+func metaUint(meta map[string]interface{}, key string) (uint64, error) {
+	v, ok := meta[key]
+	if !ok {
+		return 0, fmt.Errorf("geoip: metadata missing %q", key)
+	}
+	u, ok := v.(uint64)
+	if !ok {
+		return 0, fmt.Errorf("geoip: metadata %q is not a uint64", key)
+	}
+	return u, nil
+}
+
+// Lookup resolves ip to its record in the database, returning the decoded
+// value (typically a map[string]interface{}) and whether a record was
+// found at all.
+func (r *Reader) Lookup(ip net.IP) (interface{}, bool, error) {
+	bits, err := addressBits(ip, r.ipVersion)
+	if err != nil {
+		return nil, false, err
+	}
+
+	node := 0
+	for _, bit := range bits {
+		if node >= r.nodeCount {
+			break // walked off the tree into the data section: no record
+		}
+		record, err := r.readRecord(node, bit)
+		if err != nil {
+			return nil, false, err
+		}
+		if record == r.nodeCount {
+			return nil, false, nil // explicit "not found" leaf
+		}
+		node = record
+	}
+
+	if node < r.nodeCount {
+		return nil, false, nil
+	}
+
+	dataOffset := node - r.nodeCount - 16
+	value, _, err := r.decoder.decode(dataOffset)
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// addressBits returns ip's address as a most-significant-bit-first slice
+// of 0/1 values, sized to match how dbIPVersion's tree was built: 32 bits
+// for an IPv4 database, 128 for IPv6 (with IPv4 addresses embedded at the
+// ::ffff:0:0/96 offset, per the spec).
+func addressBits(ip net.IP, dbIPVersion int) ([]byte, error) {
+	v4 := ip.To4()
+	if dbIPVersion == 4 {
+		if v4 == nil {
+			return nil, fmt.Errorf("geoip: database is IPv4-only but got IPv6 address %s", ip)
+		}
+		return bytesToBits(v4), nil
+	}
+
+	if v4 != nil {
+		return bytesToBits(v4.To16()), nil
+	}
+	v6 := ip.To16()
+	if v6 == nil {
+		return nil, fmt.Errorf("geoip: invalid IP address %s", ip)
+	}
+	return bytesToBits(v6), nil
+}
+
+func bytesToBits(b []byte) []byte {
+	bits := make([]byte, 0, len(b)*8)
+	for _, by := range b {
+		for i := 7; i >= 0; i-- {
+			bits = append(bits, (by>>uint(i))&1)
+		}
+	}
+	return bits
+}
+
+// readRecord reads the left (bit==0) or right (bit==1) record of node,
+// per the record layout the search tree uses for recordSize bits/record.
+func (r *Reader) readRecord(node int, bit byte) (int, error) {
+	recordBytes := r.recordSize * 2 / 8
+	offset := node * recordBytes
+	if offset+recordBytes > len(r.data) {
+		return 0, fmt.Errorf("geoip: search tree node %d out of range", node)
+	}
+	chunk := r.data[offset : offset+recordBytes]
+
+	switch r.recordSize {
+	case 24:
+		if bit == 0 {
+			return int(chunk[0])<<16 | int(chunk[1])<<8 | int(chunk[2]), nil
+		}
+		return int(chunk[3])<<16 | int(chunk[4])<<8 | int(chunk[5]), nil
+	case 28:
+		middle := chunk[3]
+		if bit == 0 {
+			return int(middle>>4)<<24 | int(chunk[0])<<16 | int(chunk[1])<<8 | int(chunk[2]), nil
+		}
+		return int(middle&0x0f)<<24 | int(chunk[4])<<16 | int(chunk[5])<<8 | int(chunk[6]), nil
+	case 32:
+		if bit == 0 {
+			return int(chunk[0])<<24 | int(chunk[1])<<16 | int(chunk[2])<<8 | int(chunk[3]), nil
+		}
+		return int(chunk[4])<<24 | int(chunk[5])<<16 | int(chunk[6])<<8 | int(chunk[7]), nil
+	default:
+		return 0, fmt.Errorf("geoip: unsupported record size %d", r.recordSize)
+	}
+}
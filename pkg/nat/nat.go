@@ -0,0 +1,241 @@
+package nat
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// MappingBehavior classifies how a NAT assigns external mappings to an
+// internal (local IP, local port, remote IP, remote port) tuple, per
+// RFC 5780 section 4.2. FilteringBehavior uses the same scale to classify
+// which remote endpoints are allowed to send back through a mapping.
+type MappingBehavior string
+
+const (
+	EndpointIndependent     MappingBehavior = "Endpoint-Independent"
+	AddressDependent        MappingBehavior = "Address-Dependent"
+	AddressAndPortDependent MappingBehavior = "Address-and-Port-Dependent"
+	UnknownBehavior         MappingBehavior = "Unknown"
+)
+
+// FilteringBehavior is classified on the same Endpoint-Independent /
+// Address-Dependent / Address-and-Port-Dependent scale as MappingBehavior.
+type FilteringBehavior = MappingBehavior
+
+// NATReport summarizes one NAT behavior-discovery run against a STUN
+// server.
+type NATReport struct {
+	StunServer      string
+	PublicIP        string
+	PublicPort      int
+	Mapping         MappingBehavior
+	Filtering       FilteringBehavior
+	Hairpinning     bool
+	MappingLifetime time.Duration
+	Probed          time.Time
+}
+
+// Options configures a NAT probe.
+type Options struct {
+	// Timeout bounds each individual STUN round trip.
+	Timeout time.Duration
+	// LifetimeDelays are the re-probe intervals discoverMappingLifetime
+	// waits through, in order, stopping as soon as the mapping changes.
+	LifetimeDelays []time.Duration
+}
+
+// DefaultOptions returns the Options ProbeNAT uses.
+func DefaultOptions() Options {
+	return Options{
+		Timeout: 3 * time.Second,
+		LifetimeDelays: []time.Duration{
+			30 * time.Second,
+			60 * time.Second,
+			120 * time.Second,
+		},
+	}
+}
+
+// ProbeNAT runs a full RFC 5780 NAT behavior discovery against stunServer
+// (e.g. "stun.l.google.com:19302") using DefaultOptions.
+func ProbeNAT(stunServer string) (NATReport, error) {
+	return ProbeNATWithOptions(stunServer, DefaultOptions())
+}
+
+// ProbeNATWithOptions runs ProbeNAT with caller-supplied timing.
+func ProbeNATWithOptions(stunServer string, opts Options) (NATReport, error) {
+	report := NATReport{StunServer: stunServer}
+
+	primary, err := net.ResolveUDPAddr("udp4", stunServer)
+	if err != nil {
+		return report, fmt.Errorf("nat: resolve %s: %w", stunServer, err)
+	}
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return report, fmt.Errorf("nat: open local socket: %w", err)
+	}
+	defer conn.Close()
+
+	// Test I: a plain binding request establishes our mapped address and
+	// discovers the server's alternate (OTHER-ADDRESS) IP/port, which the
+	// rest of RFC 5780 section 4 depends on.
+	base, err := stunRoundTrip(conn, primary, false, false, opts.Timeout)
+	if err != nil {
+		return report, fmt.Errorf("nat: initial binding request to %s: %w", stunServer, err)
+	}
+	if base.other == nil {
+		return report, fmt.Errorf("nat: %s did not return an OTHER-ADDRESS attribute; it doesn't support RFC 5780 behavior discovery", stunServer)
+	}
+
+	report.PublicIP = base.mapped.IP.String()
+	report.PublicPort = base.mapped.Port
+	report.Probed = time.Now()
+
+	mapping, err := discoverMapping(conn, primary, base, opts.Timeout)
+	if err != nil {
+		return report, fmt.Errorf("nat: mapping behavior discovery: %w", err)
+	}
+	report.Mapping = mapping
+
+	report.Filtering = discoverFiltering(conn, primary, opts.Timeout)
+
+	report.Hairpinning, err = discoverHairpinning(primary, base.mapped, opts.Timeout)
+	if err != nil {
+		report.Hairpinning = false
+	}
+
+	report.MappingLifetime = discoverMappingLifetime(conn, primary, base, opts)
+
+	return report, nil
+}
+
+// discoverMapping implements RFC 5780 section 4.2's Test I/II decision
+// tree: if a second request to the server's OTHER-ADDRESS yields the same
+// mapped address as Test I, the NAT reuses one mapping regardless of the
+// destination (Endpoint-Independent). Otherwise a third request to the
+// alternate IP but the *original* port distinguishes whether the NAT keys
+// on destination address alone (Address-Dependent) or on the full
+// destination address+port (Address-and-Port-Dependent).
+func discoverMapping(conn *net.UDPConn, primary *net.UDPAddr, base *bindingResponse, timeout time.Duration) (MappingBehavior, error) {
+	second, err := stunRoundTrip(conn, base.other, false, false, timeout)
+	if err != nil {
+		return UnknownBehavior, fmt.Errorf("request to OTHER-ADDRESS %s: %w", base.other, err)
+	}
+	if sameAddr(second.mapped, base.mapped) {
+		return EndpointIndependent, nil
+	}
+
+	altSamePort := &net.UDPAddr{IP: base.other.IP, Port: primary.Port}
+	third, err := stunRoundTrip(conn, altSamePort, false, false, timeout)
+	if err != nil {
+		// The server's alternate IP may not listen on the primary port;
+		// fall back to the Test II result alone.
+		return AddressAndPortDependent, nil
+	}
+	if sameAddr(third.mapped, second.mapped) {
+		return AddressDependent, nil
+	}
+	return AddressAndPortDependent, nil
+}
+
+// discoverFiltering implements RFC 5780 section 4.3: it asks the server,
+// via CHANGE-REQUEST, to reply from its alternate IP and port, then from
+// its alternate port only, timing out increasingly strict requests until
+// one succeeds (or none do). A response to a changed IP+port means the
+// NAT accepts traffic from anyone (Endpoint-Independent filtering); a
+// response only when just the port changed means it filters by remote
+// address (Address-Dependent); no response to either means it filters by
+// the full remote address+port (Address-and-Port-Dependent).
+func discoverFiltering(conn *net.UDPConn, primary *net.UDPAddr, timeout time.Duration) FilteringBehavior {
+	if _, err := stunRoundTrip(conn, primary, true, true, timeout); err == nil {
+		return EndpointIndependent
+	}
+	if _, err := stunRoundTrip(conn, primary, false, true, timeout); err == nil {
+		return AddressDependent
+	}
+	return AddressAndPortDependent
+}
+
+// discoverHairpinning opens a second local socket and sends it a probe
+// datagram addressed to the first socket's publicly mapped endpoint,
+// checking whether the router loops the packet back internally rather
+// than requiring it to leave and re-enter the network.
+func discoverHairpinning(primary *net.UDPAddr, mapped *net.UDPAddr, timeout time.Duration) (bool, error) {
+	peer, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return false, err
+	}
+	defer peer.Close()
+
+	probe := []byte("hairpin-probe")
+	if _, err := peer.WriteToUDP(probe, mapped); err != nil {
+		return false, err
+	}
+
+	buf := make([]byte, 64)
+	if err := peer.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return false, err
+	}
+	n, _, err := peer.ReadFromUDP(buf)
+	if err != nil {
+		return false, nil // timeout: no hairpinning, not an error worth surfacing
+	}
+	return n > 0, nil
+}
+
+// discoverMappingLifetime re-probes the STUN server after each of
+// opts.LifetimeDelays in turn, returning the elapsed time once the mapped
+// endpoint changes (the previous mapping expired) or once a probe fails
+// to respond. If the mapping survives every delay, it returns the total
+// time elapsed across all delays as a lower bound on the lifetime.
+func discoverMappingLifetime(conn *net.UDPConn, primary *net.UDPAddr, base *bindingResponse, opts Options) time.Duration {
+	started := time.Now()
+	for _, delay := range opts.LifetimeDelays {
+		time.Sleep(delay)
+		resp, err := stunRoundTrip(conn, primary, false, false, opts.Timeout)
+		if err != nil || !sameAddr(resp.mapped, base.mapped) {
+			return time.Since(started)
+		}
+	}
+	return time.Since(started)
+}
+
+// stunRoundTrip sends a single Binding Request (optionally with a
+// CHANGE-REQUEST attribute) to dst and waits up to timeout for a matching
+// response, discarding stray/mismatched packets until the deadline.
+func stunRoundTrip(conn *net.UDPConn, dst *net.UDPAddr, changeIP, changePort bool, timeout time.Duration) (*bindingResponse, error) {
+	txID, err := newTransactionID()
+	if err != nil {
+		return nil, err
+	}
+	req := buildBindingRequest(txID, changeIP, changePort)
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := conn.WriteToUDP(req, dst); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := parseBindingResponse(buf[:n], txID)
+		if err != nil {
+			continue // not our transaction (e.g. a straggler from a changed-source reply); keep waiting until the deadline
+		}
+		return resp, nil
+	}
+}
+
+func sameAddr(a, b *net.UDPAddr) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	return a.IP.Equal(b.IP) && a.Port == b.Port
+}
@@ -0,0 +1,200 @@
+// Package nat performs RFC 5780 NAT behavior discovery against a STUN
+// server: the classic mapping/filtering classification, hairpinning, and
+// mapping-lifetime tests used by tools like stunclient to characterize
+// how restrictive a NAT is for peer-to-peer traffic.
+package nat
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+const (
+	magicCookie = 0x2112A442
+
+	msgTypeBindingRequest uint16 = 0x0001
+	msgTypeBindingSuccess uint16 = 0x0101
+
+	attrMappedAddress    uint16 = 0x0001
+	attrChangeRequest    uint16 = 0x0003
+	attrXorMappedAddress uint16 = 0x0020
+	attrChangedAddress   uint16 = 0x0005 // legacy pre-RFC5780 equivalent of OTHER-ADDRESS
+	attrOtherAddress     uint16 = 0x802C
+
+	familyIPv4 byte = 0x01
+
+	changeRequestIP   uint32 = 0x04
+	changeRequestPort uint32 = 0x02
+)
+
+// transactionID is a STUN message's 96-bit transaction ID, used to match a
+// response to the request that triggered it.
+type transactionID [12]byte
+
+func newTransactionID() (transactionID, error) {
+	var id transactionID
+	_, err := rand.Read(id[:])
+	return id, err
+}
+
+// buildBindingRequest encodes a Binding Request with an optional
+// CHANGE-REQUEST attribute (RFC 5780), asking the server to source its
+// response from a different IP and/or port than it received the request
+// on - the mechanism the filtering-behavior test relies on.
+func buildBindingRequest(txID transactionID, changeIP, changePort bool) []byte {
+	var attrs []byte
+	if changeIP || changePort {
+		var flags uint32
+		if changeIP {
+			flags |= changeRequestIP
+		}
+		if changePort {
+			flags |= changeRequestPort
+		}
+		value := make([]byte, 4)
+		binary.BigEndian.PutUint32(value, flags)
+		attrs = appendAttribute(attrs, attrChangeRequest, value)
+	}
+
+	header := make([]byte, 20)
+	binary.BigEndian.PutUint16(header[0:2], msgTypeBindingRequest)
+	binary.BigEndian.PutUint16(header[2:4], uint16(len(attrs)))
+	binary.BigEndian.PutUint32(header[4:8], magicCookie)
+	copy(header[8:20], txID[:])
+
+	return append(header, attrs...)
+}
+
+// appendAttribute appends one TLV attribute, padding its value to a
+// 4-byte boundary per RFC 5389 section 15.
+func appendAttribute(buf []byte, attrType uint16, value []byte) []byte {
+	header := make([]byte, 4)
+	binary.BigEndian.PutUint16(header[0:2], attrType)
+	binary.BigEndian.PutUint16(header[2:4], uint16(len(value)))
+
+	buf = append(buf, header...)
+	buf = append(buf, value...)
+	if pad := (4 - len(value)%4) % 4; pad > 0 {
+		buf = append(buf, make([]byte, pad)...)
+	}
+	return buf
+}
+
+// bindingResponse is the subset of a Binding Success Response this package
+// needs: the server's view of our public endpoint, and (if present) the
+// OTHER-ADDRESS it offers for the behavior-discovery tests.
+type bindingResponse struct {
+	mapped *net.UDPAddr
+	other  *net.UDPAddr
+}
+
+// parseBindingResponse decodes a Binding Success Response, verifying its
+// transaction ID matches txID before trusting any attribute in it. Only
+// IPv4 addresses are supported, matching the IPv4-only sockets ProbeNAT
+// opens.
+func parseBindingResponse(data []byte, txID transactionID) (*bindingResponse, error) {
+	if len(data) < 20 {
+		return nil, fmt.Errorf("stun: response too short (%d bytes)", len(data))
+	}
+	msgType := binary.BigEndian.Uint16(data[0:2])
+	msgLen := binary.BigEndian.Uint16(data[2:4])
+	cookie := binary.BigEndian.Uint32(data[4:8])
+
+	if msgType != msgTypeBindingSuccess {
+		return nil, fmt.Errorf("stun: unexpected message type 0x%04x", msgType)
+	}
+	if cookie != magicCookie {
+		return nil, fmt.Errorf("stun: bad magic cookie")
+	}
+	if !bytesEqual(data[8:20], txID[:]) {
+		return nil, fmt.Errorf("stun: transaction ID mismatch")
+	}
+	if int(msgLen)+20 > len(data) {
+		return nil, fmt.Errorf("stun: truncated attributes")
+	}
+
+	resp := &bindingResponse{}
+	attrs := data[20 : 20+int(msgLen)]
+	for len(attrs) >= 4 {
+		attrType := binary.BigEndian.Uint16(attrs[0:2])
+		attrLen := int(binary.BigEndian.Uint16(attrs[2:4]))
+		if 4+attrLen > len(attrs) {
+			break
+		}
+		value := attrs[4 : 4+attrLen]
+
+		switch attrType {
+		case attrXorMappedAddress:
+			if addr, err := decodeXorAddress(value, txID); err == nil {
+				resp.mapped = addr
+			}
+		case attrMappedAddress:
+			if resp.mapped == nil { // XOR-MAPPED-ADDRESS takes precedence when both are present
+				if addr, err := decodeAddress(value); err == nil {
+					resp.mapped = addr
+				}
+			}
+		case attrOtherAddress, attrChangedAddress:
+			if addr, err := decodeAddress(value); err == nil {
+				resp.other = addr
+			}
+		}
+
+		padded := attrLen + (4-attrLen%4)%4
+		if 4+padded > len(attrs) {
+			break
+		}
+		attrs = attrs[4+padded:]
+	}
+
+	if resp.mapped == nil {
+		return nil, fmt.Errorf("stun: response had no (XOR-)MAPPED-ADDRESS attribute")
+	}
+	return resp, nil
+}
+
+// decodeAddress decodes a MAPPED-ADDRESS/OTHER-ADDRESS value (RFC 5389
+// section 15.1): a one-byte family, a big-endian port, and the address.
+func decodeAddress(value []byte) (*net.UDPAddr, error) {
+	if len(value) < 8 || value[1] != familyIPv4 {
+		return nil, fmt.Errorf("stun: unsupported or malformed address attribute")
+	}
+	port := binary.BigEndian.Uint16(value[2:4])
+	ip := net.IPv4(value[4], value[5], value[6], value[7])
+	return &net.UDPAddr{IP: ip, Port: int(port)}, nil
+}
+
+// decodeXorAddress decodes an XOR-MAPPED-ADDRESS value (RFC 5389 section
+// 15.2): the port is XORed with the cookie's high 16 bits, the address
+// with the full 32-bit cookie.
+func decodeXorAddress(value []byte, txID transactionID) (*net.UDPAddr, error) {
+	if len(value) < 8 || value[1] != familyIPv4 {
+		return nil, fmt.Errorf("stun: unsupported or malformed xor-address attribute")
+	}
+	xport := binary.BigEndian.Uint16(value[2:4])
+	port := xport ^ uint16(magicCookie>>16)
+
+	var cookieBytes [4]byte
+	binary.BigEndian.PutUint32(cookieBytes[:], magicCookie)
+
+	ipBytes := make([]byte, 4)
+	for i := 0; i < 4; i++ {
+		ipBytes[i] = value[4+i] ^ cookieBytes[i]
+	}
+
+	return &net.UDPAddr{IP: net.IPv4(ipBytes[0], ipBytes[1], ipBytes[2], ipBytes[3]), Port: int(port)}, nil
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+package passwd
+
+import "golang.org/x/crypto/bcrypt"
+
+// BcryptHasher implements Hasher using bcrypt, which already encodes its
+// own cost and salt into the output string (e.g. "$2a$10$...").
+type BcryptHasher struct {
+	Cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher { return &BcryptHasher{Cost: cost} }
+
+func (BcryptHasher) Prefix() string { return "$2" } // matches $2a$, $2b$, $2y$
+
+func (h *BcryptHasher) Hash(password []byte) ([]byte, error) {
+	return bcrypt.GenerateFromPassword(password, h.Cost)
+}
+
+func (h *BcryptHasher) Verify(password, encoded []byte) error {
+	if err := bcrypt.CompareHashAndPassword(encoded, password); err != nil {
+		return ErrMismatch
+	}
+	return nil
+}
+
+func (h *BcryptHasher) NeedsRehash(encoded []byte) bool {
+	cost, err := bcrypt.Cost(encoded)
+	if err != nil {
+		return true
+	}
+	return cost < h.Cost
+}
@@ -0,0 +1,78 @@
+package passwd
+
+import (
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// DefaultBenchmarkTarget is the per-hash latency the Recommend* functions
+// aim for by default: slow enough to meaningfully cost an offline cracking
+// attempt, fast enough not to be a nuisance on an interactive login path.
+const DefaultBenchmarkTarget = 250 * time.Millisecond
+
+// RecommendArgon2idParams doubles Memory from params' starting point until
+// a single Hash call takes at least target, returning the parameters that
+// hit it and the latency observed. Time and Parallelism are held fixed;
+// Memory is the standard knob to tune for a latency target.
+func RecommendArgon2idParams(params Argon2idParams, target time.Duration) (Argon2idParams, time.Duration) {
+	current := params
+	for {
+		elapsed := timeHash(NewArgon2idHasher(current))
+		if elapsed >= target {
+			return current, elapsed
+		}
+		current.Memory *= 2
+	}
+}
+
+// RecommendScryptParams doubles N from params' starting point until a
+// single Hash call takes at least target.
+func RecommendScryptParams(params ScryptParams, target time.Duration) (ScryptParams, time.Duration) {
+	current := params
+	for {
+		elapsed := timeHash(NewScryptHasher(current))
+		if elapsed >= target {
+			return current, elapsed
+		}
+		current.N *= 2
+	}
+}
+
+// RecommendBcryptCost increments cost from startCost until a single Hash
+// call takes at least target, or bcrypt's maximum cost is reached.
+func RecommendBcryptCost(startCost int, target time.Duration) (int, time.Duration) {
+	cost := startCost
+	for {
+		elapsed := timeHash(NewBcryptHasher(cost))
+		if elapsed >= target || cost >= bcrypt.MaxCost {
+			return cost, elapsed
+		}
+		cost++
+	}
+}
+
+// RecommendPBKDF2Params doubles Iterations from params' starting point
+// until a single Hash call takes at least target.
+func RecommendPBKDF2Params(params PBKDF2Params, target time.Duration) (PBKDF2Params, time.Duration) {
+	current := params
+	for {
+		elapsed := timeHash(NewPBKDF2Hasher(current))
+		if elapsed >= target {
+			return current, elapsed
+		}
+		current.Iterations *= 2
+	}
+}
+
+// timeHash hashes a fixed benchmark password once and returns the latency.
+// A Hash error (e.g. scrypt's N overflowing its memory limit) is treated as
+// "target reached" so Recommend* functions terminate rather than loop
+// forever tuning past a parameter's valid range.
+func timeHash(h Hasher) time.Duration {
+	start := time.Now()
+	if _, err := h.Hash([]byte("benchmark-password")); err != nil {
+		return time.Hour
+	}
+	return time.Since(start)
+}
@@ -0,0 +1,50 @@
+package passwd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecommendArgon2idParamsReachesTarget(t *testing.T) {
+	target := 5 * time.Millisecond
+	params, elapsed := RecommendArgon2idParams(Argon2idParams{Memory: 8, Time: 1, Parallelism: 1, SaltLen: 16, KeyLen: 32}, target)
+	if elapsed < target {
+		t.Fatalf("elapsed %v below target %v", elapsed, target)
+	}
+	if params.Memory < 8 {
+		t.Fatalf("Memory should never shrink below the starting point, got %d", params.Memory)
+	}
+}
+
+func TestRecommendScryptParamsReachesTarget(t *testing.T) {
+	target := 5 * time.Millisecond
+	params, elapsed := RecommendScryptParams(ScryptParams{N: 1 << 8, R: 8, P: 1, SaltLen: 16, KeyLen: 32}, target)
+	if elapsed < target {
+		t.Fatalf("elapsed %v below target %v", elapsed, target)
+	}
+	if params.N < 1<<8 {
+		t.Fatalf("N should never shrink below the starting point, got %d", params.N)
+	}
+}
+
+func TestRecommendBcryptCostReachesTarget(t *testing.T) {
+	target := time.Nanosecond
+	cost, elapsed := RecommendBcryptCost(4, target)
+	if elapsed < target {
+		t.Fatalf("elapsed %v below target %v", elapsed, target)
+	}
+	if cost < 4 {
+		t.Fatalf("cost should never go below the starting point, got %d", cost)
+	}
+}
+
+func TestRecommendPBKDF2ParamsReachesTarget(t *testing.T) {
+	target := 5 * time.Millisecond
+	params, elapsed := RecommendPBKDF2Params(PBKDF2Params{Iterations: 1000, SaltLen: 16, KeyLen: 32}, target)
+	if elapsed < target {
+		t.Fatalf("elapsed %v below target %v", elapsed, target)
+	}
+	if params.Iterations < 1000 {
+		t.Fatalf("Iterations should never shrink below the starting point, got %d", params.Iterations)
+	}
+}
@@ -0,0 +1,47 @@
+package passwd
+
+import "testing"
+
+func TestCatenaDeriveKeyRejectsInvalidMode(t *testing.T) {
+	c := NewCatenaKDF(Argon2idParams{Memory: 8 * 1024, Time: 1, Parallelism: 1})
+	if _, err := c.DeriveKey(2, nil, []byte("salt"), []byte("pw"), 32); err != ErrInvalidCatenaMode {
+		t.Fatalf("expected ErrInvalidCatenaMode, got %v", err)
+	}
+}
+
+func TestCatenaDeriveKeyRejectsInvalidOutputLength(t *testing.T) {
+	c := NewCatenaKDF(Argon2idParams{Memory: 8 * 1024, Time: 1, Parallelism: 1})
+	if _, err := c.DeriveKey(ModePassHash, nil, []byte("salt"), []byte("pw"), 0); err != ErrInvalidOutputLength {
+		t.Fatalf("expected ErrInvalidOutputLength, got %v", err)
+	}
+}
+
+func TestCatenaDeriveKeyIsDeterministic(t *testing.T) {
+	c := NewCatenaKDF(Argon2idParams{Memory: 8 * 1024, Time: 1, Parallelism: 1})
+	a, err := c.DeriveKey(ModePassHash, []byte("ad"), []byte("some-salt-16byte"), []byte("hunter2"), 32)
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	b, err := c.DeriveKey(ModePassHash, []byte("ad"), []byte("some-salt-16byte"), []byte("hunter2"), 32)
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	if string(a) != string(b) {
+		t.Fatal("DeriveKey should be deterministic for identical inputs")
+	}
+}
+
+func TestCatenaDeriveKeyDiffersByMode(t *testing.T) {
+	c := NewCatenaKDF(Argon2idParams{Memory: 8 * 1024, Time: 1, Parallelism: 1})
+	passHash, err := c.DeriveKey(ModePassHash, []byte("ad"), []byte("some-salt-16byte"), []byte("hunter2"), 32)
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	derivedKey, err := c.DeriveKey(ModeKeyDerivation, []byte("ad"), []byte("some-salt-16byte"), []byte("hunter2"), 32)
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	if string(passHash) == string(derivedKey) {
+		t.Fatal("ModePassHash and ModeKeyDerivation should produce unrelated outputs for the same password/salt/ad")
+	}
+}
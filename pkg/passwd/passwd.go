@@ -0,0 +1,86 @@
+// Package passwd provides a pluggable password hashing subsystem: a Hasher
+// interface with argon2id/bcrypt/scrypt implementations that encode their
+// parameters into a self-describing PHC-style string, plus a Multi hasher
+// that dispatches Verify by prefix and can flag hashes for upgrade.
+package passwd
+
+import "errors"
+
+// Hasher hashes and verifies passwords, and knows when its own output is
+// using outdated parameters that should be upgraded on next successful login.
+type Hasher interface {
+	// Hash returns a self-describing encoded hash, e.g.
+	// "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>".
+	Hash(password []byte) ([]byte, error)
+	// Verify reports whether password matches the given encoded hash.
+	Verify(password, encoded []byte) error
+	// NeedsRehash reports whether encoded was produced with parameters
+	// weaker than this Hasher's current configuration.
+	NeedsRehash(encoded []byte) bool
+}
+
+// ErrMismatch is returned by Verify when the password does not match.
+var ErrMismatch = errors.New("passwd: password does not match hash")
+
+// Multi dispatches Verify to whichever registered Hasher's prefix matches
+// the encoded hash, and always Hashes with Preferred. This is the shape
+// used to migrate a user base from an old scheme to a new one: register the
+// old scheme(s) so existing hashes still verify, and set Preferred to the
+// new one so NeedsRehash/re-hashing moves users forward over time.
+type Multi struct {
+	Preferred Hasher
+	ByPrefix  map[string]Hasher // e.g. "$argon2id$" -> Argon2idHasher
+}
+
+// NewMulti builds a Multi from an ordered list of hashers; the first is
+// Preferred, and all are registered for Verify dispatch by their own prefix.
+func NewMulti(hashers ...Hasher) (*Multi, error) {
+	if len(hashers) == 0 {
+		return nil, errors.New("passwd: NewMulti requires at least one hasher")
+	}
+	m := &Multi{Preferred: hashers[0], ByPrefix: map[string]Hasher{}}
+	for _, h := range hashers {
+		prefixed, ok := h.(interface{ Prefix() string })
+		if !ok {
+			return nil, errors.New("passwd: hasher does not expose a Prefix()")
+		}
+		m.ByPrefix[prefixed.Prefix()] = h
+	}
+	return m, nil
+}
+
+func (m *Multi) Hash(password []byte) ([]byte, error) { return m.Preferred.Hash(password) }
+
+func (m *Multi) Verify(password, encoded []byte) error {
+	h := m.hasherFor(encoded)
+	if h == nil {
+		return errors.New("passwd: unrecognized hash format")
+	}
+	return h.Verify(password, encoded)
+}
+
+func (m *Multi) NeedsRehash(encoded []byte) bool {
+	h := m.hasherFor(encoded)
+	if h == nil || h != m.Preferred {
+		return true
+	}
+	return h.NeedsRehash(encoded)
+}
+
+// IsPreferred reports whether encoded was produced by Preferred using
+// parameters at least as strong as its current configuration - the
+// inverse of NeedsRehash, restricted to "is this the scheme we'd pick
+// today" rather than "does this specific hash need upgrading".
+func (m *Multi) IsPreferred(encoded []byte) bool {
+	return m.hasherFor(encoded) == m.Preferred && !m.Preferred.NeedsRehash(encoded)
+}
+
+func (m *Multi) hasherFor(encoded []byte) Hasher {
+	s := string(encoded)
+	for prefix, h := range m.ByPrefix {
+		if len(s) >= len(prefix) && s[:len(prefix)] == prefix {
+			return h
+		}
+	}
+	return nil
+}
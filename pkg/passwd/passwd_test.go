@@ -0,0 +1,102 @@
+package passwd
+
+import "testing"
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	h := NewArgon2idHasher(Argon2idParams{Memory: 8 * 1024, Time: 1, Parallelism: 1, SaltLen: 16, KeyLen: 32})
+	encoded, err := h.Hash([]byte("hunter2"))
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if err := h.Verify([]byte("hunter2"), encoded); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := h.Verify([]byte("wrong"), encoded); err != ErrMismatch {
+		t.Fatalf("expected ErrMismatch, got %v", err)
+	}
+}
+
+func TestBcryptRoundTrip(t *testing.T) {
+	h := NewBcryptHasher(4)
+	encoded, err := h.Hash([]byte("hunter2"))
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if err := h.Verify([]byte("hunter2"), encoded); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestScryptRoundTrip(t *testing.T) {
+	h := NewScryptHasher(ScryptParams{N: 1 << 10, R: 8, P: 1, SaltLen: 16, KeyLen: 32})
+	encoded, err := h.Hash([]byte("hunter2"))
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if err := h.Verify([]byte("hunter2"), encoded); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestPBKDF2RoundTrip(t *testing.T) {
+	h := NewPBKDF2Hasher(PBKDF2Params{Iterations: 1000, SaltLen: 16, KeyLen: 32})
+	encoded, err := h.Hash([]byte("hunter2"))
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if err := h.Verify([]byte("hunter2"), encoded); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := h.Verify([]byte("wrong"), encoded); err != ErrMismatch {
+		t.Fatalf("expected ErrMismatch, got %v", err)
+	}
+}
+
+func TestMultiIsPreferred(t *testing.T) {
+	oldScheme := NewScryptHasher(ScryptParams{N: 1 << 10, R: 8, P: 1, SaltLen: 16, KeyLen: 32})
+	newScheme := NewArgon2idHasher(Argon2idParams{Memory: 8 * 1024, Time: 1, Parallelism: 1, SaltLen: 16, KeyLen: 32})
+
+	m, err := NewMulti(newScheme, oldScheme)
+	if err != nil {
+		t.Fatalf("NewMulti: %v", err)
+	}
+
+	oldEncoded, _ := oldScheme.Hash([]byte("legacy-user"))
+	if m.IsPreferred(oldEncoded) {
+		t.Fatal("a non-preferred scheme's hash should not be reported as preferred")
+	}
+
+	newEncoded, err := m.Hash([]byte("new-user"))
+	if err != nil {
+		t.Fatalf("Multi.Hash: %v", err)
+	}
+	if !m.IsPreferred(newEncoded) {
+		t.Fatal("a freshly hashed password with the preferred scheme should be reported as preferred")
+	}
+}
+
+func TestMultiDispatchesByPrefix(t *testing.T) {
+	oldScheme := NewScryptHasher(ScryptParams{N: 1 << 10, R: 8, P: 1, SaltLen: 16, KeyLen: 32})
+	newScheme := NewArgon2idHasher(Argon2idParams{Memory: 8 * 1024, Time: 1, Parallelism: 1, SaltLen: 16, KeyLen: 32})
+
+	m, err := NewMulti(newScheme, oldScheme)
+	if err != nil {
+		t.Fatalf("NewMulti: %v", err)
+	}
+
+	oldEncoded, _ := oldScheme.Hash([]byte("legacy-user"))
+	if err := m.Verify([]byte("legacy-user"), oldEncoded); err != nil {
+		t.Fatalf("Multi.Verify on legacy hash: %v", err)
+	}
+	if !m.NeedsRehash(oldEncoded) {
+		t.Fatal("expected a non-preferred scheme's hash to need rehashing")
+	}
+
+	newEncoded, err := m.Hash([]byte("new-user"))
+	if err != nil {
+		t.Fatalf("Multi.Hash: %v", err)
+	}
+	if m.NeedsRehash(newEncoded) {
+		t.Fatal("a freshly hashed password with the preferred scheme should not need rehashing")
+	}
+}
@@ -0,0 +1,89 @@
+package passwd
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ScryptParams mirrors scrypt.Key's N/r/p cost knobs.
+type ScryptParams struct {
+	N, R, P int
+	SaltLen int
+	KeyLen  int
+}
+
+// DefaultScryptParams matches the widely-used N=2^15 baseline.
+var DefaultScryptParams = ScryptParams{N: 1 << 15, R: 8, P: 1, SaltLen: 16, KeyLen: 32}
+
+// ScryptHasher implements Hasher using scrypt.
+type ScryptHasher struct {
+	Params ScryptParams
+}
+
+func NewScryptHasher(params ScryptParams) *ScryptHasher { return &ScryptHasher{Params: params} }
+
+func (ScryptHasher) Prefix() string { return "$scrypt$" }
+
+func (h *ScryptHasher) Hash(password []byte) ([]byte, error) {
+	salt := make([]byte, h.Params.SaltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(password, salt, h.Params.N, h.Params.R, h.Params.P, h.Params.KeyLen)
+	if err != nil {
+		return nil, err
+	}
+	encoded := fmt.Sprintf("$scrypt$n=%d,r=%d,p=%d$%s$%s",
+		h.Params.N, h.Params.R, h.Params.P,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+	return []byte(encoded), nil
+}
+
+func (h *ScryptHasher) Verify(password, encoded []byte) error {
+	params, salt, key, err := parseScrypt(string(encoded))
+	if err != nil {
+		return err
+	}
+	candidate, err := scrypt.Key(password, salt, params.N, params.R, params.P, len(key))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
+
+func (h *ScryptHasher) NeedsRehash(encoded []byte) bool {
+	params, _, _, err := parseScrypt(string(encoded))
+	if err != nil {
+		return true
+	}
+	return params.N < h.Params.N || params.R < h.Params.R || params.P < h.Params.P
+}
+
+func parseScrypt(encoded string) (ScryptParams, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return ScryptParams{}, nil, nil, fmt.Errorf("passwd: malformed scrypt hash")
+	}
+	var p ScryptParams
+	if _, err := fmt.Sscanf(parts[2], "n=%d,r=%d,p=%d", &p.N, &p.R, &p.P); err != nil {
+		return ScryptParams{}, nil, nil, err
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return ScryptParams{}, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return ScryptParams{}, nil, nil, err
+	}
+	return p, salt, key, nil
+}
@@ -0,0 +1,343 @@
+// Package rainbow builds and queries rainbow tables: precomputed chains of
+// alternating hash/reduce steps that trade disk space for the CPU time a
+// plain brute-force or dictionary search would otherwise spend.
+//
+// A chain starts at a random plaintext, then alternates hash -> reduce for
+// ChainLength steps; only the (start, end) pair is kept. To look up a
+// target hash, Lookup guesses which step it could have been produced at,
+// finishes that chain out to its end, and checks the endpoint table; a hit
+// means the target is somewhere in that chain, so the chain is regenerated
+// from its start to recover the actual plaintext.
+package rainbow
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// HashFunc computes the raw hash bytes of a plaintext.
+type HashFunc func(plaintext string) []byte
+
+// ReductionFn maps a hash to a same-length plaintext candidate.
+type ReductionFn func(hash []byte) string
+
+// chain is a single precomputed (start, end) pair.
+type chain struct {
+	start, end string
+}
+
+// Table is a built rainbow table for one algorithm/charset/length
+// combination. The zero value is not usable; construct with Build or Load.
+type Table struct {
+	Algorithm   string
+	ChainLength int
+	ChainCount  int
+	Charset     string
+	PasswordLen int
+	Reductions  []ReductionFn
+
+	hashFunc HashFunc
+	chains   []chain // sorted by end, for binary search
+}
+
+// BuildOptions configures Build.
+type BuildOptions struct {
+	Algorithm   string
+	ChainLength int
+	ChainCount  int
+	Charset     string
+	PasswordLen int
+}
+
+// Build generates a rainbow table by computing ChainCount independent
+// chains in parallel across runtime.NumCPU() workers. It returns whatever
+// chains completed if ctx is cancelled early.
+func Build(ctx context.Context, opts BuildOptions, hashFunc HashFunc) *Table {
+	t := &Table{
+		Algorithm:   opts.Algorithm,
+		ChainLength: opts.ChainLength,
+		ChainCount:  opts.ChainCount,
+		Charset:     opts.Charset,
+		PasswordLen: opts.PasswordLen,
+		hashFunc:    hashFunc,
+	}
+	t.Reductions = generateReductions(opts.Charset, opts.PasswordLen, opts.ChainLength)
+
+	workers := runtime.NumCPU()
+	results := make(chan chain, opts.ChainCount)
+	var wg sync.WaitGroup
+	perWorker := (opts.ChainCount + workers - 1) / workers
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(workerID, count int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(workerID) + 1))
+			for i := 0; i < count; i++ {
+				if ctx.Err() != nil {
+					return
+				}
+				start := randomPlaintext(rng, opts.Charset, opts.PasswordLen)
+				results <- chain{start: start, end: t.walk(start, 0, opts.ChainLength)}
+			}
+		}(w, perWorker)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for c := range results {
+		t.chains = append(t.chains, c)
+		if len(t.chains) >= opts.ChainCount {
+			break
+		}
+	}
+
+	sort.Slice(t.chains, func(i, j int) bool { return t.chains[i].end < t.chains[j].end })
+	return t
+}
+
+// walk applies hash -> reduce starting at step `from` up to (but not
+// including) step ChainLength, returning the resulting plaintext.
+func (t *Table) walk(plaintext string, from, chainLength int) string {
+	current := plaintext
+	for step := from; step < chainLength; step++ {
+		h := t.hashFunc(current)
+		current = t.Reductions[step](h)
+	}
+	return current
+}
+
+// Lookup checks whether hash belongs to some chain in the table. It tries
+// every possible step at which hash could have been produced, from the
+// last step back to the first, since most positions are covered near the
+// end of a chain.
+func (t *Table) Lookup(hash []byte) (string, bool) {
+	for k := t.ChainLength - 1; k >= 0; k-- {
+		candidateEnd := t.Reductions[k](hash)
+		if k+1 < t.ChainLength {
+			candidateEnd = t.walk(candidateEnd, k+1, t.ChainLength)
+		}
+		start, ok := t.findChainByEnd(candidateEnd)
+		if !ok {
+			continue
+		}
+		if plaintext, ok := t.recoverFromChain(start, hash); ok {
+			return plaintext, true
+		}
+	}
+	return "", false
+}
+
+// findChainByEnd binary-searches the endpoint table, which is kept sorted
+// by end.
+func (t *Table) findChainByEnd(end string) (string, bool) {
+	i := sort.Search(len(t.chains), func(i int) bool { return t.chains[i].end >= end })
+	if i < len(t.chains) && t.chains[i].end == end {
+		return t.chains[i].start, true
+	}
+	return "", false
+}
+
+// recoverFromChain re-walks a chain from its start looking for the
+// plaintext whose hash equals target, since multiple plaintexts in the
+// chain can reduce to the same endpoint.
+func (t *Table) recoverFromChain(start string, target []byte) (string, bool) {
+	current := start
+	for step := 0; step < t.ChainLength; step++ {
+		h := t.hashFunc(current)
+		if bytesEqual(h, target) {
+			return current, true
+		}
+		current = t.Reductions[step](h)
+	}
+	return "", false
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func randomPlaintext(rng *rand.Rand, charset string, length int) string {
+	out := make([]byte, length)
+	for i := range out {
+		out[i] = charset[rng.Intn(len(charset))]
+	}
+	return string(out)
+}
+
+// generateReductions builds one reduction function per chain step. Step i
+// maps hash byte j to charset[(hash[j]+i) % len(charset)], wrapping around
+// the hash if it's shorter than passwordLen.
+func generateReductions(charset string, passwordLen, chainLength int) []ReductionFn {
+	fns := make([]ReductionFn, chainLength)
+	for i := 0; i < chainLength; i++ {
+		step := i
+		fns[i] = func(hash []byte) string {
+			out := make([]byte, passwordLen)
+			for j := 0; j < passwordLen; j++ {
+				b := hash[j%len(hash)]
+				out[j] = charset[(int(b)+step)%len(charset)]
+			}
+			return string(out)
+		}
+	}
+	return fns
+}
+
+const magic = "RNBW"
+const formatVersion = 1
+
+// Save writes the table in a compact binary format: magic bytes, a version
+// byte, a header of the build parameters, then ChainCount fixed-width
+// (start, end) pairs.
+func (t *Table) Save(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(magic); err != nil {
+		return err
+	}
+	if err := bw.WriteByte(formatVersion); err != nil {
+		return err
+	}
+	if err := writeString(bw, t.Algorithm); err != nil {
+		return err
+	}
+	if err := writeString(bw, t.Charset); err != nil {
+		return err
+	}
+	if err := writeUint32(bw, uint32(t.ChainLength)); err != nil {
+		return err
+	}
+	if err := writeUint32(bw, uint32(len(t.chains))); err != nil {
+		return err
+	}
+	if err := writeUint32(bw, uint32(t.PasswordLen)); err != nil {
+		return err
+	}
+	for _, c := range t.chains {
+		if _, err := bw.WriteString(c.start); err != nil {
+			return err
+		}
+		if _, err := bw.WriteString(c.end); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// Load reads a table previously written by Save. hashFunc must compute the
+// same algorithm the table was built with; Load does not verify this.
+func Load(r io.Reader, hashFunc HashFunc) (*Table, error) {
+	br := bufio.NewReader(r)
+	got := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, got); err != nil {
+		return nil, fmt.Errorf("rainbow: reading magic: %w", err)
+	}
+	if string(got) != magic {
+		return nil, fmt.Errorf("rainbow: not a rainbow table file")
+	}
+	version, err := br.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != formatVersion {
+		return nil, fmt.Errorf("rainbow: unsupported format version %d", version)
+	}
+
+	algorithm, err := readString(br)
+	if err != nil {
+		return nil, err
+	}
+	charset, err := readString(br)
+	if err != nil {
+		return nil, err
+	}
+	chainLength, err := readUint32(br)
+	if err != nil {
+		return nil, err
+	}
+	chainCount, err := readUint32(br)
+	if err != nil {
+		return nil, err
+	}
+	passwordLen, err := readUint32(br)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Table{
+		Algorithm:   algorithm,
+		ChainLength: int(chainLength),
+		ChainCount:  int(chainCount),
+		Charset:     charset,
+		PasswordLen: int(passwordLen),
+		hashFunc:    hashFunc,
+	}
+	t.Reductions = generateReductions(charset, int(passwordLen), int(chainLength))
+
+	t.chains = make([]chain, chainCount)
+	buf := make([]byte, passwordLen)
+	for i := range t.chains {
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, fmt.Errorf("rainbow: reading chain %d start: %w", i, err)
+		}
+		t.chains[i].start = string(buf)
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, fmt.Errorf("rainbow: reading chain %d end: %w", i, err)
+		}
+		t.chains[i].end = string(buf)
+	}
+	return t, nil
+}
+
+func writeString(w io.Writer, s string) error {
+	if err := writeUint32(w, uint32(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+func readString(r io.Reader) (string, error) {
+	n, err := readUint32(r)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func writeUint32(w io.Writer, v uint32) error {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	_, err := w.Write(buf[:])
+	return err
+}
+
+func readUint32(r io.Reader) (uint32, error) {
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(buf[:]), nil
+}
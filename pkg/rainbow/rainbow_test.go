@@ -0,0 +1,68 @@
+package rainbow
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"testing"
+)
+
+func md5HashFunc(plaintext string) []byte {
+	sum := md5.Sum([]byte(plaintext))
+	return sum[:]
+}
+
+func TestBuildAndLookupFindsChainedPlaintext(t *testing.T) {
+	opts := BuildOptions{
+		Algorithm:   "MD5",
+		ChainLength: 200,
+		ChainCount:  2000,
+		Charset:     "abcdefghijklmnopqrstuvwxyz",
+		PasswordLen: 4,
+	}
+	table := Build(context.Background(), opts, md5HashFunc)
+	if len(table.chains) != opts.ChainCount {
+		t.Fatalf("got %d chains, want %d", len(table.chains), opts.ChainCount)
+	}
+
+	// A chain's start is itself covered by the table (step 0).
+	start := table.chains[0].start
+	hash := md5HashFunc(start)
+	plaintext, ok := table.Lookup(hash)
+	if !ok {
+		t.Fatal("Lookup: expected a hit for a chain's own start plaintext")
+	}
+	if plaintext != start {
+		t.Fatalf("Lookup: got %q, want %q", plaintext, start)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	opts := BuildOptions{
+		Algorithm:   "MD5",
+		ChainLength: 50,
+		ChainCount:  100,
+		Charset:     "abcdefghijklmnopqrstuvwxyz",
+		PasswordLen: 4,
+	}
+	table := Build(context.Background(), opts, md5HashFunc)
+
+	var buf bytes.Buffer
+	if err := table.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(&buf, md5HashFunc)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if loaded.ChainLength != table.ChainLength || loaded.PasswordLen != table.PasswordLen {
+		t.Fatalf("loaded table params mismatch: %+v vs original", loaded)
+	}
+
+	start := table.chains[0].start
+	plaintext, ok := loaded.Lookup(md5HashFunc(start))
+	if !ok || plaintext != start {
+		t.Fatalf("loaded table Lookup: got (%q, %v), want (%q, true)", plaintext, ok, start)
+	}
+}
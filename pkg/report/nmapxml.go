@@ -0,0 +1,95 @@
+package report
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// WriteNmapXML writes r in (a subset of) nmap's XML output schema, so
+// results can be loaded into Zenmap or any other tool that already
+// understands nmap's format instead of a repo-specific one.
+func WriteNmapXML(w io.Writer, r Report) error {
+	run := nmapRun{
+		Scanner: r.Tool,
+		Start:   r.StartedAt.Unix(),
+	}
+	for _, host := range r.Hosts {
+		xHost := nmapHost{
+			Status:  nmapStatus{State: "up"},
+			Address: nmapAddress{Addr: host.Address, AddrType: "ipv4"},
+		}
+		for _, port := range host.Ports {
+			xPort := nmapPort{
+				Protocol: port.Protocol,
+				PortID:   port.Number,
+				State:    nmapPortState{State: port.State},
+			}
+			if port.Service != "" || port.Banner != "" {
+				xPort.Service = &nmapService{Name: port.Service, Banner: port.Banner}
+			}
+			for _, finding := range port.Findings {
+				xPort.Scripts = append(xPort.Scripts, nmapScript{ID: finding.Source, Output: finding.Summary})
+			}
+			for _, cve := range port.CVEs {
+				xPort.Scripts = append(xPort.Scripts, nmapScript{ID: "vulners", Output: cve})
+			}
+			xHost.Ports.Port = append(xHost.Ports.Port, xPort)
+		}
+		run.Hosts = append(run.Hosts, xHost)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(run)
+}
+
+type nmapRun struct {
+	XMLName xml.Name   `xml:"nmaprun"`
+	Scanner string     `xml:"scanner,attr"`
+	Start   int64      `xml:"start,attr"`
+	Hosts   []nmapHost `xml:"host"`
+}
+
+type nmapHost struct {
+	Status  nmapStatus  `xml:"status"`
+	Address nmapAddress `xml:"address"`
+	Ports   nmapPorts   `xml:"ports"`
+}
+
+type nmapStatus struct {
+	State string `xml:"state,attr"`
+}
+
+type nmapAddress struct {
+	Addr     string `xml:"addr,attr"`
+	AddrType string `xml:"addrtype,attr"`
+}
+
+type nmapPorts struct {
+	Port []nmapPort `xml:"port"`
+}
+
+type nmapPort struct {
+	Protocol string        `xml:"protocol,attr"`
+	PortID   int           `xml:"portid,attr"`
+	State    nmapPortState `xml:"state"`
+	Service  *nmapService  `xml:"service,omitempty"`
+	Scripts  []nmapScript  `xml:"script,omitempty"`
+}
+
+type nmapPortState struct {
+	State string `xml:"state,attr"`
+}
+
+type nmapService struct {
+	Name   string `xml:"name,attr"`
+	Banner string `xml:"banner,attr,omitempty"`
+}
+
+type nmapScript struct {
+	ID     string `xml:"id,attr"`
+	Output string `xml:"output,attr"`
+}
@@ -0,0 +1,41 @@
+// Package report defines a tool-agnostic scan result model plus writers
+// for the structured formats downstream tooling expects (JSON, nmap's XML
+// schema, SARIF), so any scanner in this repo can emit the same three
+// formats without reimplementing the encoders.
+package report
+
+import "time"
+
+// Report is one completed scan, covering every host it touched.
+type Report struct {
+	Tool      string    `json:"tool"`
+	StartedAt time.Time `json:"started_at"`
+	Hosts     []Host    `json:"hosts"`
+}
+
+// Host is one scanned target and the ports found on it.
+type Host struct {
+	Address string `json:"address"`
+	Ports   []Port `json:"ports,omitempty"`
+}
+
+// Port is one scanned port and everything learned about it: its open/
+// closed/filtered state, service and banner, any CVEs its banner
+// fingerprinted against, and any script/plugin findings run against it.
+type Port struct {
+	Number   int       `json:"number"`
+	Protocol string    `json:"protocol"` // "tcp" or "udp"
+	State    string    `json:"state"`    // "open", "closed", or "filtered"
+	Service  string    `json:"service,omitempty"`
+	Banner   string    `json:"banner,omitempty"`
+	CVEs     []string  `json:"cves,omitempty"`
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// Finding is one script, credential check, or other plugin's result
+// against a port.
+type Finding struct {
+	Source   string `json:"source"`   // e.g. script name or "bruteforce"
+	Summary  string `json:"summary"`
+	Severity string `json:"severity,omitempty"` // "low", "medium", "high"
+}
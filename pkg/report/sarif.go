@@ -0,0 +1,123 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteSARIF writes r as SARIF 2.1.0, one result per port finding and per
+// CVE, so scan output can be uploaded to GitHub code-scanning or another
+// DevSecOps pipeline alongside the rest of a repo's static-analysis
+// results.
+func WriteSARIF(w io.Writer, r Report) error {
+	ruleSeen := make(map[string]bool)
+	var rules []sarifRule
+	var results []sarifResult
+
+	addRule := func(id string) {
+		if !ruleSeen[id] {
+			ruleSeen[id] = true
+			rules = append(rules, sarifRule{ID: id, Name: id})
+		}
+	}
+
+	for _, host := range r.Hosts {
+		for _, port := range host.Ports {
+			location := fmt.Sprintf("%s:%d/%s", host.Address, port.Number, port.Protocol)
+			for _, finding := range port.Findings {
+				addRule(finding.Source)
+				results = append(results, sarifResult{
+					RuleID:  finding.Source,
+					Level:   sarifLevel(finding.Severity),
+					Message: sarifMessage{Text: finding.Summary},
+					Locations: []sarifLocation{{
+						LogicalLocations: []sarifLogicalLocation{{Name: location, FullyQualifiedName: location}},
+					}},
+				})
+			}
+			for _, cve := range port.CVEs {
+				addRule(cve)
+				results = append(results, sarifResult{
+					RuleID:  cve,
+					Level:   "warning",
+					Message: sarifMessage{Text: fmt.Sprintf("%s: possible match on %s", cve, location)},
+					Locations: []sarifLocation{{
+						LogicalLocations: []sarifLogicalLocation{{Name: location, FullyQualifiedName: location}},
+					}},
+				})
+			}
+		}
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: r.Tool, Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifLevel maps a finding's severity to the SARIF 2.1.0 result level
+// enum ("error", "warning", "note").
+func sarifLevel(severity string) string {
+	switch severity {
+	case "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
@@ -0,0 +1,21 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// Write renders r in the format named by format ("json", "xml", or
+// "sarif") to w.
+func Write(w io.Writer, format string, r Report) error {
+	switch format {
+	case "json":
+		return WriteJSON(w, r)
+	case "xml":
+		return WriteNmapXML(w, r)
+	case "sarif":
+		return WriteSARIF(w, r)
+	default:
+		return fmt.Errorf("report: unsupported format %q (want \"json\", \"xml\", or \"sarif\")", format)
+	}
+}
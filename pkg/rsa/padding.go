@@ -0,0 +1,115 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+)
+
+// oaepPad implements a minimal OAEP padding (RFC 8017 section 7.1.1) using
+// SHA-256 as both the hash and the MGF1 mask generation function.
+func oaepPad(message []byte, k int) ([]byte, error) {
+	hLen := sha256.Size
+	if len(message) > k-2*hLen-2 {
+		return nil, errors.New("rsa: message too long for OAEP padding")
+	}
+
+	lHash := sha256.Sum256(nil)
+	ps := make([]byte, k-len(message)-2*hLen-2)
+	db := make([]byte, 0, k-hLen-1)
+	db = append(db, lHash[:]...)
+	db = append(db, ps...)
+	db = append(db, 0x01)
+	db = append(db, message...)
+
+	seed := make([]byte, hLen)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+
+	dbMask := mgf1(seed, len(db))
+	maskedDB := xorBytes(db, dbMask)
+
+	seedMask := mgf1(maskedDB, hLen)
+	maskedSeed := xorBytes(seed, seedMask)
+
+	em := make([]byte, 0, k)
+	em = append(em, 0x00)
+	em = append(em, maskedSeed...)
+	em = append(em, maskedDB...)
+	return em, nil
+}
+
+func oaepUnpad(em []byte) ([]byte, error) {
+	hLen := sha256.Size
+	if len(em) < 2*hLen+2 || em[0] != 0x00 {
+		return nil, errors.New("rsa: decryption error")
+	}
+
+	maskedSeed := em[1 : 1+hLen]
+	maskedDB := em[1+hLen:]
+
+	seedMask := mgf1(maskedDB, hLen)
+	seed := xorBytes(maskedSeed, seedMask)
+
+	dbMask := mgf1(seed, len(maskedDB))
+	db := xorBytes(maskedDB, dbMask)
+
+	lHash := sha256.Sum256(nil)
+	if !bytesEqual(db[:hLen], lHash[:]) {
+		return nil, errors.New("rsa: decryption error")
+	}
+
+	rest := db[hLen:]
+	i := 0
+	for i < len(rest) && rest[i] == 0x00 {
+		i++
+	}
+	if i == len(rest) || rest[i] != 0x01 {
+		return nil, errors.New("rsa: decryption error")
+	}
+	return rest[i+1:], nil
+}
+
+// mgf1 is the MGF1 mask generation function (RFC 8017 appendix B.2.1) over SHA-256.
+func mgf1(seed []byte, length int) []byte {
+	var out []byte
+	for counter := uint32(0); len(out) < length; counter++ {
+		c := []byte{byte(counter >> 24), byte(counter >> 16), byte(counter >> 8), byte(counter)}
+		h := sha256.Sum256(append(append([]byte{}, seed...), c...))
+		out = append(out, h[:]...)
+	}
+	return out[:length]
+}
+
+func xorBytes(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i%len(b)]
+	}
+	return out
+}
+
+// sha256DigestInfo is the DER prefix identifying a SHA-256 digest inside a
+// PKCS#1 v1.5 DigestInfo structure.
+var sha256DigestInfo = []byte{
+	0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04,
+	0x02, 0x01, 0x05, 0x00, 0x04, 0x20,
+}
+
+// emsaPKCS1Encode builds an EMSA-PKCS1-v1_5 encoded message of exactly k
+// bytes: 0x00 0x01 0xFF...0xFF 0x00 DigestInfo digest.
+func emsaPKCS1Encode(digest []byte, k int) ([]byte, error) {
+	t := append(append([]byte{}, sha256DigestInfo...), digest...)
+	if k < len(t)+11 {
+		return nil, errors.New("rsa: intended encoded message length too short")
+	}
+	em := make([]byte, 0, k)
+	em = append(em, 0x00, 0x01)
+	for i := 0; i < k-len(t)-3; i++ {
+		em = append(em, 0xFF)
+	}
+	em = append(em, 0x00)
+	em = append(em, t...)
+	return em, nil
+}
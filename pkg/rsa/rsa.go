@@ -0,0 +1,162 @@
+// Package rsa implements textbook RSA key generation, OAEP encryption, and
+// PKCS#1 v1.5 signing on top of math/big, replacing the float64-based demo
+// that lost precision once messages or keys grew past 2^53.
+package rsa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/arya2004/cybersecurity/pkg/bigint"
+)
+
+// PublicKey is the (N, E) pair used for encryption and signature verification.
+type PublicKey struct {
+	N *big.Int
+	E *big.Int
+}
+
+// PrivateKey holds the full key, including the CRT parameters used to speed
+// up decryption and signing.
+type PrivateKey struct {
+	PublicKey
+	D    *big.Int
+	P, Q *big.Int
+	// Dp, Dq, Qinv are the CRT exponents: Dp = D mod (P-1), Dq = D mod (Q-1),
+	// Qinv = Q^-1 mod P.
+	Dp, Dq, Qinv *big.Int
+}
+
+var one = big.NewInt(1)
+
+// GenerateKey produces an RSA key pair with an N of the given bit size using
+// crypto/rand and Miller-Rabin primality testing (big.Int.ProbablyPrime).
+func GenerateKey(bits int) (*PrivateKey, error) {
+	if bits < 16 {
+		return nil, errors.New("rsa: key size too small")
+	}
+	for {
+		p, err := rand.Prime(rand.Reader, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(rand.Reader, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		n := bigint.Mul(p, q)
+		pMinus1 := bigint.Sub(p, one)
+		qMinus1 := bigint.Sub(q, one)
+		phi := bigint.Mul(pMinus1, qMinus1)
+
+		e := big.NewInt(65537)
+		g := new(big.Int).GCD(nil, nil, e, phi)
+		if g.Cmp(one) != 0 {
+			continue
+		}
+
+		d := bigint.ModInverse(e, phi)
+		if d == nil {
+			continue
+		}
+
+		return &PrivateKey{
+			PublicKey: PublicKey{N: n, E: e},
+			D:         d,
+			P:         p,
+			Q:         q,
+			Dp:        bigint.Mod(d, pMinus1),
+			Dq:        bigint.Mod(d, qMinus1),
+			Qinv:      bigint.ModInverse(q, p),
+		}, nil
+	}
+}
+
+// Encrypt OAEP-pads message and encrypts it under pub, returning the
+// ciphertext as a big-endian byte slice the size of N.
+func Encrypt(pub *PublicKey, message []byte) ([]byte, error) {
+	k := (pub.N.BitLen() + 7) / 8
+	padded, err := oaepPad(message, k)
+	if err != nil {
+		return nil, err
+	}
+	m := new(big.Int).SetBytes(padded)
+	if m.Cmp(pub.N) >= 0 {
+		return nil, errors.New("rsa: message representative out of range")
+	}
+	c := bigint.Exp(m, pub.E, pub.N)
+	return leftPad(c.Bytes(), k), nil
+}
+
+// Decrypt reverses Encrypt using the CRT for the modular exponentiation:
+// m = c^d mod n computed via (c^Dp mod P, c^Dq mod Q) and Garner's formula.
+func Decrypt(priv *PrivateKey, ciphertext []byte) ([]byte, error) {
+	k := (priv.N.BitLen() + 7) / 8
+	c := new(big.Int).SetBytes(ciphertext)
+
+	mP := bigint.Exp(c, priv.Dp, priv.P)
+	mQ := bigint.Exp(c, priv.Dq, priv.Q)
+	h := bigint.Mod(bigint.Mul(priv.Qinv, bigint.Sub(mP, mQ)), priv.P)
+	m := bigint.Add(mQ, bigint.Mul(h, priv.Q))
+
+	padded := leftPad(m.Bytes(), k)
+	return oaepUnpad(padded)
+}
+
+// Sign produces a PKCS#1 v1.5 signature over a SHA-256 digest of message.
+func Sign(priv *PrivateKey, message []byte) ([]byte, error) {
+	k := (priv.N.BitLen() + 7) / 8
+	digest := sha256.Sum256(message)
+	em, err := emsaPKCS1Encode(digest[:], k)
+	if err != nil {
+		return nil, err
+	}
+	m := new(big.Int).SetBytes(em)
+	s := bigint.Exp(m, priv.D, priv.N)
+	return leftPad(s.Bytes(), k), nil
+}
+
+// Verify checks a PKCS#1 v1.5 signature produced by Sign.
+func Verify(pub *PublicKey, message, signature []byte) error {
+	k := (pub.N.BitLen() + 7) / 8
+	s := new(big.Int).SetBytes(signature)
+	m := bigint.Exp(s, pub.E, pub.N)
+	em := leftPad(m.Bytes(), k)
+
+	digest := sha256.Sum256(message)
+	want, err := emsaPKCS1Encode(digest[:], k)
+	if err != nil {
+		return err
+	}
+	if !bytesEqual(em, want) {
+		return errors.New("rsa: signature verification failed")
+	}
+	return nil
+}
+
+func leftPad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	out := make([]byte, size)
+	copy(out[size-len(b):], b)
+	return out
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
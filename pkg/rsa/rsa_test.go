@@ -0,0 +1,61 @@
+package rsa
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestEncryptDecryptRoundTrip exercises OAEP encrypt/decrypt end to end; the
+// padding scheme is randomized (a fresh seed per call, as in RFC 3447
+// section 7.1), so we check round-trip equality rather than a fixed vector.
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	priv, err := GenerateKey(1024)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	message := []byte("RFC 3447 test message")
+	ciphertext, err := Encrypt(&priv.PublicKey, message)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	plaintext, err := Decrypt(priv, ciphertext)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(plaintext, message) {
+		t.Fatalf("round trip mismatch: got %q, want %q", plaintext, message)
+	}
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	priv, err := GenerateKey(1024)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	message := []byte("sign me")
+	sig, err := Sign(priv, message)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := Verify(&priv.PublicKey, message, sig); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	if err := Verify(&priv.PublicKey, []byte("tampered"), sig); err == nil {
+		t.Fatal("Verify unexpectedly succeeded for a tampered message")
+	}
+}
+
+func TestMessageTooLongForOAEP(t *testing.T) {
+	priv, err := GenerateKey(1024)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	longMessage := bytes.Repeat([]byte("a"), 200)
+	if _, err := Encrypt(&priv.PublicKey, longMessage); err == nil {
+		t.Fatal("expected an error for an over-long OAEP message")
+	}
+}
@@ -0,0 +1,33 @@
+package saes
+
+import "encoding/binary"
+
+// block implements a crypto/cipher.Block-shaped S-AES cipher. Its block
+// size is 2 bytes, since S-AES operates on 16-bit blocks.
+type block struct {
+	key uint16
+}
+
+// NewCipher returns a crypto/cipher.Block-shaped S-AES cipher keyed by key.
+func NewCipher(key uint16) Block {
+	return &block{key: key}
+}
+
+// Block matches crypto/cipher.Block's shape without importing it, since
+// S-AES's 2-byte block size is smaller than crypto/cipher's callers
+// generally expect.
+type Block interface {
+	BlockSize() int
+	Encrypt(dst, src []byte)
+	Decrypt(dst, src []byte)
+}
+
+func (b *block) BlockSize() int { return 2 }
+
+func (b *block) Encrypt(dst, src []byte) {
+	binary.BigEndian.PutUint16(dst, Encrypt(binary.BigEndian.Uint16(src), b.key))
+}
+
+func (b *block) Decrypt(dst, src []byte) {
+	binary.BigEndian.PutUint16(dst, Decrypt(binary.BigEndian.Uint16(src), b.key))
+}
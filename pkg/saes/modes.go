@@ -0,0 +1,156 @@
+package saes
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const blockSize = 2
+
+// ErrInvalidPadding is returned by the Decrypt* functions when the
+// trailing PKCS#7 padding is missing or corrupt.
+var ErrInvalidPadding = errors.New("saes: invalid padding")
+
+// padPKCS7 pads data to a multiple of the 2-byte S-AES block size.
+func padPKCS7(data []byte) []byte {
+	padLen := blockSize - len(data)%blockSize
+	padded := append(append([]byte{}, data...), make([]byte, padLen)...)
+	for i := len(padded) - padLen; i < len(padded); i++ {
+		padded[i] = byte(padLen)
+	}
+	return padded
+}
+
+func unpadPKCS7(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidPadding
+	}
+	padLen := int(data[len(data)-1])
+	if padLen == 0 || padLen > blockSize || padLen > len(data) {
+		return nil, ErrInvalidPadding
+	}
+	for _, b := range data[len(data)-padLen:] {
+		if int(b) != padLen {
+			return nil, ErrInvalidPadding
+		}
+	}
+	return data[:len(data)-padLen], nil
+}
+
+// EncryptECB pads and encrypts plaintext one 2-byte block at a time,
+// independently.
+func EncryptECB(key uint16, plaintext []byte) []byte {
+	padded := padPKCS7(plaintext)
+	ciphertext := make([]byte, len(padded))
+	for i := 0; i < len(padded); i += blockSize {
+		c := Encrypt(binary.BigEndian.Uint16(padded[i:i+blockSize]), key)
+		binary.BigEndian.PutUint16(ciphertext[i:i+blockSize], c)
+	}
+	return ciphertext
+}
+
+// DecryptECB decrypts ciphertext one 2-byte block at a time and strips
+// padding.
+func DecryptECB(key uint16, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext)%blockSize != 0 {
+		return nil, errors.New("saes: ciphertext is not a multiple of the block size")
+	}
+	plaintext := make([]byte, len(ciphertext))
+	for i := 0; i < len(ciphertext); i += blockSize {
+		p := Decrypt(binary.BigEndian.Uint16(ciphertext[i:i+blockSize]), key)
+		binary.BigEndian.PutUint16(plaintext[i:i+blockSize], p)
+	}
+	return unpadPKCS7(plaintext)
+}
+
+// EncryptCBC pads plaintext, generates a random 2-byte IV, and chains
+// blocks with XOR-then-encrypt. The returned slice is the IV followed by
+// the ciphertext blocks.
+func EncryptCBC(key uint16, plaintext []byte) ([]byte, error) {
+	padded := padPKCS7(plaintext)
+
+	iv := make([]byte, blockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, blockSize+len(padded))
+	copy(out, iv)
+	prev := binary.BigEndian.Uint16(iv)
+	for i := 0; i < len(padded); i += blockSize {
+		c := Encrypt(binary.BigEndian.Uint16(padded[i:i+blockSize])^prev, key)
+		binary.BigEndian.PutUint16(out[blockSize+i:blockSize+i+blockSize], c)
+		prev = c
+	}
+	return out, nil
+}
+
+// DecryptCBC reverses EncryptCBC: ciphertext must be the IV followed by one
+// or more 2-byte ciphertext blocks.
+func DecryptCBC(key uint16, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 2*blockSize || len(ciphertext)%blockSize != 0 {
+		return nil, errors.New("saes: ciphertext too short for CBC")
+	}
+	iv, body := ciphertext[:blockSize], ciphertext[blockSize:]
+
+	plaintext := make([]byte, len(body))
+	prev := binary.BigEndian.Uint16(iv)
+	for i := 0; i < len(body); i += blockSize {
+		c := binary.BigEndian.Uint16(body[i : i+blockSize])
+		p := Decrypt(c, key) ^ prev
+		binary.BigEndian.PutUint16(plaintext[i:i+blockSize], p)
+		prev = c
+	}
+	return unpadPKCS7(plaintext)
+}
+
+// EncryptCTR generates a random 2-byte counter seed and XORs plaintext with
+// the keystream produced by encrypting successive counter values, so
+// plaintext of any length (no padding) can be encrypted. The returned
+// slice is the counter seed followed by the ciphertext.
+func EncryptCTR(key uint16, plaintext []byte) ([]byte, error) {
+	seed := make([]byte, blockSize)
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, blockSize+len(plaintext))
+	copy(out, seed)
+	xorCTR(key, binary.BigEndian.Uint16(seed), plaintext, out[blockSize:])
+	return out, nil
+}
+
+// DecryptCTR reverses EncryptCTR: ciphertext must be the counter seed
+// followed by the ciphertext bytes. CTR is symmetric, so this runs the
+// same keystream XOR as EncryptCTR.
+func DecryptCTR(key uint16, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < blockSize {
+		return nil, errors.New("saes: ciphertext too short for CTR")
+	}
+	seed, body := ciphertext[:blockSize], ciphertext[blockSize:]
+
+	plaintext := make([]byte, len(body))
+	xorCTR(key, binary.BigEndian.Uint16(seed), body, plaintext)
+	return plaintext, nil
+}
+
+// xorCTR XORs src with the keystream obtained by encrypting counter,
+// counter+1, counter+2, ... (wrapping at 16 bits), writing the result into
+// dst.
+func xorCTR(key uint16, counter uint16, src, dst []byte) {
+	for i := 0; i < len(src); i += blockSize {
+		var ks [blockSize]byte
+		binary.BigEndian.PutUint16(ks[:], Encrypt(counter, key))
+		counter++
+
+		end := i + blockSize
+		if end > len(src) {
+			end = len(src)
+		}
+		for j := i; j < end; j++ {
+			dst[j] = src[j] ^ ks[j-i]
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Package saes implements Simplified AES (S-AES): a 16-bit-block,
+// 16-bit-key teaching cipher with the same round structure as full AES
+// (AddRoundKey/SubNibbles/ShiftRows/MixColumns) scaled down to nibbles
+// instead of bytes.
+package saes
+
+import "encoding/hex"
+
+var substitutionMap = map[uint8]uint8{
+	0b0000: 0b1001,
+	0b0001: 0b0100,
+	0b0010: 0b1010,
+	0b0011: 0b1011,
+	0b0100: 0b1101,
+	0b0101: 0b0001,
+	0b0110: 0b1000,
+	0b0111: 0b0101,
+	0b1000: 0b0110,
+	0b1001: 0b0010,
+	0b1010: 0b0000,
+	0b1011: 0b0011,
+	0b1100: 0b1100,
+	0b1101: 0b1110,
+	0b1110: 0b1111,
+	0b1111: 0b0111,
+}
+
+var inverseSubstitutionMap = invert(substitutionMap)
+
+func invert(m map[uint8]uint8) map[uint8]uint8 {
+	out := make(map[uint8]uint8, len(m))
+	for k, v := range m {
+		out[v] = k
+	}
+	return out
+}
+
+// SwapNibble swaps the high and low nibble of a byte.
+func SwapNibble(byteVal uint8) uint8 {
+	return (byteVal << 4) | (byteVal >> 4)
+}
+
+// SubNibble substitutes both nibbles of a byte through the S-AES S-box.
+func SubNibble(byteVal uint8) uint8 {
+	return subNibbleWith(byteVal, substitutionMap)
+}
+
+// InvSubNibble substitutes both nibbles of a byte through the inverse
+// S-AES S-box.
+func InvSubNibble(byteVal uint8) uint8 {
+	return subNibbleWith(byteVal, inverseSubstitutionMap)
+}
+
+func subNibbleWith(byteVal uint8, table map[uint8]uint8) uint8 {
+	highNibble := byteVal >> 4
+	lowNibble := byteVal & 0x0F
+	return (table[highNibble] << 4) | table[lowNibble]
+}
+
+// KeyGeneration expands a 16-bit key into the three 16-bit round keys S-AES
+// uses: k0 is the key itself, k1 and k2 are derived from it via two rounds
+// of the S-AES key schedule (word XOR with a round constant and a
+// sub-nibble'd, swapped previous word).
+func KeyGeneration(key uint16) (k0, k1, k2 uint16) {
+	word0 := uint8(key >> 8)
+	word1 := uint8(key & 0xFF)
+
+	word2 := word0 ^ 0b10000000 ^ SubNibble(SwapNibble(word1))
+	word3 := word2 ^ word1
+
+	word4 := word2 ^ 0b00110000 ^ SubNibble(SwapNibble(word3))
+	word5 := word4 ^ word3
+
+	k0 = (uint16(word0) << 8) | uint16(word1)
+	k1 = (uint16(word2) << 8) | uint16(word3)
+	k2 = (uint16(word4) << 8) | uint16(word5)
+	return k0, k1, k2
+}
+
+// gfMul4 multiplies two GF(2^4) elements modulo the S-AES polynomial
+// x^4+x+1 (0b10011).
+func gfMul4(a, b uint8) uint8 {
+	var product uint8
+	for i := 0; i < 4; i++ {
+		if b&1 != 0 {
+			product ^= a
+		}
+		hiBitSet := a & 0x8
+		a <<= 1
+		if hiBitSet != 0 {
+			a ^= 0x13
+		}
+		a &= 0xF
+		b >>= 1
+	}
+	return product & 0xF
+}
+
+// state is the S-AES 2x2 nibble matrix packed into a uint16:
+//
+//	n0 n2
+//	n1 n3
+type state struct {
+	n0, n1, n2, n3 uint8
+}
+
+func stateFromUint16(v uint16) state {
+	return state{
+		n0: uint8(v>>12) & 0xF,
+		n1: uint8(v>>8) & 0xF,
+		n2: uint8(v>>4) & 0xF,
+		n3: uint8(v) & 0xF,
+	}
+}
+
+func (s state) toUint16() uint16 {
+	return uint16(s.n0)<<12 | uint16(s.n1)<<8 | uint16(s.n2)<<4 | uint16(s.n3)
+}
+
+func (s state) subNibbles(table map[uint8]uint8) state {
+	return state{table[s.n0], table[s.n1], table[s.n2], table[s.n3]}
+}
+
+// shiftRows swaps the bottom-row nibbles (n1, n3); it is its own inverse,
+// since swapping two elements of a 2-element row twice is a no-op.
+func (s state) shiftRows() state {
+	return state{s.n0, s.n3, s.n2, s.n1}
+}
+
+// mixColumns multiplies each column of the state by the S-AES MDS matrix
+// [[1,4],[4,1]] over GF(2^4).
+func (s state) mixColumns() state {
+	return state{
+		n0: s.n0 ^ gfMul4(4, s.n1),
+		n1: gfMul4(4, s.n0) ^ s.n1,
+		n2: s.n2 ^ gfMul4(4, s.n3),
+		n3: gfMul4(4, s.n2) ^ s.n3,
+	}
+}
+
+// invMixColumns multiplies each column of the state by the inverse S-AES
+// MDS matrix [[9,2],[2,9]] over GF(2^4).
+func (s state) invMixColumns() state {
+	return state{
+		n0: gfMul4(9, s.n0) ^ gfMul4(2, s.n1),
+		n1: gfMul4(2, s.n0) ^ gfMul4(9, s.n1),
+		n2: gfMul4(9, s.n2) ^ gfMul4(2, s.n3),
+		n3: gfMul4(2, s.n2) ^ gfMul4(9, s.n3),
+	}
+}
+
+func (s state) addRoundKey(key uint16) state {
+	return stateFromUint16(s.toUint16() ^ key)
+}
+
+// Encrypt runs the full S-AES round structure:
+//
+//	AddRoundKey(k0) -> SubNibbles -> ShiftRows -> MixColumns -> AddRoundKey(k1)
+//	             -> SubNibbles -> ShiftRows -> AddRoundKey(k2)
+func Encrypt(plaintext, key uint16) uint16 {
+	k0, k1, k2 := KeyGeneration(key)
+
+	s := stateFromUint16(plaintext)
+	s = s.addRoundKey(k0)
+	s = s.subNibbles(substitutionMap)
+	s = s.shiftRows()
+	s = s.mixColumns()
+	s = s.addRoundKey(k1)
+	s = s.subNibbles(substitutionMap)
+	s = s.shiftRows()
+	s = s.addRoundKey(k2)
+	return s.toUint16()
+}
+
+// Decrypt reverses Encrypt: round keys are consumed in reverse order, and
+// every step uses its inverse (InvShiftRows is ShiftRows itself, since the
+// swap is self-inverse).
+func Decrypt(ciphertext, key uint16) uint16 {
+	k0, k1, k2 := KeyGeneration(key)
+
+	s := stateFromUint16(ciphertext)
+	s = s.addRoundKey(k2)
+	s = s.shiftRows()
+	s = s.subNibbles(inverseSubstitutionMap)
+	s = s.addRoundKey(k1)
+	s = s.invMixColumns()
+	s = s.shiftRows()
+	s = s.subNibbles(inverseSubstitutionMap)
+	s = s.addRoundKey(k0)
+	return s.toUint16()
+}
+
+// ParseKey parses a 4-character hex string (e.g. "A73B") into the 16-bit
+// key Encrypt/Decrypt expect.
+func ParseKey(s string) (uint16, error) {
+	if len(s) != 4 {
+		return 0, hex.ErrLength
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return 0, err
+	}
+	return uint16(b[0])<<8 | uint16(b[1]), nil
+}
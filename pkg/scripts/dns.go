@@ -0,0 +1,135 @@
+package scripts
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// dnsVersionScript is nmap's dns-nsid/dns-recursion cousin dns-version-bind:
+// a CHAOS-class TXT query for "version.bind" that many resolvers (notably
+// BIND) answer with their software version - useful recon, nothing
+// intrusive.
+type dnsVersionScript struct{}
+
+func init() { Register(dnsVersionScript{}) }
+
+func (dnsVersionScript) Name() string         { return "dns-version-bind" }
+func (dnsVersionScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (dnsVersionScript) PortRule(t Target) bool {
+	return t.Service == "DNS" || t.Port == 53
+}
+
+func (dnsVersionScript) Run(ctx context.Context, t Target) (string, error) {
+	d := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := d.DialContext(ctx, "udp", fmt.Sprintf("%s:%d", t.Host, t.Port))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write(dnsVersionBindQuery()); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 512)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := parseDNSTXTAnswer(buf[:n])
+	if err != nil {
+		return "", err
+	}
+	if version == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("version.bind: %s", version), nil
+}
+
+// dnsVersionBindQuery builds a CH/TXT query for "version.bind." with a
+// fixed transaction ID, recursion not requested - the server's own CHAOS
+// zone is authoritative for it.
+func dnsVersionBindQuery() []byte {
+	msg := []byte{
+		0x13, 0x37, // transaction ID
+		0x00, 0x00, // flags: standard query
+		0x00, 0x01, // QDCOUNT
+		0x00, 0x00, // ANCOUNT
+		0x00, 0x00, // NSCOUNT
+		0x00, 0x00, // ARCOUNT
+	}
+	for _, label := range strings.Split("version.bind", ".") {
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0x00)     // root label
+	msg = append(msg, 0, 16)    // QTYPE  = TXT (16)
+	msg = append(msg, 0, 3)     // QCLASS = CH (3)
+	return msg
+}
+
+// parseDNSTXTAnswer extracts the first TXT record's character-string from
+// a DNS response, skipping the echoed question section.
+func parseDNSTXTAnswer(msg []byte) (string, error) {
+	if len(msg) < 12 {
+		return "", fmt.Errorf("dns-version-bind: truncated response")
+	}
+	ancount := binary.BigEndian.Uint16(msg[6:8])
+	if ancount == 0 {
+		return "", nil
+	}
+
+	i := 12
+	skipName := func() error {
+		for i < len(msg) {
+			l := int(msg[i])
+			if l == 0 {
+				i++
+				return nil
+			}
+			if l&0xc0 == 0xc0 { // compression pointer
+				i += 2
+				return nil
+			}
+			i += 1 + l
+		}
+		return fmt.Errorf("truncated name")
+	}
+
+	if err := skipName(); err != nil {
+		return "", err
+	}
+	i += 4 // QTYPE + QCLASS
+
+	for a := 0; a < int(ancount); a++ {
+		if err := skipName(); err != nil {
+			return "", err
+		}
+		if i+10 > len(msg) {
+			return "", fmt.Errorf("dns-version-bind: truncated answer")
+		}
+		rtype := binary.BigEndian.Uint16(msg[i:])
+		rdlen := int(binary.BigEndian.Uint16(msg[i+8:]))
+		i += 10
+		if i+rdlen > len(msg) {
+			return "", fmt.Errorf("dns-version-bind: truncated rdata")
+		}
+		rdata := msg[i : i+rdlen]
+		i += rdlen
+
+		if rtype == 16 && len(rdata) > 0 { // TXT
+			n := int(rdata[0])
+			if 1+n <= len(rdata) {
+				return string(rdata[1 : 1+n]), nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,63 @@
+package scripts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// esHealthScript is nmap's elasticsearch-info for cluster health: an
+// unauthenticated GET /_cluster/health, which on a misconfigured cluster
+// reveals the cluster name and status (green/yellow/red) without any
+// credentials at all.
+type esHealthScript struct{}
+
+func init() { Register(esHealthScript{}) }
+
+func (esHealthScript) Name() string         { return "elasticsearch-cluster-health" }
+func (esHealthScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (esHealthScript) PortRule(t Target) bool {
+	return t.Service == "Elasticsearch" || t.Port == 9200
+}
+
+type esClusterHealth struct {
+	ClusterName string `json:"cluster_name"`
+	Status      string `json:"status"`
+	Nodes       int    `json:"number_of_nodes"`
+}
+
+func (esHealthScript) Run(ctx context.Context, t Target) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s:%d/_cluster/health", t.Host, t.Port), nil)
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("elasticsearch-cluster-health: unexpected status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", err
+	}
+
+	var health esClusterHealth
+	if err := json.Unmarshal(body, &health); err != nil {
+		return "", fmt.Errorf("elasticsearch-cluster-health: %w", err)
+	}
+	if health.ClusterName == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("cluster: %s, status: %s, nodes: %d", health.ClusterName, health.Status, health.Nodes), nil
+}
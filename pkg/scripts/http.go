@@ -0,0 +1,70 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// httpTitleScript is the equivalent of nmap's http-title: a plain GET that
+// reports the page title and a couple of headers that tend to leak stack
+// information (Server, X-Powered-By).
+type httpTitleScript struct{}
+
+func init() { Register(httpTitleScript{}) }
+
+var titleTag = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+func (httpTitleScript) Name() string         { return "http-title" }
+func (httpTitleScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (httpTitleScript) PortRule(t Target) bool {
+	switch t.Service {
+	case "HTTP", "HTTP-Alt", "HTTPS":
+		return true
+	}
+	return t.Port == 80 || t.Port == 8080 || t.Port == 443
+}
+
+func (httpTitleScript) Run(ctx context.Context, t Target) (string, error) {
+	scheme := "http"
+	if t.Service == "HTTPS" || t.Port == 443 {
+		scheme = "https"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s:%d/", scheme, t.Host, t.Port), nil)
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	if m := titleTag.FindSubmatch(body); m != nil {
+		parts = append(parts, fmt.Sprintf("title: %s", strings.TrimSpace(string(m[1]))))
+	}
+	if server := resp.Header.Get("Server"); server != "" {
+		parts = append(parts, fmt.Sprintf("server: %s", server))
+	}
+	if xpb := resp.Header.Get("X-Powered-By"); xpb != "" {
+		parts = append(parts, fmt.Sprintf("x-powered-by: %s", xpb))
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+	return strings.Join(parts, ", "), nil
+}
@@ -0,0 +1,188 @@
+package scripts
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"time"
+)
+
+// mongoIsMasterScript is nmap's mongodb-info: an unauthenticated isMaster
+// command against admin.$cmd, which every MongoDB server (even one
+// requiring auth for everything else) answers with its version and wire
+// protocol range. Speaks just enough of OP_QUERY and BSON to build the
+// command and read the fields back out - not a general BSON codec.
+type mongoIsMasterScript struct{}
+
+func init() { Register(mongoIsMasterScript{}) }
+
+func (mongoIsMasterScript) Name() string         { return "mongodb-info" }
+func (mongoIsMasterScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (mongoIsMasterScript) PortRule(t Target) bool {
+	return t.Service == "MongoDB" || t.Port == 27017
+}
+
+func (mongoIsMasterScript) Run(ctx context.Context, t Target) (string, error) {
+	d := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", t.Host, t.Port))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write(mongoIsMasterQuery()); err != nil {
+		return "", err
+	}
+
+	var head [16]byte
+	if _, err := readFullMongo(conn, head[:]); err != nil {
+		return "", err
+	}
+	length := int(binary.LittleEndian.Uint32(head[0:4]))
+	if length < 16 || length > 4*1024*1024 {
+		return "", fmt.Errorf("mongodb-info: implausible reply length %d", length)
+	}
+	body := make([]byte, length-16)
+	if _, err := readFullMongo(conn, body); err != nil {
+		return "", err
+	}
+	// OP_REPLY prefix: responseFlags(4) cursorID(8) startingFrom(4) numberReturned(4)
+	if len(body) < 20 {
+		return "", fmt.Errorf("mongodb-info: truncated OP_REPLY")
+	}
+	doc := body[20:]
+
+	fields := decodeBSONTopLevel(doc)
+	version, _ := fields["version"].(string)
+	wireVersion, _ := fields["maxWireVersion"].(int32)
+	if version == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("version: %s, maxWireVersion: %d", version, wireVersion), nil
+}
+
+// mongoIsMasterQuery builds an OP_QUERY message running {isMaster: 1}
+// against admin.$cmd, the collection every server accepts commands on
+// regardless of auth state.
+func mongoIsMasterQuery() []byte {
+	query := bsonInt32Doc("isMaster", 1)
+
+	var body []byte
+	body = appendUint32LE(body, 0) // flags
+	body = append(body, "admin.$cmd"...)
+	body = append(body, 0x00)
+	body = appendUint32LE(body, 0)          // numberToSkip
+	body = appendUint32LE(body, 0xFFFFFFFF) // numberToReturn = -1
+	body = append(body, query...)
+
+	var msg []byte
+	msg = appendUint32LE(msg, uint32(16+len(body)))
+	msg = appendUint32LE(msg, 1) // requestID
+	msg = appendUint32LE(msg, 0) // responseTo
+	msg = appendUint32LE(msg, 2004) // opCode = OP_QUERY
+	msg = append(msg, body...)
+	return msg
+}
+
+// bsonInt32Doc builds a single-field BSON document { key: int32(value) }.
+func bsonInt32Doc(key string, value int32) []byte {
+	elem := append([]byte{0x10}, key...)
+	elem = append(elem, 0x00)
+	elem = appendUint32LE(elem, uint32(value))
+
+	doc := appendUint32LE(nil, uint32(4+len(elem)+1))
+	doc = append(doc, elem...)
+	doc = append(doc, 0x00)
+	return doc
+}
+
+func appendUint32LE(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// decodeBSONTopLevel decodes only the top-level elements of doc into a Go
+// map, supporting just the element types isMaster's reply actually uses
+// (double, string, 32/64-bit int, bool) - nested documents and arrays are
+// skipped rather than recursed into.
+func decodeBSONTopLevel(doc []byte) map[string]interface{} {
+	out := map[string]interface{}{}
+	if len(doc) < 5 {
+		return out
+	}
+	i := 4 // skip total length
+	for i < len(doc) && doc[i] != 0x00 {
+		elemType := doc[i]
+		i++
+
+		start := i
+		for i < len(doc) && doc[i] != 0x00 {
+			i++
+		}
+		name := string(doc[start:i])
+		i++ // skip name terminator
+
+		switch elemType {
+		case 0x01: // double
+			if i+8 > len(doc) {
+				return out
+			}
+			out[name] = math.Float64frombits(binary.LittleEndian.Uint64(doc[i : i+8]))
+			i += 8
+		case 0x02: // UTF-8 string
+			if i+4 > len(doc) {
+				return out
+			}
+			n := int(binary.LittleEndian.Uint32(doc[i : i+4]))
+			i += 4
+			if i+n > len(doc) || n == 0 {
+				return out
+			}
+			out[name] = string(doc[i : i+n-1]) // drop NUL terminator
+			i += n
+		case 0x08: // bool
+			if i+1 > len(doc) {
+				return out
+			}
+			out[name] = doc[i] != 0
+			i++
+		case 0x10: // int32
+			if i+4 > len(doc) {
+				return out
+			}
+			out[name] = int32(binary.LittleEndian.Uint32(doc[i : i+4]))
+			i += 4
+		case 0x12: // int64
+			if i+8 > len(doc) {
+				return out
+			}
+			out[name] = int64(binary.LittleEndian.Uint64(doc[i : i+8]))
+			i += 8
+		case 0x03, 0x04: // embedded document / array - skip
+			if i+4 > len(doc) {
+				return out
+			}
+			n := int(binary.LittleEndian.Uint32(doc[i : i+4]))
+			i += n
+		default:
+			// Unknown/unsupported type; nothing safe to do but stop.
+			return out
+		}
+	}
+	return out
+}
+
+func readFullMongo(conn net.Conn, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := conn.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,80 @@
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// mysqlVersionScript is nmap's mysql-info: the server's initial handshake
+// packet carries its version string and capability flags before any
+// authentication happens, so reading it and disconnecting is enough.
+type mysqlVersionScript struct{}
+
+func init() { Register(mysqlVersionScript{}) }
+
+func (mysqlVersionScript) Name() string         { return "mysql-info" }
+func (mysqlVersionScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (mysqlVersionScript) PortRule(t Target) bool {
+	return t.Service == "MySQL" || t.Port == 3306
+}
+
+func (mysqlVersionScript) Run(ctx context.Context, t Target) (string, error) {
+	d := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", t.Host, t.Port))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	handshake, err := readMySQLHandshake(conn)
+	if err != nil {
+		return "", err
+	}
+	if len(handshake) < 2 || handshake[0] != 10 {
+		return "", fmt.Errorf("mysql-info: unsupported protocol version")
+	}
+
+	end := 1
+	for end < len(handshake) && handshake[end] != 0 {
+		end++
+	}
+	version := string(handshake[1:end])
+	if version == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("version: %s", version), nil
+}
+
+// readMySQLHandshake reads one 4-byte length-prefixed MySQL protocol
+// packet and returns its payload - duplicated from bruteforce's identical
+// helper since this package doesn't depend on it.
+func readMySQLHandshake(conn net.Conn) ([]byte, error) {
+	var head [4]byte
+	if _, err := readFullMySQL(conn, head[:]); err != nil {
+		return nil, err
+	}
+	length := int(head[0]) | int(head[1])<<8 | int(head[2])<<16
+	payload := make([]byte, length)
+	if length > 0 {
+		if _, err := readFullMySQL(conn, payload); err != nil {
+			return nil, err
+		}
+	}
+	return payload, nil
+}
+
+func readFullMySQL(conn net.Conn, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := conn.Read(buf[total:])
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,86 @@
+package scripts
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// redisInfoScript is nmap's redis-info: send INFO with no authentication
+// and report the redis_version and redis_mode fields from the bulk-string
+// reply, the same information an unauthenticated client gets for free on
+// a misconfigured instance.
+type redisInfoScript struct{}
+
+func init() { Register(redisInfoScript{}) }
+
+func (redisInfoScript) Name() string         { return "redis-info" }
+func (redisInfoScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (redisInfoScript) PortRule(t Target) bool {
+	return t.Service == "Redis" || t.Port == 6379
+}
+
+func (redisInfoScript) Run(ctx context.Context, t Target) (string, error) {
+	d := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", t.Host, t.Port))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write(encodeRESPArray("INFO", "server")); err != nil {
+		return "", err
+	}
+
+	reader := bufio.NewReader(conn)
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	header = strings.TrimRight(header, "\r\n")
+	if !strings.HasPrefix(header, "$") {
+		return "", fmt.Errorf("redis-info: authentication required or unexpected reply %q", header)
+	}
+
+	// The bulk string spans many \r\n-separated lines; read all size bytes.
+	var size int
+	if _, err := fmt.Sscanf(header, "$%d", &size); err != nil {
+		return "", fmt.Errorf("redis-info: unexpected reply %q", header)
+	}
+	var body string
+	for len(body) < size {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		body += line
+	}
+
+	fields := map[string]string{}
+	for _, line := range strings.Split(body, "\r\n") {
+		k, v, ok := strings.Cut(line, ":")
+		if ok {
+			fields[k] = v
+		}
+	}
+	if fields["redis_version"] == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("redis_version: %s, redis_mode: %s", fields["redis_version"], fields["redis_mode"]), nil
+}
+
+// encodeRESPArray builds a RESP array of bulk strings - the same command
+// framing bruteforce's redisChecker uses, duplicated here since this
+// package doesn't depend on bruteforce.
+func encodeRESPArray(args ...string) []byte {
+	out := fmt.Sprintf("*%d\r\n", len(args))
+	for _, a := range args {
+		out += fmt.Sprintf("$%d\r\n%s\r\n", len(a), a)
+	}
+	return []byte(out)
+}
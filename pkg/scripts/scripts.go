@@ -0,0 +1,143 @@
+// Package scripts is an nmap-NSE-inspired library of per-service probes
+// for NetworkScanner. Where VulnerabilityCheck used to hardcode a switch
+// on port number and banner substrings, each probe here is instead a
+// self-contained Script registered through an init(), so adding one is a
+// new file rather than a change to the scanner core. A Script declares
+// which ports it applies to (PortRule) and which categories it belongs to
+// (Categories), mirroring nmap's --script=vuln,safe selection.
+package scripts
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Target is one open, service-identified port a Script can probe. It
+// deliberately doesn't depend on NetworkScanner's ScanResult type so this
+// package stays importable from any caller, the same reasoning behind
+// bruteforce.Target.
+type Target struct {
+	Host    string
+	Port    int
+	Service string
+	Banner  string
+}
+
+// Finding is one script's result against one Target. Err is set instead of
+// Summary when the probe itself failed (connection refused, protocol
+// mismatch) rather than succeeding with nothing to report.
+type Finding struct {
+	Script  string
+	Target  Target
+	Summary string
+	Err     error
+}
+
+// Script is one NSE-style probe. PortRule decides whether Run is worth
+// calling for a given open port; Run does the actual protocol work and
+// returns what it found.
+type Script interface {
+	Name() string
+	Categories() []string
+	PortRule(t Target) bool
+	Run(ctx context.Context, t Target) (string, error)
+}
+
+// registry holds every Script registered via Register, normally from an
+// init() in the script's own file.
+var registry []Script
+
+// Register adds s to the set of scripts Run considers. Called from each
+// script file's init(), so registering a new probe never touches this
+// file or the scanner core.
+func Register(s Script) {
+	registry = append(registry, s)
+}
+
+// All returns every registered script, for callers that want to list or
+// filter them directly (e.g. a `--list-scripts` flag).
+func All() []Script {
+	return registry
+}
+
+// DefaultCategories is the set Run uses when the caller didn't select any,
+// matching nmap's implicit "default,safe" behavior: useful information,
+// nothing intrusive.
+var DefaultCategories = []string{"default", "safe"}
+
+func wantedSet(categories []string) map[string]bool {
+	wanted := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		wanted[c] = true
+	}
+	return wanted
+}
+
+func matchesCategories(s Script, wanted map[string]bool) bool {
+	for _, c := range s.Categories() {
+		if wanted[c] {
+			return true
+		}
+	}
+	return false
+}
+
+// Run dispatches every registered script whose Categories intersect
+// selected and whose PortRule matches a target, across all targets in
+// parallel, bounded by maxConcurrency - the same semaphore-backed worker
+// pool pattern ScanPortRange uses. A zero-length selected falls back to
+// DefaultCategories.
+func Run(ctx context.Context, targets []Target, selected []string, maxConcurrency int) []Finding {
+	if len(selected) == 0 {
+		selected = DefaultCategories
+	}
+	if maxConcurrency <= 0 {
+		maxConcurrency = 10
+	}
+	wanted := wantedSet(selected)
+
+	type job struct {
+		script Script
+		target Target
+	}
+	var jobs []job
+	for _, t := range targets {
+		for _, s := range registry {
+			if matchesCategories(s, wanted) && s.PortRule(t) {
+				jobs = append(jobs, job{script: s, target: t})
+			}
+		}
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	findings := make([]Finding, len(jobs))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrency)
+
+	for i, j := range jobs {
+		wg.Add(1)
+		go func(i int, j job) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			runCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			summary, err := j.script.Run(runCtx, j.target)
+			findings[i] = Finding{Script: j.script.Name(), Target: j.target, Summary: summary, Err: err}
+		}(i, j)
+	}
+	wg.Wait()
+
+	var out []Finding
+	for _, f := range findings {
+		if f.Err == nil && f.Summary != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
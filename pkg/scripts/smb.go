@@ -0,0 +1,167 @@
+package scripts
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// smbOSDiscoveryScript is nmap's smb-os-discovery: a null/anonymous SMB1
+// session setup whose response carries the server's Native OS and Native
+// LAN Manager strings in plaintext, the same NetBIOS-level fingerprint
+// the fscan findnet/NetBIOS plugins rely on. A minimal, standalone SMB1
+// encoder - this package doesn't depend on NetworkScanner's smb_wire.go.
+type smbOSDiscoveryScript struct{}
+
+func init() { Register(smbOSDiscoveryScript{}) }
+
+func (smbOSDiscoveryScript) Name() string         { return "smb-os-discovery" }
+func (smbOSDiscoveryScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (smbOSDiscoveryScript) PortRule(t Target) bool {
+	return t.Service == "SMB" || t.Port == 445
+}
+
+func (smbOSDiscoveryScript) Run(ctx context.Context, t Target) (string, error) {
+	d := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", t.Host, t.Port))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	if _, err := conn.Write(smbNegotiateRequest()); err != nil {
+		return "", err
+	}
+	if _, err := readSMBMessage(conn); err != nil {
+		return "", fmt.Errorf("smb-os-discovery: negotiate: %w", err)
+	}
+
+	if _, err := conn.Write(smbNullSessionSetupRequest()); err != nil {
+		return "", err
+	}
+	resp, err := readSMBMessage(conn)
+	if err != nil {
+		return "", fmt.Errorf("smb-os-discovery: session setup: %w", err)
+	}
+
+	nativeOS, nativeLanMan, err := parseSMBSessionSetupStrings(resp)
+	if err != nil {
+		return "", err
+	}
+	if nativeOS == "" && nativeLanMan == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("os: %s, lan manager: %s", nativeOS, nativeLanMan), nil
+}
+
+// wrapNetBIOS prepends the 4-byte NetBIOS Session Service header every
+// SMB1 message over TCP/445 is framed in: type 0, 24-bit big-endian
+// length.
+func wrapNetBIOS(msg []byte) []byte {
+	out := make([]byte, 4+len(msg))
+	out[1] = byte(len(msg) >> 16)
+	out[2] = byte(len(msg) >> 8)
+	out[3] = byte(len(msg))
+	copy(out[4:], msg)
+	return out
+}
+
+// readSMBMessage reads one NetBIOS-framed SMB1 message and returns the
+// message body with the framing stripped.
+func readSMBMessage(conn net.Conn) ([]byte, error) {
+	var nb [4]byte
+	if _, err := io.ReadFull(conn, nb[:]); err != nil {
+		return nil, err
+	}
+	length := int(nb[1])<<16 | int(nb[2])<<8 | int(nb[3])
+	msg := make([]byte, length)
+	if _, err := io.ReadFull(conn, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func smbHeader(command byte) []byte {
+	h := make([]byte, 32)
+	copy(h[0:4], []byte{0xFF, 'S', 'M', 'B'})
+	h[4] = command
+	binary.LittleEndian.PutUint16(h[10:12], 0x4000) // Flags2: NT status codes
+	return h
+}
+
+// smbNegotiateRequest advertises a single dialect, "NT LM 0.12" - the
+// last pre-SMB2 CIFS dialect, sufficient to get a real Native OS string
+// out of the session setup response that follows.
+func smbNegotiateRequest() []byte {
+	const commandNegotiate = 0x72
+	body := smbHeader(commandNegotiate)
+	body = append(body, 0x00) // WordCount
+	dialect := append([]byte{0x02}, []byte("NT LM 0.12\x00")...)
+	body = append(body, byte(len(dialect)), byte(len(dialect)>>8))
+	body = append(body, dialect...)
+	return wrapNetBIOS(body)
+}
+
+// smbNullSessionSetupRequest builds a classic (non-extended-security)
+// SMB_COM_SESSION_SETUP_ANDX with an empty account/password - an
+// anonymous session, all that's needed to see the server's own identity
+// strings in the response.
+func smbNullSessionSetupRequest() []byte {
+	const commandSessionSetup = 0x73
+	body := smbHeader(commandSessionSetup)
+
+	words := make([]byte, 26) // 13 words
+	words[0] = 0xFF           // AndXCommand: none
+	binary.LittleEndian.PutUint16(words[4:6], 4356)
+	binary.LittleEndian.PutUint16(words[6:8], 2)
+	binary.LittleEndian.PutUint16(words[8:10], 1)
+
+	body = append(body, 13)
+	body = append(body, words...)
+
+	bytesField := []byte{0x00, 0x00} // empty AccountName, PrimaryDomain
+	bytesField = append(bytesField, []byte("Unix\x00")...)
+	bytesField = append(bytesField, []byte("Samba\x00")...)
+	body = append(body, byte(len(bytesField)), byte(len(bytesField)>>8))
+	body = append(body, bytesField...)
+
+	return wrapNetBIOS(body)
+}
+
+// parseSMBSessionSetupStrings pulls NativeOS and NativeLanMan - the first
+// two NUL-terminated strings in a SESSION_SETUP_ANDX response's byte
+// data, following the fixed header, WordCount, and word block.
+func parseSMBSessionSetupStrings(resp []byte) (nativeOS, nativeLanMan string, err error) {
+	if len(resp) < 33 {
+		return "", "", fmt.Errorf("smb-os-discovery: truncated response")
+	}
+	wordCount := int(resp[32])
+	dataOffset := 33 + wordCount*2
+	if dataOffset+2 > len(resp) {
+		return "", "", fmt.Errorf("smb-os-discovery: truncated word block")
+	}
+	byteCount := int(binary.LittleEndian.Uint16(resp[dataOffset : dataOffset+2]))
+	data := resp[dataOffset+2:]
+	if byteCount > len(data) {
+		byteCount = len(data)
+	}
+	data = data[:byteCount]
+
+	readCString := func(b []byte) (string, []byte) {
+		for i, c := range b {
+			if c == 0 {
+				return string(b[:i]), b[i+1:]
+			}
+		}
+		return string(b), nil
+	}
+
+	nativeOS, data = readCString(data)
+	nativeLanMan, _ = readCString(data)
+	return nativeOS, nativeLanMan, nil
+}
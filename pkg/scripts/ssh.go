@@ -0,0 +1,118 @@
+package scripts
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// sshAlgosScript is nmap's ssh2-enum-algos: read the server's identification
+// banner, then parse its SSH_MSG_KEXINIT to list the key-exchange and host
+// key algorithms it offers, all before any authentication is attempted.
+type sshAlgosScript struct{}
+
+func init() { Register(sshAlgosScript{}) }
+
+func (sshAlgosScript) Name() string         { return "ssh2-enum-algos" }
+func (sshAlgosScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (sshAlgosScript) PortRule(t Target) bool {
+	return t.Service == "SSH" || t.Port == 22
+}
+
+func (sshAlgosScript) Run(ctx context.Context, t Target) (string, error) {
+	d := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", t.Host, t.Port))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	reader := bufio.NewReader(conn)
+	banner, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("ssh2-enum-algos: reading identification string: %w", err)
+	}
+	banner = strings.TrimSpace(banner)
+	if !strings.HasPrefix(banner, "SSH-") {
+		return "", fmt.Errorf("ssh2-enum-algos: not an SSH server")
+	}
+
+	if _, err := conn.Write([]byte("SSH-2.0-NSEScript\r\n")); err != nil {
+		return "", err
+	}
+
+	payload, err := readSSHPacket(reader)
+	if err != nil {
+		return "", fmt.Errorf("ssh2-enum-algos: reading KEXINIT: %w", err)
+	}
+	if len(payload) == 0 || payload[0] != 20 { // SSH_MSG_KEXINIT
+		return "", fmt.Errorf("ssh2-enum-algos: expected KEXINIT, got message %d", payload[0])
+	}
+
+	kexAlgos, hostKeyAlgos, err := parseSSHKexInit(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s, kex: %s, host key: %s", banner, kexAlgos, hostKeyAlgos), nil
+}
+
+// readSSHPacket reads one binary packet (before any MAC/encryption is
+// negotiated) and returns its payload, per RFC 4253 section 6:
+// uint32 packet_length, byte padding_length, payload, padding.
+func readSSHPacket(r io.Reader) ([]byte, error) {
+	var head [5]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(head[:4])
+	paddingLen := head[4]
+	if length < 1 || length > 256*1024 {
+		return nil, fmt.Errorf("implausible packet length %d", length)
+	}
+
+	rest := make([]byte, length-1)
+	if _, err := io.ReadFull(r, rest); err != nil {
+		return nil, err
+	}
+	if int(paddingLen) > len(rest) {
+		return nil, fmt.Errorf("padding longer than packet")
+	}
+	return rest[:len(rest)-int(paddingLen)], nil
+}
+
+// parseSSHKexInit pulls the first two name-lists (kex_algorithms and
+// server_host_key_algorithms) out of a KEXINIT payload: message type (1) +
+// cookie (16), then each name-list as a uint32 length + comma-separated
+// string.
+func parseSSHKexInit(payload []byte) (kexAlgos, hostKeyAlgos string, err error) {
+	i := 1 + 16
+	readNameList := func() (string, error) {
+		if i+4 > len(payload) {
+			return "", fmt.Errorf("truncated KEXINIT")
+		}
+		n := int(binary.BigEndian.Uint32(payload[i:]))
+		i += 4
+		if i+n > len(payload) {
+			return "", fmt.Errorf("truncated KEXINIT name-list")
+		}
+		s := string(payload[i : i+n])
+		i += n
+		return s, nil
+	}
+
+	if kexAlgos, err = readNameList(); err != nil {
+		return "", "", err
+	}
+	if hostKeyAlgos, err = readNameList(); err != nil {
+		return "", "", err
+	}
+	return kexAlgos, hostKeyAlgos, nil
+}
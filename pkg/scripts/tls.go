@@ -0,0 +1,52 @@
+package scripts
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+)
+
+// tlsCertScript is nmap's ssl-cert plus a slice of ssl-enum-ciphers: it
+// completes a real handshake (InsecureSkipVerify, since the goal is to
+// report what's presented, not to validate trust) and reports the leaf
+// certificate's subject/issuer/expiry and the cipher suite the server
+// chose.
+type tlsCertScript struct{}
+
+func init() { Register(tlsCertScript{}) }
+
+func (tlsCertScript) Name() string         { return "ssl-cert" }
+func (tlsCertScript) Categories() []string { return []string{"default", "safe", "discovery"} }
+
+func (tlsCertScript) PortRule(t Target) bool {
+	switch t.Service {
+	case "HTTPS", "IMAPS", "POP3S", "MSSQL":
+		return true
+	}
+	switch t.Port {
+	case 443, 465, 636, 993, 995, 8443:
+		return true
+	}
+	return false
+}
+
+func (tlsCertScript) Run(ctx context.Context, t Target) (string, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", fmt.Sprintf("%s:%d", t.Host, t.Port), &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return "", fmt.Errorf("ssl-cert: no certificate presented")
+	}
+	leaf := state.PeerCertificates[0]
+
+	return fmt.Sprintf("subject: %s, issuer: %s, expires: %s, cipher: %s",
+		leaf.Subject.CommonName, leaf.Issuer.CommonName,
+		leaf.NotAfter.Format("2006-01-02"), tls.CipherSuiteName(state.CipherSuite)), nil
+}
@@ -0,0 +1,32 @@
+package sdes
+
+// block implements crypto/cipher.Block for S-DES. Its block size is a
+// single byte, since S-DES operates on 8-bit blocks.
+type block struct {
+	key uint16
+}
+
+// NewCipher returns a crypto/cipher.Block-shaped S-DES cipher keyed by key
+// (only its low 10 bits are used).
+func NewCipher(key uint16) Block {
+	return &block{key: key}
+}
+
+// Block matches crypto/cipher.Block's shape without importing it, since
+// S-DES's 1-byte block size is smaller than crypto/cipher's callers
+// generally expect.
+type Block interface {
+	BlockSize() int
+	Encrypt(dst, src []byte)
+	Decrypt(dst, src []byte)
+}
+
+func (b *block) BlockSize() int { return 1 }
+
+func (b *block) Encrypt(dst, src []byte) {
+	dst[0] = EncryptBlock(src[0], b.key)
+}
+
+func (b *block) Decrypt(dst, src []byte) {
+	dst[0] = DecryptBlock(src[0], b.key)
+}
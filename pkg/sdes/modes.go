@@ -0,0 +1,83 @@
+package sdes
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// ErrInvalidPadding is returned by the Decrypt* functions when the
+// trailing PKCS#7 padding byte is missing or corrupt.
+var ErrInvalidPadding = errors.New("sdes: invalid padding")
+
+// padPKCS7 pads data to S-DES's 1-byte block size. Since every length is
+// already a multiple of 1, PKCS#7 always appends exactly one padding byte
+// of value 0x01.
+func padPKCS7(data []byte) []byte {
+	return append(append([]byte{}, data...), 0x01)
+}
+
+func unpadPKCS7(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[len(data)-1] != 0x01 {
+		return nil, ErrInvalidPadding
+	}
+	return data[:len(data)-1], nil
+}
+
+// EncryptECB pads and encrypts plaintext one block at a time, independently.
+func EncryptECB(key uint16, plaintext []byte) []byte {
+	padded := padPKCS7(plaintext)
+	ciphertext := make([]byte, len(padded))
+	for i, b := range padded {
+		ciphertext[i] = EncryptBlock(b, key)
+	}
+	return ciphertext
+}
+
+// DecryptECB decrypts ciphertext one block at a time and strips padding.
+func DecryptECB(key uint16, ciphertext []byte) ([]byte, error) {
+	plaintext := make([]byte, len(ciphertext))
+	for i, b := range ciphertext {
+		plaintext[i] = DecryptBlock(b, key)
+	}
+	return unpadPKCS7(plaintext)
+}
+
+// EncryptCBC pads plaintext, generates a random IV, and chains blocks with
+// XOR-then-encrypt. The returned slice is the IV followed by the
+// ciphertext blocks.
+func EncryptCBC(key uint16, plaintext []byte) ([]byte, error) {
+	padded := padPKCS7(plaintext)
+
+	iv := make([]byte, 1)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 1+len(padded))
+	out[0] = iv[0]
+	prev := iv[0]
+	for i, p := range padded {
+		c := EncryptBlock(p^prev, key)
+		out[1+i] = c
+		prev = c
+	}
+	return out, nil
+}
+
+// DecryptCBC reverses EncryptCBC: ciphertext must be the IV followed by
+// one or more ciphertext blocks.
+func DecryptCBC(key uint16, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 2 {
+		return nil, errors.New("sdes: ciphertext too short for CBC")
+	}
+	iv, body := ciphertext[0], ciphertext[1:]
+
+	plaintext := make([]byte, len(body))
+	prev := iv
+	for i, c := range body {
+		plaintext[i] = DecryptBlock(c, key) ^ prev
+		prev = c
+	}
+	return unpadPKCS7(plaintext)
+}
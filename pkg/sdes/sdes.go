@@ -0,0 +1,159 @@
+// Package sdes implements Simplified DES (S-DES): an 8-bit-block, 10-bit-key
+// teaching cipher with the same two-round Feistel structure as full DES.
+// EncryptBlock/DecryptBlock operate on packed bytes and keys; the bit-level
+// permutation tables and round function mirror the original textbook
+// algorithm.
+package sdes
+
+import "strconv"
+
+var (
+	p10 = []uint8{3, 5, 2, 7, 4, 10, 1, 9, 8, 6}
+	ls1 = []uint8{2, 3, 4, 5, 1}
+	ls2 = []uint8{3, 4, 5, 1, 2}
+	p8  = []uint8{6, 3, 7, 4, 8, 5, 10, 9}
+
+	ip   = []uint8{2, 6, 3, 1, 4, 8, 5, 7}
+	ipI  = []uint8{4, 1, 3, 5, 7, 2, 8, 6}
+	ep   = []uint8{4, 1, 2, 3, 2, 3, 4, 1}
+	p4   = []uint8{2, 4, 3, 1}
+	s0   = [][]uint8{{1, 0, 3, 2}, {3, 2, 1, 0}, {0, 2, 1, 3}, {3, 1, 3, 2}}
+	s1   = [][]uint8{{0, 1, 2, 3}, {2, 0, 1, 3}, {3, 0, 1, 0}, {2, 1, 0, 3}}
+)
+
+// EncryptBlock encrypts a single 8-bit block under key (only the low 10
+// bits of key are used).
+func EncryptBlock(input byte, key uint16) byte {
+	k1, k2 := generateKeys(bitsFromKey(key))
+	return byteFromBits(feistelRounds(bitsFromByte(input), k1, k2))
+}
+
+// DecryptBlock decrypts a single 8-bit block under key, applying the same
+// two rounds with the subkeys in reverse order.
+func DecryptBlock(input byte, key uint16) byte {
+	k1, k2 := generateKeys(bitsFromKey(key))
+	return byteFromBits(feistelRounds(bitsFromByte(input), k2, k1))
+}
+
+// feistelRounds runs IP, fk(roundKey1), SW, fk(roundKey2), IP^-1.
+func feistelRounds(input, roundKey1, roundKey2 []uint8) []uint8 {
+	output := permutation(input, ip)
+	left, right := output[:4], output[4:8]
+
+	fOutput := functionF(left, right, roundKey1)
+	left, right = fOutput[:4], fOutput[4:8]
+	left, right = right, left // SW
+
+	output = functionF(left, right, roundKey2)
+	return permutation(output, ipI)
+}
+
+func generateKeys(key []uint8) (k1, k2 []uint8) {
+	key = permutation(key, p10)
+	halfKey1, halfKey2 := key[:5], key[5:10]
+	halfKey1 = permutation(halfKey1, ls1)
+	halfKey2 = permutation(halfKey2, ls1)
+	key = append(append([]uint8{}, halfKey1...), halfKey2...)
+	k1 = permutation(key, p8)
+
+	halfKey1 = permutation(halfKey1, ls2)
+	halfKey2 = permutation(halfKey2, ls2)
+	key = append(append([]uint8{}, halfKey1...), halfKey2...)
+	k2 = permutation(key, p8)
+
+	return k1, k2
+}
+
+func functionF(leftInput, rightInput, key []uint8) (fOutput []uint8) {
+	tempValue := permutation(rightInput, ep)
+	tempValue = xorBits(tempValue, key)
+	sBoxSide0, sBoxSide1 := tempValue[0:4], tempValue[4:8]
+	sBoxSide0 = sBox(sBoxSide0, s0)
+	sBoxSide1 = sBox(sBoxSide1, s1)
+	partialOutput := append(append([]uint8{}, sBoxSide0...), sBoxSide1...)
+	partialOutput = permutation(partialOutput, p4)
+	partialOutput = xorBits(leftInput, partialOutput)
+
+	fOutput = append(partialOutput, rightInput...)
+	return fOutput
+}
+
+func sBox(bitList []uint8, sMatrix [][]uint8) []uint8 {
+	row := binToInt([]uint8{bitList[0], bitList[3]})
+	column := binToInt([]uint8{bitList[1], bitList[2]})
+	value := sMatrix[row][column]
+	return []uint8{value / 2, value % 2}
+}
+
+// permutation rebuilds a bit slice by position, reading positions as
+// 1-indexed into list (so a P8 table can select 8 of 10 input bits).
+func permutation(list []uint8, positions []uint8) []uint8 {
+	permuted := make([]uint8, len(positions))
+	for i, pos := range positions {
+		permuted[i] = list[pos-1]
+	}
+	return permuted
+}
+
+func xorBits(a, b []uint8) []uint8 {
+	out := make([]uint8, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
+
+func binToInt(bits []uint8) uint8 {
+	var value uint8
+	for i, bit := range bits {
+		value += bit << uint(len(bits)-i-1)
+	}
+	return value
+}
+
+// bitsFromByte unpacks b into 8 MSB-first bits.
+func bitsFromByte(b byte) []uint8 {
+	bits := make([]uint8, 8)
+	for i := 0; i < 8; i++ {
+		bits[i] = (b >> uint(7-i)) & 1
+	}
+	return bits
+}
+
+// byteFromBits packs 8 MSB-first bits into a byte.
+func byteFromBits(bits []uint8) byte {
+	var b byte
+	for _, bit := range bits {
+		b = b<<1 | byte(bit)
+	}
+	return b
+}
+
+// bitsFromKey unpacks the low 10 bits of key into 10 MSB-first bits.
+func bitsFromKey(key uint16) []uint8 {
+	bits := make([]uint8, 10)
+	for i := 0; i < 10; i++ {
+		bits[i] = uint8((key >> uint(9-i)) & 1)
+	}
+	return bits
+}
+
+// ParseKey parses a 10-character string of '0'/'1' characters (e.g.
+// "1010000010") into the packed key representation EncryptBlock expects.
+func ParseKey(s string) (uint16, error) {
+	if len(s) != 10 {
+		return 0, strconv.ErrSyntax
+	}
+	var key uint16
+	for i := 0; i < 10; i++ {
+		switch s[i] {
+		case '0':
+			key <<= 1
+		case '1':
+			key = key<<1 | 1
+		default:
+			return 0, strconv.ErrSyntax
+		}
+	}
+	return key, nil
+}
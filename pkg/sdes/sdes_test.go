@@ -0,0 +1,85 @@
+package sdes
+
+import "testing"
+
+// TestWorkedExample traces the classic S-DES worked example (10-bit key
+// 1010000010, plaintext 10100101) through IP, the two fk rounds, SW, and
+// IP^-1 by hand, arriving at ciphertext 11001010.
+func TestWorkedExample(t *testing.T) {
+	key, err := ParseKey("1010000010")
+	if err != nil {
+		t.Fatalf("ParseKey: %v", err)
+	}
+	const plaintext = 0b10100101
+	const wantCiphertext = 0b11001010
+
+	got := EncryptBlock(plaintext, key)
+	if got != wantCiphertext {
+		t.Fatalf("EncryptBlock(%08b, key) = %08b, want %08b", plaintext, got, wantCiphertext)
+	}
+
+	roundTripped := DecryptBlock(got, key)
+	if roundTripped != plaintext {
+		t.Fatalf("DecryptBlock(EncryptBlock(p)) = %08b, want %08b", roundTripped, plaintext)
+	}
+}
+
+func TestEncryptDecryptBlockRoundTrip(t *testing.T) {
+	key, _ := ParseKey("0111111101")
+	for p := 0; p < 256; p++ {
+		c := EncryptBlock(byte(p), key)
+		if got := DecryptBlock(c, key); got != byte(p) {
+			t.Fatalf("round trip failed for plaintext %d: got %d", p, got)
+		}
+	}
+}
+
+func TestParseKeyRejectsBadInput(t *testing.T) {
+	for _, s := range []string{"101", "10100000102", "1010000Ö10"} {
+		if _, err := ParseKey(s); err == nil {
+			t.Fatalf("ParseKey(%q): expected error", s)
+		}
+	}
+}
+
+func TestECBRoundTrip(t *testing.T) {
+	key, _ := ParseKey("1010000010")
+	plaintext := []byte("hello, s-des!")
+
+	ciphertext := EncryptECB(key, plaintext)
+	got, err := DecryptECB(key, ciphertext)
+	if err != nil {
+		t.Fatalf("DecryptECB: %v", err)
+	}
+	if string(got) != string(plaintext) {
+		t.Fatalf("ECB round trip: got %q, want %q", got, plaintext)
+	}
+}
+
+func TestCBCRoundTrip(t *testing.T) {
+	key, _ := ParseKey("1010000010")
+	plaintext := []byte("hello, s-des!")
+
+	ciphertext, err := EncryptCBC(key, plaintext)
+	if err != nil {
+		t.Fatalf("EncryptCBC: %v", err)
+	}
+	got, err := DecryptCBC(key, ciphertext)
+	if err != nil {
+		t.Fatalf("DecryptCBC: %v", err)
+	}
+	if string(got) != string(plaintext) {
+		t.Fatalf("CBC round trip: got %q, want %q", got, plaintext)
+	}
+}
+
+func TestDecryptECBRejectsBadPadding(t *testing.T) {
+	key, _ := ParseKey("1010000010")
+	ciphertext := EncryptECB(key, []byte("x"))
+	// Force the last block to decrypt to 0x02 instead of the 0x01 padding
+	// byte EncryptECB would have produced.
+	ciphertext[len(ciphertext)-1] = EncryptBlock(0x02, key)
+	if _, err := DecryptECB(key, ciphertext); err != ErrInvalidPadding {
+		t.Fatalf("got err %v, want ErrInvalidPadding", err)
+	}
+}
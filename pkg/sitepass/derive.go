@@ -0,0 +1,132 @@
+package sitepass
+
+import (
+	"crypto/sha512"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// KDFParams configures the scrypt stretch applied to (masterSecret, site).
+type KDFParams struct {
+	N, R, P int
+}
+
+// DefaultKDFParams matches pkg/passwd's scrypt baseline.
+var DefaultKDFParams = KDFParams{N: 1 << 15, R: 8, P: 1}
+
+const lowerCharset = "abcdefghijklmnopqrstuvwxyz"
+const upperCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+const digitCharset = "0123456789"
+
+// category tracks one character class's quota and running count during
+// re-encoding.
+type category struct {
+	charset  string
+	min, max int
+	count    int
+}
+
+// Derive deterministically derives a password for site from masterSecret,
+// satisfying profile's character-class quotas. The same inputs always
+// produce the same password.
+func Derive(masterSecret []byte, site string, profile PasswordProfile, kdf KDFParams) (string, error) {
+	if err := profile.validate(); err != nil {
+		return "", err
+	}
+
+	seed, err := scrypt.Key(masterSecret, []byte(site), kdf.N, kdf.R, kdf.P, 64)
+	if err != nil {
+		return "", err
+	}
+	stream := newEntropyStream(seed)
+
+	categories := []*category{
+		{charset: lowerCharset, min: profile.MinLower, max: profile.MaxLower},
+		{charset: upperCharset, min: profile.MinUpper, max: profile.MaxUpper},
+		{charset: digitCharset, min: profile.MinDigits, max: profile.MaxDigits},
+		{charset: profile.AllowedSpecial, min: profile.MinSpecial, max: profile.MaxSpecial},
+	}
+
+	length := profile.MinLen
+	if span := profile.MaxLen - profile.MinLen; span > 0 {
+		length += int(stream.next()) % (span + 1)
+	}
+
+	var out strings.Builder
+	for i := 0; i < length; i++ {
+		remaining := length - i
+		alphabet, picked := pickAlphabet(categories, remaining)
+
+		b := stream.next()
+		idx := int(b) % len(alphabet)
+		ch := alphabet[idx]
+		out.WriteByte(ch)
+		picked(ch)
+	}
+	return out.String(), nil
+}
+
+// pickAlphabet builds the alphabet available for the next character: if
+// every remaining slot is already spoken for by some category's unmet
+// minimum, only mandatory categories are offered; otherwise any
+// not-yet-maxed category is. It also returns a closure that records which
+// category a chosen character belonged to.
+func pickAlphabet(categories []*category, remaining int) (string, func(byte)) {
+	remainingMinSum := 0
+	for _, c := range categories {
+		if need := c.min - c.count; need > 0 {
+			remainingMinSum += need
+		}
+	}
+
+	var alphabet strings.Builder
+	var eligible []*category
+	mandatoryOnly := remainingMinSum == remaining
+	for _, c := range categories {
+		if c.charset == "" {
+			continue
+		}
+		needsMin := c.min-c.count > 0
+		if mandatoryOnly && !needsMin {
+			continue
+		}
+		if !mandatoryOnly && c.count >= c.max {
+			continue
+		}
+		alphabet.WriteString(c.charset)
+		eligible = append(eligible, c)
+	}
+
+	return alphabet.String(), func(ch byte) {
+		for _, c := range eligible {
+			if strings.IndexByte(c.charset, ch) >= 0 {
+				c.count++
+				return
+			}
+		}
+	}
+}
+
+// entropyStream serves bytes drawn from seed, extending itself by hashing
+// the previous block with SHA-512 whenever it runs out, so a derivation
+// is never capped at a fixed number of output bits.
+type entropyStream struct {
+	block []byte
+	pos   int
+}
+
+func newEntropyStream(seed []byte) *entropyStream {
+	return &entropyStream{block: seed}
+}
+
+func (s *entropyStream) next() byte {
+	if s.pos >= len(s.block) {
+		next := sha512.Sum512(s.block)
+		s.block = next[:]
+		s.pos = 0
+	}
+	b := s.block[s.pos]
+	s.pos++
+	return b
+}
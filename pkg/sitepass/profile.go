@@ -0,0 +1,58 @@
+// Package sitepass derives per-site passwords deterministically from a
+// master secret: a KDF stretches (masterSecret, site) into a byte stream,
+// and that stream is re-encoded into a password matching a site-specific
+// character-class quota, so the same inputs always reproduce the same
+// password without storing it anywhere.
+package sitepass
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PasswordProfile describes the character-class quotas a derived password
+// must satisfy for one site.
+type PasswordProfile struct {
+	MinLen         int    `yaml:"min_len"`
+	MaxLen         int    `yaml:"max_len"`
+	MinLower       int    `yaml:"min_lower"`
+	MaxLower       int    `yaml:"max_lower"`
+	MinUpper       int    `yaml:"min_upper"`
+	MaxUpper       int    `yaml:"max_upper"`
+	MinDigits      int    `yaml:"min_digits"`
+	MaxDigits      int    `yaml:"max_digits"`
+	MinSpecial     int    `yaml:"min_special"`
+	MaxSpecial     int    `yaml:"max_special"`
+	AllowedSpecial string `yaml:"allowed_special"`
+}
+
+// validate checks that the quotas are internally consistent.
+func (p PasswordProfile) validate() error {
+	if p.MinLen <= 0 || p.MaxLen < p.MinLen {
+		return fmt.Errorf("sitepass: invalid length range [%d, %d]", p.MinLen, p.MaxLen)
+	}
+	minSum := p.MinLower + p.MinUpper + p.MinDigits + p.MinSpecial
+	if minSum > p.MaxLen {
+		return fmt.Errorf("sitepass: minimum character quotas (%d) exceed max_len (%d)", minSum, p.MaxLen)
+	}
+	if p.MinSpecial > 0 && p.AllowedSpecial == "" {
+		return fmt.Errorf("sitepass: min_special > 0 but allowed_special is empty")
+	}
+	return nil
+}
+
+// LoadProfiles parses a YAML document mapping site name to PasswordProfile,
+// as shipped in profiles.yaml.
+func LoadProfiles(data []byte) (map[string]PasswordProfile, error) {
+	var profiles map[string]PasswordProfile
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("sitepass: parsing profiles: %w", err)
+	}
+	for site, p := range profiles {
+		if err := p.validate(); err != nil {
+			return nil, fmt.Errorf("sitepass: profile %q: %w", site, err)
+		}
+	}
+	return profiles, nil
+}
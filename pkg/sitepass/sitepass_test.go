@@ -0,0 +1,85 @@
+package sitepass
+
+import "testing"
+
+var testKDF = KDFParams{N: 1 << 10, R: 8, P: 1}
+
+var testProfile = PasswordProfile{
+	MinLen: 12, MaxLen: 16,
+	MinLower: 2, MaxLower: 16,
+	MinUpper: 2, MaxUpper: 16,
+	MinDigits: 2, MaxDigits: 16,
+	MinSpecial: 1, MaxSpecial: 4,
+	AllowedSpecial: "!@#$%^&*",
+}
+
+func TestDeriveIsDeterministic(t *testing.T) {
+	a, err := Derive([]byte("correct horse battery staple"), "etrade", testProfile, testKDF)
+	if err != nil {
+		t.Fatalf("Derive: %v", err)
+	}
+	b, err := Derive([]byte("correct horse battery staple"), "etrade", testProfile, testKDF)
+	if err != nil {
+		t.Fatalf("Derive: %v", err)
+	}
+	if a != b {
+		t.Fatalf("Derive is not deterministic: %q != %q", a, b)
+	}
+}
+
+func TestDeriveDiffersBySite(t *testing.T) {
+	a, _ := Derive([]byte("same secret"), "etrade", testProfile, testKDF)
+	b, _ := Derive([]byte("same secret"), "fidelity", testProfile, testKDF)
+	if a == b {
+		t.Fatal("expected different sites to derive different passwords")
+	}
+}
+
+func TestDeriveSatisfiesQuotas(t *testing.T) {
+	password, err := Derive([]byte("master secret"), "etrade", testProfile, testKDF)
+	if err != nil {
+		t.Fatalf("Derive: %v", err)
+	}
+	if len(password) < testProfile.MinLen || len(password) > testProfile.MaxLen {
+		t.Fatalf("password length %d out of range [%d, %d]", len(password), testProfile.MinLen, testProfile.MaxLen)
+	}
+
+	var lower, upper, digits, special int
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower++
+		case r >= 'A' && r <= 'Z':
+			upper++
+		case r >= '0' && r <= '9':
+			digits++
+		default:
+			special++
+		}
+	}
+	if lower < testProfile.MinLower || upper < testProfile.MinUpper ||
+		digits < testProfile.MinDigits || special < testProfile.MinSpecial {
+		t.Fatalf("password %q does not satisfy minimum quotas (lower=%d upper=%d digits=%d special=%d)",
+			password, lower, upper, digits, special)
+	}
+}
+
+func TestLoadProfilesRejectsInconsistentQuotas(t *testing.T) {
+	data := []byte(`
+bad:
+  min_len: 4
+  max_len: 4
+  min_lower: 3
+  max_lower: 4
+  min_upper: 3
+  max_upper: 4
+  min_digits: 0
+  max_digits: 0
+  min_special: 0
+  max_special: 0
+  allowed_special: ""
+`)
+	if _, err := LoadProfiles(data); err == nil {
+		t.Fatal("expected an error for quotas summing above max_len")
+	}
+}
@@ -0,0 +1,440 @@
+// Package strength estimates password strength the way zxcvbn does:
+// decompose the password into overlapping pattern matches, estimate a
+// guess count for each, then run a dynamic-programming search that picks
+// the segmentation minimizing the total number of guesses an attacker
+// would need, instead of an additive point score.
+package strength
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// matchKind identifies which rule produced a match.
+type matchKind string
+
+const (
+	kindDictionary matchKind = "dictionary"
+	kindRepeat     matchKind = "repeat"
+	kindSequence   matchKind = "sequence"
+	kindDate       matchKind = "date"
+	kindKeyboard   matchKind = "keyboard"
+	kindBruteforce matchKind = "bruteforce"
+)
+
+type match struct {
+	kind       matchKind
+	start, end int
+	guesses    float64
+}
+
+// commonWords is a small embedded word list, ranked by frequency (rank 1 =
+// weakest). A real deployment would embed a much larger list; this is
+// enough to demonstrate dictionary-based guess estimation.
+var commonWords = []string{
+	"password", "123456", "qwerty", "letmein", "admin", "welcome",
+	"monkey", "dragon", "master", "login", "princess", "football",
+	"baseball", "superman", "trustno1",
+}
+
+var leetTable = map[rune]rune{'@': 'a', '4': 'a', '3': 'e', '1': 'i', '0': 'o', '$': 's', '5': 's', '7': 't'}
+
+func unleet(s string) string {
+	out := []rune(s)
+	for i, r := range out {
+		if plain, ok := leetTable[r]; ok {
+			out[i] = plain
+		}
+	}
+	return string(out)
+}
+
+var sequenceRows = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm", "abcdefghijklmnopqrstuvwxyz", "0123456789"}
+
+// qwertyAdjacency maps each key on a standard QWERTY keyboard to its
+// horizontal neighbors, enough to recognize spatial walks like "qwerty" or
+// "asdfgh" that a dictionary or sequence match wouldn't catch (the keys
+// aren't alphabetically or numerically sequential, just physically close).
+var qwertyAdjacency = buildQwertyAdjacency()
+
+func buildQwertyAdjacency() map[byte]string {
+	rows := []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+	adjacency := make(map[byte]string, 40)
+	for _, row := range rows {
+		for i := 0; i < len(row); i++ {
+			var neighbors []byte
+			if i > 0 {
+				neighbors = append(neighbors, row[i-1])
+			}
+			if i < len(row)-1 {
+				neighbors = append(neighbors, row[i+1])
+			}
+			adjacency[row[i]] = string(neighbors)
+		}
+	}
+	return adjacency
+}
+
+// qwertyAvgDegree is the mean neighbor count across qwertyAdjacency.
+var qwertyAvgDegree = func() float64 {
+	total := 0
+	for _, neighbors := range qwertyAdjacency {
+		total += len(neighbors)
+	}
+	return float64(total) / float64(len(qwertyAdjacency))
+}()
+
+var dateRe = regexp.MustCompile(`\b(19|20)\d{2}\b|\b\d{1,2}[-/.]\d{1,2}[-/.]\d{2,4}\b`)
+
+// Result is the outcome of Estimate.
+type Result struct {
+	Guesses   float64
+	Score     int // 0 (trivial) to 4 (very strong), zxcvbn-style buckets
+	CrackTime string
+	Matches   []string // human-readable description of each match used
+}
+
+// Estimate analyzes password and returns its zxcvbn-style guess estimate.
+func Estimate(password string) Result {
+	if password == "" {
+		return Result{Score: 0, CrackTime: "instantly"}
+	}
+
+	matches := findMatches(password)
+	guesses, used := minGuessCover(password, matches)
+
+	result := Result{Guesses: guesses, Score: scoreFromGuesses(guesses), CrackTime: crackTimeDisplay(guesses)}
+	for _, m := range used {
+		result.Matches = append(result.Matches, fmt.Sprintf("%s match %q", matchKindLabel(m.kind), password[m.start:m.end]))
+	}
+	return result
+}
+
+// matchKindLabel renders a matchKind the way a human would describe it, for
+// Result.Matches.
+func matchKindLabel(kind matchKind) string {
+	switch kind {
+	case kindDictionary:
+		return "dictionary"
+	case kindRepeat:
+		return "repeated-character"
+	case kindSequence:
+		return "sequence"
+	case kindDate:
+		return "date"
+	case kindKeyboard:
+		return "keyboard-walk"
+	default:
+		return "bruteforce"
+	}
+}
+
+func findMatches(password string) []match {
+	var matches []match
+	matches = append(matches, dictionaryMatches(password)...)
+	matches = append(matches, dictionaryMatchesLeet(password)...)
+	matches = append(matches, sequenceMatches(password)...)
+	matches = append(matches, repeatMatches(password)...)
+	matches = append(matches, dateMatches(password)...)
+	matches = append(matches, keyboardMatches(password)...)
+	return matches
+}
+
+func dictionaryMatches(password string) []match {
+	lower := strings.ToLower(password)
+	var out []match
+	for rank, word := range commonWords {
+		idx := 0
+		for {
+			pos := strings.Index(lower[idx:], word)
+			if pos == -1 {
+				break
+			}
+			s := idx + pos
+			e := s + len(word)
+			guesses := float64(rank+1) * capitalizationMultiplier(password[s:e])
+			out = append(out, match{kind: kindDictionary, start: s, end: e, guesses: guesses})
+			idx = s + 1
+		}
+	}
+	return out
+}
+
+// dictionaryMatchesLeet re-runs dictionaryMatches against the un-substituted
+// password (e.g. "p4ssw0rd" -> "password") and charges one extra bit (a
+// factor of 2) per substituted character in the matched span, after
+// zxcvbn's l33t-speak handling, since an attacker who knows the dictionary
+// still has to guess which of the common substitutions in leetTable
+// (@/4->a, 3->e, 1->i, 0->o, $/5->s, 7->t) the user applied.
+func dictionaryMatchesLeet(password string) []match {
+	unleeted := unleet(password)
+	if unleeted == password {
+		return nil
+	}
+	var out []match
+	for _, m := range dictionaryMatches(unleeted) {
+		substitutions := 0
+		for i := m.start; i < m.end; i++ {
+			if password[i] != unleeted[i] {
+				substitutions++
+			}
+		}
+		out = append(out, match{kind: kindDictionary, start: m.start, end: m.end, guesses: m.guesses * math.Pow(2, float64(substitutions))})
+	}
+	return out
+}
+
+// capitalizationMultiplier scores a matched token's capitalization the way
+// zxcvbn does: an all-lowercase or all-uppercase token costs nothing extra,
+// only the first or only the last letter capitalized costs just 1 extra bit
+// since an attacker tries those first, while any other mix of upper/lower
+// costs log2(sum of C(n,i) for i in 1..countUpper) - the number of ways to
+// choose which positions are uppercase.
+func capitalizationMultiplier(token string) float64 {
+	if token == strings.ToLower(token) || token == strings.ToUpper(token) {
+		return 1
+	}
+	runes := []rune(token)
+	upperFirst := runes[0] >= 'A' && runes[0] <= 'Z'
+	upperLast := runes[len(runes)-1] >= 'A' && runes[len(runes)-1] <= 'Z'
+	rest := string(runes[1 : len(runes)-1])
+	if (upperFirst || upperLast) && rest == strings.ToLower(rest) {
+		return 2
+	}
+
+	n := len(runes)
+	upper := 0
+	for _, r := range runes {
+		if r >= 'A' && r <= 'Z' {
+			upper++
+		}
+	}
+	var ways float64
+	for i := 1; i <= upper; i++ {
+		ways += binomial(n, i)
+	}
+	if ways < 1 {
+		ways = 1
+	}
+	return ways
+}
+
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 0; i < k; i++ {
+		result *= float64(n-i) / float64(i+1)
+	}
+	return result
+}
+
+func sequenceMatches(password string) []match {
+	lower := strings.ToLower(password)
+	var out []match
+	for i := 0; i < len(lower); i++ {
+		for j := i + 3; j <= len(lower); j++ {
+			token := lower[i:j]
+			if isSequentialRun(token) {
+				out = append(out, match{kind: kindSequence, start: i, end: j, guesses: float64(len(token)) * 2})
+			}
+		}
+	}
+	return out
+}
+
+func isSequentialRun(token string) bool {
+	for _, row := range sequenceRows {
+		if strings.Contains(row, token) {
+			return true
+		}
+		reversed := reverse(row)
+		if strings.Contains(reversed, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// keyboardMatches finds runs of at least 4 characters where each key is a
+// horizontal neighbor of the previous one on a QWERTY keyboard (e.g.
+// "qwerty", "asdfgh"), estimating guesses as
+// startingKeys * L * avgDegree^turns, where turns counts how many times
+// the walk changes direction (left-to-right vs. right-to-left) - a
+// straight run across the row is far more guessable than one that
+// zig-zags back and forth.
+func keyboardMatches(password string) []match {
+	lower := strings.ToLower(password)
+	n := len(lower)
+	var out []match
+	for i := 0; i < n; i++ {
+		j := i + 1
+		turns := 0
+		direction := 0 // 0 = undetermined, 1 = forward, -1 = backward
+		for j < n && isAdjacentKey(lower[j-1], lower[j]) {
+			step := stepDirection(lower[j-1], lower[j])
+			if direction != 0 && step != direction {
+				turns++
+			}
+			direction = step
+			j++
+		}
+		length := j - i
+		if length >= 4 {
+			guesses := float64(len(qwertyAdjacency)) * float64(length) * math.Pow(qwertyAvgDegree, float64(turns))
+			out = append(out, match{kind: kindKeyboard, start: i, end: j, guesses: guesses})
+		}
+	}
+	return out
+}
+
+func isAdjacentKey(a, b byte) bool {
+	return strings.IndexByte(qwertyAdjacency[a], b) != -1
+}
+
+// stepDirection reports which side of a in its row b sits on, used to spot
+// a change of direction partway through a keyboard walk.
+func stepDirection(a, b byte) int {
+	neighbors := qwertyAdjacency[a]
+	if len(neighbors) > 0 && neighbors[0] == b {
+		return -1
+	}
+	return 1
+}
+
+// repeatMatches finds runs made of a repeating substring (e.g. "abab",
+// "aaa"), preferring the shortest repeating unit at each position and
+// extending it as far as it will go - the same behavior Go's RE2 engine
+// can't give us directly, since `(.+?)\1+` requires a backreference that
+// RE2 doesn't support.
+func repeatMatches(password string) []match {
+	var out []match
+	n := len(password)
+	for i := 0; i < n; {
+		matched := false
+		for u := 1; u <= (n-i)/2; u++ {
+			unit := password[i : i+u]
+			end := i + u
+			for end+u <= n && password[end:end+u] == unit {
+				end += u
+			}
+			if end >= i+2*u {
+				out = append(out, match{kind: kindRepeat, start: i, end: end, guesses: float64(end-i) * 2})
+				i = end
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			i++
+		}
+	}
+	return out
+}
+
+func dateMatches(password string) []match {
+	var out []match
+	for _, loc := range dateRe.FindAllStringIndex(password, -1) {
+		out = append(out, match{kind: kindDate, start: loc[0], end: loc[1], guesses: 365})
+	}
+	return out
+}
+
+// minGuessCover runs a dynamic program over password picking the
+// segmentation of matches that minimizes total estimated guesses, falling
+// back to brute-force bits (charset^1) for any uncovered character.
+func minGuessCover(password string, matches []match) (float64, []match) {
+	n := len(password)
+	byEnd := make(map[int][]match, n)
+	for _, m := range matches {
+		byEnd[m.end] = append(byEnd[m.end], m)
+	}
+
+	minGuesses := make([]float64, n+1)
+	back := make([]*match, n+1)
+	minGuesses[0] = 1 // multiplicative identity: the empty prefix costs nothing extra
+	for k := 1; k <= n; k++ {
+		best := minGuesses[k-1] * bruteforceCharset(password[k-1])
+		var bestMatch *match
+		for i := range byEnd[k] {
+			m := byEnd[k][i]
+			candidate := minGuesses[m.start] * m.guesses
+			if candidate < best {
+				best = candidate
+				bestMatch = &byEnd[k][i]
+			}
+		}
+		minGuesses[k] = best
+		back[k] = bestMatch
+	}
+
+	var used []match
+	k := n
+	for k > 0 {
+		if back[k] == nil {
+			k--
+			continue
+		}
+		used = append(used, *back[k])
+		k = back[k].start
+	}
+	return minGuesses[n], used
+}
+
+func bruteforceCharset(c byte) float64 {
+	switch {
+	case c >= '0' && c <= '9':
+		return 10
+	case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		return 52
+	default:
+		return 33
+	}
+}
+
+func scoreFromGuesses(guesses float64) int {
+	switch {
+	case guesses < 1e3:
+		return 0
+	case guesses < 1e6:
+		return 1
+	case guesses < 1e8:
+		return 2
+	case guesses < 1e10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func crackTimeDisplay(guesses float64) string {
+	const guessesPerSecond = 1e10 // offline fast-hash attacker
+	seconds := guesses / guessesPerSecond
+	switch {
+	case seconds < 1:
+		return "instantly"
+	case seconds < 60:
+		return fmt.Sprintf("%.0f seconds", seconds)
+	case seconds < 3600:
+		return fmt.Sprintf("%.0f minutes", seconds/60)
+	case seconds < 86400:
+		return fmt.Sprintf("%.0f hours", seconds/3600)
+	case seconds < 31536000:
+		return fmt.Sprintf("%.0f days", seconds/86400)
+	case seconds < 31536000000:
+		return fmt.Sprintf("%.0f years", seconds/31536000)
+	default:
+		return "centuries"
+	}
+}
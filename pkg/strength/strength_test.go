@@ -0,0 +1,70 @@
+package strength
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateEmptyPassword(t *testing.T) {
+	r := Estimate("")
+	if r.Score != 0 {
+		t.Fatalf("empty password: got score %d, want 0", r.Score)
+	}
+}
+
+func TestEstimateCommonPasswordIsWeak(t *testing.T) {
+	r := Estimate("password123")
+	if r.Score > 1 {
+		t.Fatalf("common password: got score %d, want <= 1", r.Score)
+	}
+	if len(r.Matches) == 0 {
+		t.Fatal("expected at least one match against a common password")
+	}
+}
+
+func TestEstimateLongRandomPasswordIsStrong(t *testing.T) {
+	r := Estimate("xQ7!vL2@rT9#mK4$")
+	if r.Score < 3 {
+		t.Fatalf("long random password: got score %d, want >= 3", r.Score)
+	}
+}
+
+func TestEstimateSequenceMatch(t *testing.T) {
+	r := Estimate("qwertyuiop")
+	if r.Score > 1 {
+		t.Fatalf("keyboard sequence: got score %d, want <= 1", r.Score)
+	}
+}
+
+// TestEstimateRepeatMatch exercises the repeated-substring detector
+// (repeatMatches), which replaced a backreference regex RE2 can't compile -
+// calling Estimate here means a regression back to an uncompilable pattern
+// fails this test instead of panicking every binary that imports the package.
+func TestEstimateRepeatMatch(t *testing.T) {
+	r := Estimate("abcabcabc")
+	found := false
+	for _, m := range r.Matches {
+		if strings.Contains(m, "repeated-character") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a repeated-character match in %v", r.Matches)
+	}
+}
+
+func TestEstimateKeyboardWalkMatch(t *testing.T) {
+	r := Estimate("qwewq")
+	if r.Score > 1 {
+		t.Fatalf("keyboard walk: got score %d, want <= 1", r.Score)
+	}
+	found := false
+	for _, m := range r.Matches {
+		if strings.Contains(m, "keyboard-walk") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a keyboard-walk match in %v", r.Matches)
+	}
+}
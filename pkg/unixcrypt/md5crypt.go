@@ -0,0 +1,83 @@
+package unixcrypt
+
+import "crypto/md5"
+
+const md5SaltLen = 8
+
+// MD5Crypt implements Poul-Henning Kamp's md5-crypt, used by /etc/shadow
+// entries with the $1$ prefix.
+func MD5Crypt(password, salt string) string {
+	return md5CryptMagic(password, salt, "$1$")
+}
+
+// Apr1Crypt implements Apache's apr1-crypt, the same algorithm as
+// MD5Crypt with a different magic string, used by .htpasswd entries with
+// the $apr1$ prefix.
+func Apr1Crypt(password, salt string) string {
+	return md5CryptMagic(password, salt, "$apr1$")
+}
+
+func md5CryptMagic(password, salt, magic string) string {
+	if len(salt) > md5SaltLen {
+		salt = salt[:md5SaltLen]
+	}
+	pw := []byte(password)
+	s := []byte(salt)
+
+	altCtx := md5.New()
+	altCtx.Write(pw)
+	altCtx.Write(s)
+	altCtx.Write(pw)
+	altResult := altCtx.Sum(nil)
+
+	ctx := md5.New()
+	ctx.Write(pw)
+	ctx.Write([]byte(magic))
+	ctx.Write(s)
+	for pl := len(pw); pl > 0; pl -= 16 {
+		if pl > 16 {
+			ctx.Write(altResult)
+		} else {
+			ctx.Write(altResult[:pl])
+		}
+	}
+	for i := len(pw); i != 0; i >>= 1 {
+		if i&1 != 0 {
+			ctx.Write([]byte{0})
+		} else {
+			ctx.Write(pw[:1])
+		}
+	}
+	final := ctx.Sum(nil)
+
+	for i := 0; i < 1000; i++ {
+		c := md5.New()
+		if i&1 != 0 {
+			c.Write(pw)
+		} else {
+			c.Write(final)
+		}
+		if i%3 != 0 {
+			c.Write(s)
+		}
+		if i%7 != 0 {
+			c.Write(pw)
+		}
+		if i&1 != 0 {
+			c.Write(final)
+		} else {
+			c.Write(pw)
+		}
+		final = c.Sum(nil)
+	}
+
+	out := make([]byte, 0, 22)
+	out = encode24(final[0], final[6], final[12], 4, out)
+	out = encode24(final[1], final[7], final[13], 4, out)
+	out = encode24(final[2], final[8], final[14], 4, out)
+	out = encode24(final[3], final[9], final[15], 4, out)
+	out = encode24(final[4], final[10], final[5], 4, out)
+	out = encode24(0, 0, final[11], 2, out)
+
+	return magic + string(s) + "$" + string(out)
+}
@@ -0,0 +1,179 @@
+package unixcrypt
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultRounds = 5000
+	minRounds     = 1000
+	maxRounds     = 999_999_999
+)
+
+// sha256ByteOrder and sha512ByteOrder are the digest byte indices each
+// scheme reads three-at-a-time to build its base64 output, per Ulrich
+// Drepper's "Unix crypt using SHA-256 and SHA-512" spec appendix B/C.
+var sha256ByteOrder = [][3]int{
+	{0, 10, 20}, {21, 1, 11}, {12, 22, 2}, {3, 13, 23}, {24, 4, 14},
+	{15, 25, 5}, {6, 16, 26}, {27, 7, 17}, {18, 28, 8}, {9, 19, 29},
+}
+
+var sha512ByteOrder = [][3]int{
+	{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4},
+	{47, 5, 26}, {6, 27, 48}, {28, 49, 7}, {50, 8, 29}, {9, 30, 51},
+	{31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13}, {56, 14, 35},
+	{15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19},
+	{62, 20, 41},
+}
+
+// SHA256Crypt implements glibc's sha256-crypt, used by /etc/shadow
+// entries with the $5$ prefix.
+func SHA256Crypt(password, salt string, rounds int) string {
+	return sha2Crypt(sha256.New, "$5$", 32, sha256ByteOrder, []int{31, 30}, password, salt, rounds)
+}
+
+// SHA512Crypt implements glibc's sha512-crypt, used by /etc/shadow
+// entries with the $6$ prefix.
+func SHA512Crypt(password, salt string, rounds int) string {
+	return sha2Crypt(sha512.New, "$6$", 64, sha512ByteOrder, []int{63}, password, salt, rounds)
+}
+
+func sha2Crypt(newHash func() hash.Hash, magic string, digestLen int, byteOrder [][3]int, tail []int, password, salt string, rounds int) string {
+	roundsSpecified := rounds > 0
+	if rounds <= 0 {
+		rounds = defaultRounds
+	}
+	if rounds < minRounds {
+		rounds = minRounds
+	}
+	if rounds > maxRounds {
+		rounds = maxRounds
+	}
+	if len(salt) > maxSaltLen {
+		salt = salt[:maxSaltLen]
+	}
+	pw := []byte(password)
+	s := []byte(salt)
+
+	b := newHash()
+	b.Write(pw)
+	b.Write(s)
+	b.Write(pw)
+	digestB := b.Sum(nil)
+
+	a := newHash()
+	a.Write(pw)
+	a.Write(s)
+	writeCycled(a, digestB, len(pw))
+	for n := len(pw); n != 0; n >>= 1 {
+		if n&1 != 0 {
+			a.Write(digestB)
+		} else {
+			a.Write(pw)
+		}
+	}
+	digestA := a.Sum(nil)
+
+	dp := newHash()
+	for i := 0; i < len(pw); i++ {
+		dp.Write(pw)
+	}
+	digestDP := dp.Sum(nil)
+	p := repeatToLen(digestDP, len(pw))
+
+	ds := newHash()
+	repeatCount := 16 + int(digestA[0])
+	for i := 0; i < repeatCount; i++ {
+		ds.Write(s)
+	}
+	digestDS := ds.Sum(nil)
+	saltSeq := repeatToLen(digestDS, len(s))
+
+	digestC := digestA
+	for i := 0; i < rounds; i++ {
+		c := newHash()
+		if i%2 != 0 {
+			c.Write(p)
+		} else {
+			c.Write(digestC)
+		}
+		if i%3 != 0 {
+			c.Write(saltSeq)
+		}
+		if i%7 != 0 {
+			c.Write(p)
+		}
+		if i%2 != 0 {
+			c.Write(digestC)
+		} else {
+			c.Write(p)
+		}
+		digestC = c.Sum(nil)
+	}
+
+	out := make([]byte, 0, digestLen*4/3+2)
+	for _, g := range byteOrder {
+		out = encode24(digestC[g[0]], digestC[g[1]], digestC[g[2]], 4, out)
+	}
+	switch len(tail) {
+	case 1:
+		out = encode24(0, 0, digestC[tail[0]], 2, out)
+	case 2:
+		out = encode24(0, digestC[tail[0]], digestC[tail[1]], 3, out)
+	}
+
+	var b2 strings.Builder
+	b2.WriteString(magic)
+	if roundsSpecified {
+		fmt.Fprintf(&b2, "rounds=%d$", rounds)
+	}
+	b2.Write(s)
+	b2.WriteByte('$')
+	b2.Write(out)
+	return b2.String()
+}
+
+// writeCycled writes digest's bytes into w, cycling through digest as
+// many times as needed to cover exactly n total bytes.
+func writeCycled(w hash.Hash, digest []byte, n int) {
+	for n > 0 {
+		chunk := len(digest)
+		if chunk > n {
+			chunk = n
+		}
+		w.Write(digest[:chunk])
+		n -= chunk
+	}
+}
+
+// repeatToLen builds an n-byte sequence by repeating digest cyclically.
+func repeatToLen(digest []byte, n int) []byte {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = digest[i%len(digest)]
+	}
+	return out
+}
+
+// parseRounds extracts an explicit "rounds=N$" prefix from an encoded
+// hash's parameter section, returning 0 if none is present (meaning
+// defaultRounds applies).
+func parseRounds(params string) (int, error) {
+	if !strings.HasPrefix(params, "rounds=") {
+		return 0, nil
+	}
+	end := strings.IndexByte(params, '$')
+	if end < 0 {
+		return 0, fmt.Errorf("unixcrypt: malformed rounds parameter %q", params)
+	}
+	n, err := strconv.Atoi(params[len("rounds="):end])
+	if err != nil {
+		return 0, fmt.Errorf("unixcrypt: malformed rounds parameter %q: %w", params, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,27 @@
+// Package unixcrypt implements the MCF-prefixed password hashes found in
+// Unix /etc/shadow files and Apache .htpasswd files: md5-crypt ($1$),
+// Apache's apr1-crypt ($apr1$), and glibc's sha256-crypt ($5$) and
+// sha512-crypt ($6$). These are not recommended for new designs (see
+// pkg/passwd for that) - this package exists to verify passwords against
+// hashes that already exist in the wild.
+package unixcrypt
+
+// itoa64 is crypt's non-standard base64 alphabet, least-significant-bit
+// first, shared by every scheme in this package.
+const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// maxSaltLen is the longest salt any scheme here reads from the input;
+// excess salt characters are ignored, matching crypt(3) behavior.
+const maxSaltLen = 16
+
+// encode24 packs three bytes (b2 high .. b0 low, per this family's
+// byte-order convention) into 4 itoa64 characters, 6 bits each,
+// least-significant first.
+func encode24(b2, b1, b0 byte, n int, out []byte) []byte {
+	v := uint32(b2)<<16 | uint32(b1)<<8 | uint32(b0)
+	for i := 0; i < n; i++ {
+		out = append(out, itoa64[v&0x3f])
+		v >>= 6
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CVEFingerprint pairs a CVE identifier with the banner substring that
+// indicates a host may be vulnerable to it.
+type CVEFingerprint struct {
+	CVE         string
+	Description string
+	Match       string // case-insensitive substring to look for in the banner
+}
+
+// knownVulnerableBanners is a small curated set of version-string
+// fingerprints for well-known, high-impact CVEs. It is not exhaustive -
+// real fingerprinting should cross-reference a CVE feed - but it is enough
+// to flag the textbook "ancient OpenSSH/Apache" cases during a scan.
+var knownVulnerableBanners = []CVEFingerprint{
+	{CVE: "CVE-2021-41773", Description: "Apache HTTP Server path traversal/RCE", Match: "apache/2.4.49"},
+	{CVE: "CVE-2021-42013", Description: "Apache HTTP Server path traversal/RCE (incomplete fix)", Match: "apache/2.4.50"},
+	{CVE: "CVE-2014-0160", Description: "OpenSSL Heartbleed", Match: "openssl/1.0.1"},
+	{CVE: "CVE-2019-0708", Description: "Windows RDP BlueKeep RCE", Match: "microsoft-termservice"},
+	{CVE: "CVE-2008-0166", Description: "Debian OpenSSL predictable randomness", Match: "openssh_4.7p1 debian"},
+	{CVE: "CVE-2020-0796", Description: "SMBv3 compression buffer overflow (SMBGhost)", Match: "smbv3"},
+	{CVE: "CVE-2006-5229", Description: "vsftpd glob heap overflow", Match: "vsftpd 2.0.1"},
+	{CVE: "CVE-2011-2523", Description: "vsftpd 2.3.4 backdoor", Match: "vsftpd 2.3.4"},
+}
+
+// probeForService sends a minimal, protocol-appropriate probe for ports that
+// don't send a banner unprompted (most servers greet you on connect, but
+// HTTP and a few others wait for a request line first).
+var probeForService = map[int]string{
+	80:   "HEAD / HTTP/1.0\r\n\r\n",
+	8080: "HEAD / HTTP/1.0\r\n\r\n",
+	443:  "HEAD / HTTP/1.0\r\n\r\n",
+	8443: "HEAD / HTTP/1.0\r\n\r\n",
+}
+
+// GrabBanner connects to host:port and reads whatever the service sends
+// within timeout, optionally prompting it first for protocols that expect a
+// client request before responding. It returns the first line of the
+// response with surrounding whitespace trimmed, or "" if nothing was read.
+func GrabBanner(hostname string, port int, timeout time.Duration) string {
+	address := net.JoinHostPort(hostname, strconv.Itoa(port))
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return ""
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	if probe, ok := probeForService[port]; ok {
+		if _, err := conn.Write([]byte(probe)); err != nil {
+			return ""
+		}
+	}
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return ""
+	}
+	return strings.TrimSpace(line)
+}
+
+// FingerprintCVEs matches a banner against knownVulnerableBanners and
+// returns every CVE whose fingerprint substring appears in it.
+func FingerprintCVEs(banner string) []CVEFingerprint {
+	if banner == "" {
+		return nil
+	}
+	lower := strings.ToLower(banner)
+	var hits []CVEFingerprint
+	for _, fp := range knownVulnerableBanners {
+		if strings.Contains(lower, fp.Match) {
+			hits = append(hits, fp)
+		}
+	}
+	return hits
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"math/rand"
 	"net"
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/arya2004/cybersecurity/pkg/report"
 )
 
 // PortScanResult represents the result of scanning a single port
@@ -15,9 +20,13 @@ type PortScanResult struct {
 	Port    int
 	State   string
 	Service string
+	Banner  string
+	CVEs    []CVEFingerprint
 }
 
-// ScanPort attempts to connect to a specific port on the target host
+// ScanPort attempts to connect to a specific port on the target host, then
+// grabs its banner and fingerprints it against known-vulnerable version
+// strings.
 func ScanPort(protocol, hostname string, port int, timeout time.Duration) PortScanResult {
 	result := PortScanResult{Port: port, State: "Closed"}
 	address := hostname + ":" + strconv.Itoa(port)
@@ -27,9 +36,11 @@ func ScanPort(protocol, hostname string, port int, timeout time.Duration) PortSc
 		return result
 	}
 
-	defer conn.Close()
+	conn.Close()
 	result.State = "Open"
 	result.Service = getServiceName(port)
+	result.Banner = GrabBanner(hostname, port, timeout)
+	result.CVEs = FingerprintCVEs(result.Banner)
 	return result
 }
 
@@ -63,11 +74,35 @@ func getServiceName(port int) string {
 }
 
 // ScanPorts performs concurrent port scanning on the target host
-func ScanPorts(hostname string, startPort, endPort int, timeout time.Duration, workers int) []PortScanResult {
+// ScanOptions configures ScanPorts beyond the plain hostname/port-range/
+// worker-count that the original Connect-only scanner took.
+type ScanOptions struct {
+	Mode      ScanMode
+	Randomize bool
+	MaxRate   int // packets/connections per second, 0 = unlimited
+}
+
+func ScanPorts(hostname string, startPort, endPort int, timeout time.Duration, workers int, opts ScanOptions) []PortScanResult {
 	var results []PortScanResult
 	var mutex sync.Mutex
 	var wg sync.WaitGroup
 
+	mode := ResolveScanMode(opts.Mode, hasRawSocketCap)
+	if mode != opts.Mode {
+		fmt.Printf("[!] %s scan requires root/CAP_NET_RAW; falling back to connect scan\n", opts.Mode)
+	}
+
+	portList := make([]int, 0, endPort-startPort+1)
+	for port := startPort; port <= endPort; port++ {
+		portList = append(portList, port)
+	}
+	if opts.Randomize {
+		rand.Shuffle(len(portList), func(i, j int) { portList[i], portList[j] = portList[j], portList[i] })
+	}
+
+	limiter := NewRateLimiter(opts.MaxRate)
+	defer limiter.Stop()
+
 	ports := make(chan int, workers)
 
 	// Start worker goroutines
@@ -76,19 +111,26 @@ func ScanPorts(hostname string, startPort, endPort int, timeout time.Duration, w
 		go func() {
 			defer wg.Done()
 			for port := range ports {
-				result := ScanPort("tcp", hostname, port, timeout)
-				if result.State == "Open" {
+				limiter.Wait()
+				result := scanOnePort(mode, hostname, port, timeout)
+				if result.State == "Open" || result.State == "unfiltered" || result.State == "open|filtered" {
 					mutex.Lock()
 					results = append(results, result)
 					mutex.Unlock()
-					fmt.Printf("[+] Port %d is OPEN (%s)\n", port, result.Service)
+					fmt.Printf("[+] Port %d is %s (%s)\n", port, result.State, result.Service)
+					if result.Banner != "" {
+						fmt.Printf("    Banner: %s\n", result.Banner)
+					}
+					for _, cve := range result.CVEs {
+						fmt.Printf("    ⚠️  Possible %s: %s\n", cve.CVE, cve.Description)
+					}
 				}
 			}
 		}()
 	}
 
 	// Send ports to workers
-	for port := startPort; port <= endPort; port++ {
+	for _, port := range portList {
 		ports <- port
 	}
 	close(ports)
@@ -103,6 +145,20 @@ func ScanPorts(hostname string, startPort, endPort int, timeout time.Duration, w
 	return results
 }
 
+// scanOnePort dispatches to the right probe for mode.
+func scanOnePort(mode ScanMode, hostname string, port int, timeout time.Duration) PortScanResult {
+	switch mode {
+	case ModeUDP:
+		return ScanUDPPort(hostname, port, timeout)
+	case ModeSYN, ModeFIN, ModeNULL, ModeXmas, ModeACK:
+		result := rawFlagScan(mode, hostname, port, timeout)
+		result.Service = getServiceName(port)
+		return result
+	default:
+		return ScanPort("tcp", hostname, port, timeout)
+	}
+}
+
 // PrintBanner displays the program banner
 func PrintBanner() {
 	banner := `
@@ -116,26 +172,27 @@ func PrintBanner() {
 
 // PrintUsage displays usage information
 func PrintUsage() {
-	fmt.Println("Usage: go run main.go <hostname> <start_port> <end_port>")
+	fmt.Println("Usage: go run . [-mode connect|syn|udp|fin|null|xmas|ack] [-randomize] [-max-rate pps] [-o json|xml|sarif -oFile path] <hostname> <start_port> <end_port>")
 	fmt.Println("\nExample:")
-	fmt.Println("  go run main.go localhost 1 1000")
-	fmt.Println("  go run main.go scanme.nmap.org 20 80")
+	fmt.Println("  go run . localhost 1 1000")
+	fmt.Println("  go run . -mode syn -randomize scanme.nmap.org 20 80")
 	fmt.Println("\nNOTE: Only scan systems you have permission to test!")
 }
 
-// ValidateInput validates command line arguments
+// ValidateInput validates the positional hostname/start/end command line
+// arguments (after flag.Parse has stripped any -mode/-randomize/-max-rate flags).
 func ValidateInput(args []string) (string, int, int, error) {
-	if len(args) != 4 {
+	if len(args) != 3 {
 		return "", 0, 0, fmt.Errorf("invalid number of arguments")
 	}
 
-	hostname := args[1]
-	startPort, err := strconv.Atoi(args[2])
+	hostname := args[0]
+	startPort, err := strconv.Atoi(args[1])
 	if err != nil || startPort < 1 || startPort > 65535 {
 		return "", 0, 0, fmt.Errorf("invalid start port")
 	}
 
-	endPort, err := strconv.Atoi(args[3])
+	endPort, err := strconv.Atoi(args[2])
 	if err != nil || endPort < 1 || endPort > 65535 {
 		return "", 0, 0, fmt.Errorf("invalid end port")
 	}
@@ -149,9 +206,9 @@ func ValidateInput(args []string) (string, int, int, error) {
 
 // PrintSummary displays the scan summary
 func PrintSummary(hostname string, startPort, endPort int, results []PortScanResult, duration time.Duration) {
-	fmt.Println("\n" + "═"*50)
+	fmt.Println("\n" + strings.Repeat("═", 50))
 	fmt.Printf("Scan Summary for %s\n", hostname)
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
 	fmt.Printf("Port Range: %d-%d\n", startPort, endPort)
 	fmt.Printf("Total Ports Scanned: %d\n", endPort-startPort+1)
 	fmt.Printf("Open Ports Found: %d\n", len(results))
@@ -159,21 +216,82 @@ func PrintSummary(hostname string, startPort, endPort int, results []PortScanRes
 	
 	if len(results) > 0 {
 		fmt.Println("\nOpen Ports Details:")
-		fmt.Println("─"*50)
+		fmt.Println(strings.Repeat("─", 50))
 		fmt.Printf("%-10s %-10s %-20s\n", "PORT", "STATE", "SERVICE")
-		fmt.Println("─"*50)
+		fmt.Println(strings.Repeat("─", 50))
 		for _, result := range results {
 			fmt.Printf("%-10d %-10s %-20s\n", result.Port, result.State, result.Service)
+			if result.Banner != "" {
+				fmt.Printf("  Banner: %s\n", result.Banner)
+			}
+			for _, cve := range result.CVEs {
+				fmt.Printf("  ⚠️  %s: %s\n", cve.CVE, cve.Description)
+			}
 		}
 	}
-	fmt.Println("═"*50)
+	fmt.Println(strings.Repeat("═", 50))
+}
+
+// buildReport converts one host's PortScanResults into the tool-agnostic
+// report.Report model, for writeReportFile to render.
+func buildReport(hostname string, results []PortScanResult, mode ScanMode) report.Report {
+	protocol := "tcp"
+	if mode == ModeUDP {
+		protocol = "udp"
+	}
+
+	rep := report.Report{Tool: "port-scanner", StartedAt: time.Now()}
+	h := report.Host{Address: hostname}
+	for _, r := range results {
+		port := report.Port{
+			Number:   r.Port,
+			Protocol: protocol,
+			State:    strings.ToLower(r.State),
+			Service:  r.Service,
+			Banner:   r.Banner,
+		}
+		for _, cve := range r.CVEs {
+			port.CVEs = append(port.CVEs, cve.CVE)
+		}
+		h.Ports = append(h.Ports, port)
+	}
+	rep.Hosts = append(rep.Hosts, h)
+	return rep
+}
+
+// writeReportFile renders rep in format and writes it to path, doing
+// nothing if either is empty so -o/-oFile stay optional.
+func writeReportFile(rep report.Report, format, path string) error {
+	if format == "" || path == "" {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	defer f.Close()
+	return report.Write(f, format, rep)
 }
 
 func main() {
 	PrintBanner()
 
+	modeFlag := flag.String("mode", string(ModeConnect), "scan mode: connect|syn|udp|fin|null|xmas|ack")
+	randomize := flag.Bool("randomize", false, "shuffle the port list before scanning")
+	maxRate := flag.Int("max-rate", 0, "maximum probes per second (0 = unlimited)")
+	outFormat := flag.String("o", "", "structured report format to also write: json|xml|sarif (xml is nmap-compatible)")
+	outFile := flag.String("oFile", "", "path to write the -o report to")
+	flag.Parse()
+
+	mode, err := ParseScanMode(*modeFlag)
+	if err != nil {
+		fmt.Printf("Error: %v\n\n", err)
+		PrintUsage()
+		os.Exit(1)
+	}
+
 	// Validate input
-	hostname, startPort, endPort, err := ValidateInput(os.Args)
+	hostname, startPort, endPort, err := ValidateInput(flag.Args())
 	if err != nil {
 		fmt.Printf("Error: %v\n\n", err)
 		PrintUsage()
@@ -186,17 +304,25 @@ func main() {
 
 	// Disclaimer
 	fmt.Println("⚠️  WARNING: Only scan systems you have explicit permission to test!")
-	fmt.Printf("\nStarting port scan on %s (Ports %d-%d)\n", hostname, startPort, endPort)
+	fmt.Printf("\nStarting %s scan on %s (Ports %d-%d)\n", mode, hostname, startPort, endPort)
 	fmt.Println("This may take a few moments...\n")
 
 	// Perform scan
 	startTime := time.Now()
-	results := ScanPorts(hostname, startPort, endPort, timeout, workers)
+	results := ScanPorts(hostname, startPort, endPort, timeout, workers, ScanOptions{
+		Mode:      mode,
+		Randomize: *randomize,
+		MaxRate:   *maxRate,
+	})
 	duration := time.Since(startTime)
 
 	// Print summary
 	PrintSummary(hostname, startPort, endPort, results, duration)
 
+	if err := writeReportFile(buildReport(hostname, results, mode), *outFormat, *outFile); err != nil {
+		fmt.Println(err)
+	}
+
 	// Exit with appropriate status
 	if len(results) > 0 {
 		os.Exit(0)
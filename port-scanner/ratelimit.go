@@ -0,0 +1,58 @@
+package main
+
+import "time"
+
+// RateLimiter is a simple token-bucket shared across worker goroutines,
+// used to cap the scan to --max-rate packets (or connections) per second.
+type RateLimiter struct {
+	tokens chan struct{}
+	ticker *time.Ticker
+	done   chan struct{}
+}
+
+// NewRateLimiter starts refilling one token per tick at the given rate. A
+// rate of 0 or less disables limiting entirely (Wait becomes a no-op).
+func NewRateLimiter(pps int) *RateLimiter {
+	if pps <= 0 {
+		return nil
+	}
+	rl := &RateLimiter{
+		tokens: make(chan struct{}, pps),
+		ticker: time.NewTicker(time.Second / time.Duration(pps)),
+		done:   make(chan struct{}),
+	}
+	go rl.refill()
+	return rl
+}
+
+func (rl *RateLimiter) refill() {
+	for {
+		select {
+		case <-rl.ticker.C:
+			select {
+			case rl.tokens <- struct{}{}:
+			default:
+			}
+		case <-rl.done:
+			rl.ticker.Stop()
+			return
+		}
+	}
+}
+
+// Wait blocks until a token is available. A nil receiver (rate limiting
+// disabled) returns immediately.
+func (rl *RateLimiter) Wait() {
+	if rl == nil {
+		return
+	}
+	<-rl.tokens
+}
+
+// Stop releases the refill goroutine.
+func (rl *RateLimiter) Stop() {
+	if rl == nil {
+		return
+	}
+	close(rl.done)
+}
@@ -0,0 +1,247 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+// hasRawSocketCap reports whether the process can open raw sockets, by
+// attempting to open one and immediately closing it. This covers both
+// "running as root" and "has CAP_NET_RAW".
+func hasRawSocketCap() bool {
+	if os.Geteuid() == 0 {
+		return true
+	}
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_RAW, syscall.IPPROTO_TCP)
+	if err != nil {
+		return false
+	}
+	syscall.Close(fd)
+	return true
+}
+
+// tcpFlags are the header flag bits used to build crafted probes.
+type tcpFlags struct {
+	syn, ack, fin, psh, urg bool
+}
+
+var (
+	flagsSYN  = tcpFlags{syn: true}
+	flagsFIN  = tcpFlags{fin: true}
+	flagsNULL = tcpFlags{}
+	flagsXmas = tcpFlags{fin: true, psh: true, urg: true}
+	flagsACK  = tcpFlags{ack: true}
+)
+
+func flagsForMode(mode ScanMode) tcpFlags {
+	switch mode {
+	case ModeSYN:
+		return flagsSYN
+	case ModeFIN:
+		return flagsFIN
+	case ModeNULL:
+		return flagsNULL
+	case ModeXmas:
+		return flagsXmas
+	case ModeACK:
+		return flagsACK
+	default:
+		return flagsSYN
+	}
+}
+
+// buildTCPHeader constructs a minimal 20-byte TCP header (no options) plus
+// the checksum computed over the pseudo-header + header, per RFC 793.
+func buildTCPHeader(srcIP, dstIP net.IP, srcPort, dstPort uint16, seq uint32, f tcpFlags) []byte {
+	header := make([]byte, 20)
+	binary.BigEndian.PutUint16(header[0:2], srcPort)
+	binary.BigEndian.PutUint16(header[2:4], dstPort)
+	binary.BigEndian.PutUint32(header[4:8], seq)
+	binary.BigEndian.PutUint32(header[8:12], 0) // ack number
+	header[12] = 5 << 4                         // data offset: 5 words, no options
+
+	var flagByte byte
+	if f.fin {
+		flagByte |= 0x01
+	}
+	if f.syn {
+		flagByte |= 0x02
+	}
+	if f.psh {
+		flagByte |= 0x08
+	}
+	if f.ack {
+		flagByte |= 0x10
+	}
+	if f.urg {
+		flagByte |= 0x20
+	}
+	header[13] = flagByte
+
+	binary.BigEndian.PutUint16(header[14:16], 65535) // window
+	binary.BigEndian.PutUint16(header[16:18], 0)      // checksum, filled below
+	binary.BigEndian.PutUint16(header[18:20], 0)      // urgent pointer
+
+	checksum := tcpChecksum(srcIP, dstIP, header)
+	binary.BigEndian.PutUint16(header[16:18], checksum)
+	return header
+}
+
+func tcpChecksum(srcIP, dstIP net.IP, tcpSegment []byte) uint16 {
+	pseudo := make([]byte, 0, 12+len(tcpSegment))
+	pseudo = append(pseudo, srcIP.To4()...)
+	pseudo = append(pseudo, dstIP.To4()...)
+	pseudo = append(pseudo, 0, syscall.IPPROTO_TCP)
+	pseudo = append(pseudo, byte(len(tcpSegment)>>8), byte(len(tcpSegment)))
+	pseudo = append(pseudo, tcpSegment...)
+	return checksum16(pseudo)
+}
+
+func checksum16(data []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(data); i += 2 {
+		sum += uint32(data[i])<<8 | uint32(data[i+1])
+	}
+	if len(data)%2 == 1 {
+		sum += uint32(data[len(data)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
+
+// rawFlagScan sends a single crafted TCP segment for modes that don't need
+// the three-way handshake (SYN/FIN/NULL/Xmas/ACK) and classifies the result
+// per Nmap's response semantics. It opens a short-lived raw socket per call
+// rather than a shared AF_PACKET listener, trading some scan throughput for
+// portability across kernels without libpcap.
+func rawFlagScan(mode ScanMode, hostname string, port int, timeout time.Duration) PortScanResult {
+	result := PortScanResult{Port: port, State: "filtered"}
+
+	dstAddr, err := net.ResolveIPAddr("ip4", hostname)
+	if err != nil {
+		return result
+	}
+
+	sendFD, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_RAW, syscall.IPPROTO_TCP)
+	if err != nil {
+		result.State = "Closed" // no raw capability; treat as undetermined-closed
+		return result
+	}
+	defer syscall.Close(sendFD)
+	syscall.SetsockoptInt(sendFD, syscall.IPPROTO_IP, syscall.IP_HDRINCL, 0)
+
+	recvFD, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_RAW, syscall.IPPROTO_TCP)
+	if err != nil {
+		return result
+	}
+	defer syscall.Close(recvFD)
+	tv := syscall.NsecToTimeval(timeout.Nanoseconds())
+	syscall.SetsockoptTimeval(recvFD, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv)
+
+	srcPort := uint16(40000 + port%10000)
+	localIP := outboundIP(dstAddr.IP)
+	segment := buildTCPHeader(localIP, dstAddr.IP, srcPort, uint16(port), 0, flagsForMode(mode))
+
+	var sockAddr syscall.SockaddrInet4
+	copy(sockAddr.Addr[:], dstAddr.IP.To4())
+	if err := syscall.Sendto(sendFD, segment, 0, &sockAddr); err != nil {
+		return result
+	}
+
+	buf := make([]byte, 4096)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		n, _, err := syscall.Recvfrom(recvFD, buf, 0)
+		if err != nil || n < 20 {
+			break
+		}
+		reply := parseTCPReply(buf[:n])
+		if reply == nil || reply.srcPort != uint16(port) || reply.dstPort != srcPort {
+			continue
+		}
+		result.State = classifyFlagScan(mode, *reply)
+		return result
+	}
+
+	// No reply within the timeout.
+	result.State = classifyNoReply(mode)
+	return result
+}
+
+type tcpReplyFlags struct {
+	srcPort, dstPort uint16
+	syn, ack, rst     bool
+}
+
+// parseTCPReply interprets a raw IPv4 packet read from a SOCK_RAW socket,
+// whose payload starts with the IP header followed by the TCP header.
+func parseTCPReply(packet []byte) *tcpReplyFlags {
+	if len(packet) < 20 {
+		return nil
+	}
+	ihl := int(packet[0]&0x0f) * 4
+	if len(packet) < ihl+20 {
+		return nil
+	}
+	tcp := packet[ihl:]
+	flags := tcp[13]
+	return &tcpReplyFlags{
+		srcPort: binary.BigEndian.Uint16(tcp[0:2]),
+		dstPort: binary.BigEndian.Uint16(tcp[2:4]),
+		syn:     flags&0x02 != 0,
+		ack:     flags&0x10 != 0,
+		rst:     flags&0x04 != 0,
+	}
+}
+
+func classifyFlagScan(mode ScanMode, r tcpReplyFlags) string {
+	switch mode {
+	case ModeSYN:
+		if r.syn && r.ack {
+			return "Open"
+		}
+		if r.rst {
+			return "Closed"
+		}
+	case ModeFIN, ModeNULL, ModeXmas:
+		if r.rst {
+			return "Closed"
+		}
+	case ModeACK:
+		if r.rst {
+			return "unfiltered"
+		}
+	}
+	return "filtered"
+}
+
+func classifyNoReply(mode ScanMode) string {
+	switch mode {
+	case ModeFIN, ModeNULL, ModeXmas:
+		return "open|filtered"
+	case ModeSYN:
+		return "filtered"
+	case ModeACK:
+		return "filtered"
+	default:
+		return "filtered"
+	}
+}
+
+// outboundIP returns the local address the kernel would use to reach dst,
+// by opening a UDP "connection" (which performs no I/O) and inspecting it.
+func outboundIP(dst net.IP) net.IP {
+	conn, err := net.Dial("udp", net.JoinHostPort(dst.String(), "80"))
+	if err != nil {
+		return net.IPv4zero
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP
+}
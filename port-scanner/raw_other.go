@@ -0,0 +1,16 @@
+//go:build !linux
+
+package main
+
+import "time"
+
+// hasRawSocketCap is always false on non-Linux platforms: raw TCP/IP
+// crafting here is Linux-specific (syscall.SOCK_RAW with a hand-built TCP
+// header).
+func hasRawSocketCap() bool { return false }
+
+// rawFlagScan is unreachable on non-Linux platforms because ResolveScanMode
+// always falls back to ModeConnect when hasRawSocketCap is false.
+func rawFlagScan(mode ScanMode, hostname string, port int, timeout time.Duration) PortScanResult {
+	return PortScanResult{Port: port, State: "filtered"}
+}
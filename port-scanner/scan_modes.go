@@ -0,0 +1,48 @@
+package main
+
+// ScanMode selects how ScanPorts probes each port.
+type ScanMode string
+
+const (
+	ModeConnect ScanMode = "connect"
+	ModeSYN     ScanMode = "syn"
+	ModeUDP     ScanMode = "udp"
+	ModeFIN     ScanMode = "fin"
+	ModeNULL    ScanMode = "null"
+	ModeXmas    ScanMode = "xmas"
+	ModeACK     ScanMode = "ack"
+)
+
+// rawModes are the scan modes that need a raw socket and root/CAP_NET_RAW.
+var rawModes = map[ScanMode]bool{
+	ModeSYN:  true,
+	ModeFIN:  true,
+	ModeNULL: true,
+	ModeXmas: true,
+	ModeACK:  true,
+}
+
+// ParseScanMode validates a -mode flag value.
+func ParseScanMode(s string) (ScanMode, error) {
+	switch ScanMode(s) {
+	case ModeConnect, ModeSYN, ModeUDP, ModeFIN, ModeNULL, ModeXmas, ModeACK:
+		return ScanMode(s), nil
+	default:
+		return "", errInvalidScanMode(s)
+	}
+}
+
+type errInvalidScanMode string
+
+func (e errInvalidScanMode) Error() string {
+	return "invalid scan mode: " + string(e)
+}
+
+// ResolveScanMode falls back to ModeConnect (with a warning) when the
+// requested mode needs raw-socket privileges the process doesn't have.
+func ResolveScanMode(requested ScanMode, hasRawSocketCap func() bool) ScanMode {
+	if rawModes[requested] && !hasRawSocketCap() {
+		return ModeConnect
+	}
+	return requested
+}
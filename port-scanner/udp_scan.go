@@ -0,0 +1,101 @@
+package main
+
+import (
+	"net"
+	"strconv"
+	"time"
+)
+
+// udpProbePayload carries protocol-specific payloads for services that
+// don't reply to an empty datagram, so the scan can tell "open" from
+// "open|filtered".
+var udpProbePayload = map[int][]byte{
+	53:  {0xAA, 0xAA, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // minimal DNS query header
+	161: {0x30, 0x26, 0x02, 0x01, 0x00, 0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c'},       // SNMPv1 GetRequest-ish header
+	123: make([]byte, 48),                                                              // NTP client request (all-zero is a valid v0 request)
+}
+
+// ScanUDPPort sends an empty (or protocol-specific) datagram and classifies
+// the result: any UDP reply means open, an ICMP port-unreachable (type 3,
+// code 3) means closed, any other ICMP error means filtered, and no reply
+// within the timeout means open|filtered (the conventional Nmap UDP result
+// when a firewall silently drops probes).
+func ScanUDPPort(hostname string, port int, timeout time.Duration) PortScanResult {
+	result := PortScanResult{Port: port, State: "open|filtered", Service: getServiceName(port)}
+
+	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
+	conn, err := net.DialTimeout("udp", addr, timeout)
+	if err != nil {
+		result.State = "filtered"
+		return result
+	}
+	defer conn.Close()
+
+	payload := udpProbePayload[port]
+	if _, err := conn.Write(payload); err != nil {
+		result.State = "filtered"
+		return result
+	}
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	buf := make([]byte, 512)
+	n, err := conn.Read(buf)
+	if err == nil && n > 0 {
+		result.State = "Open"
+		return result
+	}
+
+	if icmpErr, ok := readICMPUnreachable(hostname, timeout); ok {
+		if icmpErr.code == 3 { // port unreachable
+			result.State = "Closed"
+		} else {
+			result.State = "filtered"
+		}
+	}
+	return result
+}
+
+type icmpUnreachable struct {
+	code byte
+}
+
+// readICMPUnreachable listens briefly on a raw ICMP socket for a
+// destination-unreachable message from hostname. It degrades to "no signal"
+// (ok=false, leaving the UDP result as open|filtered) when raw ICMP sockets
+// aren't available, which is the normal unprivileged case.
+func readICMPUnreachable(hostname string, timeout time.Duration) (icmpUnreachable, bool) {
+	conn, err := net.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return icmpUnreachable{}, false
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(timeout))
+
+	buf := make([]byte, 512)
+	n, peer, err := conn.ReadFrom(buf)
+	if err != nil || n < 8 {
+		return icmpUnreachable{}, false
+	}
+	if peer.String() != hostname && !resolvesToSame(hostname, peer.String()) {
+		return icmpUnreachable{}, false
+	}
+	icmpType := buf[0]
+	icmpCode := buf[1]
+	if icmpType != 3 { // not destination-unreachable
+		return icmpUnreachable{}, false
+	}
+	return icmpUnreachable{code: icmpCode}, true
+}
+
+func resolvesToSame(hostname, ip string) bool {
+	addrs, err := net.LookupHost(hostname)
+	if err != nil {
+		return false
+	}
+	for _, a := range addrs {
+		if a == ip {
+			return true
+		}
+	}
+	return false
+}